@@ -1,53 +1,88 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/routes"
-	bulkImageUploadRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/routes"
-	configMinio "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/config"
-	oldProductRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/oldProductMigration/routes"
-	PORoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/routes"
-	posManagementRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/routes"
-	productRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/routes"
-	minioConfig "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/config"
-	imageUploadRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/routes"
-	profileModuleRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/profileModule/routes"
-	purchaseOrderRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/routes"
-	reportRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/routes"
-	settingsRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/routes"
-	shopfiyRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/routes"
-	supplierRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/routes"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db/migrations"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	mailService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/MailService"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	storage "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Storage"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	shopifyConfig "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/shopify"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"gorm.io/gorm"
 
 )
 
 func main() {
-	r := gin.Default()
+	// API DOCS - `go run ./cmd openapi [check]` needs neither configuration nor a database
+	if len(os.Args) > 1 && os.Args[1] == "openapi" {
+		runOpenAPI(os.Args[2:])
+		return
+	}
 
-	err := godotenv.Load()
+	// CONFIG - .env (or CONFIG_FILE) plus the environment, validated before anything starts
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Error in loading .env file")
+		log.Fatal(err)
 	}
+
+	// LOGGING - one JSON logger for the process, level from LOG_LEVEL
+	logger.Configure(cfg.Log)
+
+	r := gin.New()
+	// Errors and panics answer in one envelope: {"status": false, "code", "message", ...}
+	r.Use(logger.RequestLogger(), metrics.Middleware(), apperror.Middleware())
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(apperror.RouteNotFound())
+	r.NoMethod(apperror.MethodNotAllowed())
 	r.SetTrustedProxies(nil)
 
-	// INIT DB
-	globalDB, _ := db.InitDB()
+	// REQUEST VALIDATION RULES (mobile, gstin, pincode ...)
+	validation.Register()
+
+	// INIT DB - one pool for the whole process, see DB_MAX_OPEN_CONNS and friends
+	globalDB, dbErr := db.Open(cfg.DB)
 	if globalDB == nil {
-		fmt.Println("⚠️ Warning: Server started WITHOUT database connection.")
+		log.Fatal(dbErr)
+	}
+	defer db.Close(globalDB)
+
+	// ADMIN COMMANDS - e.g. `go run ./cmd rotate-signing-key` or `go run ./cmd migrate up`
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1:], globalDB, dbErr)
+		return
 	}
 
-	//MIN IO INIT
-	minioConfig.InitMinio()
-	configMinio.InitMinio()
-	shopifyConfig.Init()
+	accesstoken.UseDB(globalDB)
+	permission.UseDB(globalDB)
+	idempotency.UseDB(globalDB)
+
+	if dbErr != nil {
+		fmt.Println("⚠️ Warning: Server started WITHOUT database connection, startup steps wait for it: " + dbErr.Error())
+	}
+
+	// OBJECT STORAGE - MinIO, or the local disk with STORAGE_DRIVER=local
+	store, err := storage.Open(cfg.Storage, cfg.MinIO)
+	if err != nil {
+		log.Fatal(err)
+	}
+	storage.Use(store)
+	shopifyConfig.Init(cfg.Shopify)
+	mailService.Configure(cfg.Mail)
 
 	// CORS CONFIG
 	r.Use(cors.New(cors.Config{
@@ -56,33 +91,67 @@ func main() {
 			return true
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", logger.RequestIDHeader, idempotency.Header},
+		ExposeHeaders:    []string{logger.RequestIDHeader, idempotency.ReplayedHeader},
 		AllowCredentials: true,
 	}))
 
-	// API CALLS
-	routes.RegisterAdminRoutes(r)
-	settingsRoutes.SettingsAdminRoutes(r)
-	supplierRoutes.SupplierRoutes(r)
-	imageUploadRoutes.ImageUploadRoutes(r)
-	productRoutes.ProductManagementRoutes(r)
-	purchaseOrderRoutes.PurhcaseOrderRoutes(r)
-	profileModuleRoutes.ProfileModuleRoutes(r)
-	posManagementRoutes.POSManagementRoutes(r)
-	reportRoutes.ReportRoutes(r)
-	oldProductRoutes.OldProductMigrationRoutes(r)
-	shopfiyRoutes.RegisterShopifyRoutes(r)
-	PORoutes.PurchaseOrderRoutes(r)
-	PORoutes.PurchaseOrderProductRoutes(r)
-	bulkImageUploadRoutes.BulkImageUploadRoutes(r)
-	// PING PONG API CALL FOR TESTING
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Pong from User Service",
-		})
+	// Answer 503 instead of failing handlers while Postgres is unreachable
+	r.Use(db.Guard(globalDB))
+
+	// API CALLS - every module, then /docs built from them
+	registerRoutes(r, globalDB, cfg)
+
+	// SCHEMA, SEEDS AND BACKGROUND JOBS - as soon as Postgres answers, now or later; Guard
+	// answers 503 until they ran. Pending migrations are applied when DB_MIGRATE_ON_START is
+	// set, then a schema whose version does not match this build stops the server. The jobs
+	// start once the modules registered their handlers and event subscribers; the outbox
+	// registers its delivery job before the workers start.
+	ctx := context.Background()
+	db.WhenReady(ctx, globalDB, func() error {
+		if cfg.DB.MigrateOnStart {
+			if _, err := migrations.Up(globalDB, "Startup"); err != nil {
+				return err
+			}
+		}
+		if err := migrations.Check(globalDB); err != nil {
+			return err
+		}
+		if err := permission.SeedDefaults(globalDB); err != nil {
+			if db.Ping(ctx, globalDB) != nil {
+				return err
+			}
+			fmt.Println("⚠️ Warning: Could not seed default role permissions: " + err.Error())
+		}
+
+		outbox.Start(ctx, globalDB, cfg.Jobs.PollInterval)
+		jobqueue.Start(ctx, globalDB, cfg.Jobs)
+		return nil
+	}, func(err error) {
+		log.Fatal(err)
 	})
 
 	// RUN SERVER AND LOG MESSAGE
-	fmt.Println("Server is Running at Port : " + os.Getenv("PORT"))
-	r.Run("0.0.0.0:" + os.Getenv("PORT"))
+	fmt.Println("Server is Running at Port : " + cfg.Port)
+	r.Run("0.0.0.0:" + cfg.Port)
+}
+
+func runCommand(args []string, globalDB *gorm.DB, dbErr error) {
+	command := args[0]
+	if dbErr != nil {
+		log.Fatal("Command " + command + " needs a database connection: " + dbErr.Error())
+	}
+
+	switch command {
+	case "rotate-signing-key":
+		kid, err := accesstoken.RotateSigningKey(globalDB, "CLI")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Active signing key is now " + kid)
+	case "migrate":
+		runMigrate(args[1:], globalDB)
+	default:
+		log.Fatal("Unknown command: " + command)
+	}
 }
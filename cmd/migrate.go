@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db/migrations"
+	"gorm.io/gorm"
+)
+
+// runMigrate serves `migrate up`, `migrate down [steps]` and `migrate status`.
+func runMigrate(args []string, globalDB *gorm.DB) {
+	if len(args) == 0 {
+		log.Fatal("Usage: migrate up | down [steps] | status")
+	}
+
+	switch args[0] {
+	case "up":
+		ran, err := migrations.Up(globalDB, "CLI")
+		for _, m := range ran {
+			fmt.Println("Applied " + m.Label())
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(ran) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+		fmt.Printf("Schema version is now %d\n", migrations.Latest())
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal("migrate down takes a positive number of steps")
+			}
+			steps = n
+		}
+		reverted, err := migrations.Down(globalDB, steps)
+		for _, m := range reverted {
+			fmt.Println("Reverted " + m.Label())
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		current, err := migrations.Current(globalDB)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Schema version is now %d\n", current)
+	case "status":
+		states, err := migrations.Status(globalDB)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range states {
+			line := fmt.Sprintf("%04d_%-40s %-9s", s.Version, s.Name, s.State)
+			if s.AppliedAt != "" {
+				line += " " + s.AppliedAt + " by " + s.AppliedBy
+			}
+			fmt.Println(line)
+		}
+	default:
+		log.Fatal("Unknown migrate command: " + args[0])
+	}
+}
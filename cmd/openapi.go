@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	"github.com/gin-gonic/gin"
+)
+
+// runOpenAPI serves `openapi`, which prints the document, and `openapi check`, which
+// fails when a registered route is undocumented or a description matches no route.
+// The router is built without a database: registering routes does not touch it.
+func runOpenAPI(args []string) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = io.Discard
+
+	r := gin.New()
+	registerRoutes(r, nil, &config.Config{})
+
+	if len(args) == 0 {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(openapi.Build(r.Routes())); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if args[0] != "check" {
+		log.Fatal("Usage: openapi [check]")
+	}
+
+	undocumented, stale := openapi.Check(r.Routes())
+	for _, key := range undocumented {
+		fmt.Println("Undocumented route: " + key)
+	}
+	for _, key := range stale {
+		fmt.Println("Documented route is not registered: " + key)
+	}
+	if len(undocumented) > 0 || len(stale) > 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("All %d routes are documented\n", len(r.Routes()))
+}
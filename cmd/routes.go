@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/routes"
+	auditRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/routes"
+	bulkImageUploadRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/routes"
+	healthRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/healthModule/routes"
+	jobRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/jobModule/routes"
+	oldProductRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/oldProductMigration/routes"
+	PORoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/routes"
+	posManagementRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/routes"
+	productRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/routes"
+	imageUploadRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/routes"
+	profileModuleRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/profileModule/routes"
+	purchaseOrderRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/routes"
+	reportRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/routes"
+	settingsRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/routes"
+	shopfiyRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/routes"
+	supplierRoutes "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/routes"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	storage "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// registerRoutes mounts every module on r. The server and `openapi check` share it, so
+// the document describes exactly the routes that are served.
+func registerRoutes(r *gin.Engine, globalDB *gorm.DB, cfg *config.Config) {
+	routes.RegisterAdminRoutes(r, globalDB)
+	settingsRoutes.SettingsAdminRoutes(r, globalDB)
+	supplierRoutes.SupplierRoutes(r, globalDB)
+	imageUploadRoutes.ImageUploadRoutes(r)
+	productRoutes.ProductManagementRoutes(r, globalDB)
+	purchaseOrderRoutes.PurhcaseOrderRoutes(r, globalDB)
+	profileModuleRoutes.ProfileModuleRoutes(r)
+	posManagementRoutes.POSManagementRoutes(r, globalDB)
+	reportRoutes.ReportRoutes(r, globalDB)
+	oldProductRoutes.OldProductMigrationRoutes(r, globalDB)
+	shopfiyRoutes.RegisterShopifyRoutes(r, globalDB)
+	PORoutes.PurchaseOrderRoutes(r, globalDB)
+	PORoutes.PurchaseOrderProductRoutes(r, globalDB)
+	bulkImageUploadRoutes.BulkImageUploadRoutes(r)
+	auditRoutes.AuditRoutes(r, globalDB)
+	jobRoutes.JobRoutes(r, globalDB)
+	healthRoutes.HealthRoutes(r, globalDB)
+
+	// PROMETHEUS SCRAPE ENDPOINT - guarded by METRICS_TOKEN when set
+	r.GET("/metrics", metrics.Handler(cfg.Metrics))
+
+	// PING PONG API CALL FOR TESTING
+	r.GET("/ping", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Pong from User Service",
+		})
+	})
+
+	openapi.Describe("Operations", openapi.Routes{
+		"GET /metrics": {Summary: "Prometheus metrics, a bearer METRICS_TOKEN when one is configured", Public: true},
+		"GET /ping":    {Summary: "Ping", Public: true},
+	})
+
+	// SIGNED URLS OF THE LOCAL STORAGE DRIVER
+	storage.Register(r)
+
+	// API DOCS - last, the document lists the routes registered above
+	openapi.Register(r)
+}
@@ -1,50 +1,60 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
-	"math/rand"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/model"
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	becrypt "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Bcrypt"
+	transactionLogger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	loginguard "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/LoginGuard"
 	mailService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/MailService"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func AdminLoginController() gin.HandlerFunc {
-
-	log := logger.InitLogger()
+func AdminLoginController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		var reqVal model.AdminLoginReq
 
 		log.Info("\n\nAdmin Login Controller -> \n================")
 		// ERROR HANDLING - STATUS CODE IN PARAMS
-		if err := c.BindJSON(&reqVal); err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"status":  false,
-				"message": "Something went wrong, Try again ... " + err.Error(),
-			})
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.BadRequest("Something went wrong, Try again ... "+err.Error()))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		resVal := service.AdminLoginService(dbConnt, reqVal, c.Request.UserAgent(), c.ClientIP())
+		log.Info("Response for controller -> ", resVal.Status, resVal.Message)
 
-		resVal := service.AdminLoginService(dbConnt, reqVal)
-		log.Info("Response for controller -> ", resVal)
+		if !resVal.Status {
+			loginFailed(c, resVal.Message, resVal.RetryAfter)
+			return
+		}
 
 		response := gin.H{
 			"status":  resVal.Status,
 			"message": resVal.Message,
 		}
 
-		if resVal.Status {
+		if resVal.TwoFactorRequired || resVal.TwoFactorSetupRequired {
+			response["twoFactorRequired"] = resVal.TwoFactorRequired
+			response["twoFactorSetupRequired"] = resVal.TwoFactorSetupRequired
+			response["challengeToken"] = resVal.ChallengeToken
+		} else {
 			response["user"] = resVal.User
 			response["token"] = resVal.Token
+			response["refreshToken"] = resVal.RefreshToken
 		}
 
 		c.JSON(http.StatusOK, gin.H{
@@ -53,20 +63,78 @@ func AdminLoginController() gin.HandlerFunc {
 	}
 }
 
-// Send OTP (Forgot Password Step 1)
+// Exchange a refresh token for a new access token (the refresh token is rotated)
+
+func RefreshTokenController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("\n\nRefresh Token Controller -> \n================")
+
+		var reqVal model.RefreshTokenReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Refresh token is required"))
+			return
+		}
+
+		resVal := service.RefreshTokenService(dbConnt, reqVal.RefreshToken)
+		if !resVal.Status {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, resVal.Message))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":       true,
+			"message":      resVal.Message,
+			"token":        resVal.Token,
+			"refreshToken": resVal.RefreshToken,
+		})
+	}
+}
+
+// Logout revokes the current session, or every session of the user when allSessions is set
 
-func ForgotPasswordController() gin.HandlerFunc {
+func LogoutController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Email string `json:"email" binding:"required,email"`
+		log := logger.FromGin(c)
+		log.Info("\n\nLogout Controller -> \n================")
+
+		var reqVal model.LogoutReq
+		// Body is optional, default is logging out the current session only
+		_ = c.ShouldBindJSON(&reqVal)
+
+		idValue, _ := c.Get("id")
+		userId, err := roleType.ExtractIntFromInterface(idValue)
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Invalid user in token"))
+			return
+		}
+
+		if err := service.LogoutService(dbConnt, userId, accesstoken.SessionID(c), reqVal.AllSessions); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Logout failed"))
+			return
 		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Logged out successfully"})
+	}
+}
+
+// Send OTP (Forgot Password Step 1)
+
+func ForgotPasswordController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.ForgotPasswordReq
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid email format"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid email format"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		// Every request counts, unknown emails included, so the route can neither flood
+		// an inbox nor be used to probe for accounts
+		emailKey := strings.ToLower(strings.TrimSpace(req.Email))
+		if !allowAttempt(c, dbConn, loginguard.ScopeReset, emailKey) || !allowAttempt(c, dbConn, loginguard.ScopeIP, c.ClientIP()) {
+			return
+		}
+		recordResetRequest(dbConn, emailKey, c.ClientIP())
 
 		var user model.AdminLoginModelReq
 		err := dbConn.Raw(`
@@ -86,22 +154,15 @@ func ForgotPasswordController() gin.HandlerFunc {
 			Scan(&user).Error
 
 		if err != nil || user.UserId == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": "Email not found or inactive"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "Email not found or inactive"))
 			return
 		}
 
-		otp := fmt.Sprintf("%04d", rand.Intn(10000))
-		expiry := time.Now().Add(2 * time.Minute).Format("2006-01-02 15:04:05")
-
-		otpEntry := model.OTPVerification{
-			Email:      req.Email,
-			OTP:        otp,
-			ExpiresAt:  expiry,
-			IsVerified: false,
-			CreatedAt:  time.Now().Format("2006-01-02 15:04:05"),
-			CreatedBy:  req.Email,
+		otp, err := service.CreatePasswordResetOTP(dbConn, req.Email)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to generate OTP"))
+			return
 		}
-		dbConn.Create(&otpEntry)
 
 		html := fmt.Sprintf(`
 		<table width="100%%" cellspacing="0" cellpadding="0" style="font-family: Arial, sans-serif; background-color: #f9f9f9; padding: 5px;">
@@ -151,8 +212,11 @@ func ForgotPasswordController() gin.HandlerFunc {
 			</tr>
 		</table>
 	`, otp)
-		if !mailService.MailService(req.Email, html, "Password Reset OTP") {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to send OTP email"})
+		// sent by a job worker: a slow SMTP server no longer holds the request, and the OTP
+		// leaves the job once sent. Three attempts fit in the OTP's lifetime.
+		_, err = mailService.Queue(dbConn.WithContext(c.Request.Context()), req.Email, html, "Password Reset OTP", jobqueue.Options{MaxAttempts: 3, Sensitive: true})
+		if err != nil {
+			apperror.Respond(c, apperror.Internal(err, "Failed to send OTP email"))
 			return
 		}
 
@@ -162,95 +226,223 @@ func ForgotPasswordController() gin.HandlerFunc {
 
 // Verify OTP
 
-func VerifyOtpController() gin.HandlerFunc {
+func VerifyOtpController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Email string `json:"email"`
-			OTP   string `json:"otp"`
-		}
+		var req model.VerifyOtpReq
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid data"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid data"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		emailKey := strings.ToLower(strings.TrimSpace(req.Email))
+		if !allowAttempt(c, dbConn, loginguard.ScopeOTP, emailKey) || !allowAttempt(c, dbConn, loginguard.ScopeIP, c.ClientIP()) {
+			return
+		}
 
-		var otpRecord model.OTPVerification
-		err := dbConn.
-			Where("email = ? AND otp = ? AND is_verified = false", req.Email, req.OTP).
-			Order("expires_at desc").First(&otpRecord).Error
+		resetToken, err := service.VerifyPasswordResetOTP(dbConn, req.Email, req.OTP)
+		switch {
+		case errors.Is(err, service.ErrOTPInvalid), errors.Is(err, service.ErrOTPAttempts):
+			recordOtpFailure(dbConn, emailKey, c.ClientIP())
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, err.Error()))
+			return
+		case errors.Is(err, service.ErrOTPExpired):
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "OTP expired"))
+			return
+		case err != nil:
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Something went wrong, Try Again"))
+			return
+		}
+		_ = loginguard.Reset(dbConn, loginguard.ScopeOTP, emailKey)
 
+		c.JSON(http.StatusOK, gin.H{
+			"status":     true,
+			"message":    "OTP verified successfully",
+			"resetToken": resetToken,
+			"expiresIn":  int(service.ResetTokenTTL.Seconds()),
+		})
+	}
+}
+
+// allowAttempt writes a 429 with Retry-After when the key is locked or delayed
+
+func allowAttempt(c *gin.Context, dbConn *gorm.DB, scope string, key string) bool {
+	log := logger.FromGin(c)
+
+	err := loginguard.Check(dbConn, scope, key)
+	if err == nil {
+		return true
+	}
+
+	var locked *loginguard.LockedError
+	if errors.As(err, &locked) {
+		log.Warnf("Attempt blocked %s=%s: %s", scope, key, err.Error())
+		c.Header("Retry-After", strconv.Itoa(locked.RetryAfterSeconds()))
+		apperror.Respond(c, apperror.New(apperror.CodeTooManyRequests, "Too many failed attempts, Try again later").
+			With("retryAfter", locked.RetryAfterSeconds()))
+		return false
+	}
+
+	log.Error("Attempt check failed: " + err.Error())
+	apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Something went wrong, Try Again"))
+	return false
+}
+
+func recordOtpFailure(dbConn *gorm.DB, emailKey string, ip string) {
+	log := logger.FromDB(dbConn)
+
+	_ = transactionLogger.LogTransaction(dbConn, 0, emailKey, 1, fmt.Sprintf("Failed OTP verification for %s from %s", emailKey, ip))
+
+	for _, key := range []struct{ scope, value string }{
+		{loginguard.ScopeOTP, emailKey},
+		{loginguard.ScopeIP, ip},
+	} {
+		locked, err := loginguard.RecordFailure(dbConn, key.scope, key.value)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Invalid OTP"})
-			return
+			log.Error(err.Error())
+			continue
+		}
+		if locked {
+			_ = transactionLogger.LogTransaction(dbConn, 0, emailKey, 1, fmt.Sprintf("OTP verification locked for %s %s after repeated failures", key.scope, key.value))
 		}
+	}
+}
 
-		expiryTime, _ := time.Parse("2006-01-02 15:04:05", otpRecord.ExpiresAt)
-		if time.Now().After(expiryTime) {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "OTP expired"})
+func recordResetRequest(dbConn *gorm.DB, emailKey string, ip string) {
+	log := logger.FromDB(dbConn)
+
+	for _, key := range []struct{ scope, value string }{
+		{loginguard.ScopeReset, emailKey},
+		{loginguard.ScopeIP, ip},
+	} {
+		locked, err := loginguard.RecordFailure(dbConn, key.scope, key.value)
+		if err != nil {
+			log.Error(err.Error())
+			continue
+		}
+		if locked {
+			_ = transactionLogger.LogTransaction(dbConn, 0, emailKey, 1, fmt.Sprintf("Password reset requests locked for %s %s after repeated requests", key.scope, key.value))
+		}
+	}
+}
+
+//  Reset Password
+
+func ResetPasswordController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req model.ResetPasswordReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid input: "+err.Error()))
 			return
 		}
 
-		otpRecord.IsVerified = true
-		otpRecord.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-		otpRecord.UpdatedBy = req.Email
-		dbConn.Save(&otpRecord)
+		if err := service.ResetPasswordService(dbConn, req); err != nil {
+			if errors.Is(err, service.ErrResetTokenInvalid) {
+				apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, err.Error()))
+				return
+			}
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Password update failed"))
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "OTP verified successfully"})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Password reset successfully"})
 	}
 }
 
-//  Reset Password
+// Locked usernames, IPs and OTP emails (admin)
 
-func ResetPasswordController() gin.HandlerFunc {
+func GetLoginLockoutsController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req struct {
-			Email       string `json:"email"`
-			NewPassword string `json:"newPassword"`
+		log := logger.FromGin(c)
+
+		locked, err := loginguard.ListLocked(dbConnt)
+		if err != nil {
+			log.Error("❌ " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
+			return
 		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid input"})
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": locked})
+	}
+}
+
+// Unlock a username, IP or OTP email (admin)
+
+func UnlockLoginController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		var reqVal model.UnlockLoginReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		unlockedBy := actor.FromGin(c).By()
 
-		var verifiedOtp model.OTPVerification
-		err := dbConn.Where("email = ? AND is_verified = true", req.Email).
-			Order("expires_at desc").First(&verifiedOtp).Error
+		unlocked, err := service.UnlockLoginService(dbConnt, reqVal, unlockedBy)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "OTP not verified"})
+			log.Error("❌ Unlock failed: " + err.Error())
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
 			return
 		}
 
-		hashedPassword, err := becrypt.HashPassword(req.NewPassword)
+		message := "Nothing to unlock"
+		if unlocked {
+			message = "Unlocked successfully"
+		}
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": message, "unlocked": unlocked})
+	}
+}
+
+// Access token signing keys (admin)
+
+func GetSigningKeysController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		keys, err := accesstoken.ListSigningKeys(dbConnt)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Error hashing password"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		err = dbConn.Exec(`
-			WITH target_user AS (
-				SELECT u."refUserId"
-				FROM "Users" u
-				JOIN "refUserCommunicationDetails" comm ON u."refUserId" = comm."refUserId"
-				WHERE comm."refUCDEmail" = ?
-				AND (u."isDelete" IS FALSE OR u."isDelete" IS NULL)
-				ORDER BY u."refUserId"
-				LIMIT 1
-			)
-			UPDATE "refUserAuthCred"
-			SET "refUACPassword" = ?, "refUACHashedPassword" = ?
-			WHERE "refUserId" = (SELECT "refUserId" FROM target_user)`,
-			req.Email, req.NewPassword, hashedPassword).Error
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": keys})
+	}
+}
+
+func RotateSigningKeyController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
+		rotatedBy := actor.FromGin(c).By()
+		kid, err := accesstoken.RotateSigningKey(dbConnt, rotatedBy)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Password update failed"})
+			log.Error("❌ " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Password reset successfully"})
+		log.Infof("🔑 Signing key rotated to %s by %s", kid, rotatedBy)
+		_ = transactionLogger.LogActorTransaction(actor.Bind(dbConnt, c), 2, "Access token signing key rotated to "+kid)
+
+		// Tokens signed with the retired key, the caller's included, keep verifying for
+		// accesstoken.KeyGracePeriod, so nobody has to log in again
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Signing key rotated", "kid": kid})
+	}
+}
+
+func RevokeSigningKeyController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		kid := c.Param("kid")
+
+		if err := accesstoken.RevokeSigningKey(dbConnt, kid); err != nil {
+			if errors.Is(err, accesstoken.ErrUnknownKey) {
+				apperror.Respond(c, apperror.NotFound(err.Error()))
+				return
+			}
+			apperror.Respond(c, apperror.BadRequest(err.Error()))
+			return
+		}
+
+		_ = transactionLogger.LogActorTransaction(actor.Bind(dbConnt, c), 2, "Access token signing key revoked: "+kid)
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Signing key revoked"})
 	}
 }
@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/model"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/service"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func writeLoginResponse(c *gin.Context, resVal model.LoginResponse) {
+	if !resVal.Status {
+		loginFailed(c, resVal.Message, resVal.RetryAfter)
+		return
+	}
+
+	response := gin.H{
+		"status":       true,
+		"message":      resVal.Message,
+		"user":         resVal.User,
+		"token":        resVal.Token,
+		"refreshToken": resVal.RefreshToken,
+	}
+	if len(resVal.RecoveryCodes) > 0 {
+		response["recoveryCodes"] = resVal.RecoveryCodes
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// loginFailed answers a rejected login: 401, or 429 with Retry-After while locked out
+func loginFailed(c *gin.Context, message string, retryAfter int) {
+	if retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		apperror.Respond(c, apperror.New(apperror.CodeTooManyRequests, message).With("retryAfter", retryAfter))
+		return
+	}
+	apperror.Respond(c, apperror.Unauthorized(message))
+}
+
+func twoFactorError(err error) *apperror.Error {
+	switch {
+	case errors.Is(err, service.ErrTwoFactorCode):
+		return apperror.Unauthorized(err.Error())
+	case errors.Is(err, service.ErrTwoFactorEnabled),
+		errors.Is(err, service.ErrTwoFactorNotSetUp),
+		errors.Is(err, service.ErrTwoFactorMandatory):
+		return apperror.Conflict(err.Error())
+	default:
+		return apperror.Wrap(err, apperror.CodeInternal, err.Error())
+	}
+}
+
+func contextUserId(c *gin.Context) (int, bool) {
+	idValue, _ := c.Get("id")
+	userId, err := roleType.ExtractIntFromInterface(idValue)
+	if err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Invalid user in token"))
+		return 0, false
+	}
+	return userId, true
+}
+
+// Second login step with a TOTP code or a recovery code
+
+func TwoFactorLoginController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("\n\nTwo Factor Login Controller -> \n================")
+
+		var reqVal model.TwoFactorLoginReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		writeLoginResponse(c, service.TwoFactorLoginService(dbConnt, reqVal, c.Request.UserAgent(), c.ClientIP()))
+	}
+}
+
+// Enrolment forced by the role policy, started from the login screen
+
+func TwoFactorEnrollSetupController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		var reqVal model.TwoFactorEnrollReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		userId, err := accesstoken.ParseChallengeToken(reqVal.ChallengeToken, accesstoken.PurposeTwoFactorEnroll)
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, service.ErrInvalidChallenge.Error()))
+			return
+		}
+
+		setup, err := service.StartTwoFactorSetupService(dbConnt, userId)
+		if err != nil {
+			log.Error("❌ Two-factor setup failed: " + err.Error())
+			apperror.Respond(c, twoFactorError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": setup})
+	}
+}
+
+func TwoFactorEnrollConfirmController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqVal model.TwoFactorEnrollReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil || reqVal.Code == "" {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Challenge token and code are required"))
+			return
+		}
+
+		writeLoginResponse(c, service.EnrollTwoFactorLoginService(dbConnt, reqVal, c.Request.UserAgent(), c.ClientIP()))
+	}
+}
+
+// Voluntary enrolment and management by a logged in user
+
+func TwoFactorSetupController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		userId, ok := contextUserId(c)
+		if !ok {
+			return
+		}
+
+		setup, err := service.StartTwoFactorSetupService(dbConnt, userId)
+		if err != nil {
+			log.Error("❌ Two-factor setup failed: " + err.Error())
+			apperror.Respond(c, twoFactorError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": setup})
+	}
+}
+
+func TwoFactorConfirmController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqVal model.TwoFactorCodeReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+		userId, ok := contextUserId(c)
+		if !ok {
+			return
+		}
+
+		codes, err := service.ConfirmTwoFactorSetupService(dbConnt, userId, reqVal.Code)
+		if err != nil {
+			apperror.Respond(c, twoFactorError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":        true,
+			"message":       "Two-factor authentication enabled",
+			"recoveryCodes": codes,
+		})
+	}
+}
+
+func TwoFactorDisableController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqVal model.TwoFactorCodeReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+		userId, ok := contextUserId(c)
+		if !ok {
+			return
+		}
+		roleIdValue, _ := c.Get("roleId")
+		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Invalid role ID"))
+			return
+		}
+
+		if err := service.DisableTwoFactorService(dbConnt, userId, roleId, reqVal.Code); err != nil {
+			apperror.Respond(c, twoFactorError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Two-factor authentication disabled"})
+	}
+}
+
+func RegenerateRecoveryCodesController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqVal model.TwoFactorCodeReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+		userId, ok := contextUserId(c)
+		if !ok {
+			return
+		}
+
+		codes, err := service.RegenerateRecoveryCodesService(dbConnt, userId, reqVal.Code)
+		if err != nil {
+			apperror.Respond(c, twoFactorError(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "recoveryCodes": codes})
+	}
+}
+
+// Per role policy and reset of a user's enrolment (admin)
+
+func GetTwoFactorPoliciesController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		policies, err := service.GetTwoFactorPoliciesService(dbConnt)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": policies})
+	}
+}
+
+func UpdateTwoFactorPolicyController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		targetRoleId, err := strconv.Atoi(c.Param("roleId"))
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid role ID"))
+			return
+		}
+
+		var reqVal model.TwoFactorPolicyReq
+		if err := c.ShouldBindJSON(&reqVal); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		if err := service.UpdateTwoFactorPolicyService(dbConnt, targetRoleId, reqVal.Policy, actor.FromGin(c).By()); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Two-factor policy updated successfully"})
+	}
+}
+
+func ResetTwoFactorController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		targetUserId, err := strconv.Atoi(c.Param("userId"))
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid user ID"))
+			return
+		}
+
+		if err := service.ResetTwoFactorService(dbConnt, targetUserId, actor.FromGin(c).By()); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Two-factor authentication reset successfully"})
+	}
+}
@@ -8,11 +8,32 @@ type AdminLoginReq struct {
 }
 
 type LoginResponse struct {
-	Status  bool                `json:"status"`
-	Message string              `json:"message"`
-	User    *AdminLoginModelReq `json:"user,omitempty"`
-	Token   string              `json:"token,omitempty"`
-	Email   string              `json:"email"`
+	Status       bool                `json:"status"`
+	Message      string              `json:"message"`
+	User         *AdminLoginModelReq `json:"user,omitempty"`
+	Token        string              `json:"token,omitempty"`
+	RefreshToken string              `json:"refreshToken,omitempty"`
+	Email        string              `json:"email"`
+	RetryAfter   int                 `json:"retryAfter,omitempty"`
+
+	// Second login step, set instead of Token when the role uses two-factor authentication
+	TwoFactorRequired      bool     `json:"twoFactorRequired,omitempty"`
+	TwoFactorSetupRequired bool     `json:"twoFactorSetupRequired,omitempty"`
+	ChallengeToken         string   `json:"challengeToken,omitempty"`
+	RecoveryCodes          []string `json:"recoveryCodes,omitempty"`
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type LogoutReq struct {
+	AllSessions bool `json:"allSessions"`
+}
+
+type UnlockLoginReq struct {
+	Scope string `json:"scope" binding:"required,oneof=username ip otp reset"`
+	Key   string `json:"key" binding:"required"`
 }
 
 type AdminLoginModelReq struct {
@@ -26,18 +47,109 @@ type AdminLoginModelReq struct {
 	UACUsername       string `json:"refUACUsername" gorm:"column:refUACUsername"`
 	UCDMobile         string `json:"refUCDMobile" gorm:"column:refUCDMobile"`
 	UCDEmail          string `json:"refUCDEmail" gorm:"column:refUCDEmail"`
-	UCDPassword       string `json:"refUACPassword" gorm:"column:refUACPassword"`
-	UCDHashedPassword string `json:"refUACHashedPassword" gorm:"column:refUACHashedPassword"`
+	UCDHashedPassword string `json:"-" gorm:"column:refUACHashedPassword"`
 }
 
+// OTPVerification keeps only hashes: the OTP mailed to the user and the reset token
+// handed out once the OTP is verified.
 type OTPVerification struct {
-	ID         int    `gorm:"primaryKey" json:"-"`
-	Email      string `gorm:"column:email"`
-	OTP        string `gorm:"column:otp"`
-	ExpiresAt  string `gorm:"column:expires_at"` // stored as text in DB
-	IsVerified bool   `gorm:"column:is_verified"`
-	CreatedAt  string `gorm:"column:createdAt"`
-	CreatedBy  string `gorm:"column:createdBy"`
-	UpdatedAt  string `gorm:"column:updatedAt"`
-	UpdatedBy  string `gorm:"column:updatedBy"`
+	ID                  int    `gorm:"primaryKey" json:"-"`
+	Email               string `gorm:"column:email"`
+	OTP                 string `gorm:"column:otp"` // legacy plain text column, no longer written
+	OTPHash             string `gorm:"column:otp_hash"`
+	Attempts            int    `gorm:"column:attempts"`
+	ExpiresAt           string `gorm:"column:expires_at"` // stored as text in DB
+	IsVerified          bool   `gorm:"column:is_verified"`
+	ResetTokenHash      string `gorm:"column:reset_token_hash"`
+	ResetTokenExpiresAt string `gorm:"column:reset_token_expires_at"`
+	ResetTokenUsedAt    string `gorm:"column:reset_token_used_at"`
+	CreatedAt           string `gorm:"column:createdAt"`
+	CreatedBy           string `gorm:"column:createdBy"`
+	UpdatedAt           string `gorm:"column:updatedAt"`
+	UpdatedBy           string `gorm:"column:updatedBy"`
+}
+
+type ForgotPasswordReq struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type VerifyOtpReq struct {
+	Email string `json:"email" binding:"required,email"`
+	OTP   string `json:"otp" binding:"required"`
+}
+
+type ResetPasswordReq struct {
+	Email       string `json:"email" binding:"required,email"`
+	ResetToken  string `json:"resetToken" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+}
+
+// TWO FACTOR AUTHENTICATION
+
+type UserTwoFactor struct {
+	RefUserId      int    `gorm:"column:refUserId;primaryKey" json:"refUserId"`
+	RefTFSecret    string `gorm:"column:refTFSecret" json:"-"`
+	RefTFEnabled   bool   `gorm:"column:refTFEnabled" json:"refTFEnabled"`
+	RefTFLastStep  int64  `gorm:"column:refTFLastStep" json:"-"`
+	RefTFEnabledAt string `gorm:"column:refTFEnabledAt" json:"refTFEnabledAt"`
+	CreatedAt      string `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt      string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (UserTwoFactor) TableName() string {
+	return `public."UserTwoFactor"`
+}
+
+type UserRecoveryCode struct {
+	RefRCId     int    `gorm:"column:refRCId;primaryKey;autoIncrement" json:"refRCId"`
+	RefUserId   int    `gorm:"column:refUserId" json:"refUserId"`
+	RefRCHash   string `gorm:"column:refRCHash" json:"-"`
+	RefRCUsedAt string `gorm:"column:refRCUsedAt" json:"refRCUsedAt"`
+	CreatedAt   string `gorm:"column:createdAt" json:"createdAt"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return `public."UserRecoveryCodes"`
+}
+
+// RoleTwoFactorPolicy is "off", "optional" or "required" for a RoleType.
+type RoleTwoFactorPolicy struct {
+	RefRTId     int    `gorm:"column:refRTId;primaryKey" json:"refRTId"`
+	RefTFPolicy string `gorm:"column:refTFPolicy" json:"refTFPolicy"`
+	UpdatedAt   string `gorm:"column:updatedAt" json:"updatedAt"`
+	UpdatedBy   string `gorm:"column:updatedBy" json:"updatedBy"`
+}
+
+func (RoleTwoFactorPolicy) TableName() string {
+	return `public."RoleTwoFactorPolicy"`
+}
+
+type TwoFactorPolicyResponse struct {
+	RefRTId     int    `json:"refRTId" gorm:"column:refRTId"`
+	RefRTName   string `json:"refRTName" gorm:"column:refRTName"`
+	RefTFPolicy string `json:"refTFPolicy" gorm:"column:refTFPolicy"`
+}
+
+type TwoFactorPolicyReq struct {
+	Policy string `json:"policy" binding:"required,oneof=off optional required"`
+}
+
+type TwoFactorLoginReq struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+type TwoFactorEnrollReq struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code"`
+}
+
+type TwoFactorCodeReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
 }
@@ -8,7 +8,6 @@ SELECT
   u."refUserFName",
   u."refUserLName",
   u."refUserBranchId",
-  uac."refUACPassword",
   uac."refUACHashedPassword",
   uac."refUACUsername",
   ucd."refUCDMobile",
@@ -24,3 +23,74 @@ ORDER BY
   u."refUserId" ASC
 LIMIT 1;
 `
+
+var SessionUserSQL = `
+SELECT
+  u."refUserId",
+  u."refRTId",
+  u."refUserBranchId"
+FROM
+  public."Users" u
+WHERE
+  u."refUserId" = $1
+  AND u."refUserStatus" = 'Active'
+  AND (u."isDelete" IS FALSE OR u."isDelete" IS NULL)
+LIMIT 1;
+`
+
+var ResetPasswordSQL = `
+WITH target_user AS (
+  SELECT
+    u."refUserId"
+  FROM
+    "Users" u
+    JOIN "refUserCommunicationDetails" comm ON u."refUserId" = comm."refUserId"
+  WHERE
+    comm."refUCDEmail" = $1
+    AND (u."isDelete" IS FALSE OR u."isDelete" IS NULL)
+  ORDER BY
+    u."refUserId"
+  LIMIT 1
+)
+UPDATE "refUserAuthCred"
+SET
+  "refUACHashedPassword" = $2
+WHERE
+  "refUserId" = (SELECT "refUserId" FROM target_user)
+RETURNING "refUserId";
+`
+
+var LoginUserByIdSQL = `
+SELECT
+  u."refUserId",
+  u."refUserCustId",
+  u."refRTId",
+  u."refUserFName",
+  u."refUserLName",
+  u."refUserBranchId",
+  uac."refUACHashedPassword",
+  uac."refUACUsername",
+  ucd."refUCDMobile",
+  ucd."refUCDEmail"
+FROM
+  public."Users" u
+  JOIN public."refUserAuthCred" uac ON u."refUserId" = uac."refUserId"
+  JOIN public."refUserCommunicationDetails" ucd ON u."refUserId" = ucd."refUserId"
+WHERE
+  u."refUserId" = $1
+  AND u."refUserStatus" = 'Active'
+  AND (u."isDelete" IS FALSE OR u."isDelete" IS NULL)
+LIMIT 1;
+`
+
+var TwoFactorPoliciesSQL = `
+SELECT
+  rt."refRTId",
+  rt."refRTName",
+  COALESCE(p."refTFPolicy", '') AS "refTFPolicy"
+FROM
+  public."RoleType" rt
+  LEFT JOIN public."RoleTwoFactorPolicy" p ON p."refRTId" = rt."refRTId"
+ORDER BY
+  rt."refRTId";
+`
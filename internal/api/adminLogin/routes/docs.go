@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/model"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	loginguard "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/LoginGuard"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/login":                    {Summary: "Admin login", Request: model.AdminLoginReq{}, Public: true},
+	"POST /api/v1/admin/refresh-token":            {Summary: "Refresh token", Request: model.RefreshTokenReq{}, Public: true},
+	"POST /api/v1/admin/logout":                   {Summary: "Logout", Request: model.LogoutReq{}},
+	"POST /api/v1/admin/forgot-password":          {Summary: "Forgot password", Request: model.ForgotPasswordReq{}, Public: true},
+	"POST /api/v1/admin/verify-otp":               {Summary: "Verify otp", Request: model.VerifyOtpReq{}, Public: true},
+	"POST /api/v1/admin/reset-password":           {Summary: "Reset password", Request: model.ResetPasswordReq{}, Public: true},
+	"POST /api/v1/admin/login/2fa":                {Summary: "Two factor login", Description: "The challenge token is good for one code: after a wrong code the user logs in with the password again.", Request: model.TwoFactorLoginReq{}, Public: true},
+	"POST /api/v1/admin/login/2fa/setup":          {Summary: "Two factor enroll setup", Request: model.TwoFactorEnrollReq{}, Response: model.TwoFactorSetupResponse{}, Public: true},
+	"POST /api/v1/admin/login/2fa/confirm":        {Summary: "Two factor enroll confirm", Description: "Uses up the challenge token, right code or not.", Request: model.TwoFactorEnrollReq{}, Public: true},
+	"POST /api/v1/admin/2fa/setup":                {Summary: "Two factor setup", Response: model.TwoFactorSetupResponse{}},
+	"POST /api/v1/admin/2fa/confirm":              {Summary: "Two factor confirm", Request: model.TwoFactorCodeReq{}},
+	"POST /api/v1/admin/2fa/disable":              {Summary: "Two factor disable", Request: model.TwoFactorCodeReq{}},
+	"POST /api/v1/admin/2fa/recovery-codes":       {Summary: "Regenerate recovery codes", Request: model.TwoFactorCodeReq{}},
+	"GET /api/v1/admin/2fa/policies":              {Summary: "Get two factor policies", Response: []model.TwoFactorPolicyResponse{}, Permission: permission.RolesManage},
+	"PUT /api/v1/admin/2fa/policies/:roleId":      {Summary: "Update two factor policy", Request: model.TwoFactorPolicyReq{}, Permission: permission.RolesManage},
+	"POST /api/v1/admin/2fa/reset/:userId":        {Summary: "Reset two factor", Permission: permission.EmployeesManage},
+	"GET /api/v1/admin/signing-keys":              {Summary: "Get signing keys", Response: []accesstoken.SigningKey{}, Permission: permission.SecurityManage},
+	"POST /api/v1/admin/signing-keys/rotate":      {Summary: "Rotate signing key", Permission: permission.SecurityManage},
+	"POST /api/v1/admin/signing-keys/:kid/revoke": {Summary: "Revoke signing key", Permission: permission.SecurityManage},
+	"GET /api/v1/admin/login-lockouts":            {Summary: "Get login lockouts", Response: []loginguard.LoginAttempt{}, Permission: permission.EmployeesManage},
+	"POST /api/v1/admin/login-unlock":             {Summary: "Unlock login", Request: model.UnlockLoginReq{}, Permission: permission.EmployeesManage},
+}
@@ -2,16 +2,45 @@ package routes
 
 import (
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/controller"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func RegisterAdminRoutes(router *gin.Engine) {
+func RegisterAdminRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin")
 
-	route.POST("/login", controller.AdminLoginController())
+	route.POST("/login", controller.AdminLoginController(dbConn))
+	route.POST("/refresh-token", controller.RefreshTokenController(dbConn))
+	route.POST("/logout", accesstoken.JWTMiddleware(), controller.LogoutController(dbConn))
 
-	route.POST("/forgot-password", controller.ForgotPasswordController())
-	route.POST("/verify-otp", controller.VerifyOtpController())
-	route.POST("/reset-password", controller.ResetPasswordController())
+	route.POST("/forgot-password", controller.ForgotPasswordController(dbConn))
+	route.POST("/verify-otp", controller.VerifyOtpController(dbConn))
+	route.POST("/reset-password", controller.ResetPasswordController(dbConn))
 
+	// TWO FACTOR AUTHENTICATION
+	route.POST("/login/2fa", controller.TwoFactorLoginController(dbConn))
+	route.POST("/login/2fa/setup", controller.TwoFactorEnrollSetupController(dbConn))
+	route.POST("/login/2fa/confirm", controller.TwoFactorEnrollConfirmController(dbConn))
+
+	route.POST("/2fa/setup", accesstoken.JWTMiddleware(), controller.TwoFactorSetupController(dbConn))
+	route.POST("/2fa/confirm", accesstoken.JWTMiddleware(), controller.TwoFactorConfirmController(dbConn))
+	route.POST("/2fa/disable", accesstoken.JWTMiddleware(), controller.TwoFactorDisableController(dbConn))
+	route.POST("/2fa/recovery-codes", accesstoken.JWTMiddleware(), controller.RegenerateRecoveryCodesController(dbConn))
+
+	route.GET("/2fa/policies", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.RolesManage), controller.GetTwoFactorPoliciesController(dbConn))
+	route.PUT("/2fa/policies/:roleId", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.RolesManage), controller.UpdateTwoFactorPolicyController(dbConn))
+	route.POST("/2fa/reset/:userId", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), controller.ResetTwoFactorController(dbConn))
+
+	// ACCESS TOKEN SIGNING KEYS
+	route.GET("/signing-keys", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SecurityManage), controller.GetSigningKeysController(dbConn))
+	route.POST("/signing-keys/rotate", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SecurityManage), controller.RotateSigningKeyController(dbConn))
+	route.POST("/signing-keys/:kid/revoke", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SecurityManage), controller.RevokeSigningKeyController(dbConn))
+
+	route.GET("/login-lockouts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), controller.GetLoginLockoutsController(dbConn))
+	route.POST("/login-unlock", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), controller.UnlockLoginController(dbConn))
+
+	openapi.Describe("Admin", docs)
 }
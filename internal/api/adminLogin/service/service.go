@@ -1,22 +1,40 @@
 package service
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/model"
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/query"
 	transactionLogger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
 	becrypt "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Bcrypt"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	loginguard "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/LoginGuard"
 	"gorm.io/gorm"
 )
 
-func AdminLoginService(db *gorm.DB, reqVal model.AdminLoginReq) model.LoginResponse {
-	log := logger.InitLogger()
+func AdminLoginService(db *gorm.DB, reqVal model.AdminLoginReq, userAgent string, ip string) model.LoginResponse {
+	log := logger.FromDB(db)
 
 	var AdminLoginModel []model.AdminLoginModelReq
 
 	log.Info("\n\nUser Details -----> \n\n" + reqVal.Username)
 
+	usernameKey := strings.ToLower(strings.TrimSpace(reqVal.Username))
+	if resp, blocked := checkLoginGuard(db, usernameKey, ip); blocked {
+		return resp
+	}
+
 	err := db.Raw(query.AdminLoginSQL, reqVal.Username).Scan(&AdminLoginModel).Error
 	if err != nil {
 		log.Error("Login Service DB Error: " + err.Error())
@@ -28,6 +46,7 @@ func AdminLoginService(db *gorm.DB, reqVal model.AdminLoginReq) model.LoginRespo
 
 	if len(AdminLoginModel) == 0 {
 		log.Warn("LoginService Invalid Credentials (u) for Username : " + reqVal.Username)
+		recordLoginFailure(db, 0, usernameKey, ip, "unknown username")
 		return model.LoginResponse{
 			Status:  false,
 			Message: "Invalid Username or Password",
@@ -35,13 +54,12 @@ func AdminLoginService(db *gorm.DB, reqVal model.AdminLoginReq) model.LoginRespo
 	}
 
 	user := AdminLoginModel[0]
-	log.Info("Database query values ---> ", user)
 
 	match := becrypt.ComparePasswords(user.UCDHashedPassword, reqVal.Password)
-	log.Warn("\n\nPassword checking => ", match)
 
 	if !match {
 		log.Warn("Login Service Invalid Credentials for Username : ", reqVal.Username)
+		recordLoginFailure(db, user.UserId, usernameKey, ip, "wrong password")
 		return model.LoginResponse{
 			Status:  false,
 			Message: "Invalid Username or Password",
@@ -50,22 +68,390 @@ func AdminLoginService(db *gorm.DB, reqVal model.AdminLoginReq) model.LoginRespo
 
 	log.Info("Login service - Logged Successfully for Username : " + reqVal.Username)
 
+	resp, pending, err := twoFactorStep(db, user)
+	if err != nil {
+		log.Error("Login Service Two-Factor Error: " + err.Error())
+		return model.LoginResponse{
+			Status:  false,
+			Message: "Something went wrong, Try Again",
+		}
+	}
+	if pending {
+		log.Info("Login service - Two-factor step pending for Username : " + reqVal.Username)
+		return resp
+	}
+
+	return issueLogin(db, user, userAgent, ip)
+}
+
+// issueLogin opens a session for a fully authenticated user. Only here, past the second
+// factor, does the username counter reset: a known password alone must not clear it.
+func issueLogin(db *gorm.DB, user model.AdminLoginModelReq, userAgent string, ip string) model.LoginResponse {
+	log := logger.FromDB(db)
+
+	usernameKey := strings.ToLower(strings.TrimSpace(user.UACUsername))
+	if err := loginguard.Reset(db, loginguard.ScopeUsername, usernameKey); err != nil {
+		log.Error("Login Service failed to reset attempts: " + err.Error())
+	}
+
 	// 🔽 Log the login transaction
 	_ = transactionLogger.LogTransaction(
 		db,
 		user.UserId,
-		"Admin", // or user.Username if preferred
-		1,       // 1 = Login
-		"User logged in: "+reqVal.Username,
+		actor.Resolve(db, user.UserId, 0, 0).By(),
+		1, // 1 = Login
+		"User logged in: "+user.UACUsername,
 	)
 
-	token := accesstoken.CreateToken(user.UserId, user.RoleTypeId, user.UserBranchId)
-	log.Info("\n\n\nToken Testing --------->" + token)
+	sessionId, refreshToken, err := accesstoken.CreateSession(db, user.UserId, userAgent, ip)
+	if err != nil {
+		log.Error("Login Service Session Error: " + err.Error())
+		return model.LoginResponse{
+			Status:  false,
+			Message: "Something went wrong, Try Again",
+		}
+	}
+
+	token := accesstoken.CreateToken(user.UserId, user.RoleTypeId, user.UserBranchId, sessionId)
+
+	return model.LoginResponse{
+		Status:       true,
+		Message:      "Logged in Successfully",
+		User:         &user,
+		Token:        token,
+		RefreshToken: refreshToken,
+	}
+}
+
+func RefreshTokenService(db *gorm.DB, refreshToken string) model.LoginResponse {
+	log := logger.FromDB(db)
+
+	session, newRefreshToken, err := accesstoken.RotateRefreshToken(db, refreshToken)
+	if err != nil {
+		log.Warn("Refresh Token Service rejected: " + err.Error())
+		return model.LoginResponse{
+			Status:  false,
+			Message: "Session expired, Please login again",
+		}
+	}
+
+	// Re-read the user so a disabled employee cannot keep refreshing.
+	var user model.AdminLoginModelReq
+	err = db.Raw(query.SessionUserSQL, session.RefUserId).Scan(&user).Error
+	if err != nil || user.UserId == 0 {
+		log.Warnf("Refresh Token Service: user %d not active, revoking session", session.RefUserId)
+		_ = accesstoken.RevokeSession(db, session.RefSessionId, "user inactive")
+		return model.LoginResponse{
+			Status:  false,
+			Message: "Session expired, Please login again",
+		}
+	}
+
+	token := accesstoken.CreateToken(user.UserId, user.RoleTypeId, user.UserBranchId, session.RefSessionId)
 
 	return model.LoginResponse{
-		Status:  true,
-		Message: "Logged in Successfully",
-		User:    &user,
-		Token:   token,
+		Status:       true,
+		Message:      "Token refreshed",
+		Token:        token,
+		RefreshToken: newRefreshToken,
+	}
+}
+
+func LogoutService(db *gorm.DB, userId int, sessionId string, allSessions bool) error {
+	log := logger.FromDB(db)
+
+	var err error
+	if allSessions {
+		err = accesstoken.RevokeUserSessions(db, userId, "logout all")
+	} else {
+		err = accesstoken.RevokeSession(db, sessionId, "logout")
+	}
+	if err != nil {
+		log.Error("Logout Service Error: " + err.Error())
+		return err
+	}
+
+	_ = transactionLogger.LogTransaction(
+		db,
+		userId,
+		actor.Resolve(db, userId, 0, 0).By(),
+		1, // 1 = Login
+		"User logged out",
+	)
+	return nil
+}
+
+// checkLoginGuard refuses the attempt while the username or the client IP is locked or delayed.
+func checkLoginGuard(db *gorm.DB, usernameKey string, ip string) (model.LoginResponse, bool) {
+	log := logger.FromDB(db)
+
+	for _, key := range []struct{ scope, value string }{
+		{loginguard.ScopeUsername, usernameKey},
+		{loginguard.ScopeIP, ip},
+	} {
+		err := loginguard.Check(db, key.scope, key.value)
+		if err == nil {
+			continue
+		}
+
+		var locked *loginguard.LockedError
+		if errors.As(err, &locked) {
+			log.Warnf("Login Service blocked %s=%s: %s", key.scope, key.value, err.Error())
+			return model.LoginResponse{
+				Status:     false,
+				Message:    "Too many failed attempts, Try again later",
+				RetryAfter: locked.RetryAfterSeconds(),
+			}, true
+		}
+
+		log.Error("Login Service attempt check failed: " + err.Error())
+		return model.LoginResponse{
+			Status:  false,
+			Message: "Something went wrong, Try Again",
+		}, true
+	}
+	return model.LoginResponse{}, false
+}
+
+// recordLoginFailure counts the failure against the username and the IP and keeps a security trail.
+func recordLoginFailure(db *gorm.DB, userId int, usernameKey string, ip string, reason string) {
+	log := logger.FromDB(db)
+
+	_ = transactionLogger.LogTransaction(
+		db,
+		userId,
+		usernameKey,
+		1, // 1 = Login
+		fmt.Sprintf("Failed login for %s from %s: %s", usernameKey, ip, reason),
+	)
+
+	for _, key := range []struct{ scope, value string }{
+		{loginguard.ScopeUsername, usernameKey},
+		{loginguard.ScopeIP, ip},
+	} {
+		locked, err := loginguard.RecordFailure(db, key.scope, key.value)
+		if err != nil {
+			log.Error("Login Service " + err.Error())
+			continue
+		}
+		if locked {
+			log.Warnf("🔒 Login locked for %s=%s", key.scope, key.value)
+			_ = transactionLogger.LogTransaction(
+				db,
+				userId,
+				usernameKey,
+				1, // 1 = Login
+				fmt.Sprintf("Login locked for %s %s after repeated failures", key.scope, key.value),
+			)
+		}
+	}
+}
+
+// UnlockLoginService clears the failure counter of a username, an IP, an OTP email or a
+// password reset email.
+func UnlockLoginService(db *gorm.DB, reqVal model.UnlockLoginReq, unlockedBy string) (bool, error) {
+	key := strings.TrimSpace(reqVal.Key)
+	if reqVal.Scope == loginguard.ScopeUsername || reqVal.Scope == loginguard.ScopeOTP || reqVal.Scope == loginguard.ScopeReset {
+		key = strings.ToLower(key)
+	}
+
+	unlocked, err := loginguard.Unlock(db, reqVal.Scope, key)
+	if err != nil {
+		return false, err
+	}
+
+	if unlocked {
+		_ = transactionLogger.LogTransaction(
+			db,
+			0,
+			unlockedBy,
+			2, // 2 = Update
+			fmt.Sprintf("Login unlocked for %s %s", reqVal.Scope, key),
+		)
+	}
+	return unlocked, nil
+}
+
+// PASSWORD RESET - forgot-password mails an OTP, verify-otp exchanges it for a
+// single-use reset token, reset-password consumes that token
+
+const (
+	OTPTTL          = 2 * time.Minute
+	ResetTokenTTL   = 10 * time.Minute
+	MaxOTPAttempts  = 5
+	resetTimeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	ErrOTPInvalid        = errors.New("invalid OTP")
+	ErrOTPExpired        = errors.New("OTP expired")
+	ErrOTPAttempts       = errors.New("too many wrong OTP attempts, request a new OTP")
+	ErrResetTokenInvalid = errors.New("reset token is invalid or expired")
+)
+
+// hashSecret keys the hash with PASSWORD_RESET_SECRET, a 6 digit OTP would be trivial
+// to brute force from a plain SHA-256 if the table leaked.
+func hashSecret(value string) string {
+	mac := hmac.New(sha256.New, []byte(config.Get().Auth.PasswordReset.Reveal()))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func GenerateOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// CreatePasswordResetOTP stores a fresh OTP for the email and returns it for mailing.
+// Earlier OTPs of the same email stop working.
+func CreatePasswordResetOTP(db *gorm.DB, email string) (string, error) {
+	otp, err := GenerateOTP()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate OTP: %w", err)
+	}
+
+	now := time.Now()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.OTPVerification{}).
+			Where("email = ? AND is_verified = false", email).
+			Updates(map[string]interface{}{"expires_at": now.Format(resetTimeLayout), "updatedAt": now.Format(resetTimeLayout)}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.OTPVerification{
+			Email:      email,
+			OTPHash:    hashSecret(email + ":" + otp),
+			ExpiresAt:  now.Add(OTPTTL).Format(resetTimeLayout),
+			IsVerified: false,
+			CreatedAt:  now.Format(resetTimeLayout),
+			CreatedBy:  email,
+		}).Error
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store OTP: %w", err)
+	}
+	return otp, nil
+}
+
+// VerifyPasswordResetOTP checks the latest OTP of the email and returns a reset token.
+func VerifyPasswordResetOTP(db *gorm.DB, email string, otp string) (string, error) {
+	var record model.OTPVerification
+	err := db.Where("email = ? AND is_verified = false AND COALESCE(otp_hash, '') <> ''", email).
+		Order("id desc").First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrOTPInvalid
+	}
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	expiresAt, err := time.ParseInLocation(resetTimeLayout, record.ExpiresAt, time.Local)
+	if err != nil || now.After(expiresAt) {
+		return "", ErrOTPExpired
+	}
+	if record.Attempts >= MaxOTPAttempts {
+		return "", ErrOTPAttempts
+	}
+
+	if !hmac.Equal([]byte(record.OTPHash), []byte(hashSecret(email+":"+otp))) {
+		if err := db.Model(&model.OTPVerification{}).Where("id = ?", record.ID).
+			Update("attempts", gorm.Expr("COALESCE(attempts, 0) + 1")).Error; err != nil {
+			return "", err
+		}
+		if record.Attempts+1 >= MaxOTPAttempts {
+			return "", ErrOTPAttempts
+		}
+		return "", ErrOTPInvalid
+	}
+
+	resetToken, err := randomToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	// Guard on is_verified so two concurrent verifications cannot both get a token
+	result := db.Model(&model.OTPVerification{}).
+		Where("id = ? AND is_verified = false", record.ID).
+		Updates(map[string]interface{}{
+			"is_verified":            true,
+			"reset_token_hash":       hashSecret(resetToken),
+			"reset_token_expires_at": now.Add(ResetTokenTTL).Format(resetTimeLayout),
+			"updatedAt":              now.Format(resetTimeLayout),
+			"updatedBy":              email,
+		})
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", ErrOTPInvalid
+	}
+	return resetToken, nil
+}
+
+// ResetPasswordService consumes the reset token, sets the new password and ends every session.
+func ResetPasswordService(db *gorm.DB, reqVal model.ResetPasswordReq) error {
+	log := logger.FromDB(db)
+
+	hashedPassword, err := becrypt.HashPassword(reqVal.NewPassword)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
+	now := time.Now().Format(resetTimeLayout)
+	var userIds []int
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.OTPVerification{}).
+			Where(`email = ? AND reset_token_hash = ? AND is_verified = true
+				AND COALESCE(reset_token_used_at, '') = '' AND reset_token_expires_at > ?`,
+				reqVal.Email, hashSecret(reqVal.ResetToken), now).
+			Updates(map[string]interface{}{
+				"reset_token_used_at": now,
+				"updatedAt":           now,
+				"updatedBy":           reqVal.Email,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrResetTokenInvalid
+		}
+
+		var updated []struct {
+			RefUserId int `gorm:"column:refUserId"`
+		}
+		err := tx.Raw(query.ResetPasswordSQL, reqVal.Email, hashedPassword).Scan(&updated).Error
+		if err != nil {
+			return fmt.Errorf("password update failed: %w", err)
+		}
+		// A changed password ends every session opened with the old one; both commit
+		// together, so a failed revoke leaves the old password in place
+		for _, u := range updated {
+			if err := accesstoken.RevokeUserSessions(tx, u.RefUserId, "password reset"); err != nil {
+				log.Error("Reset Password failed to revoke sessions: " + err.Error())
+				return fmt.Errorf("sessions could not be revoked: %w", err)
+			}
+			userIds = append(userIds, u.RefUserId)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, userId := range userIds {
+		_ = transactionLogger.LogTransaction(db, userId, reqVal.Email, 2, "Password reset through OTP")
+	}
+	return nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
+	return hex.EncodeToString(buf), nil
 }
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/model"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/adminLogin/query"
+	transactionLogger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	totp "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/TOTP"
+	"gorm.io/gorm"
+)
+
+// TWO FACTOR POLICIES
+const (
+	TwoFactorOff      = "off"
+	TwoFactorOptional = "optional"
+	TwoFactorRequired = "required"
+
+	TwoFactorIssuer   = "Snehalayaa Silks ERP"
+	RecoveryCodeCount = 10
+)
+
+// DefaultTwoFactorPolicies applies to roles without a row in RoleTwoFactorPolicy:
+// Super Admin, Admin and Accounts Manager approve money-moving actions.
+var DefaultTwoFactorPolicies = map[int]string{
+	1: TwoFactorRequired,
+	2: TwoFactorRequired,
+	3: TwoFactorRequired,
+}
+
+var (
+	ErrTwoFactorCode        = errors.New("invalid authentication code")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp    = errors.New("two-factor authentication is not set up")
+	ErrTwoFactorMandatory   = errors.New("two-factor authentication is required for your role")
+	ErrInvalidChallenge     = errors.New("login step expired, Please login again")
+	ErrTwoFactorMissingCode = errors.New("authentication code or recovery code is required")
+)
+
+// GetTwoFactorPolicy returns the configured policy of a role, falling back to the defaults.
+func GetTwoFactorPolicy(db *gorm.DB, roleId int) (string, error) {
+	var policy model.RoleTwoFactorPolicy
+	err := db.Where(`"refRTId" = ?`, roleId).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if p, ok := DefaultTwoFactorPolicies[roleId]; ok {
+			return p, nil
+		}
+		return TwoFactorOptional, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read two-factor policy: %w", err)
+	}
+	return policy.RefTFPolicy, nil
+}
+
+func getTwoFactor(db *gorm.DB, userId int) (*model.UserTwoFactor, error) {
+	var tf model.UserTwoFactor
+	err := db.Where(`"refUserId" = ?`, userId).First(&tf).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tf, nil
+}
+
+// twoFactorStep decides what follows a correct password: nothing, a code, or enrolment.
+func twoFactorStep(db *gorm.DB, user model.AdminLoginModelReq) (model.LoginResponse, bool, error) {
+	roleId, _ := strconv.Atoi(user.RoleTypeId)
+	policy, err := GetTwoFactorPolicy(db, roleId)
+	if err != nil {
+		return model.LoginResponse{}, false, err
+	}
+	if policy == TwoFactorOff {
+		return model.LoginResponse{}, false, nil
+	}
+
+	tf, err := getTwoFactor(db, user.UserId)
+	if err != nil {
+		return model.LoginResponse{}, false, err
+	}
+
+	if tf != nil && tf.RefTFEnabled {
+		challenge, err := accesstoken.CreateChallengeToken(user.UserId, accesstoken.PurposeTwoFactor)
+		if err != nil {
+			return model.LoginResponse{}, false, err
+		}
+		return model.LoginResponse{
+			Status:            true,
+			Message:           "Enter the code from your authenticator app",
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+		}, true, nil
+	}
+
+	if policy == TwoFactorRequired {
+		challenge, err := accesstoken.CreateChallengeToken(user.UserId, accesstoken.PurposeTwoFactorEnroll)
+		if err != nil {
+			return model.LoginResponse{}, false, err
+		}
+		return model.LoginResponse{
+			Status:                 true,
+			Message:                "Set up two-factor authentication to continue",
+			TwoFactorSetupRequired: true,
+			ChallengeToken:         challenge,
+		}, true, nil
+	}
+
+	return model.LoginResponse{}, false, nil
+}
+
+// verifyTOTP accepts a code only for a step newer than the last one used.
+func verifyTOTP(db *gorm.DB, tf *model.UserTwoFactor, code string) error {
+	step, ok := totp.Validate(tf.RefTFSecret, code, time.Now())
+	if !ok || step <= tf.RefTFLastStep {
+		return ErrTwoFactorCode
+	}
+
+	result := db.Model(&model.UserTwoFactor{}).
+		Where(`"refUserId" = ? AND COALESCE("refTFLastStep", 0) < ?`, tf.RefUserId, step).
+		Updates(map[string]interface{}{
+			"refTFLastStep": step,
+			"updatedAt":     time.Now().Format(resetTimeLayout),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorCode
+	}
+	return nil
+}
+
+// useRecoveryCode burns one unused recovery code of the user.
+func useRecoveryCode(db *gorm.DB, userId int, code string) error {
+	result := db.Model(&model.UserRecoveryCode{}).
+		Where(`"refUserId" = ? AND "refRCHash" = ? AND COALESCE("refRCUsedAt", '') = ''`, userId, totp.HashRecoveryCode(code)).
+		Update("refRCUsedAt", time.Now().Format(resetTimeLayout))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTwoFactorCode
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userId int) ([]string, error) {
+	codes, err := totp.GenerateRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where(`"refUserId" = ?`, userId).Delete(&model.UserRecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now().Format(resetTimeLayout)
+	for _, code := range codes {
+		row := model.UserRecoveryCode{RefUserId: userId, RefRCHash: totp.HashRecoveryCode(code), CreatedAt: now}
+		if err := tx.Create(&row).Error; err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+func loadLoginUser(db *gorm.DB, userId int) (*model.AdminLoginModelReq, error) {
+	var user model.AdminLoginModelReq
+	if err := db.Raw(query.LoginUserByIdSQL, userId).Scan(&user).Error; err != nil {
+		return nil, err
+	}
+	if user.UserId == 0 {
+		return nil, ErrInvalidChallenge
+	}
+	return &user, nil
+}
+
+// TwoFactorLoginService is the second login step: the challenge token of the password
+// step plus a TOTP code or a recovery code.
+func TwoFactorLoginService(db *gorm.DB, reqVal model.TwoFactorLoginReq, userAgent string, ip string) model.LoginResponse {
+	log := logger.FromDB(db)
+
+	if reqVal.Code == "" && reqVal.RecoveryCode == "" {
+		return model.LoginResponse{Status: false, Message: ErrTwoFactorMissingCode.Error()}
+	}
+	// one guess per challenge, whatever its outcome
+	userId, err := accesstoken.ConsumeChallengeToken(db, reqVal.ChallengeToken, accesstoken.PurposeTwoFactor)
+	if err != nil {
+		return model.LoginResponse{Status: false, Message: ErrInvalidChallenge.Error()}
+	}
+
+	user, err := loadLoginUser(db, userId)
+	if err != nil {
+		return model.LoginResponse{Status: false, Message: ErrInvalidChallenge.Error()}
+	}
+
+	usernameKey := strings.ToLower(strings.TrimSpace(user.UACUsername))
+	if resp, blocked := checkLoginGuard(db, usernameKey, ip); blocked {
+		return resp
+	}
+
+	tf, err := getTwoFactor(db, userId)
+	if err != nil || tf == nil || !tf.RefTFEnabled {
+		return model.LoginResponse{Status: false, Message: ErrInvalidChallenge.Error()}
+	}
+
+	if reqVal.RecoveryCode != "" {
+		err = useRecoveryCode(db, userId, reqVal.RecoveryCode)
+	} else {
+		err = verifyTOTP(db, tf, reqVal.Code)
+	}
+	if errors.Is(err, ErrTwoFactorCode) {
+		log.Warnf("Two-factor login rejected for userId=%d", userId)
+		recordLoginFailure(db, userId, usernameKey, ip, "wrong two-factor code")
+		return model.LoginResponse{Status: false, Message: ErrTwoFactorCode.Error()}
+	}
+	if err != nil {
+		log.Error("Two-factor login error: " + err.Error())
+		return model.LoginResponse{Status: false, Message: "Something went wrong, Try Again"}
+	}
+
+	if reqVal.RecoveryCode != "" {
+		_ = transactionLogger.LogTransaction(db, userId, usernameKey, 1, "Recovery code used to login")
+	}
+
+	return issueLogin(db, *user, userAgent, ip)
+}
+
+// StartTwoFactorSetupService creates a new pending secret; it only takes effect once confirmed.
+func StartTwoFactorSetupService(db *gorm.DB, userId int) (*model.TwoFactorSetupResponse, error) {
+	tf, err := getTwoFactor(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.RefTFEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	user, err := loadLoginUser(db, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	now := time.Now().Format(resetTimeLayout)
+	row := model.UserTwoFactor{
+		RefUserId:   userId,
+		RefTFSecret: secret,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if tf != nil {
+		row.CreatedAt = tf.CreatedAt
+	}
+	if err := db.Save(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+
+	return &model.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(TwoFactorIssuer, user.UACUsername, secret),
+	}, nil
+}
+
+// ConfirmTwoFactorSetupService enables 2FA once the first code is correct and returns fresh recovery codes.
+func ConfirmTwoFactorSetupService(db *gorm.DB, userId int, code string) ([]string, error) {
+	tf, err := getTwoFactor(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	if tf.RefTFEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	step, ok := totp.Validate(tf.RefTFSecret, code, time.Now())
+	if !ok {
+		return nil, ErrTwoFactorCode
+	}
+
+	var codes []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Format(resetTimeLayout)
+		if err := tx.Model(&model.UserTwoFactor{}).Where(`"refUserId" = ?`, userId).
+			Updates(map[string]interface{}{
+				"refTFEnabled":   true,
+				"refTFLastStep":  step,
+				"refTFEnabledAt": now,
+				"updatedAt":      now,
+			}).Error; err != nil {
+			return err
+		}
+		var err error
+		codes, err = replaceRecoveryCodes(tx, userId)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	_ = transactionLogger.LogTransaction(db, userId, actor.Resolve(db, userId, 0, 0).By(), 2, "Two-factor authentication enabled")
+	return codes, nil
+}
+
+// EnrollTwoFactorLoginService confirms an enrolment started from the login screen and completes the login.
+func EnrollTwoFactorLoginService(db *gorm.DB, reqVal model.TwoFactorEnrollReq, userAgent string, ip string) model.LoginResponse {
+	userId, err := accesstoken.ConsumeChallengeToken(db, reqVal.ChallengeToken, accesstoken.PurposeTwoFactorEnroll)
+	if err != nil {
+		return model.LoginResponse{Status: false, Message: ErrInvalidChallenge.Error()}
+	}
+
+	codes, err := ConfirmTwoFactorSetupService(db, userId, reqVal.Code)
+	if err != nil {
+		return model.LoginResponse{Status: false, Message: err.Error()}
+	}
+
+	user, err := loadLoginUser(db, userId)
+	if err != nil {
+		return model.LoginResponse{Status: false, Message: ErrInvalidChallenge.Error()}
+	}
+
+	resp := issueLogin(db, *user, userAgent, ip)
+	resp.RecoveryCodes = codes
+	return resp
+}
+
+// DisableTwoFactorService turns 2FA off for the user, unless the role requires it.
+func DisableTwoFactorService(db *gorm.DB, userId int, roleId int, code string) error {
+	policy, err := GetTwoFactorPolicy(db, roleId)
+	if err != nil {
+		return err
+	}
+	if policy == TwoFactorRequired {
+		return ErrTwoFactorMandatory
+	}
+
+	tf, err := getTwoFactor(db, userId)
+	if err != nil {
+		return err
+	}
+	if tf == nil || !tf.RefTFEnabled {
+		return ErrTwoFactorNotSetUp
+	}
+	if err := verifyTOTP(db, tf, code); err != nil {
+		return err
+	}
+
+	if err := removeTwoFactor(db, userId); err != nil {
+		return err
+	}
+	_ = transactionLogger.LogTransaction(db, userId, actor.Resolve(db, userId, 0, 0).By(), 2, "Two-factor authentication disabled")
+	return nil
+}
+
+// RegenerateRecoveryCodesService replaces every recovery code of the user.
+func RegenerateRecoveryCodesService(db *gorm.DB, userId int, code string) ([]string, error) {
+	tf, err := getTwoFactor(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.RefTFEnabled {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	if err := verifyTOTP(db, tf, code); err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = replaceRecoveryCodes(tx, userId)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// ResetTwoFactorService is for an administrator when a user lost their device.
+func ResetTwoFactorService(db *gorm.DB, userId int, resetBy string) error {
+	if err := removeTwoFactor(db, userId); err != nil {
+		return err
+	}
+	_ = transactionLogger.LogTransaction(db, userId, resetBy, 2, fmt.Sprintf("Two-factor authentication reset for userId %d", userId))
+	return nil
+}
+
+func removeTwoFactor(db *gorm.DB, userId int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(`"refUserId" = ?`, userId).Delete(&model.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where(`"refUserId" = ?`, userId).Delete(&model.UserTwoFactor{}).Error
+	})
+}
+
+// GetTwoFactorPoliciesService lists every role with its effective policy.
+func GetTwoFactorPoliciesService(db *gorm.DB) ([]model.TwoFactorPolicyResponse, error) {
+	var policies []model.TwoFactorPolicyResponse
+	if err := db.Raw(query.TwoFactorPoliciesSQL).Scan(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch two-factor policies: %w", err)
+	}
+	for i := range policies {
+		if policies[i].RefTFPolicy != "" {
+			continue
+		}
+		policies[i].RefTFPolicy = TwoFactorOptional
+		if p, ok := DefaultTwoFactorPolicies[policies[i].RefRTId]; ok {
+			policies[i].RefTFPolicy = p
+		}
+	}
+	return policies, nil
+}
+
+// UpdateTwoFactorPolicyService sets the policy of a role.
+func UpdateTwoFactorPolicyService(db *gorm.DB, roleId int, policy string, updatedBy string) error {
+	var count int64
+	if err := db.Table(`public."RoleType"`).Where(`"refRTId" = ?`, roleId).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check role type: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("invalid role type ID: %d", roleId)
+	}
+
+	row := model.RoleTwoFactorPolicy{
+		RefRTId:     roleId,
+		RefTFPolicy: policy,
+		UpdatedAt:   time.Now().Format(resetTimeLayout),
+		UpdatedBy:   updatedBy,
+	}
+	if err := db.Save(&row).Error; err != nil {
+		return fmt.Errorf("failed to save two-factor policy: %w", err)
+	}
+
+	_ = transactionLogger.LogTransaction(db, 0, updatedBy, 2, fmt.Sprintf("Two-factor policy of role %d set to %s", roleId, policy))
+	return nil
+}
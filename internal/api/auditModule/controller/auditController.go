@@ -0,0 +1,68 @@
+package auditController
+
+import (
+	"net/http"
+
+	auditModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/model"
+	auditService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/service"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func respondAuditLogs(c *gin.Context, dbConn *gorm.DB, filter auditModel.AuditLogQuery) {
+	scope, ok := branchscope.Resolve(c)
+	if !ok {
+		return
+	}
+
+	logs, total, err := auditService.GetAuditLogsService(dbConn, filter, scope)
+	if err != nil {
+		apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch audit logs"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": true,
+		"data":   logs,
+		"total":  total,
+	})
+}
+
+// GetAuditLogsController lists the audit trail filtered by entity, entityId, action,
+// actorId and a fromDate / toDate range.
+func GetAuditLogsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("📜 GetAuditLogsController invoked")
+
+		var filter auditModel.AuditLogQuery
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			validation.Respond(c, err)
+			return
+		}
+
+		respondAuditLogs(c, dbConn, filter)
+	}
+}
+
+// GetEntityHistoryController is the full history of one record, e.g. /history/supplier/12.
+func GetEntityHistoryController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("📜 GetEntityHistoryController invoked")
+
+		var filter auditModel.AuditLogQuery
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			validation.Respond(c, err)
+			return
+		}
+		filter.Entity = c.Param("entity")
+		filter.EntityId = c.Param("entityId")
+
+		respondAuditLogs(c, dbConn, filter)
+	}
+}
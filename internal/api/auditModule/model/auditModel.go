@@ -0,0 +1,22 @@
+package auditModel
+
+import audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+
+// AuditLogQuery filters the audit trail; every field is optional. The branch comes from
+// the caller's scope (?branchId= / ?allBranches=true).
+type AuditLogQuery struct {
+	Entity   string `form:"entity"`
+	EntityId string `form:"entityId"`
+	Action   string `form:"action" binding:"omitempty,oneof=create update delete restore receive"`
+	ActorId  int    `form:"actorId" binding:"omitempty,gt=0"`
+	FromDate string `form:"fromDate" binding:"omitempty,datetime=2006-01-02"`
+	ToDate   string `form:"toDate" binding:"omitempty,datetime=2006-01-02"`
+	Page     int    `form:"page" binding:"omitempty,gt=0"`
+	Limit    int    `form:"limit" binding:"omitempty,gt=0,lte=200"`
+}
+
+type AuditLogResponse struct {
+	audit.Log
+	ActorName string `json:"actorName" gorm:"column:actorName"`
+	ActorRole string `json:"actorRole" gorm:"column:actorRole"`
+}
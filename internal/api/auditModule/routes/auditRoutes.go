@@ -0,0 +1,19 @@
+package auditRoutes
+
+import (
+	auditController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/controller"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func AuditRoutes(router *gin.Engine, dbConn *gorm.DB) {
+	route := router.Group("/api/v1/admin/audit")
+
+	route.GET("/logs", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.AuditView), auditController.GetAuditLogsController(dbConn))
+	route.GET("/history/:entity/:entityId", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.AuditView), auditController.GetEntityHistoryController(dbConn))
+
+	openapi.Describe("Audit", docs)
+}
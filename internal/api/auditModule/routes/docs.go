@@ -0,0 +1,12 @@
+package auditRoutes
+
+import (
+	auditModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/model"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"GET /api/v1/admin/audit/logs":                      {Summary: "Get audit logs", QueryParams: auditModel.AuditLogQuery{}, Permission: permission.AuditView},
+	"GET /api/v1/admin/audit/history/:entity/:entityId": {Summary: "Get entity history", QueryParams: auditModel.AuditLogQuery{}, Permission: permission.AuditView},
+}
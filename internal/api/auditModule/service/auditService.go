@@ -0,0 +1,82 @@
+package auditService
+
+import (
+	"fmt"
+
+	auditModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/auditModule/model"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"gorm.io/gorm"
+)
+
+const defaultLimit = 50
+
+// filterAuditLogs applies the scope and filters; it is built twice because a gorm chain
+// cannot be reused after Count.
+func filterAuditLogs(db *gorm.DB, filter auditModel.AuditLogQuery, scope branchscope.Scope) *gorm.DB {
+	query := db.Table(`public."AuditLog" a`)
+
+	condition, args := scope.Condition(`a."refALBranchId"`)
+	query = query.Where(condition, args...)
+
+	if filter.Entity != "" {
+		query = query.Where(`a."refALEntity" = ?`, filter.Entity)
+	}
+	if filter.EntityId != "" {
+		query = query.Where(`a."refALEntityId" = ?`, filter.EntityId)
+	}
+	if filter.Action != "" {
+		query = query.Where(`a."refALAction" = ?`, filter.Action)
+	}
+	if filter.ActorId > 0 {
+		query = query.Where(`a."refALActorId" = ?`, filter.ActorId)
+	}
+	if filter.FromDate != "" {
+		query = query.Where(`a."createdAt" >= ?`, filter.FromDate+" 00:00:00")
+	}
+	if filter.ToDate != "" {
+		query = query.Where(`a."createdAt" <= ?`, filter.ToDate+" 23:59:59")
+	}
+	return query
+}
+
+// GetAuditLogsService answers "who changed this and when": newest entries first, with the
+// total count of matches for paging.
+func GetAuditLogsService(db *gorm.DB, filter auditModel.AuditLogQuery, scope branchscope.Scope) ([]auditModel.AuditLogResponse, int64, error) {
+	log := logger.FromDB(db)
+	log.Infof("🔎 GetAuditLogsService invoked with %+v", filter)
+
+	var total int64
+	if err := filterAuditLogs(db, filter, scope).Count(&total).Error; err != nil {
+		log.Error("❌ Failed to count audit logs: " + err.Error())
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	page := filter.Page
+	if page == 0 {
+		page = 1
+	}
+
+	logs := []auditModel.AuditLogResponse{}
+	err := filterAuditLogs(db, filter, scope).
+		Select(`a.*,
+			COALESCE(TRIM(u."refUserFName" || ' ' || COALESCE(u."refUserLName", '')), 'System') AS "actorName",
+			COALESCE(r."refRTName", '') AS "actorRole"`).
+		Joins(`LEFT JOIN public."Users" u ON u."refUserId" = a."refALActorId"`).
+		Joins(`LEFT JOIN public."RoleType" r ON r."refRTId" = a."refALActorRoleId"`).
+		Order(`a."refALId" DESC`).
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Scan(&logs).Error
+	if err != nil {
+		log.Error("❌ Failed to fetch audit logs: " + err.Error())
+		return nil, 0, fmt.Errorf("failed to fetch audit logs: %w", err)
+	}
+
+	log.Infof("✅ %d of %d audit logs fetched", len(logs), total)
+	return logs, total, nil
+}
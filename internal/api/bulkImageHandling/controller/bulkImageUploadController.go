@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	bulkImageUploadService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/service"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	"github.com/gin-gonic/gin"
 	"github.com/labstack/gommon/log"
 )
@@ -20,12 +21,12 @@ func GenerateBulkUploadURLHandler(c *gin.Context) {
 	var req BulkUploadRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Errorf("Invalid request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		apperror.Respond(c, apperror.BadRequest("Invalid request body"))
 		return
 	}
 
 	if len(req.FileNames) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file names provided"})
+		apperror.Respond(c, apperror.BadRequest("No file names provided"))
 		return
 	}
 
@@ -52,7 +53,7 @@ func GenerateBulkUploadURLHandler(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
+		"status":  true,
 		"results": results,
 	})
 }
@@ -63,17 +64,18 @@ func GetImageViewURLHandler(c *gin.Context) {
 
 	expireMins, err := strconv.Atoi(expireStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expiry"})
+		apperror.Respond(c, apperror.BadRequest("Invalid expiry"))
 		return
 	}
 
 	url, err := bulkImageUploadService.GetImageViewURL(fileName, expireMins)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate view URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate view URL"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"status":  true,
 		"viewUrl": url,
 	})
 }
@@ -2,6 +2,7 @@ package bulkImageUploadRoutes
 
 import (
 	bulkImageUploadController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/controller"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
 	"github.com/gin-gonic/gin"
 
 )
@@ -14,4 +15,6 @@ func BulkImageUploadRoutes(router *gin.Engine) {
 
 	// Optional: Generate presigned GET URL for temporary preview
 	route.GET("/getImageViewURL/:filename/:expireMins", bulkImageUploadController.GetImageViewURLHandler)
+
+	openapi.Describe("Bulk Image Upload", docs)
 }
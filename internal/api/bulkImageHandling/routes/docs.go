@@ -0,0 +1,11 @@
+package bulkImageUploadRoutes
+
+import (
+	bulkImageUploadController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/controller"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/bulkImageUpload/generateUploadURL":                    {Summary: "Generate bulk upload URL", Request: bulkImageUploadController.BulkUploadRequest{}, Public: true},
+	"GET /api/v1/bulkImageUpload/getImageViewURL/:filename/:expireMins": {Summary: "Get image view URL", Public: true},
+}
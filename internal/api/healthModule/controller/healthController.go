@@ -0,0 +1,62 @@
+package healthController
+
+import (
+	"net/http"
+
+	healthModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/healthModule/model"
+	healthService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/healthModule/service"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	buildinfo "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BuildInfo"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LivenessController answers as long as the process serves requests; it checks no
+// dependency, so a database outage never gets the pod restarted.
+func LivenessController() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"build":  buildinfo.Get(),
+		})
+	}
+}
+
+// ReadinessController returns 503 while a critical dependency (Postgres) is down. Down
+// integrations report "degraded" and keep the instance in rotation. Being public, it
+// gives only ok/fail per dependency; DiagnosticsController has the details.
+func ReadinessController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checks := healthService.RunChecks(c.Request.Context(), dbConn, false)
+		status := healthService.Overall(checks)
+
+		code := http.StatusOK
+		if status == "unavailable" {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, healthModel.ReadinessResponse{
+			Status: status,
+			Build:  buildinfo.Get(),
+			Checks: healthService.Probe(checks),
+		})
+	}
+}
+
+// DiagnosticsController is the admin view: fresh checks, pool usage and the redacted config.
+func DiagnosticsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🩺 DiagnosticsController invoked")
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data": healthModel.DiagnosticsResponse{
+				Build:  buildinfo.Get(),
+				Checks: healthService.RunChecks(c.Request.Context(), dbConn, true),
+				Pool:   db.Stats(dbConn),
+				Config: healthService.RedactedConfig(),
+			},
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package healthModel
+
+import buildinfo "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BuildInfo"
+
+// CHECK STATUS
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDisabled = "disabled"
+)
+
+// Check is the state of one dependency. A down critical check makes the service not ready;
+// the others only degrade the features that use them.
+type Check struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latencyMs"`
+	Detail    string `json:"detail,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt string `json:"checkedAt"`
+}
+
+// Probe states, the only thing the unauthenticated /readyz tells about a dependency
+const (
+	ProbeOK       = "ok"
+	ProbeFail     = "fail"
+	ProbeDisabled = "disabled"
+)
+
+// ProbeCheck is the public view of a Check: no error text, detail or timing, which can
+// name hosts, versions or accounts. Those stay in the authenticated diagnostics.
+type ProbeCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string         `json:"status"`
+	Build  buildinfo.Info `json:"build"`
+	Checks []ProbeCheck   `json:"checks"`
+}
+
+type DiagnosticsResponse struct {
+	Build  buildinfo.Info         `json:"build"`
+	Checks []Check                `json:"checks"`
+	Pool   map[string]interface{} `json:"pool"`
+	Config map[string]string      `json:"config"`
+}
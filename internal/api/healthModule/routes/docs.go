@@ -0,0 +1,12 @@
+package healthRoutes
+
+import (
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"GET /healthz":                  {Summary: "Liveness", Public: true},
+	"GET /readyz":                   {Summary: "Readiness", Public: true},
+	"GET /api/v1/admin/diagnostics": {Summary: "Diagnostics", Permission: permission.SecurityManage},
+}
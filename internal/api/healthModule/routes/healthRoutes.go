@@ -0,0 +1,23 @@
+package healthRoutes
+
+import (
+	healthController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/healthModule/controller"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func HealthRoutes(router *gin.Engine, dbConn *gorm.DB) {
+	// PROBES - unauthenticated, outside /api so db.Guard lets them through
+	router.GET("/healthz", healthController.LivenessController())
+	router.GET("/readyz", healthController.ReadinessController(dbConn))
+
+	// DIAGNOSTICS - answer while the database is down, which is when they are needed
+	router.GET("/api/v1/admin/diagnostics", accesstoken.DiagnosticsJWTMiddleware(), permission.RequirePermission(permission.SecurityManage), healthController.DiagnosticsController(dbConn))
+	db.Unguard("/api/v1/admin/diagnostics")
+
+	openapi.Describe("Health", docs)
+}
@@ -0,0 +1,124 @@
+package healthRoutes
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakePostgres answers the keyset and role permission reads until down is set, then
+// fails every query and ping like a pool whose server went away.
+type fakePostgres struct {
+	down atomic.Bool
+}
+
+var errConnRefused = errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+
+func (f *fakePostgres) Open(string) (driver.Conn, error) {
+	if f.down.Load() {
+		return nil, errConnRefused
+	}
+	return &fakeConn{f}, nil
+}
+
+type fakeConn struct{ f *fakePostgres }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) Ping(context.Context) error {
+	if c.f.down.Load() {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if c.f.down.Load() {
+		return nil, errConnRefused
+	}
+	if strings.Contains(query, `"RolePermissions"`) {
+		return &fakeRows{columns: []string{"permissionKey"}, values: []string{permission.SecurityManage}}, nil
+	}
+	return &fakeRows{columns: []string{"id"}}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  []string
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func TestDiagnosticsAnswerWithDatabaseDownAfterCacheExpiry(t *testing.T) {
+	config.Set(&config.Config{Auth: config.AuthConfig{AccessToken: "test-access-token"}})
+	gin.SetMode(gin.TestMode)
+
+	fake := &fakePostgres{}
+	sql.Register("fakepostgres-diagnostics", fake)
+	sqlDB, err := sql.Open("fakepostgres-diagnostics", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	dbConn, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accesstoken.UseDB(dbConn)
+	permission.UseDB(dbConn)
+
+	// A non super admin role, so RequirePermission has to consult the permission cache
+	const roleId = 2
+	token := accesstoken.CreateToken(7, roleId, 1, "session-1")
+	if ok, err := permission.HasPermission(roleId, permission.SecurityManage); err != nil || !ok {
+		t.Fatalf("priming permissions: %v, %v", ok, err)
+	}
+
+	// Postgres goes away and both caches pass their TTL
+	fake.down.Store(true)
+	accesstoken.InvalidateKeyset()
+	permission.InvalidateCache()
+
+	router := gin.New()
+	router.Use(apperror.Middleware(), db.Guard(dbConn))
+	HealthRoutes(router, dbConn)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/diagnostics", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"status":"down"`) {
+		t.Errorf("diagnostics do not report postgres down: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,178 @@
+package healthService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	healthModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/healthModule/model"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db/migrations"
+	storage "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Storage"
+	shopifyConfig "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/shopify"
+	"gorm.io/gorm"
+)
+
+const (
+	checkTimeout = 3 * time.Second
+
+	// Probes hit /readyz every few seconds; the integrations, Shopify above all, are
+	// rate limited, so their results are reused for this long.
+	integrationCacheTTL = 30 * time.Second
+)
+
+type checker struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) (string, error)
+}
+
+func checkers(dbConn *gorm.DB) []checker {
+	return []checker{
+		{name: "postgres", critical: true, run: func(ctx context.Context) (string, error) {
+			return "", db.Ping(ctx, dbConn)
+		}},
+		{name: "schema", critical: true, run: func(ctx context.Context) (string, error) {
+			return schemaCheck(dbConn.WithContext(ctx))
+		}},
+		{name: "storage", run: storageCheck},
+		{name: "shopify", run: shopifyCheck},
+	}
+}
+
+// errDisabled marks an integration that was never configured, which is not a failure.
+var errDisabled = fmt.Errorf("not configured")
+
+func storageCheck(ctx context.Context) (string, error) {
+	detail, err := storage.Get().Check(ctx)
+	if errors.Is(err, storage.ErrNotConfigured) {
+		return "", errDisabled
+	}
+	return detail, err
+}
+
+// schemaCheck reports the applied schema version and fails when it drifts from the
+// migrations embedded in this build.
+func schemaCheck(dbConn *gorm.DB) (string, error) {
+	current, err := migrations.Current(dbConn)
+	if err != nil {
+		return "", err
+	}
+	detail := fmt.Sprintf("version %d of %d", current, migrations.Latest())
+	return detail, migrations.Check(dbConn)
+}
+
+func shopifyCheck(ctx context.Context) (string, error) {
+	if shopifyConfig.ShopifyClient == nil {
+		return "", errDisabled
+	}
+	shop, err := shopifyConfig.ShopifyClient.Shop.Get(ctx, nil)
+	if err != nil {
+		return shopifyConfig.ShopName, err
+	}
+	return shop.Domain, nil
+}
+
+func runCheck(ctx context.Context, c checker) healthModel.Check {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	started := time.Now()
+	detail, err := c.run(ctx)
+	check := healthModel.Check{
+		Name:      c.name,
+		Status:    healthModel.StatusUp,
+		Critical:  c.critical,
+		LatencyMs: time.Since(started).Milliseconds(),
+		Detail:    detail,
+		CheckedAt: started.Format("2006-01-02 15:04:05"),
+	}
+	switch {
+	case err == errDisabled:
+		check.Status = healthModel.StatusDisabled
+	case err != nil:
+		check.Status = healthModel.StatusDown
+		check.Error = err.Error()
+	}
+	return check
+}
+
+var (
+	cacheMu   sync.Mutex
+	cached    = map[string]healthModel.Check{}
+	cachedAts = map[string]time.Time{}
+)
+
+// RunChecks checks every dependency concurrently. Postgres is always checked live; the
+// integrations come from the cache unless fresh is set.
+func RunChecks(ctx context.Context, dbConn *gorm.DB, fresh bool) []healthModel.Check {
+	list := checkers(dbConn)
+	results := make([]healthModel.Check, len(list))
+
+	var wg sync.WaitGroup
+	for i, c := range list {
+		if !fresh && !c.critical {
+			cacheMu.Lock()
+			check, ok := cached[c.name]
+			at := cachedAts[c.name]
+			cacheMu.Unlock()
+			if ok && time.Since(at) < integrationCacheTTL {
+				results[i] = check
+				continue
+			}
+		}
+
+		wg.Add(1)
+		go func(i int, c checker) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+
+			cacheMu.Lock()
+			cached[c.name] = results[i]
+			cachedAts[c.name] = time.Now()
+			cacheMu.Unlock()
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+// Overall is "ready" when everything is up, "degraded" when only integrations are down
+// and "unavailable" when a critical dependency is down.
+func Overall(checks []healthModel.Check) string {
+	status := "ready"
+	for _, check := range checks {
+		if check.Status != healthModel.StatusDown {
+			continue
+		}
+		if check.Critical {
+			return "unavailable"
+		}
+		status = "degraded"
+	}
+	return status
+}
+
+// Probe reduces checks to ok/fail per dependency for the public readiness probe.
+func Probe(checks []healthModel.Check) []healthModel.ProbeCheck {
+	probes := make([]healthModel.ProbeCheck, len(checks))
+	for i, check := range checks {
+		status := healthModel.ProbeOK
+		switch check.Status {
+		case healthModel.StatusDown:
+			status = healthModel.ProbeFail
+		case healthModel.StatusDisabled:
+			status = healthModel.ProbeDisabled
+		}
+		probes[i] = healthModel.ProbeCheck{Name: check.Name, Status: status}
+	}
+	return probes
+}
+
+// RedactedConfig lists the loaded settings with secrets masked.
+func RedactedConfig() map[string]string {
+	return config.Get().Redacted()
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
 	"gorm.io/gorm"
 )
 
@@ -17,3 +18,9 @@ func LogTransaction(db *gorm.DB, userId int, createdBy string, transTypeId int,
 	}
 	return db.Table(`"TransactionHistory"`).Create(&history).Error
 }
+
+// LogActorTransaction logs on behalf of the actor the connection was bound to with actor.Bind.
+func LogActorTransaction(db *gorm.DB, transTypeId int, message string) error {
+	by := actor.FromDB(db)
+	return LogTransaction(db, by.UserId, by.By(), transTypeId, message)
+}
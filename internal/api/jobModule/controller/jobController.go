@@ -0,0 +1,142 @@
+package jobController
+
+import (
+	"net/http"
+	"strconv"
+
+	jobService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/jobModule/service"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func jobID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		apperror.Respond(c, apperror.BadRequest("Invalid job ID"))
+		return 0, false
+	}
+	return id, true
+}
+
+// GetJobsController lists background jobs, e.g. ?status=dead to see the dead letters.
+func GetJobsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("⚙️ GetJobsController invoked")
+
+		query, err := listquery.Parse(c, jobService.JobList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		jobs, page, err := jobService.GetJobsService(dbConn, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch jobs"))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":     true,
+			"data":       jobs,
+			"pagination": page,
+		})
+	}
+}
+
+func GetJobStatsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("⚙️ GetJobStatsController invoked")
+
+		stats, err := jobService.GetJobStatsService(dbConn)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to count jobs"))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   stats,
+		})
+	}
+}
+
+func GetJobController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("⚙️ GetJobController invoked")
+
+		id, ok := jobID(c)
+		if !ok {
+			return
+		}
+
+		job, err := jobService.GetJobService(dbConn, id)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   job,
+		})
+	}
+}
+
+// RetryJobController puts a dead job back in the queue with a fresh set of attempts.
+func RetryJobController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("⚙️ RetryJobController invoked")
+
+		id, ok := jobID(c)
+		if !ok {
+			return
+		}
+
+		job, err := jobService.RetryJobService(actor.Bind(dbConn, c), id)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  true,
+			"message": "Job queued for another run",
+			"data":    job,
+		})
+	}
+}
+
+// GetEventsController lists outbox events; each dispatched one has a delivery job per
+// subscriber, see GET /jobs?kind=outbox.deliver.
+func GetEventsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("⚙️ GetEventsController invoked")
+
+		query, err := listquery.Parse(c, jobService.EventList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		events, page, err := jobService.GetEventsService(dbConn, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch events"))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":     true,
+			"data":       events,
+			"pagination": page,
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package jobRoutes
+
+import (
+	jobService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/jobModule/service"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"GET /api/v1/admin/jobs":            {Summary: "List background jobs", Description: "?status=dead lists the jobs that ran out of attempts. Payloads of sensitive jobs are never shown.", Response: []jobqueue.View{}, List: &jobService.JobList, Permission: permission.JobsManage},
+	"GET /api/v1/admin/jobs/stats":      {Summary: "Count background jobs by kind and status", Response: jobService.Stats{}, Permission: permission.JobsManage},
+	"GET /api/v1/admin/jobs/:id":        {Summary: "Get a background job with its last error and result", Response: jobqueue.View{}, Permission: permission.JobsManage},
+	"GET /api/v1/admin/events":          {Summary: "List outbox events", Description: "Domain events such as grn.created, stock.transferred and sku.sold, with the number of subscribers each was dispatched to. Delivery is at least once, one job per subscriber; events of a type without subscribers stay pending.", Response: []outbox.View{}, List: &jobService.EventList, Permission: permission.JobsManage},
+	"POST /api/v1/admin/jobs/:id/retry": {Summary: "Retry a dead job", Description: "Resets the attempts and runs the job again as soon as a worker is free. Only dead jobs can be retried.", Response: jobqueue.View{}, Permission: permission.JobsManage},
+}
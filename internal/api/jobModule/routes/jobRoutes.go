@@ -0,0 +1,24 @@
+package jobRoutes
+
+import (
+	jobController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/jobModule/controller"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func JobRoutes(router *gin.Engine, dbConn *gorm.DB) {
+	route := router.Group("/api/v1/admin/jobs")
+
+	route.GET("", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.JobsManage), jobController.GetJobsController(dbConn))
+	route.GET("/stats", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.JobsManage), jobController.GetJobStatsController(dbConn))
+	route.GET("/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.JobsManage), jobController.GetJobController(dbConn))
+	route.POST("/:id/retry", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.JobsManage), jobController.RetryJobController(dbConn))
+
+	events := router.Group("/api/v1/admin/events")
+	events.GET("", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.JobsManage), jobController.GetEventsController(dbConn))
+
+	openapi.Describe("Jobs", docs)
+}
@@ -0,0 +1,129 @@
+package jobService
+
+import (
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
+	"gorm.io/gorm"
+)
+
+// JobList is what GET /jobs may be filtered, searched and sorted on.
+var JobList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"status":    {Column: "refJobStatus", Kind: listquery.Text},
+		"kind":      {Column: "refJobKind", Kind: listquery.Text},
+		"attempts":  {Column: "refJobAttempts", Kind: listquery.Number},
+		"createdAt": {Column: "createdAt", Kind: listquery.Date},
+		"createdBy": {Column: "createdBy", Kind: listquery.Text},
+	},
+	Sorts: map[string]string{
+		"id":        "refJobId",
+		"kind":      "refJobKind",
+		"status":    "refJobStatus",
+		"attempts":  "refJobAttempts",
+		"runAt":     "refJobRunAt",
+		"createdAt": "createdAt",
+	},
+	Search:      []string{"refJobKind", "refJobLastError", "refJobRequestId"},
+	Key:         "refJobId",
+	DefaultSort: "-id",
+}
+
+// KindCount is how many jobs of a kind are in a status.
+type KindCount struct {
+	Kind   string `json:"kind" gorm:"column:refJobKind"`
+	Status string `json:"status" gorm:"column:refJobStatus"`
+	Count  int64  `json:"count" gorm:"column:count"`
+}
+
+func GetJobsService(db *gorm.DB, query listquery.Query) ([]jobqueue.View, listquery.Page, error) {
+	log := logger.FromDB(db)
+	log.Info("⚙️ GetJobsService invoked")
+
+	jobs := []jobqueue.Job{}
+	page, err := listquery.Find(db, `SELECT * FROM public."Jobs"`, nil, query, &jobs)
+	if err != nil {
+		return nil, page, err
+	}
+
+	views := make([]jobqueue.View, 0, len(jobs))
+	for _, job := range jobs {
+		views = append(views, job.View())
+	}
+	return views, page, nil
+}
+
+// Stats lists the kinds this process handles and how many jobs of each are in a status.
+type Stats struct {
+	Kinds  []string    `json:"kinds"`
+	Counts []KindCount `json:"counts"`
+}
+
+func GetJobStatsService(db *gorm.DB) (Stats, error) {
+	counts := []KindCount{}
+	err := db.Model(&jobqueue.Job{}).
+		Select(`"refJobKind", "refJobStatus", COUNT(*) AS count`).
+		Group(`"refJobKind", "refJobStatus"`).
+		Order(`"refJobKind", "refJobStatus"`).
+		Scan(&counts).Error
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{Kinds: jobqueue.Kinds(), Counts: counts}, nil
+}
+
+func GetJobService(db *gorm.DB, id int64) (jobqueue.View, error) {
+	job, err := jobqueue.Get(db, id)
+	if err != nil {
+		return jobqueue.View{}, err
+	}
+	return job.View(), nil
+}
+
+func RetryJobService(db *gorm.DB, id int64) (jobqueue.View, error) {
+	log := logger.FromDB(db)
+	log.Infof("⚙️ RetryJobService invoked for job %d", id)
+
+	job, err := jobqueue.Retry(db, id)
+	if err != nil {
+		return jobqueue.View{}, err
+	}
+	return job.View(), nil
+}
+
+// EventList is what GET /events may be filtered, searched and sorted on.
+var EventList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"type":      {Column: "refOEType", Kind: listquery.Text},
+		"key":       {Column: "refOEKey", Kind: listquery.Text},
+		"status":    {Column: "refOEStatus", Kind: listquery.Text},
+		"branchId":  {Column: "refOEBranchId", Kind: listquery.ID},
+		"createdAt": {Column: "createdAt", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"id":        "refOEId",
+		"type":      "refOEType",
+		"createdAt": "createdAt",
+	},
+	Search:      []string{"refOEType", "refOEKey", "refOERequestId"},
+	Key:         "refOEId",
+	DefaultSort: "-id",
+}
+
+func GetEventsService(db *gorm.DB, query listquery.Query) ([]outbox.View, listquery.Page, error) {
+	log := logger.FromDB(db)
+	log.Info("⚙️ GetEventsService invoked")
+
+	events := []outbox.Event{}
+	page, err := listquery.Find(db, `SELECT * FROM public."OutboxEvents"`, nil, query, &events)
+	if err != nil {
+		return nil, page, err
+	}
+
+	views := make([]outbox.View, 0, len(events))
+	for _, event := range events {
+		views = append(views, event.View())
+	}
+	return views, page, nil
+}
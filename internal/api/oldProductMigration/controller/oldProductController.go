@@ -1,13 +1,12 @@
 package oldProductController
 
 import (
-	"fmt"
 	"net/http"
 
 	oldProductMigrationModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/oldProductMigration/model"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func getUserContext(c *gin.Context) (interface{}, interface{}, interface{}) {
@@ -16,18 +15,15 @@ func getUserContext(c *gin.Context) (interface{}, interface{}, interface{}) {
 	branchIdValue, branchIdExists := c.Get("branchId")
 
 	if !idExists || !roleIdExists || !branchIdExists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  false,
-			"message": "User ID, RoleID, Branch ID not found in request context.",
-		})
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 		return nil, nil, nil
 	}
 	return idValue, roleIdValue, branchIdValue
 }
 
-func MigrateOldProductsController() gin.HandlerFunc {
+func MigrateOldProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
@@ -35,16 +31,11 @@ func MigrateOldProductsController() gin.HandlerFunc {
 		var oldProdMigr oldProductMigrationModel.MigrateOldProductToDbModel
 
 		if err := c.ShouldBindJSON(&oldProdMigr); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		fmt.Print("dbConn", dbConn)
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product created successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product created successfully"})
 
 	}
 }
@@ -0,0 +1,11 @@
+package oldProductRoutes
+
+import (
+	oldProductMigrationModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/oldProductMigration/model"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/oldProductMigration/create": {Summary: "Migrate old products", Request: oldProductMigrationModel.MigrateOldProductToDbModel{}, Permission: permission.ProductsManage, Idempotent: true},
+}
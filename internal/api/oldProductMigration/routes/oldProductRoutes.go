@@ -3,10 +3,16 @@ package oldProductRoutes
 import (
 	oldProductController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/oldProductMigration/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func OldProductMigrationRoutes(router *gin.Engine) {
+func OldProductMigrationRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/oldProductMigration")
-	route.POST("/create", accesstoken.JWTMiddleware(), oldProductController.MigrateOldProductsController())
+	route.POST("/create", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ProductsManage), idempotency.Middleware(), oldProductController.MigrateOldProductsController(dbConn))
+
+	openapi.Describe("Old Product Migration", docs)
 }
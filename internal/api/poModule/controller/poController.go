@@ -5,17 +5,17 @@ import (
 
 	poModuleModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/model"
 	poService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
-	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func CreatePurchaseOrderController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreatePurchaseOrderController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n🚀 CreatePurchaseOrderController invoked")
 
 		// Extract context
@@ -27,10 +27,7 @@ func CreatePurchaseOrderController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found"))
 			return
 		}
 
@@ -38,42 +35,23 @@ func CreatePurchaseOrderController() gin.HandlerFunc {
 		var poPayload poModuleModel.PurchaseOrderPayload
 		if err := c.ShouldBindJSON(&poPayload); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("📦 PO Payload Received: %+v", poPayload)
 
 		// DB connection
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		// Role resolve
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID provided: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Warn("⚠️ Failed to resolve role name: " + err.Error())
-			roleName = "Unknown"
-		} else {
-			log.Infof("👤 Role Name: %s", roleName)
-		}
 
 		// Service call
-		purchaseOrderNumber, err := poService.CreatePurchaseOrderService(dbConnt, &poPayload, roleName)
+		purchaseOrderNumber, err := poService.CreatePurchaseOrderService(actor.Bind(dbConnt, c), &poPayload)
 		if err != nil {
 			log.Error("❌ PO Service Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		// Create token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		log.Infof("✅ Purchase Order created successfully | PO Number: %s", purchaseOrderNumber)
 		log.Info("=================================================================\n")
@@ -82,15 +60,13 @@ func CreatePurchaseOrderController() gin.HandlerFunc {
 			"status":              true,
 			"message":             "Purchase Order created successfully",
 			"purchaseOrderNumber": purchaseOrderNumber,
-			"token":               token,
 		})
 	}
 }
 
-func GetAllPurchaseOrdersController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllPurchaseOrdersController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n📥 GetAllPurchaseOrdersController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -101,28 +77,26 @@ func GetAllPurchaseOrdersController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Missing token claims"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing token claims"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		log.Info("📡 Fetching all purchase orders...")
-		data := poService.GetAllPurchaseOrdersService(dbConnt)
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
+		data, err := poService.GetAllPurchaseOrdersService(dbConnt)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch purchase orders"))
+			return
+		}
 
 		log.Infof("✅ %d Purchase Orders retrieved\n", len(data))
 
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": data, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": data})
 	}
 }
 
-func UpdatePurchaseOrderController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdatePurchaseOrderController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n✏️ UpdatePurchaseOrderController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -135,43 +109,39 @@ func UpdatePurchaseOrderController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing claims in token")
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Missing claims"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing claims"))
 			return
 		}
 
 		var poPayload poModuleModel.PurchaseOrderPayload
 		if err := c.ShouldBindJSON(&poPayload); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		log.Infof("📦 Update Payload: %+v", poPayload)
-
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		if poPayload.PurchaseOrderID <= 0 {
+			validation.RespondFields(c, validation.FieldError{Field: "purchaseOrderId", Rule: "required", Message: "is required"})
+			return
+		}
 
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
+		log.Infof("📦 Update Payload: %+v", poPayload)
 
-		if err := poService.UpdatePurchaseOrderService(dbConnt, &poPayload, roleName); err != nil {
+		if err := poService.UpdatePurchaseOrderService(actor.Bind(dbConnt, c), &poPayload); err != nil {
 			log.Error("❌ Update Service Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Purchase Order updated successfully\n")
 
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Purchase Order updated", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Purchase Order updated"})
 	}
 }
 
-func GetAllPurchaseOrdersListController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllPurchaseOrdersListController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n📋 GetAllPurchaseOrdersListController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -182,58 +152,48 @@ func GetAllPurchaseOrdersListController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Missing claims"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing claims"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		log.Infof("🗄️ DB Stats: %+v", sqlDB.Stats())
+		if sqlDB, err := dbConnt.DB(); err == nil {
+			log.Infof("🗄️ DB Stats: %+v", sqlDB.Stats())
+		}
 
 		poList, err := poService.GetAllPurchaseOrdersListService(dbConnt)
 		if err != nil {
 			log.Error("❌ Failed to fetch Purchase Orders: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch purchase orders"))
 			return
 		}
 
 		log.Infof("✅ %d Purchase Orders fetched", len(poList))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": poList, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": poList})
 	}
 }
 
-func UpdatePurchaseOrderProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdatePurchaseOrderProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📝 UpdatePurchaseOrderProductsController invoked")
 
 		var payload []poService.UpdatePOProductRequest // ✅ use struct from service
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Errorf("❌ Invalid request body: %v", err)
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid request payload"})
+			validation.Respond(c, err)
 			return
 		}
 
 		if len(payload) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Empty payload"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Empty payload"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := poService.UpdatePurchaseOrderProductsService(dbConn, payload)
 		if err != nil {
 			log.Errorf("❌ Failed to update products: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Database update failed"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Database update failed"))
 			return
 		}
 
@@ -244,25 +204,21 @@ func UpdatePurchaseOrderProductsController() gin.HandlerFunc {
 	}
 }
 
-func SavePurchaseOrderProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func SavePurchaseOrderProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🧾 SavePurchaseOrderProductsController invoked")
 
 		var payload poService.SavePurchaseOrderProductsRequest
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Errorf("❌ Invalid request payload: %v", err)
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid request payload"})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		if err := poService.SavePurchaseOrderProductsService(dbConn, payload); err != nil {
+		if err := poService.SavePurchaseOrderProductsService(actor.Bind(dbConn, c), payload); err != nil {
 			log.Errorf("❌ Failed to save PO products: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Database save failed"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Database save failed"))
 			return
 		}
 
@@ -273,74 +229,62 @@ func SavePurchaseOrderProductsController() gin.HandlerFunc {
 	}
 }
 
-func GetPurchaseOrderDetailsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetPurchaseOrderDetailsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetPurchaseOrderDetailsController invoked")
 
 		// Verify token claims
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User ID, RoleID, Branch ID not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found"))
 			return
 		}
 
 		purchaseOrderNumber := c.Param("purchaseOrderNumber")
 		if purchaseOrderNumber == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "purchaseOrderNumber is required"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "purchaseOrderNumber is required"))
 			return
 		}
 
 		// DB connection
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
 		// Call service
 		data, err := poService.GetPurchaseOrderDetailsService(dbConn, purchaseOrderNumber)
 		if err != nil {
 			log.Error("❌ Failed to fetch PO details: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to fetch purchase order details"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch purchase order details"))
 			return
 		}
 
 		// Create new JWT token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   data,
-			"token":  token,
 		})
 	}
 }
 
-func GetAcceptedProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAcceptedProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📦 GetAcceptedProductsController invoked")
 
 		purchaseOrderId := c.Param("purchaseOrderId")
 		if purchaseOrderId == "" {
 			log.Error("❌ Missing purchaseOrderId in request")
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "purchaseOrderId is required"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "purchaseOrderId is required"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		results, err := poService.GetAcceptedProductsService(dbConn, purchaseOrderId)
 		if err != nil {
 			log.Errorf("❌ Failed to fetch accepted products: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch accepted products",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch accepted products"))
 			return
 		}
 
@@ -353,52 +297,46 @@ func GetAcceptedProductsController() gin.HandlerFunc {
 	}
 }
 
-func GetPurchaseOrderDetailsHandler(c *gin.Context) {
-	log := logger.InitLogger()
-	poNumber := c.Param("purchaseOrderNumber")
-	if poNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "PurchaseOrderNumber is required"})
-		return
-	}
+func GetPurchaseOrderDetailsHandler(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		poNumber := c.Param("purchaseOrderNumber")
+		if poNumber == "" {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "PurchaseOrderNumber is required"))
+			return
+		}
 
-	dbConn, sqlDB := db.InitDB()
-	defer sqlDB.Close()
+		response, err := poService.GetPurchaseOrderFullDetailsService(dbConn, poNumber)
+		if err != nil {
+			log.Error("❌ Failed to get PO details: " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
+			return
+		}
 
-	response, err := poService.GetPurchaseOrderFullDetailsService(dbConn, poNumber)
-	if err != nil {
-		log.Error("❌ Failed to get PO details: " + err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
-		return
+		c.JSON(http.StatusOK, gin.H{
+			"status":  true,
+			"message": "Purchase order details retrieved successfully",
+			"data":    response,
+		})
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":  true,
-		"message": "Purchase order details retrieved successfully",
-		"data":    response,
-	})
 }
 
-func GetAllPurchaseOrderAcceptedProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllPurchaseOrderAcceptedProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetAllPurchaseOrderAcceptedProductsController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User ID, RoleID, Branch ID not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		data := poService.GetAllPurchaseOrderAcceptedProductsService(dbConn)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": data, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": data})
 	}
 }
@@ -25,12 +25,12 @@ type PurchaseOrder struct {
 type PurchaseOrderProduct struct {
 	POProductID     int              `gorm:"primaryKey;column:po_product_id" json:"poProductId"`
 	PurchaseOrderID int              `gorm:"column:purchase_order_id" json:"purchaseOrderId"`
-	CategoryID      int              `gorm:"column:category_id" json:"categoryId"`
+	CategoryID      int              `gorm:"column:category_id" json:"categoryId" binding:"required,gt=0"`
 	Description     string           `gorm:"column:description" json:"description"`
-	UnitPrice       string           `gorm:"column:unit_price" json:"unitPrice"`
-	Discount        string           `gorm:"column:discount" json:"discount"`
-	Quantity        string           `gorm:"column:quantity" json:"quantity"`
-	Total           string           `gorm:"column:total" json:"total"`
+	UnitPrice       string           `gorm:"column:unit_price" json:"unitPrice" binding:"required,number"`
+	Discount        string           `gorm:"column:discount" json:"discount" binding:"omitempty,number"`
+	Quantity        string           `gorm:"column:quantity" json:"quantity" binding:"required,number"`
+	Total           string           `gorm:"column:total" json:"total" binding:"omitempty,number"`
 	CreatedAt       string           `gorm:"column:createdAt" json:"createdAt"`
 	CreatedBy       string           `gorm:"column:createdBy" json:"createdBy"`
 	UpdatedAt       string           `gorm:"column:updatedAt" json:"updatedAt"`
@@ -39,7 +39,7 @@ type PurchaseOrderProduct struct {
 }
 
 type SupplierDetails struct {
-	SupplierId           int    `json:"supplierId"`
+	SupplierId           int    `json:"supplierId" binding:"required,gt=0"`
 	SupplierName         string `json:"supplierName"`
 	SupplierCompanyName  string `json:"supplierCompanyName"`
 	SupplierCode         string `json:"supplierCode"`
@@ -51,7 +51,7 @@ type SupplierDetails struct {
 }
 
 type BranchDetails struct {
-	RefBranchId   int    `json:"refBranchId"`
+	RefBranchId   int    `json:"refBranchId" binding:"required,gt=0"`
 	RefBranchName string `json:"refBranchName"`
 	RefBranchCode string `json:"refBranchCode"`
 	RefLocation   string `json:"refLocation"`
@@ -76,18 +76,18 @@ type InitialCategory struct {
 type PurchaseOrderPayload struct {
 	PurchaseOrderID     int             `json:"purchaseOrderId"`
 	PurchaseOrderNumber string          `json:"purchaseOrderNumber"`
-	Supplier            SupplierDetails `json:"supplier"`
-	Branch              BranchDetails   `json:"branch"`
+	Supplier            SupplierDetails `json:"supplier" binding:"required"`
+	Branch              BranchDetails   `json:"branch" binding:"required"`
 	Summary             struct {
-		SubTotal      string `json:"subTotal"`
-		TotalDiscount string `json:"totalDiscount"`
+		SubTotal      string `json:"subTotal" binding:"omitempty,number"`
+		TotalDiscount string `json:"totalDiscount" binding:"omitempty,number"`
 		TaxEnabled    bool   `json:"taxEnabled"`
-		TaxPercentage string `json:"taxPercentage"`
-		TaxAmount     string `json:"taxAmount"`
-		TotalAmount   string `json:"totalAmount"`
+		TaxPercentage string `json:"taxPercentage" binding:"omitempty,percent"`
+		TaxAmount     string `json:"taxAmount" binding:"omitempty,number"`
+		TotalAmount   string `json:"totalAmount" binding:"omitempty,number"`
 	} `json:"summary"`
 	CreditedDate string                 `json:"creditedDate"`
-	Products     []PurchaseOrderProduct `json:"products"`
+	Products     []PurchaseOrderProduct `json:"products" binding:"required,min=1,dive"`
 }
 
 type PurchaseOrderResponse struct {
@@ -2,21 +2,21 @@ package poModuleModel
 
 type PurchaseOrderProductPayload struct {
 	PoInvoiceNumber string                   `json:"poInvoiceNumber"`
-	PoId            int                      `json:"poId"`
-	SupplierId      int                      `json:"supplierId"`
-	BranchId        int                      `json:"branchId"`
-	TotalAmount     string                   `json:"totalAmount"`
-	Products        []ProductPayloadProducts `json:"products"`
+	PoId            int                      `json:"poId" binding:"required,gt=0"`
+	SupplierId      int                      `json:"supplierId" binding:"required,gt=0"`
+	BranchId        int                      `json:"branchId" binding:"required,gt=0"`
+	TotalAmount     string                   `json:"totalAmount" binding:"omitempty,number"`
+	Products        []ProductPayloadProducts `json:"products" binding:"required,min=1,dive"`
 }
 
 type ProductPayloadProducts struct {
-	CategoryId  int    `json:"categoryId"`
-	ProductName string `json:"productName"`
-	OrderedQty  int    `json:"orderedQty"`
-	ReceivedQty int    `json:"receivedQty"`
-	RejectedQty int    `json:"rejectedQty"`
-	UnitPrice   string `json:"unitPrice"`
-	TotalPrice  int    `json:"totalPrice"`
+	CategoryId  int    `json:"categoryId" binding:"required,gt=0"`
+	ProductName string `json:"productName" binding:"required"`
+	OrderedQty  int    `json:"orderedQty" binding:"gte=0"`
+	ReceivedQty int    `json:"receivedQty" binding:"gte=0"`
+	RejectedQty int    `json:"rejectedQty" binding:"gte=0"`
+	UnitPrice   string `json:"unitPrice" binding:"omitempty,number"`
+	TotalPrice  int    `json:"totalPrice" binding:"gte=0"`
 	Status      string `json:"status"`
 }
 
@@ -0,0 +1,21 @@
+package PORoutes
+
+import (
+	poModuleModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/model"
+	poService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/service"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var poDocs = openapi.Routes{
+	"POST /api/v1/admin/purchaseOrder":                       {Summary: "Create purchase order", Request: poModuleModel.PurchaseOrderPayload{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"GET /api/v1/admin/purchaseOrder":                        {Summary: "Get all purchase orders", Response: []poModuleModel.PurchaseOrderPayload{}},
+	"PUT /api/v1/admin/purchaseOrder":                        {Summary: "Update purchase order", Request: poModuleModel.PurchaseOrderPayload{}, Permission: permission.PurchaseOrderManage},
+	"GET /api/v1/admin/getAllPurchaseOrders":                 {Summary: "Get all purchase orders list", Response: []poModuleModel.PurchaseOrderListResponse{}},
+	"POST /api/v1/admin/updatePurchaseOrderProducts":         {Summary: "Update purchase order products", Request: []poService.UpdatePOProductRequest{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"POST /api/v1/admin/savePurchaseOrderProducts":           {Summary: "Save purchase order products", Request: poService.SavePurchaseOrderProductsRequest{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"GET /api/v1/admin/getAcceptedProducts/:purchaseOrderId": {Summary: "Get accepted products"},
+	"GET /api/v1/admin/details/:purchaseOrderNumber":         {Summary: "Get purchase order details", Response: poModuleModel.PurchaseOrderDetailsResponse{}},
+	"GET /api/v1/admin/indivPODetails/:purchaseOrderNumber":  {Summary: "Get purchase order details", Response: poModuleModel.PurchaseOrderDetailsResponse{}, Public: true},
+	"GET /api/v1/admin/purchaseOrderAcceptedProducts":        {Summary: "Get all purchase order accepted products", Response: []poModuleModel.PurchaseOrderAcceptedProductResponse{}},
+}
@@ -0,0 +1,11 @@
+package PORoutes
+
+import (
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var poProductDocs = openapi.Routes{
+	"POST /api/v1/admin/poProductsUpdate": {Summary: "Create purchase order products", Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"GET /api/v1/admin/acceptedPOs":       {Summary: "Get accepted purchase orders"},
+}
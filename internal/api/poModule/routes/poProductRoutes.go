@@ -3,14 +3,20 @@ package PORoutes
 import (
 	poController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func PurchaseOrderProductRoutes(route *gin.Engine) {
+func PurchaseOrderProductRoutes(route *gin.Engine, dbConn *gorm.DB) {
 	poGroup := route.Group("/api/v1/admin")
 	{
-		poGroup.POST("/poProductsUpdate", accesstoken.JWTMiddleware(), poController.NewPurchaseOrderController().CreatePurchaseOrderProductsController())
-		poGroup.GET("/acceptedPOs", accesstoken.JWTMiddleware(), poController.NewPurchaseOrderController().GetAcceptedPurchaseOrdersController())
+		poGroup.POST("/poProductsUpdate", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), poController.NewPurchaseOrderController().CreatePurchaseOrderProductsController(dbConn))
+		poGroup.GET("/acceptedPOs", accesstoken.JWTMiddleware(), poController.NewPurchaseOrderController().GetAcceptedPurchaseOrdersController(dbConn))
 
 	}
+
+	openapi.Describe("PO Management", poProductDocs)
 }
@@ -3,27 +3,33 @@ package PORoutes
 import (
 	poController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func PurchaseOrderRoutes(route *gin.Engine) {
+func PurchaseOrderRoutes(route *gin.Engine, dbConn *gorm.DB) {
 	po := route.Group("/api/v1/admin")
 	{
-		po.POST("/purchaseOrder", accesstoken.JWTMiddleware(), poController.CreatePurchaseOrderController())
-		po.GET("/purchaseOrder", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrdersController())
-		po.PUT("/purchaseOrder", accesstoken.JWTMiddleware(), poController.UpdatePurchaseOrderController())
+		po.POST("/purchaseOrder", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), poController.CreatePurchaseOrderController(dbConn))
+		po.GET("/purchaseOrder", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrdersController(dbConn))
+		po.PUT("/purchaseOrder", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), poController.UpdatePurchaseOrderController(dbConn))
 		// po.DELETE("/:id", accesstoken.JWTMiddleware(), poController.DeletePurchaseOrderController())
 
-		po.GET("/getAllPurchaseOrders", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrdersListController())
-		po.POST("/updatePurchaseOrderProducts", accesstoken.JWTMiddleware(), poController.UpdatePurchaseOrderProductsController())
-		po.POST("/savePurchaseOrderProducts", accesstoken.JWTMiddleware(), poController.SavePurchaseOrderProductsController())
-		po.GET("/getAcceptedProducts/:purchaseOrderId", accesstoken.JWTMiddleware(), poController.GetAcceptedProductsController())
-		po.GET("/details/:purchaseOrderNumber", accesstoken.JWTMiddleware(), poController.GetPurchaseOrderDetailsController())
+		po.GET("/getAllPurchaseOrders", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrdersListController(dbConn))
+		po.POST("/updatePurchaseOrderProducts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), poController.UpdatePurchaseOrderProductsController(dbConn))
+		po.POST("/savePurchaseOrderProducts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), poController.SavePurchaseOrderProductsController(dbConn))
+		po.GET("/getAcceptedProducts/:purchaseOrderId", accesstoken.JWTMiddleware(), poController.GetAcceptedProductsController(dbConn))
+		po.GET("/details/:purchaseOrderNumber", accesstoken.JWTMiddleware(), poController.GetPurchaseOrderDetailsController(dbConn))
 
-		po.GET("/indivPODetails/:purchaseOrderNumber", poController.GetPurchaseOrderDetailsHandler)
+		po.GET("/indivPODetails/:purchaseOrderNumber", poController.GetPurchaseOrderDetailsHandler(dbConn))
 
-		po.GET("/purchaseOrderAcceptedProducts", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrderAcceptedProductsController())
+		po.GET("/purchaseOrderAcceptedProducts", accesstoken.JWTMiddleware(), poController.GetAllPurchaseOrderAcceptedProductsController(dbConn))
 
 	}
+
+	openapi.Describe("PO Management", poDocs)
 }
@@ -6,12 +6,14 @@ import (
 	"time"
 
 	poModuleModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"gorm.io/gorm"
 )
 
-func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderProductPayload, roleName string) error {
-	log := logger.InitLogger()
+func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderProductPayload) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreatePurchaseOrderService invoked")
 	log.Infof("📦 Received PO Payload: %+v", poPayload)
 
@@ -34,7 +36,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 				PurchaseOrderNumber: poPayload.PoInvoiceNumber,
 				InvoiceStatus:       true,
 				CreatedAt:           time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:           roleName,
+				CreatedBy:           author,
 			}
 
 			if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).Create(&newPO).Error; err != nil {
@@ -53,7 +55,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 		existingPO.TotalAmount = poPayload.TotalAmount
 		existingPO.InvoiceStatus = true
 		existingPO.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-		existingPO.UpdatedBy = roleName
+		existingPO.UpdatedBy = author
 
 		if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
 			Where(`purchase_order_id = ?`, existingPO.PurchaseOrderId).
@@ -87,7 +89,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 			existingProduct.Status = status
 			existingProduct.AcceptedTotal = fmt.Sprintf("%v", prod.ReceivedQty)
 			existingProduct.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-			existingProduct.UpdatedBy = roleName
+			existingProduct.UpdatedBy = author
 
 			if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).
 				Where(`po_product_id = ?`, existingProduct.PoProductId).
@@ -109,7 +111,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 				Status:           status,
 				AcceptedTotal:    fmt.Sprintf("%v", prod.ReceivedQty),
 				CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:        roleName,
+				CreatedBy:        author,
 			}
 			if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).Create(&newProduct).Error; err != nil {
 				log.Error("❌ Failed to insert new PO Product: " + err.Error())
@@ -123,9 +125,12 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 		}
 
 		// Step 3️⃣ - Manage Product Instances
-		db.Table(`"purchaseOrderMgmt"."PurchaseOrderProductInstances"`).
+		if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProductInstances"`).
 			Where(`po_product_id = ?`, existingProduct.PoProductId).
-			Delete(nil)
+			Delete(nil).Error; err != nil {
+			log.Error("❌ Failed to clear product instances: " + err.Error())
+			return err
+		}
 
 		for i := 1; i <= prod.ReceivedQty; i++ {
 			instance := poModuleModel.PurchaseOrderProductInstances{
@@ -136,7 +141,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 				UnitPrice:          prod.UnitPrice,
 				Status:             "Accepted",
 				CreatedAt:          time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:          roleName,
+				CreatedBy:          author,
 			}
 			if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProductInstances"`).Create(&instance).Error; err != nil {
 				log.Error("❌ Failed to insert product instance: " + err.Error())
@@ -154,7 +159,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 				RejectedQty:        fmt.Sprintf("%v", prod.RejectedQty),
 				Reason:             "",
 				CreatedAt:          time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:          roleName,
+				CreatedBy:          author,
 			}
 			if err := db.Table(`"purchaseOrderMgmt"."RejectedProducts"`).Create(&rejected).Error; err != nil {
 				log.Error("❌ Failed to insert rejected product: " + err.Error())
@@ -169,7 +174,7 @@ func CreatePurchaseOrderProductService(db *gorm.DB, poPayload *poModuleModel.Pur
 }
 
 func GetAcceptedPurchaseOrdersService(db *gorm.DB) ([]poModuleModel.AcceptedPOResponse, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🧾 Fetching accepted purchase orders...")
 
 	type rawPO struct {
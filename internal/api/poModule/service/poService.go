@@ -4,117 +4,103 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
 	poModuleModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
 	"gorm.io/gorm"
 )
 
-func CreatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderPayload, roleName string) (string, error) {
-	log := logger.InitLogger()
+func CreatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderPayload) (string, error) {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("\n\n🛠️ CreatePurchaseOrderService invoked")
 
-	// STEP 1: Month / Year
 	now := time.Now()
-	month := now.Month()
-	year := now.Year() % 100
 
-	log.Infof("📅 Date Extracted | Month=%02d | Year=%02d", month, year)
+	var po poModuleModel.PurchaseOrder
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// STEP 1: PO Number, drawn in the transaction so a failed insert hands it back
+		purchaseOrderNumber, err := numberseries.Issue(tx, numberseries.MgmtPurchaseOrder, poPayload.Branch.RefBranchId, now)
+		if err != nil {
+			log.Error("❌ Failed to generate PO Number: " + err.Error())
+			return err
+		}
+		log.Infof("🧾 Generated Purchase Order Number: %s", purchaseOrderNumber)
+
+		// STEP 2: Insert PO
+		log.Info("📥 Inserting purchase order header")
+
+		po = poModuleModel.PurchaseOrder{
+			SupplierID:          poPayload.Supplier.SupplierId,
+			BranchID:            poPayload.Branch.RefBranchId,
+			SubTotal:            fmt.Sprintf("%v", poPayload.Summary.SubTotal),
+			TotalDiscount:       fmt.Sprintf("%v", poPayload.Summary.TotalDiscount),
+			TaxEnabled:          poPayload.Summary.TaxEnabled,
+			TaxPercentage:       fmt.Sprintf("%v", poPayload.Summary.TaxPercentage),
+			TaxAmount:           fmt.Sprintf("%v", poPayload.Summary.TaxAmount),
+			TotalAmount:         fmt.Sprintf("%v", poPayload.Summary.TotalAmount),
+			CreditedDate:        poPayload.CreditedDate,
+			CreatedAt:           now.Format("2006-01-02 15:04:05"),
+			CreatedBy:           author,
+			IsDelete:            false,
+			PurchaseOrderNumber: purchaseOrderNumber,
+		}
 
-	// STEP 2: Fetch last invoice
-	var lastInvoice string
-	log.Info("🔍 Checking last purchase order number for the current month/year")
+		if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).Create(&po).Error; err != nil {
+			log.Error("❌ Failed to create PO header: " + err.Error())
+			return err
+		}
 
-	err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
-		Select(`"purchaseOrderNumber"`).
-		Where(`"purchaseOrderNumber" LIKE ?`, fmt.Sprintf("PO-%02d%02d-%%", month, year)).
-		Order("purchase_order_id DESC").
-		Limit(1).
-		Scan(&lastInvoice).Error
+		log.Infof("✅ PO Header Created | ID=%d | Number=%s", po.PurchaseOrderID, purchaseOrderNumber)
 
-	if err != nil {
-		log.Error("❌ DB Error fetching last invoice: " + err.Error())
-		return "", err
-	}
+		// STEP 3: Products Insertion
+		log.Infof("📦 Inserting %d products…", len(poPayload.Products))
+
+		for idx, prod := range poPayload.Products {
+			log.Infof("➡️ Product %d: %+v", idx+1, prod)
 
-	log.Infof("📌 Last Invoice Found: %s", lastInvoice)
+			product := poModuleModel.PurchaseOrderProduct{
+				PurchaseOrderID: po.PurchaseOrderID,
+				CategoryID:      prod.CategoryID,
+				Description:     prod.Description,
+				UnitPrice:       fmt.Sprintf("%v", prod.UnitPrice),
+				Discount:        fmt.Sprintf("%v", prod.Discount),
+				Quantity:        fmt.Sprintf("%v", prod.Quantity),
+				Total:           fmt.Sprintf("%v", prod.Total),
+				CreatedAt:       now.Format("2006-01-02 15:04:05"),
+				CreatedBy:       author,
+			}
 
-	// STEP 3: Sequence Logic
-	sequence := 10001
-	if lastInvoice != "" {
-		parts := strings.Split(lastInvoice, "-")
-		if len(parts) == 3 {
-			if lastSeq, convErr := strconv.Atoi(parts[2]); convErr == nil {
-				sequence = lastSeq + 1
+			if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).Create(&product).Error; err != nil {
+				log.Error("❌ Failed inserting product: " + err.Error())
+				return err
 			}
 		}
-	}
 
-	log.Infof("🔢 Generated next sequence number: %d", sequence)
-
-	// STEP 4: Build PO Number
-	purchaseOrderNumber := fmt.Sprintf("PO-%02d%02d-%05d", month, year, sequence)
-	log.Infof("🧾 Generated Purchase Order Number: %s", purchaseOrderNumber)
-
-	// STEP 5: Insert PO
-	log.Info("📥 Inserting purchase order header")
-
-	po := poModuleModel.PurchaseOrder{
-		SupplierID:          poPayload.Supplier.SupplierId,
-		BranchID:            poPayload.Branch.RefBranchId,
-		SubTotal:            fmt.Sprintf("%v", poPayload.Summary.SubTotal),
-		TotalDiscount:       fmt.Sprintf("%v", poPayload.Summary.TotalDiscount),
-		TaxEnabled:          poPayload.Summary.TaxEnabled,
-		TaxPercentage:       fmt.Sprintf("%v", poPayload.Summary.TaxPercentage),
-		TaxAmount:           fmt.Sprintf("%v", poPayload.Summary.TaxAmount),
-		TotalAmount:         fmt.Sprintf("%v", poPayload.Summary.TotalAmount),
-		CreditedDate:        poPayload.CreditedDate,
-		CreatedAt:           now.Format("2006-01-02 15:04:05"),
-		CreatedBy:           roleName,
-		IsDelete:            false,
-		PurchaseOrderNumber: purchaseOrderNumber,
-	}
+		log.Info("📚 All products inserted successfully")
 
-	if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).Create(&po).Error; err != nil {
-		log.Error("❌ Failed to create PO header: " + err.Error())
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityPurchaseOrder,
+			EntityId: po.PurchaseOrderID,
+			Action:   audit.ActionCreate,
+			BranchId: po.BranchID,
+			After:    map[string]interface{}{"purchaseOrder": po, "products": poPayload.Products},
+		})
+	})
+	if err != nil {
 		return "", err
 	}
+	purchaseOrderNumber := po.PurchaseOrderNumber
 
-	log.Infof("✅ PO Header Created | ID=%d | Number=%s", po.PurchaseOrderID, purchaseOrderNumber)
-
-	// STEP 6: Products Insertion
-	log.Infof("📦 Inserting %d products…", len(poPayload.Products))
-
-	for idx, prod := range poPayload.Products {
-		log.Infof("➡️ Product %d: %+v", idx+1, prod)
-
-		product := poModuleModel.PurchaseOrderProduct{
-			PurchaseOrderID: po.PurchaseOrderID,
-			CategoryID:      prod.CategoryID,
-			Description:     prod.Description,
-			UnitPrice:       fmt.Sprintf("%v", prod.UnitPrice),
-			Discount:        fmt.Sprintf("%v", prod.Discount),
-			Quantity:        fmt.Sprintf("%v", prod.Quantity),
-			Total:           fmt.Sprintf("%v", prod.Total),
-			CreatedAt:       now.Format("2006-01-02 15:04:05"),
-			CreatedBy:       roleName,
-		}
-
-		if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).Create(&product).Error; err != nil {
-			log.Error("❌ Failed inserting product: " + err.Error())
-			return "", err
-		}
-	}
-
-	log.Info("📚 All products inserted successfully")
-
-	// STEP 7: Transaction Log
+	// STEP 4: Transaction Log
 	log.Infof("📝 Saving transaction log for PO: %s", purchaseOrderNumber)
 
-	transErr := service.LogTransaction(db, 1, "Admin", 2, fmt.Sprintf("PO Created: %s", purchaseOrderNumber))
+	transErr := service.LogActorTransaction(db, 2, fmt.Sprintf("PO Created: %s", purchaseOrderNumber))
 	if transErr != nil {
 		log.Error("⚠️ Failed to save transaction log: " + transErr.Error())
 	} else {
@@ -125,8 +111,8 @@ func CreatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOr
 	return purchaseOrderNumber, nil
 }
 
-func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPayload {
-	log := logger.InitLogger()
+func GetAllPurchaseOrdersService(db *gorm.DB) ([]poModuleModel.PurchaseOrderPayload, error) {
+	log := logger.FromDB(db)
 	log.Info("\n📥 GetAllPurchaseOrdersService invoked")
 
 	var purchaseOrders []poModuleModel.PurchaseOrderResponse
@@ -134,8 +120,35 @@ func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPaylo
 
 	log.Info("🔍 Fetching purchase orders from DB")
 
-	err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders" AS po"`).
-		Select(`...`).
+	err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders" AS po`).
+		Select(`
+			po.purchase_order_id,
+			po.supplier_id AS "supplierId",
+			s."supplierName" AS "supplierName",
+			s."supplierCompanyName" AS "supplierCompany",
+			s."supplierCode" AS "supplierCode",
+			s."supplierEmail" AS "supplierEmail",
+			s."supplierContactNumber" AS "supplierMobile",
+			s."supplierGSTNumber" AS "supplierGST",
+			s."supplierPaymentTerms" AS "supplierTerms",
+			po.branch_id AS "branchId",
+			b."refBranchName" AS "branchName",
+			b."refBranchCode" AS "branchCode",
+			b."refLocation" AS "branchLocation",
+			b."refMobile" AS "branchMobile",
+			b."refEmail" AS "branchEmail",
+			b."isMainBranch" AS "isMainBranch",
+			b."isActive" AS "isActive",
+			po.sub_total,
+			po.total_discount,
+			po.tax_enabled,
+			po.tax_percentage,
+			po.tax_amount,
+			po.total_amount,
+			po.credited_date,
+			po."purchaseOrderNumber",
+			po."createdAt",
+			po."createdBy"`).
 		Joins(`LEFT JOIN public."Supplier" s ON po.supplier_id = s."supplierId"`).
 		Joins(`LEFT JOIN public."Branches" b ON po.branch_id = b."refBranchId"`).
 		Where(`po."isDelete" = ?`, false).
@@ -144,7 +157,7 @@ func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPaylo
 
 	if err != nil {
 		log.Error("❌ Fetch Error: " + err.Error())
-		return result
+		return nil, err
 	}
 
 	log.Infof("📦 %d Purchase orders fetched", len(purchaseOrders))
@@ -153,9 +166,13 @@ func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPaylo
 		log.Infof("📝 Processing PO ID: %d | Number: %s", po.PurchaseOrderID, po.PurchaseOrderNumber)
 
 		var products []poModuleModel.PurchaseOrderProduct
-		db.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).
+		err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderProducts"`).
 			Where("purchase_order_id = ?", po.PurchaseOrderID).
-			Scan(&products)
+			Scan(&products).Error
+		if err != nil {
+			log.Errorf("❌ Failed to fetch products for PO ID %d: %v", po.PurchaseOrderID, err)
+			return nil, err
+		}
 
 		log.Infof("   ➡️ %d Products Loaded", len(products))
 
@@ -167,9 +184,13 @@ func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPaylo
 					Where(`"initialCategoryId" = ?`, products[i].CategoryID).
 					First(&category).Error
 
-				if err == nil {
-					products[i].CategoryDetails = &category
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					continue
 				}
+				if err != nil {
+					return nil, err
+				}
+				products[i].CategoryDetails = &category
 			}
 		}
 
@@ -181,11 +202,12 @@ func GetAllPurchaseOrdersService(db *gorm.DB) []poModuleModel.PurchaseOrderPaylo
 	}
 
 	log.Infof("✅ Final result size: %d purchase orders\n", len(result))
-	return result
+	return result, nil
 }
 
-func UpdatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderPayload, roleName string) error {
-	log := logger.InitLogger()
+func UpdatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOrderPayload) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
 	updateData := map[string]interface{}{
 		"supplier_id":    poPayload.Supplier.SupplierId,
@@ -198,9 +220,10 @@ func UpdatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOr
 		"total_amount":   fmt.Sprintf("%v", poPayload.Summary.TotalAmount),
 		"credited_date":  poPayload.CreditedDate,
 		"updatedAt":      time.Now().Format("2006-01-02 15:04:05"),
-		"updatedBy":      roleName,
+		"updatedBy":      author,
 	}
 
+	change := audit.Track(db, audit.EntityPurchaseOrder, audit.ActionUpdate, "PurchaseOrders", "purchase_order_id", poPayload.PurchaseOrderID)
 	if err := db.Table("PurchaseOrders").
 		Where("purchase_order_id = ?", poPayload.PurchaseOrderID).
 		Updates(updateData).Error; err != nil {
@@ -209,10 +232,11 @@ func UpdatePurchaseOrderService(db *gorm.DB, poPayload *poModuleModel.PurchaseOr
 	}
 
 	log.Info("✅ PO updated successfully")
+	change.Done()
 	return nil
 }
 func GetAllPurchaseOrdersListService(db *gorm.DB) ([]poModuleModel.PurchaseOrderListResponse, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("📦 GetAllPurchaseOrdersService invoked")
 
 	// Step 1: Fetch all purchase orders (now includes invoice fields)
@@ -329,7 +353,7 @@ ORDER BY pop.po_product_id ASC;
 		var products []poModuleModel.PurchaseOrderProductLatest
 		if err := db.Raw(productQuery, orders[i].PurchaseOrderId).Scan(&products).Error; err != nil {
 			log.Errorf("❌ Failed to fetch products for PO ID %d: %v", orders[i].PurchaseOrderId, err)
-			continue
+			return nil, err
 		}
 
 		// ✅ map flat fields into nested CategoryDetails
@@ -354,17 +378,17 @@ ORDER BY pop.po_product_id ASC;
 }
 
 type UpdatePOProductRequest struct {
-	PurchaseOrderID     int    `json:"purchase_order_id"`
+	PurchaseOrderID     int    `json:"purchase_order_id" binding:"required,gt=0"`
 	PurchaseOrderNumber string `json:"purchase_order_number"`
 	CategoryID          int    `json:"category_id"`
-	POProductID         int    `json:"po_product_id"`
-	AcceptedQuantity    string `json:"accepted_quantity"`
-	RejectedQuantity    string `json:"rejected_quantity"`
+	POProductID         int    `json:"po_product_id" binding:"required,gt=0"`
+	AcceptedQuantity    string `json:"accepted_quantity" binding:"omitempty,number"`
+	RejectedQuantity    string `json:"rejected_quantity" binding:"omitempty,number"`
 	Status              string `json:"status"`
 }
 
 func UpdatePurchaseOrderProductsService(db *gorm.DB, payload []UpdatePOProductRequest) error {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("💾 UpdatePurchaseOrderProductsService invoked")
 
 	tx := db.Begin()
@@ -426,25 +450,25 @@ func UpdatePurchaseOrderProductsService(db *gorm.DB, payload []UpdatePOProductRe
 }
 
 type SavePurchaseOrderProductsRequest struct {
-	PurchaseOrderId int                    `json:"purchaseOrderId"`
-	Products        []SavePOProductRequest `json:"products"`
+	PurchaseOrderId int                    `json:"purchaseOrderId" binding:"required,gt=0"`
+	Products        []SavePOProductRequest `json:"products" binding:"required,min=1,dive"`
 }
 
 type SavePOProductRequest struct {
-	BranchId      int                      `json:"productBranchId"`
+	BranchId      int                      `json:"productBranchId" binding:"required,gt=0"`
 	SNo           int                      `json:"sNo"`
 	LineNumber    int                      `json:"lineNumber"`
-	ProductName   string                   `json:"productName"`
+	ProductName   string                   `json:"productName" binding:"required"`
 	Brand         string                   `json:"brand"`
-	CategoryId    int                      `json:"categoryId"`
-	SubCategoryId int                      `json:"subCategoryId"`
+	CategoryId    int                      `json:"categoryId" binding:"required,gt=0"`
+	SubCategoryId int                      `json:"subCategoryId" binding:"gte=0"`
 	TaxClass      string                   `json:"taxClass"`
-	Quantity      int                      `json:"quantity"`
-	Cost          float64                  `json:"cost"`
-	ProfitMargin  float64                  `json:"profitMargin"`
-	SellingPrice  float64                  `json:"sellingPrice"`
-	MRP           float64                  `json:"mrp"`
-	DialogRows    []SavePODialogRowRequest `json:"dialogRows"`
+	Quantity      int                      `json:"quantity" binding:"gt=0"`
+	Cost          float64                  `json:"cost" binding:"gte=0"`
+	ProfitMargin  float64                  `json:"profitMargin" binding:"gte=0"`
+	SellingPrice  float64                  `json:"sellingPrice" binding:"gte=0"`
+	MRP           float64                  `json:"mrp" binding:"gte=0"`
+	DialogRows    []SavePODialogRowRequest `json:"dialogRows" binding:"dive"`
 }
 
 type SavePODialogRowRequest struct {
@@ -454,14 +478,15 @@ type SavePODialogRowRequest struct {
 	LineNumber         int     `json:"lineNumber"`
 	ReferenceNumber    string  `json:"referenceNumber"`
 	ProductDescription string  `json:"productDescription"`
-	Discount           float64 `json:"discount"`
-	Price              float64 `json:"price"`
-	DiscountPrice      float64 `json:"discountPrice"`
+	Discount           float64 `json:"discount" binding:"gte=0"`
+	Price              float64 `json:"price" binding:"gte=0"`
+	DiscountPrice      float64 `json:"discountPrice" binding:"gte=0"`
 	Margin             float64 `json:"margin"`
-	TotalAmount        string  `json:"totalAmount"`
+	TotalAmount        string  `json:"totalAmount" binding:"omitempty,number"`
 }
 
 func SavePurchaseOrderProductsService(db *gorm.DB, payload SavePurchaseOrderProductsRequest) error {
+	author := actor.FromDB(db).By()
 	type DialogRow struct {
 		BranchId           int     `json:"productBranchId"`
 		SNo                int     `json:"sNo"`
@@ -517,118 +542,91 @@ func SavePurchaseOrderProductsService(db *gorm.DB, payload SavePurchaseOrderProd
 		SKU                string `gorm:"column:SKU"`
 	}
 
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
-	monthYear := time.Now().Format("0106") // e.g. 1025 for Oct 2025
+	now := time.Now()
+	currentTime := now.Format("2006-01-02 15:04:05")
+
+	// SKUs, accepted products and the invoice number commit together, so a failure hands
+	// the drawn numbers back
+	return db.Transaction(func(tx *gorm.DB) error {
+		var records []PurchaseOrderAcceptedProduct
+		for _, product := range payload.Products {
+			for _, row := range product.DialogRows {
+				sku, err := numberseries.Issue(tx, numberseries.POAcceptedSKU, product.BranchId, now)
+				if err != nil {
+					return err
+				}
+
+				record := PurchaseOrderAcceptedProduct{
+					ProductBranchId:    product.BranchId,
+					PoProductId:        payload.PurchaseOrderId,
+					PurchaseOrderId:    payload.PurchaseOrderId,
+					LineNumber:         fmt.Sprintf("%d", row.LineNumber),
+					ReferenceNumber:    row.ReferenceNumber,
+					ProductDescription: row.ProductDescription,
+					Discount:           fmt.Sprintf("%v", row.Discount),
+					UnitPrice:          fmt.Sprintf("%v", row.Price),
+					DiscountPrice:      fmt.Sprintf("%v", row.DiscountPrice),
+					Margin:             fmt.Sprintf("%v", row.Margin),
+					TotalAmount:        row.TotalAmount,
+					CategoryId:         product.CategoryId,
+					SubCategoryId:      product.SubCategoryId,
+					ProductName:        product.ProductName,
+					Status:             "Active",
+					CreatedAt:          currentTime,
+					CreatedBy:          author,
+					UpdatedAt:          currentTime,
+					UpdatedBy:          author,
+					IsDelete:           false,
+					SKU:                sku,
+				}
 
-	// --- Determine latest SKU number ---
-	var lastSKU string
-	err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderAcceptedProducts"`).
-		Select(`"SKU"`).
-		Order("product_instance_id DESC").
-		Limit(1).
-		Scan(&lastSKU).Error
-
-	var nextSKUCode int64 = 10001
-	if err == nil && lastSKU != "" {
-		parts := strings.Split(lastSKU, "-")
-		if len(parts) >= 3 {
-			if num, convErr := strconv.ParseInt(parts[2], 10, 64); convErr == nil {
-				nextSKUCode = num + 1
+				records = append(records, record)
 			}
 		}
-	}
-
-	var records []PurchaseOrderAcceptedProduct
-	for _, product := range payload.Products {
-		for _, row := range product.DialogRows {
-			sku := fmt.Sprintf("SS-%s-%05d", monthYear, nextSKUCode)
-			nextSKUCode++ // increment for next product
-
-			record := PurchaseOrderAcceptedProduct{
-				ProductBranchId:    product.BranchId,
-				PoProductId:        payload.PurchaseOrderId,
-				PurchaseOrderId:    payload.PurchaseOrderId,
-				LineNumber:         fmt.Sprintf("%d", row.LineNumber),
-				ReferenceNumber:    row.ReferenceNumber,
-				ProductDescription: row.ProductDescription,
-				Discount:           fmt.Sprintf("%v", row.Discount),
-				UnitPrice:          fmt.Sprintf("%v", row.Price),
-				DiscountPrice:      fmt.Sprintf("%v", row.DiscountPrice),
-				Margin:             fmt.Sprintf("%v", row.Margin),
-				TotalAmount:        row.TotalAmount,
-				CategoryId:         product.CategoryId,
-				SubCategoryId:      product.SubCategoryId,
-				ProductName:        product.ProductName,
-				Status:             "Active",
-				CreatedAt:          currentTime,
-				CreatedBy:          "Admin",
-				UpdatedAt:          currentTime,
-				UpdatedBy:          "Admin",
-				IsDelete:           false,
-				SKU:                sku,
-			}
 
-			records = append(records, record)
+		if len(records) == 0 {
+			return fmt.Errorf("no dialog rows to insert")
 		}
-	}
-
-	if len(records) == 0 {
-		return fmt.Errorf("no dialog rows to insert")
-	}
-
-	// --- Bulk insert accepted products ---
-	if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrderAcceptedProducts"`).Create(&records).Error; err != nil {
-		return fmt.Errorf("failed to insert accepted products: %v", err)
-	}
-
-	// --- Generate Invoice Number ---
-	var lastInvoice string
-	prefix := fmt.Sprintf("PO-INV-%s", monthYear)
-
-	err = db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
-		Select(`"invoiceFinalNumber"`).
-		Where(`"invoiceFinalNumber" LIKE ?`, prefix+"-%").
-		Order(`"invoiceFinalNumber" DESC`).
-		Limit(1).
-		Scan(&lastInvoice).Error
 
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("failed to fetch last invoice: %v", err)
-	}
-
-	var nextNumber int64 = 10001 // default
+		// --- Bulk insert accepted products ---
+		if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrderAcceptedProducts"`).Create(&records).Error; err != nil {
+			return fmt.Errorf("failed to insert accepted products: %w", err)
+		}
 
-	if lastInvoice != "" {
-		parts := strings.Split(lastInvoice, "-")
-		if len(parts) >= 4 {
-			if num, convErr := strconv.ParseInt(parts[3], 10, 64); convErr == nil {
-				nextNumber = num + 1
-			}
+		// --- Generate Invoice Number ---
+		var po poModuleModel.PurchaseOrder
+		if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
+			Where("purchase_order_id = ?", payload.PurchaseOrderId).
+			Take(&po).Error; err != nil {
+			return fmt.Errorf("failed to load purchase order: %w", err)
 		}
-	}
 
-	invoiceNumber := fmt.Sprintf("PO-INV-%s-%05d", monthYear, nextNumber)
+		invoiceNumber, err := numberseries.Issue(tx, numberseries.POInvoice, po.BranchID, now)
+		if err != nil {
+			return err
+		}
 
-	updateData := map[string]interface{}{
-		`"invoiceStatus"`:      true,
-		`"invoiceFinalNumber"`: invoiceNumber,
-		`"updatedAt"`:          currentTime,
-		`"updatedBy"`:          "Admin",
-	}
+		updateData := map[string]interface{}{
+			`"invoiceStatus"`:      true,
+			`"invoiceFinalNumber"`: invoiceNumber,
+			`"updatedAt"`:          currentTime,
+			`"updatedBy"`:          author,
+		}
 
-	if err := db.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
-		Where("purchase_order_id = ?", payload.PurchaseOrderId).
-		Updates(updateData).Error; err != nil {
-		return fmt.Errorf("failed to update PurchaseOrders with invoice info: %v", err)
-	}
+		if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrders"`).
+			Where("purchase_order_id = ?", payload.PurchaseOrderId).
+			Updates(updateData).Error; err != nil {
+			return fmt.Errorf("failed to update PurchaseOrders with invoice info: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // --- Structs ---
 
 func GetPurchaseOrderDetailsService(db *gorm.DB, purchaseOrderNumber string) (poModuleModel.PurchaseOrderDetailsResponse, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	var response poModuleModel.PurchaseOrderDetailsResponse
 
 	// Step 1: Get purchase order basic info (ID + invoice number)
@@ -754,7 +752,7 @@ func GetPurchaseOrderDetailsService(db *gorm.DB, purchaseOrderNumber string) (po
 }
 
 func GetAcceptedProductsService(db *gorm.DB, purchaseOrderId string) ([]map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🔍 Fetching accepted products for PurchaseOrderId: %s", purchaseOrderId)
 
 	// --- Define model mapping DB columns ---
@@ -814,7 +812,7 @@ func GetAcceptedProductsService(db *gorm.DB, purchaseOrderId string) ([]map[stri
 }
 
 func GetAllPurchaseOrderAcceptedProductsService(db *gorm.DB) []poModuleModel.PurchaseOrderAcceptedProductResponse {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("💾 GetAllPurchaseOrderAcceptedProductsService invoked")
 
 	var results []poModuleModel.PurchaseOrderAcceptedProductResponse
@@ -861,7 +859,6 @@ func GetAllPurchaseOrderAcceptedProductsService(db *gorm.DB) []poModuleModel.Pur
 	`
 
 	err := db.Raw(rawQuery).Scan(&results).Error
-	fmt.Print("\n\n\n\nresults", results)
 	if err != nil {
 		log.Errorf("❌ Failed to fetch accepted products: %v", err)
 		return nil
@@ -872,7 +869,7 @@ func GetAllPurchaseOrderAcceptedProductsService(db *gorm.DB) []poModuleModel.Pur
 }
 
 func GetPurchaseOrderFullDetailsService(db *gorm.DB, purchaseOrderNumber string) (poModuleModel.PurchaseOrderDetailsResponse, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	var response poModuleModel.PurchaseOrderDetailsResponse
 
 	//---------------------------------------------
@@ -5,38 +5,35 @@ import (
 
 	posManagementModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/model"
 	posManagementService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func AddCustomer() gin.HandlerFunc {
+func AddCustomer(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
 		var customer posManagementModel.AddCustomer
 		if err := c.ShouldBindJSON(&customer); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		if err := posManagementService.AddCustomer(dbConn, &customer); err != nil {
-			c.JSON(http.StatusConflict, gin.H{"status": false, "message": err.Error()})
+		if err := posManagementService.AddCustomer(actor.Bind(dbConn, c), &customer); err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeConflict, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Customer created successfully",
-			"token":   token,
 		})
 	}
 }
@@ -50,10 +47,7 @@ func getUserContext(c *gin.Context) (interface{}, interface{}, interface{}) {
 	branchIdValue, branchIdExists := c.Get("branchId")
 
 	if !idExists || !roleIdExists || !branchIdExists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  false,
-			"message": "User ID, RoleID, Branch ID not found in request context.",
-		})
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 		return nil, nil, nil
 	}
 	return idValue, roleIdValue, branchIdValue
@@ -0,0 +1,11 @@
+package posManagementRoutes
+
+import (
+	posManagementModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/model"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/pos/customer": {Summary: "Add customer", Request: posManagementModel.AddCustomer{}, Permission: permission.POSSales, Idempotent: true},
+}
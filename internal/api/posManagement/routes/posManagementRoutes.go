@@ -3,15 +3,21 @@ package  posManagementRoutes
 import (
 	posManagementController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 
-func POSManagementRoutes(router *gin.Engine) {
+func POSManagementRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/pos")
-	route.POST("/customer", accesstoken.JWTMiddleware(), posManagementController.AddCustomer())
-	// route.GET("/read", accesstoken.JWTMiddleware(), productController.GetAllPOProductsController())
-	// route.GET("/read/:id", accesstoken.JWTMiddleware(), productController.GetPOProductByIdController())
-	// route.PUT("/update", accesstoken.JWTMiddleware(), productController.UpdatePOProductController())
-	// route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), productController.DeletePOProductController())
+	route.POST("/customer", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.POSSales), idempotency.Middleware(), posManagementController.AddCustomer(dbConn))
+	// route.GET("/read", accesstoken.JWTMiddleware(), productController.GetAllPOProductsController(dbConn))
+	// route.GET("/read/:id", accesstoken.JWTMiddleware(), productController.GetPOProductByIdController(dbConn))
+	// route.PUT("/update", accesstoken.JWTMiddleware(), productController.UpdatePOProductController(dbConn))
+	// route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), productController.DeletePOProductController(dbConn))
+
+	openapi.Describe("POS", docs)
 }
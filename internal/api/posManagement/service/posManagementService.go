@@ -6,12 +6,14 @@ import (
 	"errors"
 
 	posManagementModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/posManagement/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"gorm.io/gorm"
 )
 
 func AddCustomer(db *gorm.DB, customer *posManagementModel.AddCustomer) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
 	// Check if mobile already exists
 	var existing posManagementModel.AddCustomer
@@ -29,7 +31,7 @@ func AddCustomer(db *gorm.DB, customer *posManagementModel.AddCustomer) error {
 
 	// If not found → insert new
 	customer.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	customer.CreatedBy = "Admin"
+	customer.CreatedBy = author
 	customer.IsDelete = false
 
 	if err := db.Table("customers").Create(customer).Error; err != nil {
@@ -1,139 +1,128 @@
 package productController
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	productModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/model"
 	productService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	helper "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/RequestHandler"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func CreatePOProductController() gin.HandlerFunc {
+func CreatePOProductController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
 		var product productModel.POProduct
 		if err := c.ShouldBindJSON(&product); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		if err := productService.CreatePOProduct(dbConn, &product); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create PO product"})
+		if err := productService.CreatePOProduct(actor.Bind(dbConn, c), &product); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create PO product"))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product created successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product created successfully"})
 	}
 }
 
-func GetAllPOProductsController() gin.HandlerFunc {
+func GetAllPOProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		products, err := productService.GetAllPOProducts(dbConn)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to fetch PO products"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch PO products"))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": products, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": products})
 	}
 }
 
-func GetPOProductByIdController() gin.HandlerFunc {
+func GetPOProductByIdController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
 		poId := c.Param("id")
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
 		product, err := productService.GetPOProductById(dbConn, poId)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": "PO product not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "PO product not found"))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": product, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": product})
 	}
 }
 
-func UpdatePOProductController() gin.HandlerFunc {
+func UpdatePOProductController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
 		var product productModel.POProduct
 		if err := c.ShouldBindJSON(&product); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		err := productService.UpdatePOProduct(dbConn, &product)
+		err := productService.UpdatePOProduct(actor.Bind(dbConn, c), &product)
 		if err != nil {
 			if err.Error() == "cannot update a deleted product" {
-				c.JSON(http.StatusForbidden, gin.H{"status": false, "message": "This product is deleted and cannot be updated"})
+				apperror.Respond(c, apperror.New(apperror.CodeForbidden, "This product is deleted and cannot be updated"))
 				return
 			}
 			if err.Error() == "product not found" {
-				c.JSON(http.StatusNotFound, gin.H{"status": false, "message": "PO Product not found"})
+				apperror.Respond(c, apperror.New(apperror.CodeNotFound, "PO Product not found"))
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update PO Product"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to update PO Product"))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product updated successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product updated successfully"})
 	}
 }
 
-func DeletePOProductController() gin.HandlerFunc {
+func DeletePOProductController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, roleId, branchId := getUserContext(c)
+		id, _, _ := getUserContext(c)
 		if id == nil {
 			return
 		}
 
 		poId := c.Param("id")
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
-		if err := productService.DeletePOProduct(dbConn, poId); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to delete PO product"})
+		if err := productService.DeletePOProduct(actor.Bind(dbConn, c), poId); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete PO product"))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, roleId, branchId)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product deleted successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "PO product deleted successfully"})
 	}
 }
 
@@ -144,10 +133,7 @@ func getUserContext(c *gin.Context) (interface{}, interface{}, interface{}) {
 	branchIdValue, branchIdExists := c.Get("branchId")
 
 	if !idExists || !roleIdExists || !branchIdExists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  false,
-			"message": "User ID, RoleID, Branch ID not found in request context.",
-		})
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 		return nil, nil, nil
 	}
 	return idValue, roleIdValue, branchIdValue
@@ -158,20 +144,17 @@ type CheckSKURequest struct {
 	SKU          string `json:"sku" binding:"required"`
 }
 
-func CheckSKUInBranchController() gin.HandlerFunc {
+func CheckSKUInBranchController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req CheckSKURequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		product, found, branchName, err := productService.GetProductBySKUInBranch(dbConn, req.FromBranchID, req.SKU)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -195,26 +178,18 @@ func CheckSKUInBranchController() gin.HandlerFunc {
 	}
 }
 
-func GetBranch4ProductsController() gin.HandlerFunc {
+func GetBranch4ProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
 		// Call service
 		products, err := productService.GetProductsByBranchID(dbConn, 4) // branchId = 4
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		if len(products) == 0 {
-			c.JSON(http.StatusOK, gin.H{
-				"status":  false,
-				"message": "No products found for branch 4",
-			})
+			apperror.Respond(c, apperror.NotFound("No products found for branch 4"))
 			return
 		}
 
@@ -225,25 +200,40 @@ func GetBranch4ProductsController() gin.HandlerFunc {
 	}
 }
 
-func CreateStockTransfer() gin.HandlerFunc {
+func CreateStockTransfer(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload productModel.StockTransferRequest
-
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[productModel.StockTransferRequest](c)
+		if !ok {
+			return
+		}
+		payload := *req
+		// The receiving branch travels in receivedBranchDetails.supplierId
+		if payload.ReceivedBranchDetails.SupplierId == payload.BranchDetails.BranchId {
+			validation.RespondFields(c, validation.FieldError{
+				Field:   "receivedBranchDetails.supplierId",
+				Rule:    "nefield",
+				Param:   "branchDetails.branchId",
+				Message: "must differ from branchDetails.branchId",
+			})
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(payload.BranchDetails.BranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
+			return
+		}
 
-		transferID, err := productService.CreateStockTransfer(dbConn, payload)
+		transferID, err := productService.CreateStockTransfer(actor.Bind(dbConn, c), payload)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":     true,
 			"message":    "Stock transfer created successfully",
 			"transferId": transferID,
@@ -251,22 +241,28 @@ func CreateStockTransfer() gin.HandlerFunc {
 	}
 }
 
-func GetStockTransferByIDController() gin.HandlerFunc {
+func GetStockTransferByIDController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		idStr := c.Param("id")
 		transferId, err := strconv.Atoi(idStr)
 		if err != nil {
-			c.JSON(400, gin.H{"status": false, "message": "Invalid ID"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid ID"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		transfer, items, err := productService.GetStockTransferByID(dbConn, transferId)
+		transfer, items, err := productService.GetStockTransferByID(dbConn, transferId, scope)
+		if errors.Is(err, branchscope.ErrOutOfScope) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, err.Error()))
+			return
+		}
 		if err != nil {
-			c.JSON(404, gin.H{"status": false, "message": "Stock transfer not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "Stock transfer not found"))
 			return
 		}
 
@@ -278,23 +274,29 @@ func GetStockTransferByIDController() gin.HandlerFunc {
 	}
 }
 
-func GetStockTransfersController() gin.HandlerFunc {
+func GetStockTransfersController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		toBranchIdStr := c.Query("toBranchId")
 
 		toBranchId, err := strconv.Atoi(toBranchIdStr)
 		if err != nil {
-			c.JSON(400, gin.H{"status": false, "message": "Invalid branch ID"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid branch ID"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(toBranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
+			return
+		}
 
 		transfers, err := productService.GetStockTransfers(dbConn, toBranchId)
 		if err != nil {
-			c.JSON(500, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -305,15 +307,17 @@ func GetStockTransfersController() gin.HandlerFunc {
 	}
 }
 
-func GetAllStockTransfersController() gin.HandlerFunc {
+func GetAllStockTransfersController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		transfers, err := productService.GetAllStockTransfers(dbConn)
+		transfers, err := productService.GetAllStockTransfers(dbConn, scope)
 		if err != nil {
-			c.JSON(500, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -324,31 +328,40 @@ func GetAllStockTransfersController() gin.HandlerFunc {
 	}
 }
 
-func ReceiveStockProductsController() gin.HandlerFunc {
+func ReceiveStockProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		var payload productModel.ReceiveStockProductsRequest
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(400, gin.H{"status": false, "message": "Invalid request", "error": err.Error()})
+		req, ok := helper.RequestHandler[productModel.ReceiveStockProductsRequest](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		if err := productService.ReceiveProductsService(dbConn, payload); err != nil {
-			c.JSON(500, gin.H{"status": false, "message": err.Error()})
+		if err := productService.ReceiveProductsService(actor.Bind(dbConn, c), payload, scope); err != nil {
+			if errors.Is(err, branchscope.ErrOutOfScope) {
+				apperror.Respond(c, apperror.New(apperror.CodeForbidden, err.Error()))
+				return
+			}
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		c.JSON(200, gin.H{"status": true, "message": "Products received successfully"})
+		helper.Respond(c, http.StatusOK, gin.H{"status": true, "message": "Products received successfully"})
 	}
 }
 
-func SaveProductImagesController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type SaveProductImagesRequest struct {
+	FileNames []string `json:"fileNames"`
+}
 
+func SaveProductImagesController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n🖼️ SaveProductImagesController invoked")
 
 		// Extract token claims
@@ -360,83 +373,62 @@ func SaveProductImagesController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found"))
 			return
 		}
 
 		// Request body
-		var body struct {
-			FileNames []string `json:"fileNames"`
-		}
+		var body SaveProductImagesRequest
 
 		if err := c.ShouldBindJSON(&body); err != nil {
 			log.Error("❌ Invalid payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid request body",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("📦 Received %d file names", len(body.FileNames))
 
-		// DB
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		if len(body.FileNames) == 0 {
+			apperror.Respond(c, apperror.BadRequest("No file names provided"))
+			return
+		}
 
-		err := productService.SaveProductImagesService(dbConn, body.FileNames, idValue)
+		// linked by a job worker, see productService.LinkImagesJob
+		job, err := jobqueue.Enqueue(actor.Bind(dbConn, c), productService.JobLinkImages, productService.LinkImagesPayload{FileNames: body.FileNames}, jobqueue.Options{})
 		if err != nil {
-			log.Error("❌ Service Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to save image details",
-			})
+			log.Error("❌ Enqueue Error: " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to save image details"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
+		log.Infof("✅ Product images queued for linking, job %d\n\n", job.RefJobId)
 
-		log.Info("✅ Product images saved successfully\n\n")
-
-		c.JSON(http.StatusOK, gin.H{
+		c.JSON(http.StatusAccepted, gin.H{
 			"status":  true,
-			"message": "Image details saved successfully",
-			"token":   token,
+			"message": "Image details queued for saving",
+			"data":    gin.H{"jobId": job.RefJobId},
 		})
 	}
 }
 
-func GetImagesByProductController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetImagesByProductController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n📥 GetImagesByProductController invoked")
 
 		productInstanceId := c.Param("productInstanceId")
 		if productInstanceId == "" {
 			log.Warn("❌ Missing productInstanceId")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "productInstanceId is required",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "productInstanceId is required"))
 			return
 		}
 
 		log.Infof("🔍 Fetching images for product_instance_id: %s", productInstanceId)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		data, err := productService.GetImagesByProductService(dbConn, productInstanceId)
 		if err != nil {
 			log.Error("❌ Failed to fetch images: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Unable to fetch images",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Unable to fetch images"))
 			return
 		}
 
@@ -449,39 +441,34 @@ func GetImagesByProductController() gin.HandlerFunc {
 	}
 }
 
-func GetSinglePurchaseOrderAcceptedProductController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetSinglePurchaseOrderAcceptedProductController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetSinglePurchaseOrderAcceptedProductController invoked")
 
 		productInstanceIdStr := c.Param("id")
 		productInstanceId, err := strconv.Atoi(productInstanceIdStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid productInstanceId"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid productInstanceId"))
 			return
 		}
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User ID, RoleID, Branch ID not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		result, err := productService.GetSinglePurchaseOrderAcceptedProductService(dbConn, productInstanceId)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": result, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": result})
 	}
 }
 
@@ -490,21 +477,15 @@ type CheckSKURequestLatest struct {
 	SKU          string `json:"sku"`
 }
 
-func CheckSKUInGRNController() gin.HandlerFunc {
+func CheckSKUInGRNController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		var req CheckSKURequestLatest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid request: " + err.Error(),
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		product, isPresent, branchName, err := productService.GetSKUFromGRN(
 			dbConn,
 			req.FromBranchID,
@@ -512,11 +493,7 @@ func CheckSKUInGRNController() gin.HandlerFunc {
 		)
 
 		if err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"status":    false,
-				"message":   err.Error(),
-				"isPresent": false,
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeNotFound, err.Error()).With("isPresent", false))
 			return
 		}
 
@@ -533,32 +510,22 @@ type CheckSKUOnlyRequest struct {
 	SKU string `json:"sku"`
 }
 
-func CheckSKUOnlyInGRNController() gin.HandlerFunc {
+func CheckSKUOnlyInGRNController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		var req CheckSKUOnlyRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid request: " + err.Error(),
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		product, isPresent, branchName, err := productService.GetSKUOnlyFromGRN(
 			dbConn,
 			req.SKU,
 		)
 
 		if err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"status":    false,
-				"message":   err.Error(),
-				"isPresent": false,
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeNotFound, err.Error()).With("isPresent", false))
 			return
 		}
 
@@ -571,26 +538,31 @@ func CheckSKUOnlyInGRNController() gin.HandlerFunc {
 	}
 }
 
-func StockTransferController() gin.HandlerFunc {
+func StockTransferController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		var req productModel.NewStockTransferRequest
-
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		body, ok := helper.RequestHandler[productModel.NewStockTransferRequest](c)
+		if !ok {
 			return
 		}
+		req := *body
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(req.FromBranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
+			return
+		}
 
-		id, err := productService.TransferStock(dbConn, req)
+		id, err := productService.TransferStock(actor.Bind(dbConn, c), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":     true,
 			"message":    "Stock transfer created successfully",
 			"transferId": id,
@@ -598,51 +570,57 @@ func StockTransferController() gin.HandlerFunc {
 	}
 }
 
-func GetStockTransferMasterController() gin.HandlerFunc {
+func GetStockTransferMasterController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		data, err := productService.GetStockTransferMasterList(dbConn)
+		query, err := listquery.Parse(c, productService.StockTransferList)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, err)
+			return
+		}
+
+		data, page, err := productService.GetStockTransferMasterList(dbConn, scope, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   data,
+			"status":     true,
+			"data":       data,
+			"pagination": page,
 		})
 	}
 }
 
-func GetStockTransferItemsController() gin.HandlerFunc {
+func GetStockTransferItemsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		transferIdStr := c.Param("transferId")         // <-- FIXED
 		transferId, err := strconv.Atoi(transferIdStr) // safer
 
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid transfer ID",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid transfer ID"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		data, err := productService.GetStockTransferItems(dbConn, transferId)
+		data, err := productService.GetStockTransferItems(dbConn, transferId, scope)
+		if errors.Is(err, branchscope.ErrOutOfScope) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, err.Error()))
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -654,207 +632,173 @@ func GetStockTransferItemsController() gin.HandlerFunc {
 }
 
 // BUNDLE IN AND OUT
-func CreateBundleInwardController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateBundleInwardController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📦 CreateBundleInwardController invoked")
 
-		idValue, idOk := c.Get("id")
-		roleValue, roleOk := c.Get("roleId")
-		branchValue, branchOk := c.Get("branchId")
+		_, idOk := c.Get("id")
+		_, roleOk := c.Get("roleId")
+		_, branchOk := c.Get("branchId")
 
 		if !idOk || !roleOk || !branchOk {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
-		var payload productModel.BundleInwardPayload
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			log.Error("❌ Invalid payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid input"})
+		req, ok := helper.RequestHandler[productModel.BundleInwardPayload](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		log.Infof("📝 Payload received: %+v", payload)
+		log.Debugf("📝 Payload received: %+v", payload)
 
-		err := productService.CreateBundleInwardService(dbConn, &payload)
+		err := productService.CreateBundleInwardService(actor.Bind(dbConn, c), &payload)
 		if err != nil {
 			log.Error("❌ Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create inward"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create inward"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleValue, branchValue)
-
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Bundle inward created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllBundleInwardsController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetAllBundleInwardsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetAllBundleInwardsController invoked")
 
-		id, idOk := c.Get("id")
-		role, roleOk := c.Get("roleId")
-		branch, branchOk := c.Get("branchId")
+		_, idOk := c.Get("id")
+		_, roleOk := c.Get("roleId")
+		_, branchOk := c.Get("branchId")
 
 		if !idOk || !roleOk || !branchOk {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		data := productService.GetAllBundleInwardsService(dbConn)
+		query, err := listquery.Parse(c, productService.BundleInwardList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
 
-		token := accesstoken.CreateToken(id, role, branch)
+		data, page, err := productService.GetAllBundleInwardsService(dbConn, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch bundle inwards"))
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   data,
-			"token":  token,
+			"status":     true,
+			"data":       data,
+			"pagination": page,
 		})
 	}
 }
 
-func UpdateBundleInwardController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func UpdateBundleInwardController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🛠 UpdateBundleInwardController invoked")
 
-		id, idOk := c.Get("id")
-		role, roleOk := c.Get("roleId")
-		branch, branchOk := c.Get("branchId")
+		_, idOk := c.Get("id")
+		_, roleOk := c.Get("roleId")
+		_, branchOk := c.Get("branchId")
 
 		if !idOk || !roleOk || !branchOk {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
-		var payload productModel.BundleInwardPayload
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid input"})
+		req, ok := helper.RequestHandler[productModel.BundleInwardPayload](c)
+		if !ok {
+			return
+		}
+		payload := *req
+		if payload.Id <= 0 {
+			validation.RespondFields(c, validation.FieldError{Field: "id", Rule: "required", Message: "is required"})
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		err := productService.UpdateBundleInwardService(dbConn, &payload)
+		err := productService.UpdateBundleInwardService(actor.Bind(dbConn, c), &payload)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(id, role, branch)
-
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Updated successfully", "token": token})
+		helper.Respond(c, http.StatusOK, gin.H{"status": true, "message": "Updated successfully"})
 	}
 }
 
-func GetBundleInwardsByPOController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetBundleInwardsByPOController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetBundleInwardsByPOController invoked")
 
-		id, idOk := c.Get("id")
-		role, roleOk := c.Get("roleId")
-		branch, branchOk := c.Get("branchId")
+		_, idOk := c.Get("id")
+		_, roleOk := c.Get("roleId")
+		_, branchOk := c.Get("branchId")
 
 		if !idOk || !roleOk || !branchOk {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
 		// ✅ Get PO ID from URL PARAM
 		poID := c.Param("po_id")
 		if poID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "po_id is required",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "po_id is required"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		// ✅ Call NEW service
 		data := productService.GetBundleInwardsByPOService(dbConn, poID)
 
-		token := accesstoken.CreateToken(id, role, branch)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   data,
-			"token":  token,
 		})
 	}
 }
 
-func CreateDebitNoteController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateDebitNoteController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
-		var payload productService.DebitNotePayload
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+		req, ok := helper.RequestHandler[productService.DebitNotePayload](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		result, err := productService.CreateDebitNoteService(dbConn, payload)
+		result, err := productService.CreateDebitNoteService(actor.Bind(dbConn, c), payload)
 		if err != nil {
 			log.Error(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status": true,
 			"data":   result,
 		})
 	}
 }
 
-func GetDebitNoteListController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetDebitNoteListController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		result, err := productService.GetDebitNoteListService(dbConn)
 		if err != nil {
 			log.Error(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -865,30 +809,20 @@ func GetDebitNoteListController() gin.HandlerFunc {
 	}
 }
 
-func GetDebitNoteByIdController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetDebitNoteByIdController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
 		debitNoteId, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid debit note id",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid debit note id"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		header, items, err := productService.GetDebitNoteByIdService(dbConn, debitNoteId)
 		if err != nil {
 			log.Error(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -2,15 +2,15 @@ package productModel
 
 type POProduct struct {
 	POId          int    `json:"poId" gorm:"column:poId;primaryKey;autoIncrement"`
-	PoName        string `json:"poName" gorm:"column:poName"`
+	PoName        string `json:"poName" gorm:"column:poName" binding:"required"`
 	PoDescription string `json:"poDescription" gorm:"column:poDescription"`
 	PoSKU         string `json:"poSKU" gorm:"column:poSKU"`
 	PoHSN         string `json:"poHSN" gorm:"column:poHSN"`
-	PoQuantity    string `json:"poQuantity" gorm:"column:poQuantity"`
-	PoPrice       string `json:"poPrice" gorm:"column:poPrice"`
-	PoDiscPercent string `json:"poDiscPercent" gorm:"column:poDiscPercent"`
-	PoDisc        string `json:"poDisc" gorm:"column:poDisc"`
-	PoTotalPrice  string `json:"poTotalPrice" gorm:"column:poTotalPrice"`
+	PoQuantity    string `json:"poQuantity" gorm:"column:poQuantity" binding:"omitempty,number"`
+	PoPrice       string `json:"poPrice" gorm:"column:poPrice" binding:"omitempty,number"`
+	PoDiscPercent string `json:"poDiscPercent" gorm:"column:poDiscPercent" binding:"omitempty,percent"`
+	PoDisc        string `json:"poDisc" gorm:"column:poDisc" binding:"omitempty,number"`
+	PoTotalPrice  string `json:"poTotalPrice" gorm:"column:poTotalPrice" binding:"omitempty,number"`
 	CreatedAt     string `json:"createdAt" gorm:"column:createdAt"`
 	CreatedBy     string `json:"createdBy" gorm:"column:createdBy"`
 	UpdatedAt     string `json:"updatedAt" gorm:"column:updatedAt"`
@@ -43,37 +43,37 @@ type PurchaseOrderProduct struct {
 	ProductBranchID    int    `json:"productBranchId"`
 	Quantity           string `json:"quantity"`
 	BranchName         string `json:"branchName" gorm:"-"` // not in table, for response
-	ProductBranchid    int    `json:"productBranchId" gorm:"productBranchId"`
+	ProductBranchid    int    `json:"-" gorm:"productBranchId"`
 }
 
 type StockTransferRequest struct {
 	ReceivedBranchDetails struct {
-		SupplierId          int    `json:"supplierId"`
+		SupplierId          int    `json:"supplierId" binding:"required,gt=0"`
 		SupplierName        string `json:"supplierName"`
 		SupplierCompanyName string `json:"supplierCompanyName"`
 		SupplierGSTNumber   string `json:"supplierGSTNumber"`
 		SupplierCode        string `json:"supplierCode"`
-	} `json:"receivedBranchDetails"`
+	} `json:"receivedBranchDetails" binding:"required"`
 
 	BranchDetails struct {
-		BranchId      int    `json:"branchId"`
+		BranchId      int    `json:"branchId" binding:"required,gt=0"`
 		BranchName    string `json:"branchName"`
 		BranchEmail   string `json:"branchEmail"`
 		BranchAddress string `json:"branchAddress"`
 		BranchCode    string `json:"branchCode"`
-	} `json:"branchDetails"`
+	} `json:"branchDetails" binding:"required"`
 
 	ProductDetails []struct {
-		ProductName      string `json:"productName"`
-		RefCategoryId    int    `json:"refCategoryid"`
-		RefSubCategoryId int    `json:"refSubCategoryId"`
+		ProductName      string `json:"productName" binding:"required"`
+		RefCategoryId    int    `json:"refCategoryid" binding:"gte=0"`
+		RefSubCategoryId int    `json:"refSubCategoryId" binding:"gte=0"`
 		HSNCode          string `json:"HSNCode"`
-		SKU              string `json:"SKU"`
-		PurchaseQuantity string `json:"purchaseQuantity"`
-		PurchasePrice    string `json:"purchasePrice"`
-		DiscountPrice    string `json:"discountPrice"`
-		DiscountAmount   string `json:"discountAmount"`
-		TotalAmount      string `json:"totalAmount"`
+		SKU              string `json:"SKU" binding:"required"`
+		PurchaseQuantity string `json:"purchaseQuantity" binding:"omitempty,number"`
+		PurchasePrice    string `json:"purchasePrice" binding:"omitempty,number"`
+		DiscountPrice    string `json:"discountPrice" binding:"omitempty,number"`
+		DiscountAmount   string `json:"discountAmount" binding:"omitempty,number"`
+		TotalAmount      string `json:"totalAmount" binding:"omitempty,number"`
 		IsReceived       bool   `json:"isReceived"`
 		AcceptanceStatus string `json:"acceptanceStatus"`
 		CreatedAt        string `json:"createdAt"`
@@ -81,18 +81,18 @@ type StockTransferRequest struct {
 		UpdatedAt        string `json:"updatedAt"`
 		UpdatedBy        string `json:"updatedBy"`
 		IsDelete         bool   `json:"isDelete"`
-	} `json:"productDetails"`
+	} `json:"productDetails" binding:"required,min=1,dive"`
 
 	TotalSummary struct {
 		PoNumber        string `json:"poNumber"`
 		BranchId        int    `json:"branchId"`
-		Status          int    `json:"status"`
+		Status          int    `json:"status" binding:"gte=0"`
 		ModeOfTransport string `json:"modeOfTransport"`
-		SubTotal        string `json:"subTotal"`
-		DiscountOverall string `json:"discountOverall"`
-		PayAmount       string `json:"payAmount"`
-		TotalAmount     string `json:"totalAmount"`
-		PaymentPending  string `json:"paymentPending"`
+		SubTotal        string `json:"subTotal" binding:"omitempty,number"`
+		DiscountOverall string `json:"discountOverall" binding:"omitempty,number"`
+		PayAmount       string `json:"payAmount" binding:"omitempty,number"`
+		TotalAmount     string `json:"totalAmount" binding:"omitempty,number"`
+		PaymentPending  string `json:"paymentPending" binding:"omitempty,number"`
 		CreatedAt       string `json:"createdAt"`
 		CreatedBy       string `json:"createdBy"`
 		UpdatedAt       string `json:"updatedAt"`
@@ -145,16 +145,16 @@ func (StockTransfer) TableName() string {
 }
 
 type ReceiveStockProductsRequest struct {
-	StockTransferId int `json:"stockTransferId"`
+	StockTransferId int `json:"stockTransferId" binding:"required,gt=0"`
 	AllProducts     []struct {
-		StockTransferItemID int    `json:"stockTransferItemId"`
+		StockTransferItemID int    `json:"stockTransferItemId" binding:"required,gt=0"`
 		StockTransferID     int    `json:"stockTransferId"`
 		ProductInstanceID   int    `json:"productInstanceId"`
 		ProductName         string `json:"productName"`
-		SKU                 string `json:"sku"`
+		SKU                 string `json:"sku" binding:"required"`
 		IsReceived          bool   `json:"isReceived"`
 		AcceptanceStatus    string `json:"acceptanceStatus"`
-	} `json:"allProducts"`
+	} `json:"allProducts" binding:"required,min=1,dive"`
 }
 type ProductImage struct {
 	ImageID           int     `json:"imageId" gorm:"column:image_id;primaryKey;autoIncrement"`
@@ -207,48 +207,48 @@ type NewStockTransferItem struct {
 }
 
 type NewStockTransferRequest struct {
-	FromBranchId int `json:"fromBranchId"`
-	ToBranchId   int `json:"toBranchId"`
+	FromBranchId int `json:"fromBranchId" binding:"required,gt=0"`
+	ToBranchId   int `json:"toBranchId" binding:"required,gt=0,nefield=FromBranchId"`
 	Items        []struct {
-		GRNItemId int    `json:"grnItemId"`
-		ProductId int    `json:"productId"`
-		SKU       string `json:"sku"`
-	} `json:"items"`
+		GRNItemId int    `json:"grnItemId" binding:"required,gt=0"`
+		ProductId int    `json:"productId" binding:"gte=0"`
+		SKU       string `json:"sku" binding:"required"`
+	} `json:"items" binding:"required,min=1,dive"`
 }
 
 type BundleInwardPayload struct {
-	Id         int        `json:"id"`
-	PoId       int        `json:"poId"`
-	PoDetails  PoDetail   `json:"poDetails"`
-	Bills      []BillItem `json:"bills"`
-	GrnDetails GrnDetail  `json:"grnDetails"`
+	Id         int        `json:"id" binding:"gte=0"`
+	PoId       int        `json:"poId" binding:"required,gt=0"`
+	PoDetails  PoDetail   `json:"poDetails" binding:"required"`
+	Bills      []BillItem `json:"bills" binding:"dive"`
+	GrnDetails GrnDetail  `json:"grnDetails" binding:"required"`
 }
 
 type PoDetail struct {
 	PoDate        string `json:"poDate"`
-	SupplierId    int    `json:"supplierId"`
+	SupplierId    int    `json:"supplierId" binding:"required,gt=0"`
 	Location      string `json:"location"`
-	PoValue       string `json:"poValue"`
+	PoValue       string `json:"poValue" binding:"omitempty,number"`
 	ReceivingType string `json:"receivingType"`
 	Remarks       string `json:"remarks"`
-	PoQty         string `json:"poQty"`
-	BoxCount      string `json:"boxCount"`
+	PoQty         string `json:"poQty" binding:"omitempty,number"`
+	BoxCount      string `json:"boxCount" binding:"omitempty,number"`
 }
 
 type BillItem struct {
 	BillDate     string `json:"billDate"`
-	BillNo       string `json:"billNo"`
-	BillQty      string `json:"billQty"`
-	TaxableValue string `json:"taxableValue"`
-	TaxPercent   string `json:"taxPercent"`
-	TaxAmount    string `json:"taxAmount"`
-	InvoiceValue string `json:"invoiceValue"`
+	BillNo       string `json:"billNo" binding:"required"`
+	BillQty      string `json:"billQty" binding:"omitempty,number"`
+	TaxableValue string `json:"taxableValue" binding:"omitempty,number"`
+	TaxPercent   string `json:"taxPercent" binding:"omitempty,percent"`
+	TaxAmount    string `json:"taxAmount" binding:"omitempty,number"`
+	InvoiceValue string `json:"invoiceValue" binding:"omitempty,number"`
 }
 
 type GrnDetail struct {
 	GrnDate      string `json:"grnDate"`
 	GrnStatus    string `json:"grnStatus"`
-	GrnValue     string `json:"grnValue"`
+	GrnValue     string `json:"grnValue" binding:"omitempty,number"`
 	BundleStatus string `json:"bundleStatus"`
 	Transporter  string `json:"transporterName"`
 	CreatedDate  string `json:"createdDate"`
@@ -0,0 +1,38 @@
+package productRoutes
+
+import (
+	productController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/controller"
+	productModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/model"
+	productService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/service"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/products/create":                           {Summary: "Create PO product", Request: productModel.POProduct{}, Permission: permission.ProductsManage, Idempotent: true},
+	"GET /api/v1/admin/products/read":                              {Summary: "Get all PO products", Response: []productModel.POProduct{}},
+	"GET /api/v1/admin/products/read/:id":                          {Summary: "Get PO product by id", Response: productModel.POProduct{}},
+	"PUT /api/v1/admin/products/update":                            {Summary: "Update PO product", Request: productModel.POProduct{}, Permission: permission.ProductsManage},
+	"DELETE /api/v1/admin/products/delete/:id":                     {Summary: "Delete PO product", Permission: permission.ProductsManage},
+	"POST /api/v1/admin/products/check-sku":                        {Summary: "Check SKU in branch", Request: productController.CheckSKURequest{}, Response: productService.ProductWithBranch{}, Permission: permission.InventoryView},
+	"GET /api/v1/admin/products/branch-4-products":                 {Summary: "Get branch 4 products", Response: []productService.Product4Branch{}},
+	"POST /api/v1/admin/products/stock-transfer":                   {Summary: "Create stock transfer", Request: productModel.StockTransferRequest{}, Permission: permission.InventoryTransfer, Idempotent: true},
+	"GET /api/v1/admin/products/stock-transfer":                    {Summary: "Get stock transfers", Query: []string{"toBranchId"}},
+	"GET /api/v1/admin/products/stock-transfer/all":                {Summary: "Get all stock transfers"},
+	"PUT /api/v1/admin/products/stock-transfer/receive":            {Summary: "Receive stock products", Request: productModel.ReceiveStockProductsRequest{}, Permission: permission.InventoryTransfer},
+	"POST /api/v1/admin/products/save":                             {Summary: "Save product images", Description: "Answers 202 with the id of the job that links the uploaded files to their products by SKU.", Request: productController.SaveProductImagesRequest{}, Permission: permission.ProductsManage, Idempotent: true},
+	"GET /api/v1/admin/products/byProduct/:productInstanceId":      {Summary: "Get images by product", Response: []productModel.ProductImage{}, Public: true},
+	"GET /api/v1/admin/products/purchaseOrderAcceptedProducts/:id": {Summary: "Get single purchase order accepted product", Response: productService.SingleProductWithImages{}},
+	"POST /api/v1/admin/products/check-sku-grn":                    {Summary: "Check SKU in GRN", Request: productController.CheckSKURequestLatest{}, Permission: permission.InventoryView},
+	"POST /api/v1/admin/products/check-sku-only-grn":               {Summary: "Check SKU only in GRN", Request: productController.CheckSKUOnlyRequest{}, Permission: permission.InventoryView},
+	"POST /api/v1/admin/products/new-stock-transfer":               {Summary: "Stock transfer", Request: productModel.NewStockTransferRequest{}, Permission: permission.InventoryTransfer, Idempotent: true},
+	"GET /api/v1/admin/products/stock-transfer/list":               {Summary: "Get stock transfer master", List: &productService.StockTransferList},
+	"GET /api/v1/admin/products/stock-transfer/items/:transferId":  {Summary: "Get stock transfer items"},
+	"POST /api/v1/admin/products/createBundle":                     {Summary: "Create bundle inward", Request: productModel.BundleInwardPayload{}, Permission: permission.GRNManage, Idempotent: true},
+	"GET /api/v1/admin/products/getBundle":                         {Summary: "Get all bundle inwards", List: &productService.BundleInwardList},
+	"PUT /api/v1/admin/products/updateBundle":                      {Summary: "Update bundle inward", Request: productModel.BundleInwardPayload{}, Permission: permission.GRNManage},
+	"GET /api/v1/admin/products/getBundleByPO/:po_id":              {Summary: "Get bundle inwards by PO"},
+	"POST /api/v1/admin/products/createDebitNote":                  {Summary: "Create debit note", Request: productService.DebitNotePayload{}, Permission: permission.DebitNoteManage, Idempotent: true},
+	"GET /api/v1/admin/products/getDebitNoteList":                  {Summary: "Get debit note list", Permission: permission.DebitNoteManage},
+	"GET /api/v1/admin/products/getDebitNoteById/:id":              {Summary: "Get debit note by id", Permission: permission.DebitNoteManage},
+}
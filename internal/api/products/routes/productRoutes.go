@@ -2,68 +2,79 @@ package productRoutes
 
 import (
 	productController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/controller"
+	productService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/service"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func ProductManagementRoutes(router *gin.Engine) {
+func ProductManagementRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/products")
-	route.POST("/create", accesstoken.JWTMiddleware(), productController.CreatePOProductController())
-	route.GET("/read", accesstoken.JWTMiddleware(), productController.GetAllPOProductsController())
-	route.GET("/read/:id", accesstoken.JWTMiddleware(), productController.GetPOProductByIdController())
-	route.PUT("/update", accesstoken.JWTMiddleware(), productController.UpdatePOProductController())
-	route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), productController.DeletePOProductController())
-	route.POST("/check-sku", accesstoken.JWTMiddleware(), productController.CheckSKUInBranchController())
-	route.GET("/branch-4-products", accesstoken.JWTMiddleware(), productController.GetBranch4ProductsController())
+	route.POST("/create", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ProductsManage), idempotency.Middleware(), productController.CreatePOProductController(dbConn))
+	route.GET("/read", accesstoken.JWTMiddleware(), productController.GetAllPOProductsController(dbConn))
+	route.GET("/read/:id", accesstoken.JWTMiddleware(), productController.GetPOProductByIdController(dbConn))
+	route.PUT("/update", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ProductsManage), productController.UpdatePOProductController(dbConn))
+	route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ProductsManage), productController.DeletePOProductController(dbConn))
+	route.POST("/check-sku", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.InventoryView), productController.CheckSKUInBranchController(dbConn))
+	route.GET("/branch-4-products", accesstoken.JWTMiddleware(), productController.GetBranch4ProductsController(dbConn))
 
 	// INVENTORY STOCK TRANSFER
-	route.POST("/stock-transfer", accesstoken.JWTMiddleware(), productController.CreateStockTransfer())
-	route.GET("/stock-transfer", accesstoken.JWTMiddleware(), productController.GetStockTransfersController())
+	route.POST("/stock-transfer", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.InventoryTransfer), idempotency.Middleware(), productController.CreateStockTransfer(dbConn))
+	route.GET("/stock-transfer", accesstoken.JWTMiddleware(), productController.GetStockTransfersController(dbConn))
 
-	route.GET("/stock-transfer/all", accesstoken.JWTMiddleware(), productController.GetAllStockTransfersController())
+	route.GET("/stock-transfer/all", accesstoken.JWTMiddleware(), productController.GetAllStockTransfersController(dbConn))
 
-	route.PUT("/stock-transfer/receive", accesstoken.JWTMiddleware(), productController.ReceiveStockProductsController())
+	route.PUT("/stock-transfer/receive", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.InventoryTransfer), productController.ReceiveStockProductsController(dbConn))
 
-	route.POST("/save", accesstoken.JWTMiddleware(), productController.SaveProductImagesController())
-	route.GET("/byProduct/:productInstanceId", productController.GetImagesByProductController())
+	route.POST("/save", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ProductsManage), idempotency.Middleware(), productController.SaveProductImagesController(dbConn))
+	route.GET("/byProduct/:productInstanceId", productController.GetImagesByProductController(dbConn))
 
-	route.GET("/purchaseOrderAcceptedProducts/:id", accesstoken.JWTMiddleware(), productController.GetSinglePurchaseOrderAcceptedProductController())
+	route.GET("/purchaseOrderAcceptedProducts/:id", accesstoken.JWTMiddleware(), productController.GetSinglePurchaseOrderAcceptedProductController(dbConn))
 
 	route.POST(
 		"/check-sku-grn",
 		accesstoken.JWTMiddleware(),
-		productController.CheckSKUInGRNController(),
+		permission.RequirePermission(permission.InventoryView),
+		productController.CheckSKUInGRNController(dbConn),
 	)
 
 	route.POST(
 		"/check-sku-only-grn",
 		accesstoken.JWTMiddleware(),
-		productController.CheckSKUOnlyInGRNController(),
+		permission.RequirePermission(permission.InventoryView),
+		productController.CheckSKUOnlyInGRNController(dbConn),
 	)
 
-	route.POST("/new-stock-transfer", accesstoken.JWTMiddleware(), productController.StockTransferController())
+	route.POST("/new-stock-transfer", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.InventoryTransfer), idempotency.Middleware(), productController.StockTransferController(dbConn))
 
-	route.GET("/stock-transfer/list", accesstoken.JWTMiddleware(), productController.GetStockTransferMasterController())
+	route.GET("/stock-transfer/list", accesstoken.JWTMiddleware(), productController.GetStockTransferMasterController(dbConn))
 
-	route.GET("/stock-transfer/items/:transferId", accesstoken.JWTMiddleware(), productController.GetStockTransferItemsController())
+	route.GET("/stock-transfer/items/:transferId", accesstoken.JWTMiddleware(), productController.GetStockTransferItemsController(dbConn))
 
-	// route.GET("/stock-transfer/:id", accesstoken.JWTMiddleware(), productController.GetStockTransferByIDController())
+	// route.GET("/stock-transfer/:id", accesstoken.JWTMiddleware(), productController.GetStockTransferByIDController(dbConn))
 
-	route.POST("/createBundle", accesstoken.JWTMiddleware(), productController.CreateBundleInwardController())
-	route.GET("/getBundle", accesstoken.JWTMiddleware(), productController.GetAllBundleInwardsController())
-	route.PUT("/updateBundle", accesstoken.JWTMiddleware(), productController.UpdateBundleInwardController())
+	route.POST("/createBundle", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.GRNManage), idempotency.Middleware(), productController.CreateBundleInwardController(dbConn))
+	route.GET("/getBundle", accesstoken.JWTMiddleware(), productController.GetAllBundleInwardsController(dbConn))
+	route.PUT("/updateBundle", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.GRNManage), productController.UpdateBundleInwardController(dbConn))
 
 	route.GET(
 		"/getBundleByPO/:po_id",
 		accesstoken.JWTMiddleware(),
-		productController.GetBundleInwardsByPOController(),
+		productController.GetBundleInwardsByPOController(dbConn),
 	)
 
 	route.POST(
 		"/createDebitNote",
 		accesstoken.JWTMiddleware(),
-		productController.CreateDebitNoteController(),
+		permission.RequirePermission(permission.DebitNoteManage),
+		idempotency.Middleware(),
+		productController.CreateDebitNoteController(dbConn),
 	)
 
 	// routes/debitNoteRoutes.go
@@ -71,13 +82,19 @@ func ProductManagementRoutes(router *gin.Engine) {
 	route.GET(
 		"/getDebitNoteList",
 		accesstoken.JWTMiddleware(),
-		productController.GetDebitNoteListController(),
+		permission.RequirePermission(permission.DebitNoteManage),
+		productController.GetDebitNoteListController(dbConn),
 	)
 
 	route.GET(
 		"/getDebitNoteById/:id",
 		accesstoken.JWTMiddleware(),
-		productController.GetDebitNoteByIdController(),
+		permission.RequirePermission(permission.DebitNoteManage),
+		productController.GetDebitNoteByIdController(dbConn),
 	)
 
+	jobqueue.Handle(productService.JobLinkImages, productService.LinkImagesJob)
+	outbox.Subscribe(outbox.StockTransferred, "products.mailReceivingBranch", productService.MailReceivingBranch)
+
+	openapi.Describe("Products", docs)
 }
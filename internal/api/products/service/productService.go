@@ -1,45 +1,41 @@
 package productService
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	bulkImageUploadService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/service"
 	poModuleModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/poModule/model"
 	productModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/products/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	mailService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/MailService"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
 	"gorm.io/gorm"
 
 )
 
 func CreatePOProduct(db *gorm.DB, product *productModel.POProduct) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
-	// Format: SKU-dd-mm-yy-00001
 	today := time.Now()
-	datePart := today.Format("02-01-06") // dd-mm-yy
-
-	var count int64
-	err := db.Table("POProducts").
-		Where(`"isDelete" = false`).
-		Count(&count).Error
-	if err != nil {
-		log.Error("Failed to count existing products: " + err.Error())
-		return err
-	}
-
-	sequenceNumber := fmt.Sprintf("%05d", count+1)
-	generatedSKU := fmt.Sprintf("SKU-%s-%s", datePart, sequenceNumber)
-
-	// Assign generated SKU
-	product.PoSKU = generatedSKU
 
 	// Check for duplicates (by poHSN + poDescription only, since SKU is new)
 	var existing productModel.POProduct
-	err = db.Table("POProducts").
+	err := db.Table("POProducts").
 		Where(`"poHSN" = ? AND "poDescription" = ? AND "isDelete" = false`,
 			product.PoHSN, product.PoDescription).
 		First(&existing).Error
@@ -54,12 +50,21 @@ func CreatePOProduct(db *gorm.DB, product *productModel.POProduct) error {
 
 	// Proceed to insert
 	product.CreatedAt = today.Format("2006-01-02 15:04:05")
-	product.CreatedBy = "Admin"
+	product.CreatedBy = author
 	product.IsDelete = false
 
-	log.Info("Generated SKU: " + product.PoSKU)
+	return db.Transaction(func(tx *gorm.DB) error {
+		sku, err := numberseries.Issue(tx, numberseries.POProductSKU, 0, today)
+		if err != nil {
+			log.Error("Failed to generate SKU: " + err.Error())
+			return err
+		}
+		product.PoSKU = sku
+
+		log.Info("Generated SKU: " + product.PoSKU)
 
-	return db.Table("POProducts").Create(product).Error
+		return tx.Table("POProducts").Create(product).Error
+	})
 }
 
 func GetAllPOProducts(db *gorm.DB) ([]productModel.POProduct, error) {
@@ -79,7 +84,8 @@ func GetPOProductById(db *gorm.DB, id string) (productModel.POProduct, error) {
 }
 
 func UpdatePOProduct(db *gorm.DB, product *productModel.POProduct) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
 	// Check if the product exists and is not deleted
 	var existing productModel.POProduct
@@ -99,7 +105,7 @@ func UpdatePOProduct(db *gorm.DB, product *productModel.POProduct) error {
 
 	// Set update metadata
 	product.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-	product.UpdatedBy = "Admin"
+	product.UpdatedBy = author
 
 	if product.PoSKU != "" {
 		log.Warn("PoSKU was passed in update payload. Ignoring it to preserve original value.")
@@ -123,12 +129,13 @@ func UpdatePOProduct(db *gorm.DB, product *productModel.POProduct) error {
 }
 
 func DeletePOProduct(db *gorm.DB, id string) error {
+	author := actor.FromDB(db).By()
 	return db.Table("POProducts").
 		Where(`"poId" = ?`, id).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": "Admin",
+			"updatedBy": author,
 		}).Error
 }
 
@@ -197,90 +204,77 @@ func GetProductsByBranchID(db *gorm.DB, branchID int) ([]Product4Branch, error)
 
 func CreateStockTransfer(db *gorm.DB, payload productModel.StockTransferRequest) (int, error) {
 
-	branchCode := payload.BranchDetails.BranchCode
-
-	var count int64
-	if err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).Count(&count).Error; err != nil {
-		return 0, fmt.Errorf("failed to count stock transfers: %v", err)
-	}
-
-	var nextNumber int64 = 10001
-
-	if count > 0 {
-		var lastST string
-		err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).
-			Select(`"po_number"`).
-			Where(`"po_number" LIKE 'ST-%'`).
-			Order(`"po_number" DESC`).
-			Limit(1).
-			Scan(&lastST).Error
-
+	var transfer productModel.StockTransfer
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// numbered by the sending branch, whose code comes from Branches and not the payload
+		poNumber, err := numberseries.Issue(tx, numberseries.InventoryStockTransfer, payload.BranchDetails.BranchId, time.Now())
 		if err != nil {
-			return 0, fmt.Errorf("failed to fetch last stock transfer number: %v", err)
+			return fmt.Errorf("failed to generate stock transfer number: %w", err)
 		}
 
-		if lastST != "" {
-			parts := strings.Split(lastST, "-")
-			if len(parts) >= 3 {
-				if num, convErr := strconv.ParseInt(parts[2], 10, 64); convErr == nil {
-					nextNumber = num + 1
-				}
-			}
+		transfer = productModel.StockTransfer{
+			FromBranchID:      payload.BranchDetails.BranchId,
+			FromBranchName:    payload.BranchDetails.BranchName,
+			FromBranchEmail:   payload.BranchDetails.BranchEmail,
+			FromBranchAddress: payload.BranchDetails.BranchAddress,
+			ToBranchID:        payload.ReceivedBranchDetails.SupplierId,
+			ToBranchName:      payload.ReceivedBranchDetails.SupplierName,
+			ToBranchEmail:     payload.ReceivedBranchDetails.SupplierCompanyName,
+			ToBranchAddress:   payload.ReceivedBranchDetails.SupplierGSTNumber,
+			ModeOfTransport:   payload.TotalSummary.ModeOfTransport,
+			SubTotal:          payload.TotalSummary.SubTotal,
+			DiscountOverall:   payload.TotalSummary.DiscountOverall,
+			TotalAmount:       payload.TotalSummary.TotalAmount,
+			PaymentPending:    payload.TotalSummary.PaymentPending,
+			PoNumber:          poNumber,
+			Status:            payload.TotalSummary.Status,
+			CreatedAt:         payload.TotalSummary.CreatedAt,
+			CreatedBy:         actor.FromDB(db).By(),
+			UpdatedAt:         payload.TotalSummary.UpdatedAt,
+			UpdatedBy:         actor.FromDB(db).By(),
+			IsDelete:          false,
 		}
-	}
 
-	poNumber := fmt.Sprintf("ST-%s-%05d", branchCode, nextNumber)
-
-	transfer := productModel.StockTransfer{
-		FromBranchID:      payload.BranchDetails.BranchId,
-		FromBranchName:    payload.BranchDetails.BranchName,
-		FromBranchEmail:   payload.BranchDetails.BranchEmail,
-		FromBranchAddress: payload.BranchDetails.BranchAddress,
-		ToBranchID:        payload.ReceivedBranchDetails.SupplierId,
-		ToBranchName:      payload.ReceivedBranchDetails.SupplierName,
-		ToBranchEmail:     payload.ReceivedBranchDetails.SupplierCompanyName,
-		ToBranchAddress:   payload.ReceivedBranchDetails.SupplierGSTNumber,
-		ModeOfTransport:   payload.TotalSummary.ModeOfTransport,
-		SubTotal:          payload.TotalSummary.SubTotal,
-		DiscountOverall:   payload.TotalSummary.DiscountOverall,
-		TotalAmount:       payload.TotalSummary.TotalAmount,
-		PaymentPending:    payload.TotalSummary.PaymentPending,
-		PoNumber:          poNumber,
-		Status:            payload.TotalSummary.Status,
-		CreatedAt:         payload.TotalSummary.CreatedAt,
-		CreatedBy:         payload.TotalSummary.CreatedBy,
-		UpdatedAt:         payload.TotalSummary.UpdatedAt,
-		UpdatedBy:         payload.TotalSummary.UpdatedBy,
-		IsDelete:          false,
-	}
-
-	if err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).
-		Create(&transfer).Error; err != nil {
-		return 0, fmt.Errorf("failed to insert stock transfer: %v", err)
-	}
+		if err := tx.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).
+			Create(&transfer).Error; err != nil {
+			return fmt.Errorf("failed to insert stock transfer: %v", err)
+		}
 
-	for _, p := range payload.ProductDetails {
+		for _, p := range payload.ProductDetails {
 
-		item := productModel.StockTransferItem{
-			StockTransferID:   transfer.StockTransferID,
-			ProductInstanceID: 0,
-			ProductName:       p.ProductName,
-			SKU:               p.SKU,
-			IsReceived:        p.IsReceived,
-			AcceptanceStatus:  "In Transit",
-		}
+			item := productModel.StockTransferItem{
+				StockTransferID:   transfer.StockTransferID,
+				ProductInstanceID: 0,
+				ProductName:       p.ProductName,
+				SKU:               p.SKU,
+				IsReceived:        p.IsReceived,
+				AcceptanceStatus:  "In Transit",
+			}
 
-		if err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransferItems"`).
-			Create(&item).Error; err != nil {
+			if err := tx.Table(`"purchaseOrderMgmt"."Inventory_StockTransferItems"`).
+				Create(&item).Error; err != nil {
 
-			return 0, fmt.Errorf("failed to insert item: %v", err)
+				return fmt.Errorf("failed to insert item: %v", err)
+			}
 		}
+
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityStockTransfer,
+			EntityId: transfer.StockTransferID,
+			Action:   audit.ActionCreate,
+			BranchId: transfer.FromBranchID,
+			After:    map[string]interface{}{"transfer": transfer, "products": payload.ProductDetails},
+		})
+	})
+	if err != nil {
+		return 0, err
 	}
+	metrics.TransfersCreated.Inc()
 
 	return transfer.StockTransferID, nil
 }
 
-func GetStockTransferByID(db *gorm.DB, transferId int) (*productModel.StockTransfer, []productModel.StockTransferItem, error) {
+func GetStockTransferByID(db *gorm.DB, transferId int, scope branchscope.Scope) (*productModel.StockTransfer, []productModel.StockTransferItem, error) {
 
 	var transfer productModel.StockTransfer
 	var items []productModel.StockTransferItem
@@ -292,6 +286,11 @@ func GetStockTransferByID(db *gorm.DB, transferId int) (*productModel.StockTrans
 		return nil, nil, err
 	}
 
+	// Visible to the sending and the receiving branch
+	if !scope.Allows(transfer.FromBranchID) && !scope.Allows(transfer.ToBranchID) {
+		return nil, nil, branchscope.ErrOutOfScope
+	}
+
 	// Fetch items
 	if err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransferItems"`).
 		Where(`stock_transfer_id = ?`, transferId).
@@ -317,13 +316,17 @@ func GetStockTransfers(db *gorm.DB, toBranchId int) ([]productModel.StockTransfe
 	return transfers, nil
 }
 
-func GetAllStockTransfers(db *gorm.DB) ([]productModel.StockTransfer, error) {
+func GetAllStockTransfers(db *gorm.DB, scope branchscope.Scope) ([]productModel.StockTransfer, error) {
 
 	var transfers []productModel.StockTransfer
 
-	// Fetch all parent transfers
-	if err := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).
-		Where(`is_delete = false`).
+	// Fetch all parent transfers sent from or to the branches in scope
+	query := db.Table(`"purchaseOrderMgmt"."Inventory_StockTransfers"`).
+		Where(`is_delete = false`)
+	if !scope.AllBranches {
+		query = query.Where(`(from_branch_id = ? OR to_branch_id = ?)`, scope.BranchId, scope.BranchId)
+	}
+	if err := query.
 		Order(`stock_transfer_id DESC`).
 		Find(&transfers).Error; err != nil {
 		return nil, err
@@ -352,7 +355,8 @@ func GetAllStockTransfers(db *gorm.DB) ([]productModel.StockTransfer, error) {
 	return transfers, nil
 }
 
-func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProductsRequest) error {
+func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProductsRequest, scope branchscope.Scope) error {
+	author := actor.FromDB(db).By()
 
 	// 1. Fetch Stock Transfer
 	var transfer productModel.StockTransfer
@@ -363,6 +367,11 @@ func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProduc
 		return fmt.Errorf("invalid stock transfer ID: %v", err)
 	}
 
+	// Only the receiving branch may accept the goods
+	if !scope.Allows(transfer.ToBranchID) {
+		return branchscope.ErrOutOfScope
+	}
+
 	toBranchId := transfer.ToBranchID
 	currentTime := time.Now().Format("2006-01-02 15:04:05")
 
@@ -374,9 +383,22 @@ func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProduc
 
 	for _, p := range payload.AllProducts {
 
-		// 3. Update Inventory Stock Transfer Item
+		// 3. Only items and SKUs of this transfer may be received
+		var item productModel.StockTransferItem
+		err := tx.Where(`stock_transfer_item_id = ? AND stock_transfer_id = ?`, p.StockTransferItemID, transfer.StockTransferID).
+			Take(&item).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && item.SKU != p.SKU) {
+			tx.Rollback()
+			return apperror.BadRequest(fmt.Sprintf("SKU %s is not on stock transfer %d", p.SKU, transfer.StockTransferID))
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to read stock transfer item: %v", err)
+		}
+
+		// Update Inventory Stock Transfer Item
 		if err := tx.Table(`"purchaseOrderMgmt"."Inventory_StockTransferItems"`).
-			Where(`stock_transfer_item_id = ?`, p.StockTransferItemID).
+			Where(`stock_transfer_item_id = ? AND stock_transfer_id = ?`, item.StockTransferItemID, transfer.StockTransferID).
 			Updates(map[string]interface{}{
 				"is_received":       true,
 				"acceptance_status": "Received",
@@ -388,11 +410,11 @@ func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProduc
 
 		// 4. Update PurchaseOrderAcceptedProducts based on SKU
 		if err := tx.Table(`"purchaseOrderMgmt"."PurchaseOrderAcceptedProducts"`).
-			Where(`"SKU" = ?`, p.SKU).
+			Where(`"SKU" = ?`, item.SKU).
 			Updates(map[string]interface{}{
 				"productBranchId": toBranchId,
 				"updatedAt":       currentTime,
-				"updatedBy":       "Admin",
+				"updatedBy":       author,
 			}).Error; err != nil {
 
 			tx.Rollback()
@@ -400,17 +422,77 @@ func ReceiveProductsService(db *gorm.DB, payload productModel.ReceiveStockProduc
 		}
 	}
 
+	if err := audit.Record(tx, audit.Entry{
+		Entity:   audit.EntityStockTransfer,
+		EntityId: transfer.StockTransferID,
+		Action:   audit.ActionReceive,
+		BranchId: toBranchId,
+		Before:   transfer,
+		After:    map[string]interface{}{"received": payload.AllProducts},
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// a transfer may be received in parts, so the event is keyed by the items received
+	skus := make([]string, 0, len(payload.AllProducts))
+	itemIds := make([]string, 0, len(payload.AllProducts))
+	for _, p := range payload.AllProducts {
+		skus = append(skus, p.SKU)
+		itemIds = append(itemIds, fmt.Sprint(p.StockTransferItemID))
+	}
+	sort.Strings(itemIds)
+	key := fmt.Sprintf("%d:%s", transfer.StockTransferID, strings.Join(itemIds, ","))
+	if err := outbox.Publish(tx, outbox.StockReceived, key, toBranchId, map[string]interface{}{
+		"stockTransferId": transfer.StockTransferID,
+		"fromBranchId":    transfer.FromBranchID,
+		"toBranchId":      toBranchId,
+		"skus":            skus,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// 5. Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return err
 	}
+	metrics.TransfersReceived.Inc()
 
 	return nil
 }
 
-func SaveProductImagesService(db *gorm.DB, fileNames []string, createdBy interface{}) error {
-	log := logger.InitLogger()
+// JobLinkImages links uploaded bulk images to their products from a job worker.
+const JobLinkImages = "products.linkImages"
+
+// LinkImagesPayload is the payload of a JobLinkImages job.
+type LinkImagesPayload struct {
+	FileNames []string `json:"fileNames"`
+}
+
+// LinkImagesJob handles JobLinkImages. SaveProductImagesService saves all the files or
+// none, so a failed attempt is safe to run again.
+func LinkImagesJob(db *gorm.DB, payload json.RawMessage) (interface{}, error) {
+	var body LinkImagesPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+	if err := SaveProductImagesService(db, body.FileNames); err != nil {
+		return nil, err
+	}
+	return map[string]int{"files": len(body.FileNames)}, nil
+}
+
+func SaveProductImagesService(db *gorm.DB, fileNames []string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		return saveProductImages(tx, fileNames)
+	})
+}
+
+func saveProductImages(db *gorm.DB, fileNames []string) error {
+	log := logger.FromDB(db)
 	log.Info("\n🛠️ SaveProductImagesService invoked")
+	createdBy := actor.FromDB(db).By()
 
 	// Correct SKU extraction
 	skuRegex := regexp.MustCompile(`^([A-Za-z0-9-]*\d)`)
@@ -448,7 +530,7 @@ func SaveProductImagesService(db *gorm.DB, fileNames []string, createdBy interfa
 			SkuFound:          &baseSku,
 			ExtractedSku:      &baseSku,
 			CreatedAt:         func() *string { t := time.Now().Format("2006-01-02 15:04:05"); return &t }(),
-			CreatedBy:         func() *string { v := fmt.Sprintf("%v", createdBy); return &v }(),
+			CreatedBy:         &createdBy,
 			IsDelete:          func() *bool { b := false; return &b }(),
 		}
 
@@ -465,7 +547,7 @@ func SaveProductImagesService(db *gorm.DB, fileNames []string, createdBy interfa
 }
 
 func GetImagesByProductService(db *gorm.DB, productInstanceId string) ([]productModel.ProductImage, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 
 	var images []productModel.ProductImage
 
@@ -497,7 +579,7 @@ type ImageResponse struct {
 }
 
 func GetSinglePurchaseOrderAcceptedProductService(db *gorm.DB, productInstanceId int) (*SingleProductWithImages, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🔍 Fetching productInstanceId: %d", productInstanceId)
 
 	// ---------- FETCH MAIN PRODUCT ----------
@@ -693,45 +775,18 @@ type StockTransferRequest struct {
 }
 
 func TransferStock(db *gorm.DB, payload productModel.NewStockTransferRequest) (int, error) {
+	author := actor.FromDB(db).By()
 
 	var transferID int
 
 	err := db.Transaction(func(tx *gorm.DB) error {
 
-		// 1️⃣ Fetch Branch Code
-		var branchCode string
-		err := tx.Raw(`
-			SELECT "refBranchCode"
-			FROM public."Branches"
-			WHERE "refBranchId" = ?
-		`, payload.FromBranchId).Scan(&branchCode).Error
-
+		// 1️⃣ Next transfer number of the sending branch
+		stockTransferNumber, err := numberseries.Issue(tx, numberseries.StockTransfer, payload.FromBranchId, time.Now())
 		if err != nil {
-			return fmt.Errorf("failed to get branch code: %v", err)
-		}
-		if branchCode == "" {
-			return fmt.Errorf("branch code not found for branch %d", payload.FromBranchId)
+			return fmt.Errorf("failed to generate stock transfer number: %w", err)
 		}
 
-		var lastNumber int
-
-		err = tx.Raw(`
-			SELECT 
-				CAST(SUBSTRING("stockTransferNumber", LENGTH("stockTransferNumber") - 4) AS INTEGER)
-			FROM "purchaseOrderMgmt"."StockTransferMaster"
-			WHERE "stockTransferNumber" LIKE 'ST%'
-			ORDER BY id DESC
-			LIMIT 1
-		`).Scan(&lastNumber).Error
-
-		if err != nil && err != gorm.ErrRecordNotFound {
-			return fmt.Errorf("failed to fetch last transfer number: %v", err)
-		}
-
-		nextNumber := lastNumber + 1
-
-		stockTransferNumber := fmt.Sprintf("ST%s%05d", branchCode, nextNumber)
-
 		master := struct {
 			ID                  int    `gorm:"column:id;primaryKey"`
 			FromBranchID        int    `gorm:"column:from_branch_id"`
@@ -746,7 +801,7 @@ func TransferStock(db *gorm.DB, payload productModel.NewStockTransferRequest) (i
 			FromBranchID:        payload.FromBranchId,
 			ToBranchID:          payload.ToBranchId,
 			CreatedAt:           time.Now().Format("2006-01-02 15:04:05"),
-			CreatedBy:           "admin",
+			CreatedBy:           author,
 			UpdatedAt:           "",
 			UpdatedBy:           "",
 			IsDelete:            false,
@@ -778,7 +833,7 @@ func TransferStock(db *gorm.DB, payload productModel.NewStockTransferRequest) (i
 				GRNItemID:        item.GRNItemId,
 				SKU:              item.SKU,
 				CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:        "admin",
+				CreatedBy:        author,
 				UpdatedAt:        "",
 				UpdatedBy:        "",
 				IsReceived:       false,
@@ -790,7 +845,7 @@ func TransferStock(db *gorm.DB, payload productModel.NewStockTransferRequest) (i
 				return fmt.Errorf("failed to insert stock transfer item: %v", err)
 			}
 
-			audit := struct {
+			transferAudit := struct {
 				ID         int    `gorm:"column:id;primaryKey"`
 				ProductID  int    `gorm:"column:productid"`
 				SKU        string `gorm:"column:sku"`
@@ -804,29 +859,120 @@ func TransferStock(db *gorm.DB, payload productModel.NewStockTransferRequest) (i
 				FromBranch: payload.FromBranchId,
 				ToBranch:   payload.ToBranchId,
 				CreatedAt:  time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:  "admin",
+				CreatedBy:  author,
 			}
 
 			if err := tx.Table(`"PurchaseOrderManagement"."StockTransferAudit"`).
-				Create(&audit).Error; err != nil {
+				Create(&transferAudit).Error; err != nil {
 				return fmt.Errorf("failed to insert audit: %v", err)
 			}
 		}
 
-		return nil
+		if err := audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityStockTransfer,
+			EntityId: master.ID,
+			Action:   audit.ActionCreate,
+			BranchId: payload.FromBranchId,
+			After:    map[string]interface{}{"stockTransferNumber": stockTransferNumber, "transfer": payload},
+		}); err != nil {
+			return err
+		}
+
+		skus := make([]string, 0, len(payload.Items))
+		for _, item := range payload.Items {
+			skus = append(skus, item.SKU)
+		}
+		return outbox.Publish(tx, outbox.StockTransferred, master.ID, payload.FromBranchId, StockTransferredEvent{
+			StockTransferId:     master.ID,
+			StockTransferNumber: stockTransferNumber,
+			FromBranchId:        payload.FromBranchId,
+			ToBranchId:          payload.ToBranchId,
+			SKUs:                skus,
+		})
 	})
 
 	if err != nil {
 		return 0, err
 	}
+	metrics.TransfersCreated.Inc()
 
 	return transferID, nil
 }
 
-func GetStockTransferMasterList(db *gorm.DB) ([]map[string]interface{}, error) {
-	var results []map[string]interface{}
+// StockTransferredEvent is the payload of an outbox.StockTransferred event.
+type StockTransferredEvent struct {
+	StockTransferId     int      `json:"stockTransferId"`
+	StockTransferNumber string   `json:"stockTransferNumber"`
+	FromBranchId        int      `json:"fromBranchId"`
+	ToBranchId          int      `json:"toBranchId"`
+	SKUs                []string `json:"skus"`
+}
 
-	query := `
+// MailReceivingBranch subscribes to outbox.StockTransferred: it tells the receiving
+// branch which SKUs are on the way.
+func MailReceivingBranch(db *gorm.DB, msg outbox.Message) error {
+	var event StockTransferredEvent
+	if err := msg.Decode(&event); err != nil {
+		return err
+	}
+
+	var branch struct {
+		RefBranchName string `gorm:"column:refBranchName"`
+		RefEmail      string `gorm:"column:refEmail"`
+	}
+	err := db.Table(`public."Branches"`).
+		Select(`"refBranchName", "refEmail"`).
+		Where(`"refBranchId" = ?`, event.ToBranchId).
+		Take(&branch).Error
+	if err != nil {
+		return err
+	}
+	if branch.RefEmail == "" {
+		logger.FromDB(db).Warnf("⚠️ Branch %d has no email, stock transfer %s not mailed", event.ToBranchId, event.StockTransferNumber)
+		return nil
+	}
+
+	html := fmt.Sprintf(`
+		<p>Dear %s team,</p>
+		<p>Stock transfer <strong>%s</strong> is on its way to your branch with %d items:</p>
+		<p>%s</p>
+		<p>Please receive them once they arrive.</p>
+		<p>Snehalayaa Silks ERP</p>
+	`, branch.RefBranchName, event.StockTransferNumber, len(event.SKUs), strings.Join(event.SKUs, ", "))
+	_, err = mailService.Queue(db, branch.RefEmail, html, "Stock transfer "+event.StockTransferNumber, jobqueue.Options{})
+	return err
+}
+
+// StockTransferList is what GET /products/stock-transfer/list may be filtered, searched and
+// sorted on.
+var StockTransferList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"from_branch_id": {Column: "from_branch_id", Kind: listquery.ID},
+		"to_branch_id":   {Column: "to_branch_id", Kind: listquery.ID},
+		"created_at":     {Column: "created_at", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"id":                  "id",
+		"stockTransferNumber": "stockTransferNumber",
+		"created_at":          "created_at",
+		"item_count":          "item_count",
+	},
+	Search:      []string{"stockTransferNumber", "from_branch_name", "to_branch_name"},
+	Key:         "id",
+	DefaultSort: "-id",
+}
+
+func GetStockTransferMasterList(db *gorm.DB, scope branchscope.Scope, query listquery.Query) ([]map[string]interface{}, listquery.Page, error) {
+	results := []map[string]interface{}{}
+
+	branchCondition := "TRUE"
+	args := []interface{}{}
+	if !scope.AllBranches {
+		branchCondition = "(stm.from_branch_id = ? OR stm.to_branch_id = ?)"
+		args = append(args, scope.BranchId, scope.BranchId)
+	}
+
+	base := `
         SELECT 
             stm.id,
             stm."stockTransferNumber",
@@ -848,20 +994,33 @@ func GetStockTransferMasterList(db *gorm.DB) ([]map[string]interface{}, error) {
         LEFT JOIN public."Branches" fb ON fb."refBranchId" = stm.from_branch_id
         LEFT JOIN public."Branches" tb ON tb."refBranchId" = stm.to_branch_id
         WHERE stm.is_delete = false
-        ORDER BY stm.id DESC
+          AND ` + branchCondition + `
     `
 
-	err := db.Raw(query).Scan(&results).Error
+	page, err := listquery.Find(db, base, args, query, &results)
 	if err != nil {
-		return nil, err
+		return nil, page, err
 	}
 
-	return results, nil
+	return results, page, nil
 }
 
-func GetStockTransferItems(db *gorm.DB, transferId int) ([]map[string]interface{}, error) {
+func GetStockTransferItems(db *gorm.DB, transferId int, scope branchscope.Scope) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
+	if !scope.AllBranches {
+		var visible int64
+		err := db.Table(`"purchaseOrderMgmt"."StockTransferMaster"`).
+			Where(`id = ? AND (from_branch_id = ? OR to_branch_id = ?)`, transferId, scope.BranchId, scope.BranchId).
+			Count(&visible).Error
+		if err != nil {
+			return nil, err
+		}
+		if visible == 0 {
+			return nil, branchscope.ErrOutOfScope
+		}
+	}
+
 	query := `
        SELECT 
             sti.id,
@@ -893,29 +1052,11 @@ func GetStockTransferItems(db *gorm.DB, transferId int) ([]map[string]interface{
 
 // BUNDLE IN OUT
 func CreateBundleInwardService(db *gorm.DB, payload *productModel.BundleInwardPayload) error {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠 CreateBundleInwardService invoked")
+	createdBy := actor.FromDB(db).By()
 
-	// STEP 1: Get last inward number
-	var lastNumber string
-	err := db.Raw(`SELECT "bundleInwardNumber" 
-                   FROM "BundleInOut".bundle_inwards
-                   WHERE "bundleInwardNumber" IS NOT NULL
-                   ORDER BY id DESC LIMIT 1`).Scan(&lastNumber).Error
-	if err != nil {
-		return err
-	}
-
-	// STEP 2: Compute next number
-	nextNumber := "SSINW0001" // default for first record
-	if lastNumber != "" {
-		num := 1
-		fmt.Sscanf(lastNumber, "SSINW%d", &num)
-		num++
-		nextNumber = fmt.Sprintf("SSINW%04d", num)
-	}
-
-	// STEP 3: Build inward record including new number
+	// STEP 1: Build inward record, numbered inside the transaction
 	inward := map[string]interface{}{
 		"po_id":              payload.PoId,
 		"po_date":            payload.PoDetails.PoDate,
@@ -933,42 +1074,73 @@ func CreateBundleInwardService(db *gorm.DB, payload *productModel.BundleInwardPa
 		"transporter_name":   payload.GrnDetails.Transporter,
 		"created_date":       payload.GrnDetails.CreatedDate,
 		"created_at":         time.Now().Format("2006-01-02 15:04:05"),
-		"bundleInwardNumber": nextNumber, // ✔ inserted here
+		"created_by":         createdBy,
 	}
 
-	// STEP 4: Insert inward
-	err = db.Table(`"BundleInOut"."bundle_inwards"`).Create(inward).Error
-	if err != nil {
-		return err
-	}
+	// STEP 2-4: Number the inward, insert it and its bills together
+	return db.Transaction(func(tx *gorm.DB) error {
+		nextNumber, err := numberseries.Issue(tx, numberseries.BundleInward, 0, time.Now())
+		if err != nil {
+			return err
+		}
+		inward["bundleInwardNumber"] = nextNumber
 
-	// STEP 5: Fetch inserted ID
-	var inwardId int
-	db.Raw(`SELECT id FROM "BundleInOut".bundle_inwards ORDER BY id DESC LIMIT 1`).Scan(&inwardId)
-
-	// STEP 6: Insert bills
-	for _, b := range payload.Bills {
-		bill := map[string]interface{}{
-			"inward_id":     inwardId,
-			"bill_date":     b.BillDate,
-			"bill_no":       b.BillNo,
-			"bill_qty":      b.BillQty,
-			"taxable_value": b.TaxableValue,
-			"tax_percent":   b.TaxPercent,
-			"tax_amount":    b.TaxAmount,
-			"invoice_value": b.InvoiceValue,
-			"created_at":    time.Now().Format("2006-01-02 15:04:05"),
+		if err := tx.Table(`"BundleInOut"."bundle_inwards"`).Create(inward).Error; err != nil {
+			return err
 		}
-		db.Table(`"BundleInOut"."bundle_inward_bills"`).Create(bill)
-	}
 
-	return nil
+		// The sequence value this session just drew, not the newest row of any session
+		var inwardId int
+		err = tx.Raw(`SELECT currval(pg_get_serial_sequence('"BundleInOut".bundle_inwards', 'id'))`).Scan(&inwardId).Error
+		if err != nil {
+			return err
+		}
+
+		for i, b := range payload.Bills {
+			bill := map[string]interface{}{
+				"inward_id":     inwardId,
+				"bill_date":     b.BillDate,
+				"bill_no":       b.BillNo,
+				"bill_qty":      b.BillQty,
+				"taxable_value": b.TaxableValue,
+				"tax_percent":   b.TaxPercent,
+				"tax_amount":    b.TaxAmount,
+				"invoice_value": b.InvoiceValue,
+				"created_at":    time.Now().Format("2006-01-02 15:04:05"),
+				"created_by":    createdBy,
+			}
+			if err := tx.Table(`"BundleInOut"."bundle_inward_bills"`).Create(bill).Error; err != nil {
+				log.Errorf("❌ Failed inserting bill %d of inward %s: %s", i+1, nextNumber, err.Error())
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func GetAllBundleInwardsService(db *gorm.DB) []map[string]interface{} {
-	var results []map[string]interface{}
+// BundleInwardList is what GET /products/getBundle may be filtered, searched and sorted on.
+var BundleInwardList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"po_id":         {Column: "po_id", Kind: listquery.ID},
+		"supplier_id":   {Column: "supplier_id", Kind: listquery.ID},
+		"grn_status":    {Column: "grn_status", Kind: listquery.Text},
+		"bundle_status": {Column: "bundle_status", Kind: listquery.Text},
+		"created_at":    {Column: "created_at", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"inward_id":  "inward_id",
+		"po_number":  "po_number",
+		"created_at": "created_at",
+	},
+	Search:      []string{"po_number", "transporter_name", "location"},
+	Key:         "inward_id",
+	DefaultSort: "-inward_id",
+}
 
-	inwardRows, err := db.Raw(`
+func GetAllBundleInwardsService(db *gorm.DB, query listquery.Query) ([]map[string]interface{}, listquery.Page, error) {
+	results := []map[string]interface{}{}
+
+	page, err := listquery.Find(db, `
         SELECT
             bin.id AS inward_id,
             bin.po_id,
@@ -987,7 +1159,9 @@ func GetAllBundleInwardsService(db *gorm.DB) []map[string]interface{} {
             bin.transporter_name,
             bin.created_date,
             bin.created_at,
+            bin.created_by,
             bin.updated_at,
+            bin.updated_by,
 
             po.id AS po_record_id,
             po.po_number,
@@ -996,51 +1170,41 @@ func GetAllBundleInwardsService(db *gorm.DB) []map[string]interface{} {
         FROM "BundleInOut"."bundle_inwards" bin
         JOIN "PurchaseOrderManagement"."PurchaseOrders" po
             ON po.id = bin.po_id
-        ORDER BY bin.id DESC
-    `).Rows()
+    `, nil, query, &results)
+	if err != nil || len(results) == 0 {
+		return results, page, err
+	}
 
+	// ===== Bills of the inwards on this page, in one query =====
+	inwardIds := make([]interface{}, 0, len(results))
+	for _, inward := range results {
+		inwardIds = append(inwardIds, inward["inward_id"])
+	}
+	var bills []map[string]interface{}
+	err = db.Raw(`
+        SELECT *
+        FROM "BundleInOut"."bundle_inward_bills"
+        WHERE inward_id IN ?
+        ORDER BY id ASC
+    `, inwardIds).Scan(&bills).Error
 	if err != nil {
-		fmt.Println("❌ Error fetching bundle_inwards:", err)
-		return results
+		return nil, page, err
 	}
-	defer inwardRows.Close()
-
-	for inwardRows.Next() {
-		inward := make(map[string]interface{})
-		db.ScanRows(inwardRows, &inward)
-
-		inwardID := inward["inward_id"] // ← Correct ID reference
-		if inwardID == nil {
-			fmt.Println("❌ inward_id is nil, row:", inward)
-			continue
-		}
 
-		// ===== Fetch Bills for this inward =====
-		var bills []map[string]interface{}
-		billRows, err := db.Raw(`
-            SELECT *
-            FROM "BundleInOut"."bundle_inward_bills"
-            WHERE inward_id = ?
-            ORDER BY id ASC
-        `, inwardID).Rows()
-
-		if err == nil {
-			for billRows.Next() {
-				bill := make(map[string]interface{})
-				db.ScanRows(billRows, &bill)
-				bills = append(bills, bill)
-			}
-			billRows.Close()
-		}
-
-		inward["bills"] = bills
-		results = append(results, inward)
+	billsByInward := map[string][]map[string]interface{}{}
+	for _, bill := range bills {
+		key := fmt.Sprint(bill["inward_id"])
+		billsByInward[key] = append(billsByInward[key], bill)
+	}
+	for _, inward := range results {
+		inward["bills"] = billsByInward[fmt.Sprint(inward["inward_id"])]
 	}
 
-	return results
+	return results, page, nil
 }
 
 func UpdateBundleInwardService(db *gorm.DB, payload *productModel.BundleInwardPayload) error {
+	updatedBy := actor.FromDB(db).By()
 	inwardData := map[string]interface{}{
 		"po_date":          payload.PoDetails.PoDate,
 		"supplier_id":      payload.PoDetails.SupplierId,
@@ -1057,41 +1221,50 @@ func UpdateBundleInwardService(db *gorm.DB, payload *productModel.BundleInwardPa
 		"transporter_name": payload.GrnDetails.Transporter,
 		"created_date":     payload.GrnDetails.CreatedDate,
 		"updated_at":       time.Now().Format("2006-01-02 15:04:05"),
+		"updated_by":       updatedBy,
 	}
 
-	err := db.Table(`"BundleInOut"."bundle_inwards"`).
-		Where("id = ?", payload.Id).
-		Updates(inwardData).Error
-
-	if err != nil {
-		return err
-	}
+	// Inward, old bills and new bills change together
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Table(`"BundleInOut"."bundle_inwards"`).
+			Where("id = ?", payload.Id).
+			Updates(inwardData).Error
+		if err != nil {
+			return err
+		}
 
-	// Delete old bills
-	db.Table(`"BundleInOut"."bundle_inward_bills"`).
-		Where("inward_id = ?", payload.Id).
-		Delete(nil)
-
-	// Insert new bills
-	for _, b := range payload.Bills {
-		bill := map[string]interface{}{
-			"inward_id":     payload.Id,
-			"bill_date":     b.BillDate,
-			"bill_no":       b.BillNo,
-			"bill_qty":      b.BillQty,
-			"taxable_value": b.TaxableValue,
-			"tax_percent":   b.TaxPercent,
-			"tax_amount":    b.TaxAmount,
-			"invoice_value": b.InvoiceValue,
-			"created_at":    time.Now().Format("2006-01-02 15:04:05"),
+		// Delete old bills
+		err = tx.Table(`"BundleInOut"."bundle_inward_bills"`).
+			Where("inward_id = ?", payload.Id).
+			Delete(nil).Error
+		if err != nil {
+			return err
 		}
-		db.Table(`"BundleInOut"."bundle_inward_bills"`).Create(bill)
-	}
 
-	return nil
+		// Insert new bills
+		for _, b := range payload.Bills {
+			bill := map[string]interface{}{
+				"inward_id":     payload.Id,
+				"bill_date":     b.BillDate,
+				"bill_no":       b.BillNo,
+				"bill_qty":      b.BillQty,
+				"taxable_value": b.TaxableValue,
+				"tax_percent":   b.TaxPercent,
+				"tax_amount":    b.TaxAmount,
+				"invoice_value": b.InvoiceValue,
+				"created_at":    time.Now().Format("2006-01-02 15:04:05"),
+				"created_by":    updatedBy,
+			}
+			if err := tx.Table(`"BundleInOut"."bundle_inward_bills"`).Create(bill).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func GetBundleInwardsByPOService(db *gorm.DB, poID string) []map[string]interface{} {
+	log := logger.FromDB(db)
 	var results []map[string]interface{}
 
 	inwardRows, err := db.Raw(`
@@ -1113,7 +1286,9 @@ func GetBundleInwardsByPOService(db *gorm.DB, poID string) []map[string]interfac
             bin.transporter_name,
             bin.created_date,
             bin.created_at,
+            bin.created_by,
             bin.updated_at,
+            bin.updated_by,
 
             po.id AS po_record_id,
             po.po_number,
@@ -1129,7 +1304,7 @@ func GetBundleInwardsByPOService(db *gorm.DB, poID string) []map[string]interfac
     `, poID).Rows()
 
 	if err != nil {
-		fmt.Println("❌ Error fetching bundle_inwards by po_id:", err)
+		log.Error("❌ Error fetching bundle_inwards by po_id: " + err.Error())
 		return results
 	}
 	defer inwardRows.Close()
@@ -1140,7 +1315,7 @@ func GetBundleInwardsByPOService(db *gorm.DB, poID string) []map[string]interfac
 
 		inwardID := inward["inward_id"]
 		if inwardID == nil {
-			fmt.Println("❌ inward_id is nil, row:", inward)
+			log.Warn("❌ inward_id is nil, skipping row")
 			continue
 		}
 
@@ -1170,20 +1345,21 @@ func GetBundleInwardsByPOService(db *gorm.DB, poID string) []map[string]interfac
 }
 
 type DebitNoteItem struct {
-	SKU             string `json:"sku"`
-	ProductId       int    `json:"productId"`
-	PurchaseOrderId int    `json:"purchaseOrderId"`
-	Quantity        int    `json:"quantity"`
+	SKU             string `json:"sku" binding:"required"`
+	ProductId       int    `json:"productId" binding:"gte=0"`
+	PurchaseOrderId int    `json:"purchaseOrderId" binding:"gte=0"`
+	Quantity        int    `json:"quantity" binding:"required,gt=0"`
 }
 
 type DebitNotePayload struct {
-	PoId  int             `json:"poId"`
-	Items []DebitNoteItem `json:"items"`
+	PoId  int             `json:"poId" binding:"required,gt=0"`
+	Items []DebitNoteItem `json:"items" binding:"required,min=1,dive"`
 }
 
 func CreateDebitNoteService(db *gorm.DB, payload DebitNotePayload) (map[string]interface{}, error) {
+	author := actor.FromDB(db).By()
 
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	now := time.Now().Format("2006-01-02 15:04:05")
 
 	log.Info("\n\n\nCreateDebitNoteService")
@@ -1224,7 +1400,7 @@ func CreateDebitNoteService(db *gorm.DB, payload DebitNotePayload) (map[string]i
 		supplierId,
 		fmt.Sprintf("%d", len(payload.Items)),
 		now,
-		"admin",
+		author,
 	).Scan(&debitNoteId).Error
 
 	if err != nil {
@@ -1258,7 +1434,7 @@ func CreateDebitNoteService(db *gorm.DB, payload DebitNotePayload) (map[string]i
 			item.PurchaseOrderId,
 			item.Quantity,
 			now,
-			"admin",
+			author,
 		).Error
 
 		if err != nil {
@@ -1285,8 +1461,30 @@ func CreateDebitNoteService(db *gorm.DB, payload DebitNotePayload) (map[string]i
 		return nil, err
 	}
 
+	if err := audit.Record(tx, audit.Entry{
+		Entity:   audit.EntityDebitNote,
+		EntityId: debitNoteId,
+		Action:   audit.ActionCreate,
+		After:    map[string]interface{}{"supplierId": supplierId, "debitNote": payload},
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := outbox.Publish(tx, outbox.DebitNoteCreated, debitNoteId, 0, map[string]interface{}{
+		"debitNoteId": debitNoteId,
+		"poId":        payload.PoId,
+		"supplierId":  supplierId,
+		"skus":        skuList,
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// ✅ ✅ COMMIT
-	tx.Commit()
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
 
 	return map[string]interface{}{
 		"debitNoteId": debitNoteId,
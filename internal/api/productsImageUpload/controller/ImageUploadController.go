@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	imageUploadService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/service"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	"github.com/gin-gonic/gin"
 	"github.com/labstack/gommon/log"
 )
@@ -19,7 +20,7 @@ func CreateUploadURLHandler(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Errorf("Invalid request body | Error: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		apperror.Respond(c, apperror.BadRequest("Invalid request body"))
 		return
 	}
 
@@ -34,11 +35,12 @@ func CreateUploadURLHandler(c *gin.Context) {
 	uploadURL, fileURL, err := imageUploadService.CreateUploadURL(req.FileName, expireMins)
 	if err != nil {
 		log.Errorf("Failed to create presigned PUT URL | fileName: %s | expireMins: %d | Error: %+v", req.FileName, expireMins, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate upload URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate upload URL"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"status":    true,
 		"uploadUrl": uploadURL,
 		"fileUrl":   fileURL,
 	})
@@ -53,27 +55,19 @@ func GetFileURLHandler(c *gin.Context) {
 	expireMins, err := strconv.Atoi(expireStr)
 	if err != nil {
 		log.Errorf("Invalid expiry string: %s | Error: %v", expireStr, err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expiry"})
+		apperror.Respond(c, apperror.BadRequest("Invalid expiry"))
 		return
 	}
 
 	fileURL, err := imageUploadService.GetFileURL(fileName, expireMins)
 	if err != nil {
 		log.Errorf("Failed to generate presigned GET URL for file %s | Error: %v", fileName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate file URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate file URL"))
 		return
 	}
 
 	log.Infof("File URL generated successfully | fileURL: %s", fileURL)
-	c.JSON(http.StatusOK, gin.H{"fileUrl": fileURL})
-}
-
-func GetEnvVariables(c *gin.Context) {
-	log.Info("GetEnvVariables called")
-	envVars := imageUploadService.FetchAllEnvVariables()
-	c.JSON(http.StatusOK, gin.H{
-		"env": envVars,
-	})
+	c.JSON(http.StatusOK, gin.H{"status": true, "fileUrl": fileURL})
 }
 
 type PresignRequest struct {
@@ -83,17 +77,18 @@ type PresignRequest struct {
 func GetPresignedURL(c *gin.Context) {
 	var req PresignRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Respond(c, apperror.BadRequest(err.Error()))
 		return
 	}
 
 	url, filename, err := imageUploadService.GeneratePresignedURL(req.Extension)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate URL"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"status":    true,
 		"uploadUrl": url,
 		"fileName":  filename,
 	})
@@ -107,7 +102,7 @@ func GeneratePDFPresignedURL(c *gin.Context) {
 	var req PDFPresignRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		apperror.Respond(c, apperror.BadRequest("Invalid request body"))
 		return
 	}
 
@@ -118,11 +113,12 @@ func GeneratePDFPresignedURL(c *gin.Context) {
 
 	uploadURL, fileName, err := imageUploadService.GeneratePDFPresignedURL(expireMins)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDF upload URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate PDF upload URL"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"status":    true,
 		"uploadUrl": uploadURL,
 		"fileName":  fileName,
 	})
@@ -134,15 +130,15 @@ func GetPDFFileURL(c *gin.Context) {
 
 	expireMins, err := strconv.Atoi(expireStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expiry"})
+		apperror.Respond(c, apperror.BadRequest("Invalid expiry"))
 		return
 	}
 
 	fileURL, err := imageUploadService.GetPDFFileURL(fileName, expireMins)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PDF file URL"})
+		apperror.Respond(c, apperror.Internal(err, "Failed to generate PDF file URL"))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"fileUrl": fileURL})
+	c.JSON(http.StatusOK, gin.H{"status": true, "fileUrl": fileURL})
 }
@@ -0,0 +1,14 @@
+package imageUploadRoutes
+
+import (
+	imageUploadController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/controller"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/imageUpload/productImages":                        {Summary: "Create upload URL", Request: imageUploadController.UploadRequest{}, Public: true},
+	"GET /api/v1/imageUpload/getProductImage/:filename/:expireMins": {Summary: "Get file URL", Public: true},
+	"POST /api/v1/imageUpload/generateURL":                          {Summary: "Get presigned URL", Request: imageUploadController.PresignRequest{}, Public: true},
+	"POST /api/v1/imageUpload/generateURLForPDF":                    {Summary: "Generate PDF presigned URL", Request: imageUploadController.PDFPresignRequest{}, Public: true},
+	"GET /api/v1/imageUpload/getPDF/:filename/:expireMins":          {Summary: "Get PDF file URL", Public: true},
+}
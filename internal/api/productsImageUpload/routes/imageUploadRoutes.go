@@ -2,6 +2,7 @@ package imageUploadRoutes
 
 import (
 	imageUploadController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/productsImageUpload/controller"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
 	"github.com/gin-gonic/gin"
 
 )
@@ -11,11 +12,10 @@ func ImageUploadRoutes(router *gin.Engine) {
 	route.POST("/productImages", imageUploadController.CreateUploadURLHandler)
 	route.GET("/getProductImage/:filename/:expireMins", imageUploadController.GetFileURLHandler)
 
-	route.GET("/env", imageUploadController.GetEnvVariables)
-
 	route.POST("/generateURL", imageUploadController.GetPresignedURL)
 
 	route.POST("/generateURLForPDF", imageUploadController.GeneratePDFPresignedURL)
 	route.GET("/getPDF/:filename/:expireMins", imageUploadController.GetPDFFileURL)
 
+	openapi.Describe("Image Upload", docs)
 }
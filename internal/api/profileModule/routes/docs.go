@@ -0,0 +1,9 @@
+package profileModuleRoutes
+
+import (
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/profile/details": {Summary: "Placeholder without a handler, answers an empty 200 once the token is accepted"},
+}
@@ -2,6 +2,7 @@ package profileModuleRoutes
 
 import (
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,4 +10,6 @@ func ProfileModuleRoutes(router *gin.Engine) {
 	route := router.Group("/api/v1/admin/profile")
 
 	route.POST("details", accesstoken.JWTMiddleware())
+
+	openapi.Describe("Profile", docs)
 }
@@ -6,152 +6,127 @@ import (
 
 	purchaseOrderModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/model"
 	purchaseOrderService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
-	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	helper "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/RequestHandler"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
 // CREATE PURCHASE ORDER
-func CreatePurchaseOrderController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreatePurchaseOrderController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Create Purchase Order Controller")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
-		createdBy := "Admin"
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var payload purchaseOrderModel.CreatePORequest
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			log.Error("Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[purchaseOrderModel.CreatePORequest](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		err := purchaseOrderService.CreatePurchaseOrderService(dbConnt, &payload, createdBy)
+		err := purchaseOrderService.CreatePurchaseOrderService(actor.Bind(dbConnt, c), &payload)
 		if err != nil {
 			log.Error("Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create purchase order"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create purchase order"))
 			return
 		}
 
 		log.Info("Purchase Order created successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Purchase Order created successfully", "token": token})
+		helper.Respond(c, http.StatusOK, gin.H{"status": true, "message": "Purchase Order created successfully"})
 	}
 }
 
-func GetAllPurchaseOrdersController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllPurchaseOrdersController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Get All Purchase Orders Controller")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		purchaseOrders, err := purchaseOrderService.GetAllPurchaseOrdersService(dbConnt)
+		purchaseOrders, err := purchaseOrderService.GetAllPurchaseOrdersService(dbConnt, scope)
 		if err != nil {
 			log.Error("Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch purchase orders",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch purchase orders"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("Fetched all purchase orders successfully")
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Fetched purchase orders successfully",
-			"token":   token,
 			"data":    purchaseOrders,
 		})
 	}
 }
 
 func GetPurchaseOrderByIdController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Get All Purchase Orders Controller")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		// dbConnt, sqlDB := db.InitDB()
 		// defer sqlDB.Close()
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		log.Info("Fetched all purchase orders successfully")
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Fetched purchase orders successfully",
-			"token":   token,
 			// "data":    purchaseOrders,
 		})
 	}
 }
 
-func GetDummyProductsByPOID() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetDummyProductsByPOID(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Get Dummy Products by PurchaseOrderId Controller")
 
 		purchaseOrderIdStr := c.Param("purchaseOrderId")
 		if purchaseOrderIdStr == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Missing purchaseOrderId"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Missing purchaseOrderId"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		dummyProducts, err := purchaseOrderService.GetDummyProductsByPOIDService(dbConn, purchaseOrderIdStr)
 		if err != nil {
 			log.Error("Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to fetch dummy products"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch dummy products"))
 			return
 		}
 
@@ -159,31 +134,29 @@ func GetDummyProductsByPOID() gin.HandlerFunc {
 	}
 }
 
-func UpdateDummyProductStatus() gin.HandlerFunc {
-	log := logger.InitLogger()
+type UpdateDummyProductStatusRequest struct {
+	DummyProductId int         `json:"dummyProductId"`
+	Status         interface{} `json:"status"` // can be bool or string
+	Reason         string      `json:"reason"` // optional
+}
 
+func UpdateDummyProductStatus(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Update Dummy Product Status Controller")
 
-		var payload struct {
-			DummyProductId int         `json:"dummyProductId"`
-			Status         interface{} `json:"status"` // can be bool or string
-			Reason         string      `json:"reason"` // optional
-		}
+		var payload UpdateDummyProductStatusRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid request payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := purchaseOrderService.UpdateDummyProductStatusService(dbConn, payload.DummyProductId, payload.Status, payload.Reason)
 		if err != nil {
 			log.Error("Failed to update dummy product: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -191,23 +164,22 @@ func UpdateDummyProductStatus() gin.HandlerFunc {
 	}
 }
 
+type BulkDummyProductsRequest struct {
+	DummyProductIds []int `json:"dummyProductIds"`
+}
+
 // BULK UPDATE - ACCEPT, REJECT, UNDO
-func BulkAcceptDummyProducts() gin.HandlerFunc {
+func BulkAcceptDummyProducts(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload struct {
-			DummyProductIds []int `json:"dummyProductIds"`
-		}
+		var payload BulkDummyProductsRequest
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := purchaseOrderService.BulkUpdateDummyProducts(dbConn, payload.DummyProductIds, "accept", "")
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -215,23 +187,22 @@ func BulkAcceptDummyProducts() gin.HandlerFunc {
 	}
 }
 
-func BulkRejectDummyProducts() gin.HandlerFunc {
+type BulkRejectDummyProductsRequest struct {
+	DummyProductIds []int  `json:"dummyProductIds"`
+	Reason          string `json:"reason"`
+}
+
+func BulkRejectDummyProducts(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload struct {
-			DummyProductIds []int  `json:"dummyProductIds"`
-			Reason          string `json:"reason"`
-		}
+		var payload BulkRejectDummyProductsRequest
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := purchaseOrderService.BulkUpdateDummyProducts(dbConn, payload.DummyProductIds, "reject", payload.Reason)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -239,22 +210,17 @@ func BulkRejectDummyProducts() gin.HandlerFunc {
 	}
 }
 
-func BulkUndoDummyProducts() gin.HandlerFunc {
+func BulkUndoDummyProducts(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload struct {
-			DummyProductIds []int `json:"dummyProductIds"`
-		}
+		var payload BulkDummyProductsRequest
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := purchaseOrderService.BulkUpdateDummyProducts(dbConn, payload.DummyProductIds, "undo", "")
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -262,18 +228,12 @@ func BulkUndoDummyProducts() gin.HandlerFunc {
 	}
 }
 
-func GetReceivedDummyProductsController() gin.HandlerFunc {
+func GetReceivedDummyProductsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 		products, err := purchaseOrderService.GetReceivedDummyProductsService(dbConn)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch received products",
-				"error":   err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch received products"))
 			return
 		}
 
@@ -284,18 +244,12 @@ func GetReceivedDummyProductsController() gin.HandlerFunc {
 	}
 }
 
-func GetReceivedDummyProductsBarcodeController() gin.HandlerFunc {
+func GetReceivedDummyProductsBarcodeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 		products, err := purchaseOrderService.GetReceivedDummyProductsBarcodeService(dbConn)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch received products",
-				"error":   err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch received products"))
 			return
 		}
 
@@ -306,54 +260,42 @@ func GetReceivedDummyProductsBarcodeController() gin.HandlerFunc {
 	}
 }
 
-func CreateProductController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateProductController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Create Product Controller")
 
-		id, idOk := c.Get("id")
-		roleId, roleOk := c.Get("roleId")
-		branchId, branchOk := c.Get("branchId")
+		_, idOk := c.Get("id")
+		_, roleOk := c.Get("roleId")
+		_, branchOk := c.Get("branchId")
 
 		if !idOk || !roleOk || !branchOk {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Missing authentication context",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing authentication context"))
 			return
 		}
 
-		var product purchaseOrderModel.Product
-		if err := c.ShouldBindJSON(&product); err != nil {
-			log.Error("Invalid JSON: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		product, ok := helper.RequestHandler[purchaseOrderModel.Product](c)
+		if !ok {
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(id, roleId, branchId)
-
-		err := purchaseOrderService.CreateProductService(dbConnt, &product)
+		err := purchaseOrderService.CreateProductService(actor.Bind(dbConnt, c), product)
 		if err != nil {
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate SKU found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate SKU found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create product"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create product"))
 			}
 			return
 		}
 
 		log.Info("Product created successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Product created", "token": token})
+		helper.Respond(c, http.StatusOK, gin.H{"status": true, "message": "Product created"})
 	}
 }
-func NewCreatePurchaseOrderController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func NewCreatePurchaseOrderController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Create Purchase Order Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -365,87 +307,84 @@ func NewCreatePurchaseOrderController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized,
-				gin.H{"status": false, "message": "Missing user context"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing user context"))
 			return
 		}
 
-		var payload purchaseOrderService.PurchaseOrderPayload
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest,
-				gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[purchaseOrderService.PurchaseOrderPayload](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		log.Infof("📦 PO Payload: %+v", payload)
+		log.Debugf("📦 PO Payload: %+v", payload)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			c.JSON(http.StatusBadRequest,
-				gin.H{"status": false, "message": "Invalid role ID"})
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(payload.BranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
 			return
 		}
-
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConn, roleId)
-		log.Infof("👤 Role Name: %s", roleName)
-
-		createdByFloat, _ := idValue.(float64)
-		createdBy := int(createdByFloat)
 
 		result, err := purchaseOrderService.NewCreatePurchaseOrderService(
-			dbConn, payload, roleName, createdBy,
+			actor.Bind(dbConn, c), payload,
 		)
 
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"status": false, "message": "Failed to create PO"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create PO"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Purchase Order created successfully\n\n")
 		log.Info("\n=================================================================\n")
 
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Purchase Order created successfully",
 			"data":    result,
-			"token":   token,
 		})
 	}
 }
 
-func NewGetAllPurchaseOrdersController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func NewGetAllPurchaseOrdersController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetAllPurchaseOrdersController invoked")
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+
+		query, err := listquery.Parse(c, purchaseOrderService.PurchaseOrderList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
 
 		log.Info("📦 Fetching all purchase orders")
-		poList := purchaseOrderService.NewGetAllPurchaseOrdersService(dbConn)
+		poList, page, err := purchaseOrderService.NewGetAllPurchaseOrdersService(dbConn, scope, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch purchase orders"))
+			return
+		}
 
 		log.Infof("📊 Purchase Orders fetched: %d", len(poList))
 
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   poList,
+			"status":     true,
+			"data":       poList,
+			"pagination": page,
 		})
 	}
 }
 
-func NewGetSinglePurchaseOrderController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func NewGetSinglePurchaseOrderController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetSinglePurchaseOrderController invoked")
 
 		idStr := c.Param("id")
@@ -453,14 +392,15 @@ func NewGetSinglePurchaseOrderController() gin.HandlerFunc {
 
 		log.Infof("🔍 Fetching PO ID: %d", id)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		result, err := purchaseOrderService.NewGetSinglePurchaseOrderService(dbConn, id)
+		result, err := purchaseOrderService.NewGetSinglePurchaseOrderService(dbConn, id, scope)
 		if err != nil {
 			log.Error("❌ " + err.Error())
-			c.JSON(http.StatusNotFound,
-				gin.H{"status": false, "message": "PO not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "PO not found"))
 			return
 		}
 
@@ -470,31 +410,34 @@ func NewGetSinglePurchaseOrderController() gin.HandlerFunc {
 	}
 }
 
-func NewCreateGRNController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func NewCreateGRNController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📦 Create GRN Controller invoked")
 
-		var payload purchaseOrderService.GRNPayload
-		if err := c.ShouldBindJSON(&payload); err != nil {
-			log.Error("❌ Invalid GRN Payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[purchaseOrderService.GRNPayload](c)
+		if !ok {
 			return
 		}
+		payload := *req
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(payload.BranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
+			return
+		}
 
-		result, err := purchaseOrderService.NewCreateGRNService(dbConn, payload)
+		result, err := purchaseOrderService.NewCreateGRNService(actor.Bind(dbConn, c), payload, scope)
 		if err != nil {
 			log.Error("❌ " + err.Error())
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"status": false, "message": "Failed to create GRN"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create GRN"))
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":  true,
 			"message": "GRN created successfully",
 			"data":    result,
@@ -502,32 +445,46 @@ func NewCreateGRNController() gin.HandlerFunc {
 	}
 }
 
-func NewGetAllGRNController() gin.HandlerFunc {
+func NewGetAllGRNController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		data := purchaseOrderService.NewGetAllGRNService(dbConn)
+		query, err := listquery.Parse(c, purchaseOrderService.GRNList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		data, page, err := purchaseOrderService.NewGetAllGRNService(dbConn, scope, query)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch GRNs"))
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   data,
+			"status":     true,
+			"data":       data,
+			"pagination": page,
 		})
 	}
 }
 
-func NewGetSingleGRNController() gin.HandlerFunc {
+func NewGetSingleGRNController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		idStr := c.Param("id")
 		id, _ := strconv.Atoi(idStr)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		data, err := purchaseOrderService.NewGetSingleGRNService(dbConn, id)
+		data, err := purchaseOrderService.NewGetSingleGRNService(dbConn, id, scope)
 		if err != nil {
-			c.JSON(http.StatusNotFound,
-				gin.H{"status": false, "message": "GRN not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "GRN not found"))
 			return
 		}
 
@@ -538,67 +495,69 @@ func NewGetSingleGRNController() gin.HandlerFunc {
 	}
 }
 
-func NewGetInventoryListController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func NewGetInventoryListController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📦 GetInventoryListController invoked")
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+
+		query, err := listquery.Parse(c, purchaseOrderService.InventoryList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
 
 		log.Info("📥 Fetching Inventory List")
-		inventoryList, err := purchaseOrderService.NewGetInventoryListService(dbConn)
+		inventoryList, page, err := purchaseOrderService.NewGetInventoryListService(dbConn, scope, query)
 
 		if err != nil {
 			log.Error("❌ Failed to fetch inventory: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		log.Infof("📊 Inventory items fetched: %d", len(inventoryList))
 
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   inventoryList,
+			"status":     true,
+			"data":       inventoryList,
+			"pagination": page,
 		})
 	}
 }
 
-func NewGetInventoryProductBySKUController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type InventorySKURequest struct {
+	SKU string `json:"sku" binding:"required"`
+}
 
+func NewGetInventoryProductBySKUController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload struct {
-			SKU string `json:"sku" binding:"required"`
-		}
+		log := logger.FromGin(c)
+		var payload InventorySKURequest
 
 		// Bind request JSON
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "SKU is required",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("🔍 Fetching inventory details for SKU: %s", payload.SKU)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
 		// ---- Call Service ----
-		product, err := purchaseOrderService.NewGetInventoryProductBySKUService(dbConn, payload.SKU)
+		product, err := purchaseOrderService.NewGetInventoryProductBySKUService(dbConn, payload.SKU, scope)
 
 		if err != nil {
 			log.Error("❌ Failed to fetch inventory product: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -609,17 +568,22 @@ func NewGetInventoryProductBySKUController() gin.HandlerFunc {
 	}
 }
 
-func GetSinglePOGRNItemsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetSinglePOGRNItemsController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		poId := c.Param("poId")
 		log.Infof("📥 Fetch GRN items for PO ID: %s", poId)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
-		grnItems := purchaseOrderService.GetSinglePOGRNItemsService(dbConn, poId)
+		grnItems, err := purchaseOrderService.GetSinglePOGRNItemsService(dbConn, poId, scope)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch GRN items"))
+			return
+		}
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
@@ -632,22 +596,18 @@ type ScanSKURequest struct {
 	SKU string `json:"sku"`
 }
 
-func ScanSKUController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func ScanSKUController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 ScanSKUController invoked")
 
 		// Extract user token details
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
 		branchIdValue, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
@@ -655,66 +615,50 @@ func ScanSKUController() gin.HandlerFunc {
 		var req ScanSKURequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			log.Error("❌ Invalid payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "Invalid payload",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
 		if req.SKU == "" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "SKU required",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "SKU required"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
 
 		log.Infof("🔎 Scanning SKU: %s for branch %v", req.SKU, branchIdValue)
 
-		result, isFound, err := purchaseOrderService.ScanSKUService(dbConn, req.SKU, branchIdValue)
+		result, isFound, err := purchaseOrderService.ScanSKUService(dbConn, req.SKU, scope)
 
 		if err != nil {
 			log.Error("❌ DB Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		// Create new refreshed token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		// Final response
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"isFound": isFound,
 			"data":    result,
-			"token":   token,
 		})
 	}
 }
 
-func POSGetInventoryListController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func POSGetInventoryListController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📦 POSGetInventoryListController invoked (branchId = 4)")
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		list, err := purchaseOrderService.POSGetInventoryListService(dbConn)
 		if err != nil {
 			log.Error("❌ Failed loading POS inventory list: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -725,34 +669,22 @@ func POSGetInventoryListController() gin.HandlerFunc {
 	}
 }
 
-func POSGetInventoryProductBySKUController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func POSGetInventoryProductBySKUController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var payload struct {
-			SKU string `json:"sku" binding:"required"`
-		}
+		log := logger.FromGin(c)
+		var payload InventorySKURequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "SKU is required",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("🔍 POS: Fetching product for SKU: %s", payload.SKU)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		product, err := purchaseOrderService.POSGetInventoryProductBySKUService(dbConn, payload.SKU)
 		if err != nil {
 			log.Error("❌ POS SKU fetch error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -763,31 +695,33 @@ func POSGetInventoryProductBySKUController() gin.HandlerFunc {
 	}
 }
 
-func NewAcceptStockIntakeController() gin.HandlerFunc {
+type AcceptStockIntakeRequest struct {
+	ToBranchId int                              `json:"toBranchId"`
+	Items      []purchaseOrderService.StockItem `json:"items"`
+}
+
+func NewAcceptStockIntakeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		var payload struct {
-			ToBranchId int                              `json:"toBranchId"`
-			Items      []purchaseOrderService.StockItem `json:"items"`
-		}
+		var payload AcceptStockIntakeRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status": false,
-				"error":  "Invalid request payload",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		scope, ok := branchscope.Resolve(c)
+		if !ok {
+			return
+		}
+		if !scope.Allows(payload.ToBranchId) {
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, branchscope.ErrOutOfScope.Error()))
+			return
+		}
 
 		updatedCount, err := purchaseOrderService.AcceptStockIntakeService(dbConn, payload.ToBranchId, payload.Items)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -799,22 +733,15 @@ func NewAcceptStockIntakeController() gin.HandlerFunc {
 	}
 }
 
-func GetSupplierBillAgeingReportController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetSupplierBillAgeingReportController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📊 GetSupplierBillAgeingReportController invoked")
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		list, err := purchaseOrderService.GetSupplierBillAgeingReportService(dbConn)
 		if err != nil {
 			log.Error("❌ Failed loading supplier bill ageing report: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -825,22 +752,15 @@ func GetSupplierBillAgeingReportController() gin.HandlerFunc {
 	}
 }
 
-func GetPurchaseOrderReportController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetPurchaseOrderReportController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📊 GetPurchaseOrderReportController invoked")
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		list, err := purchaseOrderService.GetPurchaseOrderReportService(dbConn)
 		if err != nil {
 			log.Error("❌ Failed loading purchase order report: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status": false,
-				"error":  err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
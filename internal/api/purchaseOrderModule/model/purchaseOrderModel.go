@@ -1,7 +1,7 @@
 package purchaseOrderModel
 
 type SupplierDetails struct {
-	SupplierID            int    `json:"supplierId"`
+	SupplierID            int    `json:"supplierId" binding:"required,gt=0"`
 	SupplierName          string `json:"supplierName"`
 	SupplierCompanyName   string `json:"supplierCompanyName"`
 	SupplierGSTNumber     string `json:"supplierGSTNumber"`
@@ -12,22 +12,22 @@ type SupplierDetails struct {
 }
 
 type BranchDetails struct {
-	BranchID      int    `json:"branchId"`
+	BranchID      int    `json:"branchId" binding:"required,gt=0"`
 	BranchName    string `json:"branchName"`
 	BranchEmail   string `json:"branchEmail"`
 	BranchAddress string `json:"branchAddress"`
 }
 
 type ProductDetails struct {
-	ProductName      string `gorm:"column:productName" json:"productName"`
-	RefCategoryID    int    `gorm:"column:refCategoryid" json:"refCategoryid"`
-	RefSubCategoryID int    `gorm:"column:refSubCategoryId" json:"refSubCategoryId"`
+	ProductName      string `gorm:"column:productName" json:"productName" binding:"required"`
+	RefCategoryID    int    `gorm:"column:refCategoryid" json:"refCategoryid" binding:"gte=0"`
+	RefSubCategoryID int    `gorm:"column:refSubCategoryId" json:"refSubCategoryId" binding:"gte=0"`
 	HSNCode          string `gorm:"column:HSNCode" json:"HSNCode"`
-	PurchaseQuantity string `gorm:"column:purchaseQuantity" json:"purchaseQuantity"`
-	PurchasePrice    string `gorm:"column:purchasePrice" json:"purchasePrice"`
-	DiscountPrice    string `gorm:"column:discountPrice" json:"discountPrice"`
-	DiscountAmount   string `gorm:"column:discountAmount" json:"discountAmount"`
-	TotalAmount      string `gorm:"column:totalAmount" json:"totalAmount"`
+	PurchaseQuantity string `gorm:"column:purchaseQuantity" json:"purchaseQuantity" binding:"required,number"`
+	PurchasePrice    string `gorm:"column:purchasePrice" json:"purchasePrice" binding:"required,number"`
+	DiscountPrice    string `gorm:"column:discountPrice" json:"discountPrice" binding:"omitempty,number"`
+	DiscountAmount   string `gorm:"column:discountAmount" json:"discountAmount" binding:"omitempty,number"`
+	TotalAmount      string `gorm:"column:totalAmount" json:"totalAmount" binding:"omitempty,number"`
 	IsReceived       bool   `gorm:"column:isReceived" json:"isReceived"`
 	AcceptanceStatus string `gorm:"column:acceptanceStatus" json:"acceptanceStatus"`
 	CreatedAt        string `gorm:"column:createdAt" json:"createdAt"`
@@ -41,18 +41,18 @@ type TotalSummary struct {
 	PONumber        string `json:"poNumber"`
 	SupplierID      int    `json:"supplierId"`
 	BranchID        int    `json:"branchId"`
-	Status          int    `json:"status"`
+	Status          int    `json:"status" binding:"gte=0"`
 	ExpectedDate    string `json:"expectedDate"`
 	ModeOfTransport string `json:"modeOfTransport"`
-	SubTotal        string `json:"subTotal"`
-	DiscountOverall string `json:"discountOverall"`
-	PayAmount       string `json:"payAmount"`
+	SubTotal        string `json:"subTotal" binding:"omitempty,number"`
+	DiscountOverall string `json:"discountOverall" binding:"omitempty,number"`
+	PayAmount       string `json:"payAmount" binding:"omitempty,number"`
 	IsTaxApplied    bool   `json:"isTaxApplied"`
-	TaxPercentage   string `json:"taxPercentage"`
-	TaxedAmount     string `json:"taxedAmount"`
-	TotalAmount     string `json:"totalAmount"`
-	TotalPaid       string `json:"totalPaid"`
-	PaymentPending  string `json:"paymentPending"`
+	TaxPercentage   string `json:"taxPercentage" binding:"omitempty,percent"`
+	TaxedAmount     string `json:"taxedAmount" binding:"omitempty,number"`
+	TotalAmount     string `json:"totalAmount" binding:"omitempty,number"`
+	TotalPaid       string `json:"totalPaid" binding:"omitempty,number"`
+	PaymentPending  string `json:"paymentPending" binding:"omitempty,number"`
 	CreatedAt       string `json:"createdAt"`
 	CreatedBy       string `json:"createdBy"`
 	UpdatedAt       string `json:"updatedAt"`
@@ -61,10 +61,10 @@ type TotalSummary struct {
 }
 
 type CreatePORequest struct {
-	SupplierDetails SupplierDetails  `json:"supplierDetails"`
-	BranchDetails   BranchDetails    `json:"branchDetails"`
-	ProductDetails  []ProductDetails `json:"productDetails"`
-	TotalSummary    TotalSummary     `json:"totalSummary"`
+	SupplierDetails SupplierDetails  `json:"supplierDetails" binding:"required"`
+	BranchDetails   BranchDetails    `json:"branchDetails" binding:"required"`
+	ProductDetails  []ProductDetails `json:"productDetails" binding:"required,min=1,dive"`
+	TotalSummary    TotalSummary     `json:"totalSummary" binding:"required"`
 	PurchaseOrderID int              `json:"purchaseOrderId"`
 	IsInternalPO    bool             `json:"isInternalPO"`
 }
@@ -187,17 +187,17 @@ func (ProductsDummyAcceptance) TableName() string {
 
 type Product struct {
 	ProductID           int    `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	Name                string `gorm:"column:name" json:"name"`
-	SKU                 string `gorm:"column:sku" json:"sku"`
+	Name                string `gorm:"column:name" json:"name" binding:"required"`
+	SKU                 string `gorm:"column:sku" json:"sku" binding:"required"`
 	GTIN                string `gorm:"column:gtin" json:"gtin"`
-	CategoryID          int    `gorm:"column:category_id" json:"category"`
-	SubCategoryID       int    `gorm:"column:subcategory_id" json:"subcategory"`
+	CategoryID          int    `gorm:"column:category_id" json:"category" binding:"required,gt=0"`
+	SubCategoryID       int    `gorm:"column:subcategory_id" json:"subcategory" binding:"gte=0"`
 	Description         string `gorm:"column:description" json:"description"`
 	DetailedDescription string `gorm:"column:detailed_description" json:"detailedDescription"`
-	Price               string `gorm:"column:price" json:"price"`
-	MRP                 string `gorm:"column:mrp" json:"mrp"`
-	Cost                string `gorm:"column:cost" json:"cost"`
-	SplPrice            string `gorm:"column:spl_price" json:"splPrice"`
+	Price               string `gorm:"column:price" json:"price" binding:"omitempty,number"`
+	MRP                 string `gorm:"column:mrp" json:"mrp" binding:"omitempty,number"`
+	Cost                string `gorm:"column:cost" json:"cost" binding:"omitempty,number"`
+	SplPrice            string `gorm:"column:spl_price" json:"splPrice" binding:"omitempty,number"`
 	StartDate           string `gorm:"column:start_date" json:"startDate"`
 	EndDate             string `gorm:"column:end_date" json:"endDate"`
 	TaxClass            string `gorm:"column:tax_class" json:"taxClass"`
@@ -0,0 +1,38 @@
+package purchaseOrderRoutes
+
+import (
+	purchaseOrderController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/controller"
+	purchaseOrderModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/model"
+	purchaseOrderService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/service"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/purchaseOrder/create":                         {Summary: "Create purchase order", Request: purchaseOrderModel.CreatePORequest{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"GET /api/v1/admin/purchaseOrder/read":                            {Summary: "Get all purchase orders", Response: []purchaseOrderModel.CreatePORequest{}, Permission: permission.PurchaseOrderView},
+	"GET /api/v1/admin/purchaseOrder/read/:id":                        {Summary: "Get purchase order by id", Permission: permission.PurchaseOrderView},
+	"GET /api/v1/admin/purchaseOrder/dummy-products/:purchaseOrderId": {Summary: "Get dummy products by POID", Response: []purchaseOrderModel.ProductsDummyAcceptance{}},
+	"PUT /api/v1/admin/purchaseOrder/dummy-products/update":           {Summary: "Update dummy product status", Request: purchaseOrderController.UpdateDummyProductStatusRequest{}, Permission: permission.PurchaseOrderManage},
+	"PUT /api/v1/admin/purchaseOrder/dummy-products/bulk-accept":      {Summary: "Bulk accept dummy products", Request: purchaseOrderController.BulkDummyProductsRequest{}, Permission: permission.PurchaseOrderManage},
+	"PUT /api/v1/admin/purchaseOrder/dummy-products/bulk-reject":      {Summary: "Bulk reject dummy products", Request: purchaseOrderController.BulkRejectDummyProductsRequest{}, Permission: permission.PurchaseOrderManage},
+	"PUT /api/v1/admin/purchaseOrder/dummy-products/bulk-undo":        {Summary: "Bulk undo dummy products", Request: purchaseOrderController.BulkDummyProductsRequest{}, Permission: permission.PurchaseOrderManage},
+	"GET /api/v1/admin/purchaseOrder/list-all-products":               {Summary: "Get received dummy products", Response: []purchaseOrderService.ReceivedDummyProductWithPO{}},
+	"GET /api/v1/admin/purchaseOrder/list-all-products-barcode":       {Summary: "Get received dummy products barcode", Response: []purchaseOrderService.ReceivedDummyProduct{}},
+	"POST /api/v1/admin/purchaseOrder/products":                       {Summary: "Create product", Request: purchaseOrderModel.Product{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"POST /api/v1/admin/purchaseOrder/createPurchaseOrder":            {Summary: "Create purchase order", Request: purchaseOrderService.PurchaseOrderPayload{}, Permission: permission.PurchaseOrderManage, Idempotent: true},
+	"GET /api/v1/admin/purchaseOrder/getOurchaseOrder":                {Summary: "Get all purchase orders", List: &purchaseOrderService.PurchaseOrderList, Permission: permission.PurchaseOrderView},
+	"GET /api/v1/admin/purchaseOrder/purchaseOrder/:id":               {Summary: "Get single purchase order", Permission: permission.PurchaseOrderView},
+	"POST /api/v1/admin/purchaseOrder/createGRN":                      {Summary: "Create GRN", Request: purchaseOrderService.GRNPayload{}, Permission: permission.GRNManage, Idempotent: true},
+	"GET /api/v1/admin/purchaseOrder/grn/list":                        {Summary: "Get all GRN", List: &purchaseOrderService.GRNList, Permission: permission.PurchaseOrderView},
+	"GET /api/v1/admin/purchaseOrder/grn/:id":                         {Summary: "Get single GRN", Permission: permission.PurchaseOrderView},
+	"GET /api/v1/admin/purchaseOrder/getInventoryList":                {Summary: "Get inventory list", Permission: permission.InventoryView, List: &purchaseOrderService.InventoryList},
+	"POST /api/v1/admin/purchaseOrder/getInventoryProductBySKU":       {Summary: "Get inventory product by SKU", Request: purchaseOrderController.InventorySKURequest{}, Permission: permission.InventoryView},
+	"GET /api/v1/admin/purchaseOrder/purchase-order/grn/items/:poId":  {Summary: "Get single POGRN items", Permission: permission.PurchaseOrderView},
+	"POST /api/v1/admin/purchaseOrder/scanSKU":                        {Summary: "Scan SKU", Request: purchaseOrderController.ScanSKURequest{}, Permission: permission.InventoryView},
+	"GET /api/v1/admin/purchaseOrder/getPOSInventoryList":             {Summary: "POS get inventory list", Permission: permission.POSSales},
+	"POST /api/v1/admin/purchaseOrder/getPOSInventoryProductBySKU":    {Summary: "POS get inventory product by SKU", Request: purchaseOrderController.InventorySKURequest{}, Permission: permission.POSSales},
+	"POST /api/v1/admin/purchaseOrder/acceptStockIntake":              {Summary: "Accept stock intake", Request: purchaseOrderController.AcceptStockIntakeRequest{}, Permission: permission.GRNManage, Idempotent: true},
+	"GET /api/v1/admin/purchaseOrder/getSupplierBillAgeingReport":     {Summary: "Get supplier bill ageing report", Permission: permission.ReportsView},
+	"GET /api/v1/admin/purchaseOrder/getPurchaseOrderReport":          {Summary: "Get purchase order report", Permission: permission.ReportsView},
+}
@@ -2,92 +2,111 @@ package purchaseOrderRoutes
 
 import (
 	purchaseOrderController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/controller"
+	purchaseOrderService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/service"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func PurhcaseOrderRoutes(router *gin.Engine) {
+func PurhcaseOrderRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/purchaseOrder")
 
 	// CREATE INITIAL PRODUCTS
-	route.POST("/create", accesstoken.JWTMiddleware(), purchaseOrderController.CreatePurchaseOrderController())
-	route.GET("/read", accesstoken.JWTMiddleware(), purchaseOrderController.GetAllPurchaseOrdersController())
-	route.GET("/read/:id", accesstoken.JWTMiddleware(), purchaseOrderController.GetPurchaseOrderByIdController())
+	route.POST("/create", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), purchaseOrderController.CreatePurchaseOrderController(dbConn))
+	route.GET("/read", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.GetAllPurchaseOrdersController(dbConn))
+	route.GET("/read/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.GetPurchaseOrderByIdController())
 
-	route.GET("/dummy-products/:purchaseOrderId", accesstoken.JWTMiddleware(), purchaseOrderController.GetDummyProductsByPOID())
+	route.GET("/dummy-products/:purchaseOrderId", accesstoken.JWTMiddleware(), purchaseOrderController.GetDummyProductsByPOID(dbConn))
 	// UPDATE PURCHASE ORDER PRODUCTS
-	route.PUT("/dummy-products/update", accesstoken.JWTMiddleware(), purchaseOrderController.UpdateDummyProductStatus())
+	route.PUT("/dummy-products/update", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), purchaseOrderController.UpdateDummyProductStatus(dbConn))
 	// BULK UPDATE - ACCEPT, REJECT, UNDO
-	route.PUT("/dummy-products/bulk-accept", accesstoken.JWTMiddleware(), purchaseOrderController.BulkAcceptDummyProducts())
-	route.PUT("/dummy-products/bulk-reject", accesstoken.JWTMiddleware(), purchaseOrderController.BulkRejectDummyProducts())
-	route.PUT("/dummy-products/bulk-undo", accesstoken.JWTMiddleware(), purchaseOrderController.BulkUndoDummyProducts())
+	route.PUT("/dummy-products/bulk-accept", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), purchaseOrderController.BulkAcceptDummyProducts(dbConn))
+	route.PUT("/dummy-products/bulk-reject", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), purchaseOrderController.BulkRejectDummyProducts(dbConn))
+	route.PUT("/dummy-products/bulk-undo", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), purchaseOrderController.BulkUndoDummyProducts(dbConn))
 
 	// PURCHASE ORDER - VIEW ALL PRODUCTS
-	route.GET("/list-all-products", accesstoken.JWTMiddleware(), purchaseOrderController.GetReceivedDummyProductsController())
-	route.GET("/list-all-products-barcode", accesstoken.JWTMiddleware(), purchaseOrderController.GetReceivedDummyProductsBarcodeController())
+	route.GET("/list-all-products", accesstoken.JWTMiddleware(), purchaseOrderController.GetReceivedDummyProductsController(dbConn))
+	route.GET("/list-all-products-barcode", accesstoken.JWTMiddleware(), purchaseOrderController.GetReceivedDummyProductsBarcodeController(dbConn))
 
 	// CREATE CATALOG
-	route.POST("/products", accesstoken.JWTMiddleware(), purchaseOrderController.CreateProductController())
+	route.POST("/products", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), purchaseOrderController.CreateProductController(dbConn))
 
 	// LATEST CHANGES FOR PO CREATION
-	route.POST("/createPurchaseOrder", accesstoken.JWTMiddleware(), purchaseOrderController.NewCreatePurchaseOrderController())
-	route.GET("/getOurchaseOrder", accesstoken.JWTMiddleware(), purchaseOrderController.NewGetAllPurchaseOrdersController())
-	route.GET("/purchaseOrder/:id", accesstoken.JWTMiddleware(), purchaseOrderController.NewGetSinglePurchaseOrderController())
+	route.POST("/createPurchaseOrder", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderManage), idempotency.Middleware(), purchaseOrderController.NewCreatePurchaseOrderController(dbConn))
+	route.GET("/getOurchaseOrder", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.NewGetAllPurchaseOrdersController(dbConn))
+	route.GET("/purchaseOrder/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.NewGetSinglePurchaseOrderController(dbConn))
 
 	// GRN
-	route.POST("/createGRN", accesstoken.JWTMiddleware(), purchaseOrderController.NewCreateGRNController())
-	route.GET("/grn/list", accesstoken.JWTMiddleware(), purchaseOrderController.NewGetAllGRNController())
-	route.GET("/grn/:id", accesstoken.JWTMiddleware(), purchaseOrderController.NewGetSingleGRNController())
+	route.POST("/createGRN", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.GRNManage), idempotency.Middleware(), purchaseOrderController.NewCreateGRNController(dbConn))
+	route.GET("/grn/list", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.NewGetAllGRNController(dbConn))
+	route.GET("/grn/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.PurchaseOrderView), purchaseOrderController.NewGetSingleGRNController(dbConn))
 
 	// INVENTORY
 	route.GET("/getInventoryList",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.NewGetInventoryListController(),
+		permission.RequirePermission(permission.InventoryView),
+		purchaseOrderController.NewGetInventoryListController(dbConn),
 	)
 
 	route.POST("/getInventoryProductBySKU",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.NewGetInventoryProductBySKUController(),
+		permission.RequirePermission(permission.InventoryView),
+		purchaseOrderController.NewGetInventoryProductBySKUController(dbConn),
 	)
 
 	route.GET("/purchase-order/grn/items/:poId",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.GetSinglePOGRNItemsController())
+		permission.RequirePermission(permission.PurchaseOrderView),
+		purchaseOrderController.GetSinglePOGRNItemsController(dbConn))
 
 	route.POST("/scanSKU",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.ScanSKUController(),
+		permission.RequirePermission(permission.InventoryView),
+		purchaseOrderController.ScanSKUController(dbConn),
 	)
 
 	// POS INVENTORY (Branch 4 fixed)
 	route.GET("/getPOSInventoryList",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.POSGetInventoryListController(),
+		permission.RequirePermission(permission.POSSales),
+		purchaseOrderController.POSGetInventoryListController(dbConn),
 	)
 
 	route.POST("/getPOSInventoryProductBySKU",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.POSGetInventoryProductBySKUController(),
+		permission.RequirePermission(permission.POSSales),
+		purchaseOrderController.POSGetInventoryProductBySKUController(dbConn),
 	)
 
 	// STOCK INTAKE - ACCEPT ITEMS (NEW API)
 	route.POST("/acceptStockIntake",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.NewAcceptStockIntakeController(),
+		permission.RequirePermission(permission.GRNManage),
+		idempotency.Middleware(),
+		purchaseOrderController.NewAcceptStockIntakeController(dbConn),
 	)
 
 	// SUPPLIER BILL AGEING REPORT
 	route.GET(
 		"/getSupplierBillAgeingReport",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.GetSupplierBillAgeingReportController(),
+		permission.RequirePermission(permission.ReportsView),
+		purchaseOrderController.GetSupplierBillAgeingReportController(dbConn),
 	)
 
 	// PURCHASE ORDER REPORT
 	route.GET(
 		"/getPurchaseOrderReport",
 		accesstoken.JWTMiddleware(),
-		purchaseOrderController.GetPurchaseOrderReportController(),
+		permission.RequirePermission(permission.ReportsView),
+		purchaseOrderController.GetPurchaseOrderReportController(dbConn),
 	)
 
+	jobqueue.Handle(purchaseOrderService.JobPushProduct, purchaseOrderService.PushProductJob)
+
+	openapi.Describe("Purchase Orders", docs)
 }
@@ -11,120 +11,131 @@ import (
 	bulkImageUploadService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/bulkImageHandling/service"
 	transactionLogger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
 	purchaseOrderModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/model"
-	purchaseOrderQuery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/purchaseOrderModule/query"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+	branchscope "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BranchScope"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
 	shopifyConfig "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/shopify"
 	goshopify "github.com/bold-commerce/go-shopify/v4"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
-
 )
 
-func CreatePurchaseOrderService(db *gorm.DB, payload *purchaseOrderModel.CreatePORequest, createdBy string) error {
+func CreatePurchaseOrderService(db *gorm.DB, payload *purchaseOrderModel.CreatePORequest) error {
+	createdBy := actor.FromDB(db).By()
 
-	poNumber, err := purchaseOrderQuery.GeneratePONumber(db)
-	if err != nil {
-		return fmt.Errorf("failed to generate PO number: %v", err)
-	}
-	// 1. Insert into CreatePurchaseOrder
-	order := purchaseOrderModel.CreatePurchaseOrder{
-		PONumber:        poNumber,
-		SupplierID:      payload.TotalSummary.SupplierID,
-		BranchID:        payload.TotalSummary.BranchID,
-		Status:          payload.TotalSummary.Status,
-		ExpectedDate:    payload.TotalSummary.ExpectedDate,
-		ModeOfTransport: payload.TotalSummary.ModeOfTransport,
-		SubTotal:        payload.TotalSummary.SubTotal,
-		DiscountOverall: payload.TotalSummary.DiscountOverall,
-		PayAmount:       payload.TotalSummary.PayAmount,
-		IsTaxApplied:    payload.TotalSummary.IsTaxApplied,
-		TaxPercentage:   payload.TotalSummary.TaxPercentage,
-		TaxedAmount:     payload.TotalSummary.TaxedAmount,
-		TotalAmount:     payload.TotalSummary.TotalAmount,
-		TotalPaid:       payload.TotalSummary.TotalPaid,
-		PaymentPending:  payload.TotalSummary.PaymentPending,
-		CreatedAt:       payload.TotalSummary.CreatedAt,
-		CreatedBy:       createdBy,
-		UpdatedAt:       payload.TotalSummary.UpdatedAt,
-		UpdatedBy:       payload.TotalSummary.UpdatedBy,
-		IsDelete:        fmt.Sprintf("%v", payload.TotalSummary.IsDelete),
-		IsInternalPO:    payload.IsInternalPO,
-	}
-
-	if err := db.Create(&order).Error; err != nil {
-		return err
-	}
-
-	// 2. Insert Product Items and Dummy Acceptance Rows
-	for _, item := range payload.ProductDetails {
-		dbItem := purchaseOrderModel.PurchaseOrderItem{
-			PurchaseOrderID:  order.PurchaseOrderID,
-			ProductName:      item.ProductName,
-			RefCategoryID:    item.RefCategoryID,
-			RefSubCategoryID: item.RefSubCategoryID,
-			HSNCode:          item.HSNCode,
-			PurchaseQuantity: item.PurchaseQuantity,
-			PurchasePrice:    item.PurchasePrice,
-			DiscountPrice:    item.DiscountPrice,
-			DiscountAmount:   item.DiscountAmount,
-			TotalAmount:      item.TotalAmount,
-			IsReceived:       item.IsReceived,
-			AcceptanceStatus: item.AcceptanceStatus,
-			CreatedAt:        item.CreatedAt,
-			CreatedBy:        item.CreatedBy,
-			UpdatedAt:        item.UpdatedAt,
-			UpdatedBy:        item.UpdatedBy,
-			IsDelete:         item.IsDelete,
+	return db.Transaction(func(tx *gorm.DB) error {
+		poNumber, err := numberseries.Issue(tx, numberseries.LegacyPurchaseOrder, payload.TotalSummary.BranchID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to generate PO number: %w", err)
 		}
-
-		if err := db.Create(&dbItem).Error; err != nil {
-			return err
+		// 1. Insert into CreatePurchaseOrder
+		order := purchaseOrderModel.CreatePurchaseOrder{
+			PONumber:        poNumber,
+			SupplierID:      payload.TotalSummary.SupplierID,
+			BranchID:        payload.TotalSummary.BranchID,
+			Status:          payload.TotalSummary.Status,
+			ExpectedDate:    payload.TotalSummary.ExpectedDate,
+			ModeOfTransport: payload.TotalSummary.ModeOfTransport,
+			SubTotal:        payload.TotalSummary.SubTotal,
+			DiscountOverall: payload.TotalSummary.DiscountOverall,
+			PayAmount:       payload.TotalSummary.PayAmount,
+			IsTaxApplied:    payload.TotalSummary.IsTaxApplied,
+			TaxPercentage:   payload.TotalSummary.TaxPercentage,
+			TaxedAmount:     payload.TotalSummary.TaxedAmount,
+			TotalAmount:     payload.TotalSummary.TotalAmount,
+			TotalPaid:       payload.TotalSummary.TotalPaid,
+			PaymentPending:  payload.TotalSummary.PaymentPending,
+			CreatedAt:       payload.TotalSummary.CreatedAt,
+			CreatedBy:       createdBy,
+			UpdatedAt:       payload.TotalSummary.UpdatedAt,
+			UpdatedBy:       createdBy,
+			IsDelete:        fmt.Sprintf("%v", payload.TotalSummary.IsDelete),
+			IsInternalPO:    payload.IsInternalPO,
 		}
 
-		// 3. Insert N dummy product entries
-		qty, err := strconv.Atoi(item.PurchaseQuantity)
-		if err != nil {
-			return fmt.Errorf("invalid purchaseQuantity for product %s: %v", item.ProductName, err)
+		if err := tx.Create(&order).Error; err != nil {
+			return err
 		}
 
-		for i := 0; i < qty; i++ {
-			dummy := purchaseOrderModel.ProductsDummyAcceptance{
+		// 2. Insert Product Items and Dummy Acceptance Rows
+		for _, item := range payload.ProductDetails {
+			dbItem := purchaseOrderModel.PurchaseOrderItem{
 				PurchaseOrderID:  order.PurchaseOrderID,
 				ProductName:      item.ProductName,
 				RefCategoryID:    item.RefCategoryID,
 				RefSubCategoryID: item.RefSubCategoryID,
 				HSNCode:          item.HSNCode,
-				DummySKU:         fmt.Sprintf("%s-%d", item.ProductName, i+1), // Or generate your own SKU logic
-				Price:            item.PurchasePrice,
+				PurchaseQuantity: item.PurchaseQuantity,
+				PurchasePrice:    item.PurchasePrice,
+				DiscountPrice:    item.DiscountPrice,
 				DiscountAmount:   item.DiscountAmount,
-				DiscountPercent:  item.DiscountPrice, // Assuming you map accordingly
-				IsReceived:       "false",
-				AcceptanceStatus: "Pending",
+				TotalAmount:      item.TotalAmount,
+				IsReceived:       item.IsReceived,
+				AcceptanceStatus: item.AcceptanceStatus,
 				CreatedAt:        item.CreatedAt,
 				CreatedBy:        createdBy,
 				UpdatedAt:        item.UpdatedAt,
-				UpdatedBy:        item.UpdatedBy,
-				IsDelete:         "false",
+				UpdatedBy:        createdBy,
+				IsDelete:         item.IsDelete,
 			}
 
-			if err := db.Create(&dummy).Error; err != nil {
-				return fmt.Errorf("failed to create dummy product for %s: %v", item.ProductName, err)
+			if err := tx.Create(&dbItem).Error; err != nil {
+				return err
+			}
+
+			// 3. Insert N dummy product entries
+			qty, err := strconv.Atoi(item.PurchaseQuantity)
+			if err != nil {
+				return fmt.Errorf("invalid purchaseQuantity for product %s: %v", item.ProductName, err)
+			}
+
+			for i := 0; i < qty; i++ {
+				dummy := purchaseOrderModel.ProductsDummyAcceptance{
+					PurchaseOrderID:  order.PurchaseOrderID,
+					ProductName:      item.ProductName,
+					RefCategoryID:    item.RefCategoryID,
+					RefSubCategoryID: item.RefSubCategoryID,
+					HSNCode:          item.HSNCode,
+					DummySKU:         fmt.Sprintf("%s-%d", item.ProductName, i+1), // Or generate your own SKU logic
+					Price:            item.PurchasePrice,
+					DiscountAmount:   item.DiscountAmount,
+					DiscountPercent:  item.DiscountPrice, // Assuming you map accordingly
+					IsReceived:       "false",
+					AcceptanceStatus: "Pending",
+					CreatedAt:        item.CreatedAt,
+					CreatedBy:        createdBy,
+					UpdatedAt:        item.UpdatedAt,
+					UpdatedBy:        createdBy,
+					IsDelete:         "false",
+				}
+
+				if err := tx.Create(&dummy).Error; err != nil {
+					return fmt.Errorf("failed to create dummy product for %s: %v", item.ProductName, err)
+				}
 			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // purchaseOrderService/purchaseOrderService.go
 
-func GetAllPurchaseOrdersService(db *gorm.DB) ([]purchaseOrderModel.CreatePORequest, error) {
-	log := logger.InitLogger()
+func GetAllPurchaseOrdersService(db *gorm.DB, scope branchscope.Scope) ([]purchaseOrderModel.CreatePORequest, error) {
+	log := logger.FromDB(db)
 
 	log.Println("INFO: GetAllPurchaseOrdersService started")
 	var orders []purchaseOrderModel.CreatePORequest
 	var orderRows []purchaseOrderModel.OrderRow
 
+	branchCond, branchArgs := scope.Condition(`po."branchId"`)
 	query := `
 		SELECT
 			po."purchaseOrderId",
@@ -154,16 +165,15 @@ func GetAllPurchaseOrdersService(db *gorm.DB) ([]purchaseOrderModel.CreatePORequ
 		FROM "purchaseOrder"."CreatePurchaseOrder" po
 		LEFT JOIN "public"."Supplier" s ON po."supplierId" = s."supplierId" AND s."isDelete" = false
 		LEFT JOIN "public"."Branches" b ON po."branchId" = b."refBranchId" AND b."isDelete" = false
-		WHERE po."isDelete" = 'false';
+		WHERE po."isDelete" = 'false' AND ` + branchCond + `;
 	`
 
-	if err := db.Raw(query).Scan(&orderRows).Error; err != nil {
+	if err := db.Raw(query, branchArgs...).Scan(&orderRows).Error; err != nil {
 		log.Println("ERROR: Failed to fetch purchase orders:", err)
 		return nil, err
 	}
 
 	log.Println("\n\nINFO: Purchase order rows fetched successfully")
-	fmt.Printf("DEBUG: orderRows = %+v\n", orderRows)
 
 	for _, row := range orderRows {
 		var products []purchaseOrderModel.ProductDetails
@@ -178,7 +188,6 @@ func GetAllPurchaseOrdersService(db *gorm.DB) ([]purchaseOrderModel.CreatePORequ
 		}
 
 		log.Printf("\n\nINFO: Fetched %d products for PurchaseOrderID = %d\n", len(products), row.PurchaseOrderID)
-		fmt.Printf("DEBUG: products for PurchaseOrderID %d = %+v\n", row.PurchaseOrderID, products)
 
 		order := purchaseOrderModel.CreatePORequest{
 			SupplierDetails: purchaseOrderModel.SupplierDetails{
@@ -223,15 +232,12 @@ func GetAllPurchaseOrdersService(db *gorm.DB) ([]purchaseOrderModel.CreatePORequ
 			PurchaseOrderID: row.PurchaseOrderID,
 		}
 
-		log.Printf("\n\nDEBUG: First row sample: %+v\n", orderRows[0])
+		log.Debugf("First row sample: %+v", orderRows[0])
 
 		orders = append(orders, order)
-
-		fmt.Printf("\n\nDEBUG: order = %+v\n", order)
 	}
 
 	log.Println("INFO: GetAllPurchaseOrdersService completed successfully")
-	fmt.Printf("DEBUG: Final orders list = %+v\n", orders)
 
 	return orders, nil
 }
@@ -246,106 +252,98 @@ func GetDummyProductsByPOIDService(db *gorm.DB, poID string) ([]purchaseOrderMod
 		return nil, err
 	}
 
-	fmt.Printf("\n\n\n\n\ndummyProducts => %v\n\n", dummyProducts)
-
 	return dummyProducts, nil
 }
 
 func UpdateDummyProductStatusService(db *gorm.DB, dummyProductId int, status interface{}, reason string) error {
-	var product purchaseOrderModel.ProductsDummyAcceptance
+	// an accepted product's SKU is drawn in the transaction that saves it
+	return db.Transaction(func(tx *gorm.DB) error {
+		var product purchaseOrderModel.ProductsDummyAcceptance
 
-	if err := db.First(&product, dummyProductId).Error; err != nil {
-		return fmt.Errorf("dummy product not found: %v", err)
-	}
+		if err := tx.First(&product, dummyProductId).Error; err != nil {
+			return fmt.Errorf("dummy product not found: %w", err)
+		}
 
-	switch val := status.(type) {
-	case bool:
-		if val {
-			// ✅ Accept
-			if product.IsReceived != "true" {
-				now := time.Now()
-				month := fmt.Sprintf("%02d", now.Month())
-				year := fmt.Sprintf("%02d", now.Year()%100)
-
-				var count int64
-				db.Model(&purchaseOrderModel.ProductsDummyAcceptance{}).
-					Where("dummySKU LIKE ?", fmt.Sprintf("SS%s%s%%", month, year)).
-					Count(&count)
-
-				sku := fmt.Sprintf("SS%s%s%05d", month, year, count+1)
-
-				product.DummySKU = sku
-				product.IsReceived = "true"
-				product.AcceptanceStatus = "Received"
+		switch val := status.(type) {
+		case bool:
+			if val {
+				// ✅ Accept
+				if product.IsReceived != "true" {
+					sku, err := numberseries.Issue(tx, numberseries.DummyProductSKU, 0, time.Now())
+					if err != nil {
+						return err
+					}
+
+					product.DummySKU = sku
+					product.IsReceived = "true"
+					product.AcceptanceStatus = "Received"
+				}
+			} else {
+				// ❌ Reject
+				product.DummySKU = ""
+				product.IsReceived = "false"
+				product.AcceptanceStatus = reason
 			}
-		} else {
-			// ❌ Reject
-			product.DummySKU = ""
-			product.IsReceived = "false"
-			product.AcceptanceStatus = reason
-		}
-	case string:
-		if val == "undo" {
-			// 🔄 Undo
-			product.DummySKU = ""
-			product.IsReceived = "false"
-			product.AcceptanceStatus = "Pending"
+		case string:
+			if val == "undo" {
+				// 🔄 Undo
+				product.DummySKU = ""
+				product.IsReceived = "false"
+				product.AcceptanceStatus = "Pending"
+			}
+		default:
+			return fmt.Errorf("invalid status type")
 		}
-	default:
-		return fmt.Errorf("invalid status type")
-	}
 
-	return db.Save(&product).Error
+		return tx.Save(&product).Error
+	})
 }
 
 // BULK UPDATE - ACCEPT, REJECT, UNDO
 func BulkUpdateDummyProducts(db *gorm.DB, ids []int, action string, reason string) error {
-	var products []purchaseOrderModel.ProductsDummyAcceptance
-	if err := db.Where(`"dummyProductsId" IN ?`, ids).Find(&products).Error; err != nil {
-		return err
-	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var products []purchaseOrderModel.ProductsDummyAcceptance
+		if err := tx.Where(`"dummyProductsId" IN ?`, ids).Order(`"dummyProductsId" ASC`).Find(&products).Error; err != nil {
+			return err
+		}
 
-	now := time.Now()
-	month := fmt.Sprintf("%02d", now.Month())
-	year := fmt.Sprintf("%02d", now.Year()%100)
-
-	switch action {
-	case "accept":
-		// Get latest SKU count
-		var count int64
-		db.Model(&purchaseOrderModel.ProductsDummyAcceptance{}).
-			Where("dummySKU LIKE ?", fmt.Sprintf("SS%s%s%%", month, year)).
-			Count(&count)
-
-		for i := range products {
-			if products[i].IsReceived != "true" {
-				count++
-				products[i].DummySKU = fmt.Sprintf("SS%s%s%05d", month, year, count)
-				products[i].IsReceived = "true"
-				products[i].AcceptanceStatus = "Received"
+		now := time.Now()
+
+		switch action {
+		case "accept":
+			for i := range products {
+				if products[i].IsReceived != "true" {
+					sku, err := numberseries.Issue(tx, numberseries.DummyProductSKU, 0, now)
+					if err != nil {
+						return err
+					}
+					products[i].DummySKU = sku
+					products[i].IsReceived = "true"
+					products[i].AcceptanceStatus = "Received"
+				}
+			}
+		case "reject":
+			for i := range products {
+				products[i].DummySKU = ""
+				products[i].IsReceived = "false"
+				products[i].AcceptanceStatus = reason
+			}
+		case "undo":
+			for i := range products {
+				products[i].DummySKU = ""
+				products[i].IsReceived = "false"
+				products[i].AcceptanceStatus = "Pending"
 			}
 		}
-	case "reject":
-		for i := range products {
-			products[i].DummySKU = ""
-			products[i].IsReceived = "false"
-			products[i].AcceptanceStatus = reason
-		}
-	case "undo":
-		for i := range products {
-			products[i].DummySKU = ""
-			products[i].IsReceived = "false"
-			products[i].AcceptanceStatus = "Pending"
-		}
-	}
 
-	// Save all products
-	for _, product := range products {
-		if err := db.Save(&product).Error; err != nil {
-			return err
+		// Save all products
+		for _, product := range products {
+			if err := tx.Save(&product).Error; err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 type ReceivedDummyProductWithPO struct {
@@ -416,12 +414,12 @@ func GetReceivedDummyProductsBarcodeService(db *gorm.DB) ([]ReceivedDummyProduct
 		return nil, err
 	}
 
-	fmt.Println("Result:", result)
 	return result, nil
 }
 
 func CreateProductService(db *gorm.DB, product *purchaseOrderModel.Product) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
 	// Check for duplicate SKU
 	var existing purchaseOrderModel.Product
@@ -441,8 +439,8 @@ func CreateProductService(db *gorm.DB, product *purchaseOrderModel.Product) erro
 	now := time.Now().Format("2006-01-02 15:04:05")
 	product.CreatedAt = now
 	product.UpdatedAt = now
-	product.CreatedBy = "Admin"
-	product.UpdatedBy = "Admin"
+	product.CreatedBy = author
+	product.UpdatedBy = author
 	product.IsDelete = "false"
 
 	// Insert into DB
@@ -468,165 +466,117 @@ type PurchaseOrderItem struct {
 	CategoryId         int     `json:"categoryId"`
 	SubCategoryId      int     `json:"subCategoryId"`
 	ProductDescription string  `json:"productDescription"`
-	UnitPrice          float64 `json:"unitPrice"`
-	Quantity           float64 `json:"quantity"`
-	DiscountPercent    float64 `json:"discountPercent"`
-	DiscountAmount     float64 `json:"discountAmount"`
-	Total              float64 `json:"total"`
+	UnitPrice          float64 `json:"unitPrice" binding:"gte=0"`
+	Quantity           float64 `json:"quantity" binding:"gt=0"`
+	DiscountPercent    float64 `json:"discountPercent" binding:"gte=0,lte=100"`
+	DiscountAmount     float64 `json:"discountAmount" binding:"gte=0"`
+	Total              float64 `json:"total" binding:"gte=0"`
 }
 
 type PurchaseOrderPayload struct {
-	SupplierId  int                 `json:"supplierId"`
-	BranchId    int                 `json:"branchId"`
+	SupplierId  int                 `json:"supplierId" binding:"required,gt=0"`
+	BranchId    int                 `json:"branchId" binding:"required,gt=0"`
 	TaxEnabled  bool                `json:"taxEnabled"`
-	TaxRate     float64             `json:"taxRate"`
-	PaymentFee  float64             `json:"paymentFee"`
-	ShippingFee float64             `json:"shippingFee"`
-	Subtotal    float64             `json:"subtotal"`
-	TaxAmount   float64             `json:"taxAmount"`
+	TaxRate     float64             `json:"taxRate" binding:"gte=0,lte=100"`
+	PaymentFee  float64             `json:"paymentFee" binding:"gte=0"`
+	ShippingFee float64             `json:"shippingFee" binding:"gte=0"`
+	Subtotal    float64             `json:"subtotal" binding:"gte=0"`
+	TaxAmount   float64             `json:"taxAmount" binding:"gte=0"`
 	RoundOff    float64             `json:"roundOff"`
-	Total       float64             `json:"total"`
-	Items       []PurchaseOrderItem `json:"items"`
-}
-
-func GeneratePONumber(db *gorm.DB, year int, month int) (string, error) {
-	log := logger.InitLogger()
-	log.Info("🧮 Generating new PO Number...")
-
-	// A = 2025, B = 2026, C = 2027 ...
-	yearCode := string(rune('A' + (year - 2025)))
-
-	// A = Jan, B = Feb ... K = Nov, L = Dec
-	monthCode := string(rune('A' + (month - 1)))
-
-	prefix := fmt.Sprintf("PO%s%s", yearCode, monthCode)
-
-	log.Infof("🔍 PO Prefix = %s", prefix)
-
-	// ==== FETCH LATEST PO NUMBER WITH THIS PREFIX ====
-	var lastPONumber string
-
-	err := db.
-		Raw(`
-            SELECT po_number 
-            FROM "PurchaseOrderManagement"."PurchaseOrders"
-            WHERE po_number LIKE ?
-            ORDER BY id DESC 
-            LIMIT 1
-        `, prefix+"%").
-		Scan(&lastPONumber).Error
-
-	if err != nil {
-		log.Error("❌ Failed reading last PO number: " + err.Error())
-		return "", err
-	}
-
-	log.Infof("📌 Last PO Number from DB = %s", lastPONumber)
-
-	// ==== EXTRACT LAST 4 DIGITS ====
-	seq := 0
-	if lastPONumber != "" && len(lastPONumber) >= len(prefix)+4 {
-		lastSeqStr := lastPONumber[len(lastPONumber)-4:]
-		lastSeq, _ := strconv.Atoi(lastSeqStr)
-		seq = lastSeq
-	}
-
-	// ==== INCREMENT ====
-	seq++
-
-	// Final PO number
-	newPONumber := fmt.Sprintf("%s%04d", prefix, seq)
-
-	log.Infof("🎉 New PO Number Generated = %s", newPONumber)
-	return newPONumber, nil
+	Total       float64             `json:"total" binding:"gte=0"`
+	Items       []PurchaseOrderItem `json:"items" binding:"required,min=1,dive"`
 }
 
-func NewCreatePurchaseOrderService(db *gorm.DB, payload PurchaseOrderPayload, roleName string, createdBy int) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+func NewCreatePurchaseOrderService(db *gorm.DB, payload PurchaseOrderPayload) (map[string]interface{}, error) {
+	log := logger.FromDB(db)
+	createdBy := actor.FromDB(db).By()
 	log.Info("🛠️ CreatePurchaseOrderService invoked")
-	log.Infof("📥 Payload: %+v", payload)
-	log.Infof("👤 Created By: %s", roleName)
+	log.Debugf("📥 Payload: %+v", payload)
+	log.Infof("👤 Created By: %s", createdBy)
 
 	now := time.Now()
 	year := now.Year()
 	month := int(now.Month())
 
-	poNumber, err := GeneratePONumber(db, year, month)
-	if err != nil {
-		log.Error("❌ Failed to generate PO Number: " + err.Error())
-		return nil, err
-	}
-
-	log.Infof("🧾 Generated PO Number: %s", poNumber)
-
 	createdAt := now.Format("2006-01-02 15:04:05")
 
-	// INSERT PO HEADER
+	// NUMBER, PO HEADER AND ITEMS - together or not at all
 	var poId int
-	err = db.Raw(`
-		INSERT INTO "PurchaseOrderManagement"."PurchaseOrders"
-		(po_number, "supplierId", branchid, "taxEnabled", "taxRate",
-		 "paymentFee", "shippingFee", "subTotal", "taxAmount", "roundOff", total,
-		 "poYear", "poMonth", status, "createdAt", "createdBy", "isDelete")
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'OPEN', ?, ?, FALSE)
-		RETURNING id
-	`,
-		poNumber, payload.SupplierId, payload.BranchId,
-		payload.TaxEnabled, fmt.Sprintf("%.2f", payload.TaxRate),
-		fmt.Sprintf("%.2f", payload.PaymentFee),
-		fmt.Sprintf("%.2f", payload.ShippingFee),
-		fmt.Sprintf("%.2f", payload.Subtotal),
-		fmt.Sprintf("%.2f", payload.TaxAmount),
-		fmt.Sprintf("%.2f", payload.RoundOff),
-		fmt.Sprintf("%.2f", payload.Total),
-		fmt.Sprintf("%d", year),
-		fmt.Sprintf("%d", month),
-		createdAt, roleName,
-	).Scan(&poId).Error
-
-	if err != nil {
-		log.Error("❌ Failed inserting PO header: " + err.Error())
-		return nil, err
-	}
-
-	log.Infof("🧾 Purchase Order ID: %d", poId)
-
-	// INSERT ITEMS
-	for _, item := range payload.Items {
-		db.Exec(`
-			INSERT INTO "PurchaseOrderManagement"."PurchaseOrderItems"
-			("purchaseOrderId", "categoryId", "subCategoryId", "productDescription",
-			 "unitPrice", quantity, "discountPercent", "discountAmount", "lineTotal",
-			 "receivedQuantity", "isClosed", "createdAt")
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, FALSE, ?)
+	var poNumber string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		poNumber, err = numberseries.Issue(tx, numberseries.PurchaseOrder, payload.BranchId, now)
+		if err != nil {
+			log.Error("❌ Failed to generate PO Number: " + err.Error())
+			return err
+		}
+		log.Infof("🧾 Generated PO Number: %s", poNumber)
+
+		err = tx.Raw(`
+			INSERT INTO "PurchaseOrderManagement"."PurchaseOrders"
+			(po_number, "supplierId", branchid, "taxEnabled", "taxRate",
+			 "paymentFee", "shippingFee", "subTotal", "taxAmount", "roundOff", total,
+			 "poYear", "poMonth", status, "createdAt", "createdBy", "isDelete")
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'OPEN', ?, ?, FALSE)
+			RETURNING id
 		`,
-			poId,
-			item.CategoryId, item.SubCategoryId, item.ProductDescription,
-			fmt.Sprintf("%.2f", item.UnitPrice),
-			fmt.Sprintf("%.2f", item.Quantity),
-			fmt.Sprintf("%.2f", item.DiscountPercent),
-			fmt.Sprintf("%.2f", item.DiscountAmount),
-			fmt.Sprintf("%.2f", item.Total),
-			createdAt,
-		)
-	}
+			poNumber, payload.SupplierId, payload.BranchId,
+			payload.TaxEnabled, fmt.Sprintf("%.2f", payload.TaxRate),
+			fmt.Sprintf("%.2f", payload.PaymentFee),
+			fmt.Sprintf("%.2f", payload.ShippingFee),
+			fmt.Sprintf("%.2f", payload.Subtotal),
+			fmt.Sprintf("%.2f", payload.TaxAmount),
+			fmt.Sprintf("%.2f", payload.RoundOff),
+			fmt.Sprintf("%.2f", payload.Total),
+			fmt.Sprintf("%d", year),
+			fmt.Sprintf("%d", month),
+			createdAt, createdBy,
+		).Scan(&poId).Error
+		if err != nil {
+			log.Error("❌ Failed inserting PO header: " + err.Error())
+			return err
+		}
 
-	// INSERT AUDIT
-	jsonData, _ := json.Marshal(payload)
+		log.Infof("🧾 Purchase Order ID: %d", poId)
 
-	db.Exec(`
-		INSERT INTO "PurchaseOrderManagement"."PurchaseOrderAudit"
-		("purchaseOrderId", "actionType", "actionDetails", "createdAt", "createdBy")
-		VALUES (?, 'CREATE', ?, ?, ?)
-	`,
-		poId, string(jsonData), createdAt, roleName,
-	)
+		for i, item := range payload.Items {
+			err := tx.Exec(`
+				INSERT INTO "PurchaseOrderManagement"."PurchaseOrderItems"
+				("purchaseOrderId", "categoryId", "subCategoryId", "productDescription",
+				 "unitPrice", quantity, "discountPercent", "discountAmount", "lineTotal",
+				 "receivedQuantity", "isClosed", "createdAt")
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, FALSE, ?)
+			`,
+				poId,
+				item.CategoryId, item.SubCategoryId, item.ProductDescription,
+				fmt.Sprintf("%.2f", item.UnitPrice),
+				fmt.Sprintf("%.2f", item.Quantity),
+				fmt.Sprintf("%.2f", item.DiscountPercent),
+				fmt.Sprintf("%.2f", item.DiscountAmount),
+				fmt.Sprintf("%.2f", item.Total),
+				createdAt,
+			).Error
+			if err != nil {
+				log.Errorf("❌ Failed inserting PO item %d: %s", i+1, err.Error())
+				return err
+			}
+		}
 
-	log.Info("📘 Logged Audit trail")
+		// INSERT AUDIT
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityPurchaseOrder,
+			EntityId: poId,
+			Action:   audit.ActionCreate,
+			BranchId: payload.BranchId,
+			After:    map[string]interface{}{"poNumber": poNumber, "purchaseOrder": payload},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// LOG TRANSACTION
-	transErr := transactionLogger.LogTransaction(
-		db, 1, roleName, 2,
+	transErr := transactionLogger.LogActorTransaction(db, 2,
 		"Purchase Order Created: "+poNumber,
 	)
 	if transErr != nil {
@@ -639,13 +589,36 @@ func NewCreatePurchaseOrderService(db *gorm.DB, payload PurchaseOrderPayload, ro
 	}, nil
 }
 
-func NewGetAllPurchaseOrdersService(db *gorm.DB) []map[string]interface{} {
-	log := logger.InitLogger()
+// PurchaseOrderList is what GET /purchaseOrder/getOurchaseOrder may be filtered, searched
+// and sorted on.
+var PurchaseOrderList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"supplierId":    {Column: "supplierId", Kind: listquery.ID},
+		"status":        {Column: "status", Kind: listquery.Text},
+		"createdAt":     {Column: "createdAt", Kind: listquery.Date},
+		"isFullyClosed": {Column: "isfullyclosed", Kind: listquery.Bool},
+	},
+	Sorts: map[string]string{
+		"id":           "id",
+		"po_number":    "po_number",
+		"supplierName": "supplierName",
+		"status":       "status",
+		"createdAt":    "createdAt",
+	},
+	Search:      []string{"po_number", "supplierName"},
+	Key:         "id",
+	DefaultSort: "-id",
+}
+
+func NewGetAllPurchaseOrdersService(db *gorm.DB, scope branchscope.Scope, query listquery.Query) ([]map[string]interface{}, listquery.Page, error) {
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetAllPurchaseOrdersService invoked")
 
-	var list []map[string]interface{}
+	list := []map[string]interface{}{}
 
-	db.Raw(`
+	branchCond, branchArgs := scope.Condition("po.branchid")
+
+	page, err := listquery.Find(db, `
 		SELECT
 			po.id,
 			po.po_number,
@@ -703,6 +676,7 @@ func NewGetAllPurchaseOrdersService(db *gorm.DB) []map[string]interface{} {
 		) grni ON grni."purchaseOrderId" = po.id
 
 		WHERE po."isDelete" = 'false'
+		AND `+branchCond+`
 
 		GROUP BY
 			po.id,
@@ -723,25 +697,27 @@ func NewGetAllPurchaseOrdersService(db *gorm.DB) []map[string]interface{} {
 			po."createdAt",
 			po."createdBy",
 			grni.total_received     -- IMPORTANT new addition
+	`, branchArgs, query, &list)
+	if err != nil {
+		log.Error("❌ Failed loading purchase orders: " + err.Error())
+		return nil, page, err
+	}
 
-		ORDER BY po.id DESC;
-
-	`).Scan(&list)
-
-	log.Infof("📊 Retrieved %d purchase orders", len(list))
+	log.Infof("📊 Retrieved %d of %d purchase orders", len(list), page.Total)
 
-	return list
+	return list, page, nil
 }
 
-func NewGetSinglePurchaseOrderService(db *gorm.DB, poId int) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+func NewGetSinglePurchaseOrderService(db *gorm.DB, poId int, scope branchscope.Scope) (map[string]interface{}, error) {
+	log := logger.FromDB(db)
 	log.Infof("🛠️ Fetching PO ID: %d", poId)
 
 	var header map[string]interface{}
+	branchCond, branchArgs := scope.Condition("branchid")
 	db.Raw(`
 		SELECT * FROM "PurchaseOrderManagement"."PurchaseOrders"
-		WHERE id = ? AND "isDelete" = FALSE
-	`, poId).Scan(&header)
+		WHERE id = ? AND "isDelete" = FALSE AND `+branchCond+`
+	`, append([]interface{}{poId}, branchArgs...)...).Scan(&header)
 
 	if header["id"] == nil {
 		return nil, fmt.Errorf("PO not found")
@@ -761,21 +737,21 @@ func NewGetSinglePurchaseOrderService(db *gorm.DB, poId int) (map[string]interfa
 }
 
 type GRNPayload struct {
-	PoId       int       `json:"poId"`
-	SupplierId int       `json:"supplierId"`
-	BranchId   int       `json:"branchId"`
-	TaxRate    any       `json:"taxRate"`
-	TaxAmount  any       `json:"taxAmount"`
-	Items      []GRNItem `json:"items"`
+	PoId       int       `json:"poId" binding:"required,gt=0"`
+	SupplierId int       `json:"supplierId" binding:"required,gt=0"`
+	BranchId   int       `json:"branchId" binding:"required,gt=0"`
+	TaxRate    any       `json:"taxRate" binding:"omitempty,percent"`
+	TaxAmount  any       `json:"taxAmount" binding:"omitempty,number"`
+	Items      []GRNItem `json:"items" binding:"required,min=1,dive"`
 }
 
 type GRNItem struct {
 	SNo              int     `json:"sNo"`
 	LineNo           string  `json:"lineNo"`
 	RefNo            string  `json:"refNo"`
-	Cost             float64 `json:"cost"`
-	ProfitPercent    float64 `json:"profitPercent"`
-	Total            float64 `json:"total"`
+	Cost             float64 `json:"cost" binding:"gte=0"`
+	ProfitPercent    float64 `json:"profitPercent" binding:"gte=0"`
+	Total            float64 `json:"total" binding:"gte=0"`
 	RoundOff         float64 `json:"roundOff"`
 	MeterQty         *string `json:"meterQty"`
 	ClothType        string  `json:"clothType"`
@@ -844,55 +820,48 @@ func toString(v any) string {
 	return fmt.Sprintf("%v", v)
 }
 
-func GenerateSKU(db *gorm.DB, year int, month int) (string, error) {
-	log := logger.InitLogger()
-	log.Info("🔧 Generating SKU...")
-
-	yearCode := string(rune('A' + (year - 2025))) // 2025=A
-	monthCode := string(rune('A' + (month - 1)))  // Jan=A
-
-	prefix := fmt.Sprintf("SS%s%s", yearCode, monthCode)
-
-	log.Infof("🔍 SKU Prefix = %s", prefix)
-
-	var lastSKU string
-	err := db.Raw(`
-        SELECT sku
-        FROM "PurchaseOrderManagement"."PurchaseOrderGRNItems"
-        WHERE sku LIKE ?
-        ORDER BY id DESC
-        LIMIT 1
-    `, prefix+"%").Scan(&lastSKU).Error
-
-	if err != nil {
-		log.Error("❌ Failed reading last SKU: " + err.Error())
-		return "", err
-	}
-
-	seq := 0
-	if lastSKU != "" && len(lastSKU) >= len(prefix)+5 {
-		lastSeqStr := lastSKU[len(lastSKU)-5:]
-		lastSeq, _ := strconv.Atoi(lastSeqStr)
-		seq = lastSeq
-	}
-
-	seq++
-
-	newSKU := fmt.Sprintf("%s%05d", prefix, seq)
-
-	log.Infof("🎉 Generated SKU = %s", newSKU)
-	return newSKU, nil
-}
-
-func NewCreateGRNService(db *gorm.DB, payload GRNPayload) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+func NewCreateGRNService(db *gorm.DB, payload GRNPayload, scope branchscope.Scope) (map[string]interface{}, error) {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ NewCreateGRNService invoked")
 
 	now := time.Now().Format("2006-01-02 15:04:05")
 
-	// ✅ INSERT GRN HEADER
+	// the GRN, its SKUs and its outbox event commit together
 	var grnId int
-	err := db.Raw(`
+	skus := make([]string, 0, len(payload.Items))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// the GRN belongs to the branch of its PO, so check the PO and not only the payload
+		var po struct {
+			Id         int `gorm:"column:id"`
+			BranchId   int `gorm:"column:branchid"`
+			SupplierId int `gorm:"column:supplierId"`
+		}
+		err := tx.Raw(`
+			SELECT id, branchid, "supplierId"
+			FROM "PurchaseOrderManagement"."PurchaseOrders"
+			WHERE id = ? AND "isDelete" = FALSE
+			FOR UPDATE
+		`, payload.PoId).Scan(&po).Error
+		if err != nil {
+			log.Error("❌ Failed loading PO for GRN: " + err.Error())
+			return err
+		}
+		if po.Id == 0 {
+			return apperror.NotFound(fmt.Sprintf("Purchase order %d not found", payload.PoId))
+		}
+		if !scope.Allows(po.BranchId) {
+			return apperror.Forbidden(branchscope.ErrOutOfScope.Error())
+		}
+		if payload.BranchId != po.BranchId {
+			return apperror.BadRequest("branchId does not match the branch of the purchase order")
+		}
+		if payload.SupplierId != po.SupplierId {
+			return apperror.BadRequest("supplierId does not match the supplier of the purchase order")
+		}
+
+		// ✅ INSERT GRN HEADER
+		err = tx.Raw(`
 	INSERT INTO "PurchaseOrderManagement"."PurchaseOrderGRN"
 	(
 		"purchaseOrderId", "supplierId", "supplierName",
@@ -915,31 +884,34 @@ func NewCreateGRNService(db *gorm.DB, payload GRNPayload) (map[string]interface{
 	WHERE po.id = ?
 	RETURNING id
 	`,
-		now,
-		fmt.Sprintf("%d", len(payload.Items)),
-		payload.TaxRate,
-		payload.TaxAmount,
-		now,
-		"admin",
-		payload.PoId,
-	).Scan(&grnId).Error
+			now,
+			fmt.Sprintf("%d", len(payload.Items)),
+			payload.TaxRate,
+			payload.TaxAmount,
+			now,
+			author,
+			payload.PoId,
+		).Scan(&grnId).Error
 
-	if err != nil {
-		log.Error("❌ Failed inserting GRN header: " + err.Error())
-		return nil, err
-	}
+		if err != nil {
+			log.Error("❌ Failed inserting GRN header: " + err.Error())
+			return err
+		}
+		if grnId == 0 {
+			return apperror.NotFound(fmt.Sprintf("Supplier or branch of purchase order %d not found", payload.PoId))
+		}
 
-	log.Infof("🆔 GRN Created with ID = %d", grnId)
+		log.Infof("🆔 GRN Created with ID = %d", grnId)
 
-	// ✅ INSERT GRN ITEMS
-	for _, item := range payload.Items {
+		// ✅ INSERT GRN ITEMS
+		for _, item := range payload.Items {
 
-		sku, err := GenerateSKU(db, time.Now().Year(), int(time.Now().Month()))
-		if err != nil {
-			return nil, err
-		}
+			sku, err := numberseries.Issue(tx, numberseries.SKU, payload.BranchId, time.Now())
+			if err != nil {
+				return err
+			}
 
-		err = db.Exec(`
+			err = tx.Exec(`
 			INSERT INTO "PurchaseOrderManagement"."PurchaseOrderGRNItems"
 			(
 				"grnId", "purchaseOrderId", "supplierId",
@@ -960,83 +932,127 @@ func NewCreateGRNService(db *gorm.DB, payload GRNPayload) (map[string]interface{
 			)
 			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`,
-			grnId,
-			payload.PoId,
-			payload.SupplierId,
+				grnId,
+				payload.PoId,
+				payload.SupplierId,
 
-			item.LineNo,
-			item.RefNo,
+				item.LineNo,
+				item.RefNo,
 
-			item.ProductId,
-			item.ProductName,
+				item.ProductId,
+				item.ProductName,
 
-			SafeInt(item.Design.Id),
-			item.Design.Name,
+				SafeInt(item.Design.Id),
+				item.Design.Name,
 
-			SafeInt(item.Pattern.Id),
-			item.Pattern.Name,
+				SafeInt(item.Pattern.Id),
+				item.Pattern.Name,
 
-			SafeInt(item.Variant.Id),
-			item.Variant.Name,
+				SafeInt(item.Variant.Id),
+				item.Variant.Name,
 
-			SafeInt(item.Color.Id),
-			item.Color.Name,
+				SafeInt(item.Color.Id),
+				item.Color.Name,
 
-			SafeInt(item.Size.Id),
-			item.Size.Name,
+				SafeInt(item.Size.Id),
+				item.Size.Name,
 
-			toString(item.Cost),
-			toString(item.ProfitPercent),
-			toString(item.Total),
+				toString(item.Cost),
+				toString(item.ProfitPercent),
+				toString(item.Total),
 
-			toString(item.RoundOff),
-			toString(item.MeterQty),
-			item.ClothType,
+				toString(item.RoundOff),
+				toString(item.MeterQty),
+				item.ClothType,
 
-			item.QuantityInMeters,
-			item.IsReadymade,
-			item.IsSaree,
+				item.QuantityInMeters,
+				item.IsReadymade,
+				item.IsSaree,
 
-			now,
-			"admin",
+				now,
+				author,
 
-			payload.BranchId,
-			false,
+				payload.BranchId,
+				false,
 
-			1,
-			sku,
-		).Error
+				1,
+				sku,
+			).Error
 
-		if err != nil {
-			log.Error("❌ Failed inserting GRN item: " + err.Error())
-			return nil, err
+			if err != nil {
+				log.Error("❌ Failed inserting GRN item: " + err.Error())
+				return err
+			}
+			skus = append(skus, sku)
 		}
+
+		if err := audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityGRN,
+			EntityId: grnId,
+			Action:   audit.ActionCreate,
+			BranchId: payload.BranchId,
+			After:    payload,
+		}); err != nil {
+			return err
+		}
+		return outbox.Publish(tx, outbox.GRNCreated, grnId, payload.BranchId, map[string]interface{}{
+			"grnId":           grnId,
+			"purchaseOrderId": payload.PoId,
+			"supplierId":      payload.SupplierId,
+			"branchId":        payload.BranchId,
+			"skus":            skus,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+	metrics.GRNsCreated.Inc()
+	metrics.SKUsGenerated.Add(float64(len(payload.Items)))
 
 	return map[string]interface{}{
 		"grnId": grnId,
 	}, nil
 }
 
-func NewGetAllGRNService(db *gorm.DB) []map[string]interface{} {
-	var list []map[string]interface{}
-	db.Raw(`
+// GRNList is what GET /purchaseOrder/grn/list may be filtered, searched and sorted on.
+var GRNList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"supplierId":      {Column: "supplierId", Kind: listquery.ID},
+		"purchaseOrderId": {Column: "purchaseOrderId", Kind: listquery.ID},
+		"createdAt":       {Column: "createdAt", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"id":           "id",
+		"poNumber":     "poNumber",
+		"supplierName": "supplierName",
+		"createdAt":    "createdAt",
+	},
+	Search:      []string{"poNumber", "po_number", "supplierName"},
+	Key:         "id",
+	DefaultSort: "-id",
+}
+
+func NewGetAllGRNService(db *gorm.DB, scope branchscope.Scope, query listquery.Query) ([]map[string]interface{}, listquery.Page, error) {
+	list := []map[string]interface{}{}
+	branchCond, branchArgs := scope.Condition("grn.branchid")
+	page, err := listquery.Find(db, `
 		SELECT grn.*, po.po_number
 		FROM "PurchaseOrderManagement"."PurchaseOrderGRN" grn
 		JOIN "PurchaseOrderManagement"."PurchaseOrders" po
 			ON po.id = grn."purchaseOrderId"
-		ORDER BY grn.id DESC
-	`).Scan(&list)
+		WHERE `+branchCond+`
+	`, branchArgs, query, &list)
 
-	return list
+	return list, page, err
 }
 
-func NewGetSingleGRNService(db *gorm.DB, grnId int) (map[string]interface{}, error) {
+func NewGetSingleGRNService(db *gorm.DB, grnId int, scope branchscope.Scope) (map[string]interface{}, error) {
 	var header map[string]interface{}
+	branchCond, branchArgs := scope.Condition("branchid")
 	db.Raw(`
 		SELECT * FROM "PurchaseOrderManagement"."PurchaseOrderGRN"
-		WHERE id = ?
-	`, grnId).Scan(&header)
+		WHERE id = ? AND `+branchCond+`
+	`, append([]interface{}{grnId}, branchArgs...)...).Scan(&header)
 
 	if header["id"] == nil {
 		return nil, fmt.Errorf("GRN not found")
@@ -1052,13 +1068,42 @@ func NewGetSingleGRNService(db *gorm.DB, grnId int) (map[string]interface{}, err
 	return header, nil
 }
 
-func NewGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
-	log := logger.InitLogger()
+// InventoryList is what GET /purchaseOrder/getInventoryList may be filtered, searched and
+// sorted on.
+var InventoryList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"categoryId":    {Column: "categoryId", Kind: listquery.ID},
+		"subCategoryId": {Column: "subCategoryId", Kind: listquery.ID},
+		"productId":     {Column: "productId", Kind: listquery.ID},
+		"poSupplierId":  {Column: "poSupplierId", Kind: listquery.ID},
+		"grnId":         {Column: "grnId", Kind: listquery.ID},
+		"designId":      {Column: "designId", Kind: listquery.ID},
+		"colorId":       {Column: "colorId", Kind: listquery.ID},
+		"sizeId":        {Column: "sizeId", Kind: listquery.ID},
+		"createdAt":     {Column: "createdAt", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"id":           "id",
+		"barcode":      "barcode",
+		"productName":  "productName",
+		"categoryName": "categoryName",
+		"supplierName": "supplierName",
+		"createdAt":    "createdAt",
+	},
+	Search:      []string{"barcode", "productName", "designName", "colorName", "grnNumber"},
+	Key:         "id",
+	DefaultSort: "-id",
+}
+
+func NewGetInventoryListService(db *gorm.DB, scope branchscope.Scope, query listquery.Query) ([]map[string]interface{}, listquery.Page, error) {
+	log := logger.FromDB(db)
 	log.Info("🛠️ NewGetInventoryListService invoked")
 
-	var list []map[string]interface{}
+	list := []map[string]interface{}{}
 
-	err := db.Raw(`
+	branchCond, branchArgs := scope.Condition(`gi."productBranchId"`)
+
+	page, err := listquery.Find(db, `
 		SELECT
 		gi.id,
 		gi.sku AS "barcode",
@@ -1086,8 +1131,6 @@ func NewGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
 		po."supplierId" AS "poSupplierId",
 		sp."categoryId",
 		sp."subCategoryId",
-		c."categoryName",
-		sc."subCategoryName",
 		gi.cost AS "unitCost",
 		gi.total AS "totalAmount",
 		gi."profitPercent" AS "marginPercent",
@@ -1095,8 +1138,6 @@ func NewGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
 		poi."discountAmount",
 		--   pi.file_name AS "productImage",
 		--   pi.extracted_sku AS "imageSku",
-		s."supplierName",
-		br."refBranchName",
 		gi."createdAt",
 		gi."createdBy"
 		FROM
@@ -1113,25 +1154,26 @@ func NewGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
 		WHERE
 		gi."isDelete" = FALSE
 		AND gi.quantity > 0
-		ORDER BY
-		gi.id DESC;
-	`).Scan(&list).Error
+		AND `+branchCond+`
+	`, branchArgs, query, &list)
 
 	if err != nil {
 		log.Error("❌ Failed loading inventory list: " + err.Error())
-		return nil, err
+		return nil, page, err
 	}
 
-	log.Infof("📦 Total inventory records: %d", len(list))
-	return list, nil
+	log.Infof("📦 Inventory records: %d of %d", len(list), page.Total)
+	return list, page, nil
 }
 
-func NewGetInventoryProductBySKUService(db *gorm.DB, sku string) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+func NewGetInventoryProductBySKUService(db *gorm.DB, sku string, scope branchscope.Scope) (map[string]interface{}, error) {
+	log := logger.FromDB(db)
 	log.Infof("🛠️ Fetch product for SKU: %s", sku)
 
 	var product map[string]interface{}
 
+	branchCond, branchArgs := scope.Condition(`gi."productBranchId"`)
+
 	err := db.Raw(`
 		SELECT 
 			gi.id,
@@ -1206,8 +1248,9 @@ func NewGetInventoryProductBySKUService(db *gorm.DB, sku string) (map[string]int
 
 		WHERE gi."isDelete" = FALSE
 		AND gi.sku = ?
+		AND `+branchCond+`
 		LIMIT 1;
-	`, sku).Scan(&product).Error
+	`, append([]interface{}{sku}, branchArgs...)...).Scan(&product).Error
 
 	if err != nil {
 		log.Error("❌ Failed fetching product: " + err.Error())
@@ -1258,12 +1301,13 @@ func NewGetInventoryProductBySKUService(db *gorm.DB, sku string) (map[string]int
 	return product, nil
 }
 
-func GetSinglePOGRNItemsService(db *gorm.DB, poId string) []map[string]interface{} {
-	log := logger.InitLogger()
+func GetSinglePOGRNItemsService(db *gorm.DB, poId string, scope branchscope.Scope) ([]map[string]interface{}, error) {
+	log := logger.FromDB(db)
 	log.Info("🛠️ Fetching GRN items for single PO")
 
 	var items []map[string]interface{}
 
+	branchCond, branchArgs := scope.Condition("g.branchid")
 	query := `
         SELECT 
             gi.id AS "grnItemId",
@@ -1315,20 +1359,23 @@ func GetSinglePOGRNItemsService(db *gorm.DB, poId string) []map[string]interface
             AND gi."isDelete" = FALSE
 
         WHERE 
-            g."purchaseOrderId" = ?
+            g."purchaseOrderId" = ? AND ` + branchCond + `
         
         ORDER BY g.id DESC, gi.id ASC
     `
 
-	db.Raw(query, poId).Scan(&items)
+	if err := db.Raw(query, append([]interface{}{poId}, branchArgs...)...).Scan(&items).Error; err != nil {
+		log.Error("❌ Failed loading GRN items: " + err.Error())
+		return nil, err
+	}
 
 	log.Infof("📦 Found %d GRN items", len(items))
 
-	return items
+	return items, nil
 }
 
-func ScanSKUService(db *gorm.DB, sku string, branchId interface{}) (map[string]interface{}, bool, error) {
-	log := logger.InitLogger()
+func ScanSKUService(db *gorm.DB, sku string, scope branchscope.Scope) (map[string]interface{}, bool, error) {
+	log := logger.FromDB(db)
 	log.Infof("🛠️ ScanSKUService invoked for SKU: %s", sku)
 
 	var product map[string]interface{}
@@ -1428,14 +1475,17 @@ func ScanSKUService(db *gorm.DB, sku string, branchId interface{}) (map[string]i
 	// ======================================
 	// 2️⃣ Validate Branch (branch-level access)
 	// ======================================
-	branchInt, _ := strconv.Atoi(fmt.Sprintf("%v", branchId))
-	productBranch := int(product["productBranchId"].(int32))
+	productBranch, _ := strconv.Atoi(fmt.Sprintf("%v", product["productBranchId"]))
 
-	isFound := (productBranch == branchInt)
+	isFound := (productBranch == scope.BranchId)
 
-	// If wrong branch → still return product but mark isFound = false
+	// Wrong branch → cross-branch roles still see the product flagged isFound = false,
+	// store staff get nothing back for stock that is not theirs
 	if !isFound {
-		log.Warnf("⚠ Branch mismatch: product is in branch %d but user is in branch %d", productBranch, branchInt)
+		log.Warnf("⚠ Branch mismatch: product is in branch %d but user is in branch %d", productBranch, scope.BranchId)
+		if !scope.AllBranches {
+			return nil, false, nil
+		}
 	}
 
 	// ======================================
@@ -1490,7 +1540,7 @@ func ScanSKUService(db *gorm.DB, sku string, branchId interface{}) (map[string]i
 }
 
 func POSGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ POSGetInventoryListService invoked (branchId = 4)")
 
 	var list []map[string]interface{}
@@ -1544,7 +1594,7 @@ func POSGetInventoryListService(db *gorm.DB) ([]map[string]interface{}, error) {
 }
 
 func POSGetInventoryProductBySKUService(db *gorm.DB, sku string) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("📦 POS inventory by indiv SKU")
 
 	var product map[string]interface{}
@@ -1689,19 +1739,49 @@ func AcceptStockIntakeService(db *gorm.DB, toBranchId int, items []StockItem) (i
 	return int(updateReceive.RowsAffected), nil
 }
 
-func PushProductToShopify(p map[string]interface{}) error {
+// JobPushProduct pushes an accepted product to Shopify from a job worker.
+const JobPushProduct = "shopify.pushProduct"
+
+// PushProductToShopify queues p (productName, unitCost, categoryName, colorName,
+// sizeName, barcode and an optional imageURL) for Shopify. Pass the transaction that
+// accepted the product.
+func PushProductToShopify(db *gorm.DB, p map[string]interface{}) (jobqueue.Job, error) {
+	return jobqueue.Enqueue(db, JobPushProduct, p, jobqueue.Options{})
+}
+
+// PushProductJob handles JobPushProduct. Once Shopify has the product the job completes:
+// another attempt would create it twice.
+func PushProductJob(_ *gorm.DB, payload json.RawMessage) (interface{}, error) {
+	var p map[string]interface{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+
+	productId, err := pushProductToShopify(p)
+	if productId == 0 {
+		return nil, err
+	}
+	result := map[string]interface{}{"productId": productId}
+	if err != nil {
+		result["warning"] = err.Error()
+	}
+	return result, nil
+}
+
+// pushProductToShopify answers the id of the created product, 0 when it was not created.
+func pushProductToShopify(p map[string]interface{}) (uint64, error) {
 	ctx := context.Background()
 	client := shopifyConfig.ShopifyClient
 
 	if client == nil {
-		return fmt.Errorf("shopify client not initialized")
+		return 0, fmt.Errorf("shopify client not initialized")
 	}
 
 	// Convert price string to decimal
 	priceString := fmt.Sprintf("%v", p["unitCost"])
 	priceDecimal, err := decimal.NewFromString(priceString)
 	if err != nil {
-		return fmt.Errorf("invalid price format: %v", err)
+		return 0, jobqueue.Permanent(fmt.Errorf("invalid price format: %v", err))
 	}
 
 	// Build Shopify product
@@ -1734,15 +1814,16 @@ func PushProductToShopify(p map[string]interface{}) error {
 	// Create product in Shopify
 	createdProduct, err := client.Product.Create(ctx, product)
 	if err != nil {
-		return fmt.Errorf("Shopify create product error: %v", err)
+		return 0, fmt.Errorf("Shopify create product error: %v", err)
 	}
 
-	fmt.Println("🎉 Shopify product created:", createdProduct.Id)
+	log := logger.InitLogger()
+	log.Infof("🎉 Shopify product created: %d", createdProduct.Id)
 
 	// Get Shopify locations
 	locations, err := client.Location.List(ctx, nil)
 	if err != nil || len(locations) == 0 {
-		return fmt.Errorf("failed to fetch Shopify locations")
+		return createdProduct.Id, fmt.Errorf("failed to fetch Shopify locations")
 	}
 
 	locationID := locations[0].Id
@@ -1757,15 +1838,15 @@ func PushProductToShopify(p map[string]interface{}) error {
 
 		_, err = client.InventoryLevel.Adjust(ctx, adjust)
 		if err != nil {
-			fmt.Println("⚠️ Inventory adjust failed:", err)
+			log.Warnf("⚠️ Inventory adjust failed: %v", err)
 		}
 	}
 
-	return nil
+	return createdProduct.Id, nil
 }
 
 func GetSupplierBillAgeingReportService(db *gorm.DB) ([]map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetSupplierBillAgeingReportService invoked")
 
 	var list []map[string]interface{}
@@ -1815,7 +1896,7 @@ func GetSupplierBillAgeingReportService(db *gorm.DB) ([]map[string]interface{},
 }
 
 func GetPurchaseOrderReportService(db *gorm.DB) ([]map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetPurchaseOrderReportService invoked")
 
 	var list []map[string]interface{}
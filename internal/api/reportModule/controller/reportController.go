@@ -2,22 +2,23 @@ package reportController
 
 import (
 	"net/http"
+	"strconv"
 
 	reportModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/model"
 	reportService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	contextutil "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ExtractUserContext"
 	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func GetAllProductsReportController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllProductsReportController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("GetAllProductsReportController invoked")
 
 		ctxUser, ok := contextutil.ExtractUserContext(c)
@@ -32,24 +33,15 @@ func GetAllProductsReportController() gin.HandlerFunc {
 		var productsReportPayload reportModel.ProductsReportPayload
 		if err := c.ShouldBindJSON(&productsReportPayload); err != nil {
 			log.Error("Invalid pagination payload:", err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
 			return
 		}
 		log.Infof("Request Body: %+v", productsReportPayload)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		roleId, err := roleType.ExtractIntFromInterface(ctxUser.RoleID)
 		if err != nil {
 			log.Error("Invalid role id:", err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid role ID",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid role ID"))
 			return
 		}
 
@@ -68,13 +60,70 @@ func GetAllProductsReportController() gin.HandlerFunc {
 
 		log.Info("Reports Fetched Successfully")
 
-		token := accesstoken.CreateToken(ctxUser.ID, ctxUser.RoleID, ctxUser.BranchID)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Reports fetched successfully",
 			"data":    result,
-			"token":   token,
+		})
+	}
+}
+
+// ExportProductsReportController queues the CSV export of the product report and answers
+// 202 with the job id; GET /reports/exports/:id gives the download link once it is done.
+func ExportProductsReportController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("ExportProductsReportController invoked")
+
+		_, ok := contextutil.ExtractUserContext(c)
+		if !ok {
+			log.Warn("Missing context data")
+			return
+		}
+
+		var productsReportPayload reportModel.ProductsReportPayload
+		if err := c.ShouldBindJSON(&productsReportPayload); err != nil {
+			log.Error("Invalid export payload:", err.Error())
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		job, err := reportService.QueueProductReportExport(actor.Bind(dbConn, c), &productsReportPayload)
+		if err != nil {
+			apperror.Respond(c, apperror.Internal(err, "Unable to queue the report export"))
+			return
+		}
+
+		log.Infof("Report export queued as job %d", job.RefJobId)
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"status":  true,
+			"message": "Report export queued",
+			"data":    gin.H{"jobId": job.RefJobId},
+		})
+	}
+}
+
+func GetReportExportController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("GetReportExportController invoked")
+
+		jobId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || jobId <= 0 {
+			apperror.Respond(c, apperror.BadRequest("Invalid export ID"))
+			return
+		}
+
+		status, err := reportService.GetReportExportService(dbConn.WithContext(c.Request.Context()), jobId)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   status,
 		})
 	}
 }
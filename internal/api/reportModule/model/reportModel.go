@@ -33,3 +33,21 @@ type PurchaseOrderResponse struct {
 	UpdatedBy          string `json:"updatedBy" gorm:"column:updatedBy"`
 	IsDelete           string `json:"isDelete" gorm:"column:isDelete"`
 }
+
+// ExportResult is what a finished report export job keeps.
+type ExportResult struct {
+	Key  string `json:"key"`
+	Rows int    `json:"rows"`
+}
+
+// ExportStatus answers GET /reports/exports/:id. DownloadURL is set once the export is
+// completed and stays valid for ExpiresIn seconds.
+type ExportStatus struct {
+	JobId       int64  `json:"jobId"`
+	Status      string `json:"status"`
+	Attempts    int    `json:"attempts"`
+	LastError   string `json:"lastError,omitempty"`
+	Rows        int    `json:"rows,omitempty"`
+	DownloadURL string `json:"downloadUrl,omitempty"`
+	ExpiresIn   int    `json:"expiresIn,omitempty"`
+}
@@ -0,0 +1,13 @@
+package reportRoutes
+
+import (
+	reportModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/model"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/reports/productReports":         {Summary: "Get all products report", Request: reportModel.ProductsReportPayload{}, Permission: permission.ReportsView},
+	"POST /api/v1/admin/reports/productReportsDownload": {Summary: "Export the products report as CSV", Description: "Answers 202 with the id of the export job; poll GET /api/v1/admin/reports/exports/:id for the download link.", Request: reportModel.ProductsReportPayload{}, Permission: permission.ReportsView},
+	"GET /api/v1/admin/reports/exports/:id":             {Summary: "Get the status of a report export, with its download link once done", Response: reportModel.ExportStatus{}, Permission: permission.ReportsView},
+}
@@ -2,14 +2,23 @@ package reportRoutes
 
 import (
 	reportController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/controller"
+	reportService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/service"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func ReportRoutes(router *gin.Engine) {
+func ReportRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/reports")
 
-	route.POST("/productReports", accesstoken.JWTMiddleware(), reportController.GetAllProductsReportController())
-	route.POST("/productReportsDownload", accesstoken.JWTMiddleware(), reportController.GetAllProductsReportController())
+	route.POST("/productReports", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ReportsView), reportController.GetAllProductsReportController(dbConn))
+	route.POST("/productReportsDownload", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ReportsView), reportController.ExportProductsReportController(dbConn))
+	route.GET("/exports/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.ReportsView), reportController.GetReportExportController(dbConn))
 
+	jobqueue.Handle(reportService.JobExportProducts, reportService.ExportProductReportJob)
+
+	openapi.Describe("Reports", docs)
 }
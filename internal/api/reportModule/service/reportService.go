@@ -1,16 +1,30 @@
 package reportService
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"strconv"
+	"time"
 
 	reportModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/model"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	storage "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Storage"
 	"gorm.io/gorm"
 )
 
+// JobExportProducts writes the product report to storage as CSV from a job worker.
+const JobExportProducts = "reports.exportProducts"
+
+// DownloadExpiry is how long the download link of a finished export stays valid.
+const DownloadExpiry = 15 * time.Minute
+
 // PRODUCT REPORT SERVICE
 func GetAllProductReportsService(db *gorm.DB, productReport *reportModel.ProductsReportPayload, roleName string) (map[string]interface{}, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("Fetching all product reports with pagination")
 
 	var totalCount int64
@@ -53,7 +67,7 @@ func GetAllProductReportsService(db *gorm.DB, productReport *reportModel.Product
 
 	log.Infof("Total count of products: %d", totalCount)
 	log.Infof("Fetched %d products with offset %d and limit %d", len(resultDataOfProducts), offset, limit)
-	log.Infof("Result of the products: %+v", resultDataOfProducts)
+	log.Debugf("Result of the products: %+v", resultDataOfProducts)
 
 	return map[string]interface{}{
 		"totalCount": totalCount,
@@ -62,3 +76,84 @@ func GetAllProductReportsService(db *gorm.DB, productReport *reportModel.Product
 		"limit":      limitInt,
 	}, nil
 }
+
+// QueueProductReportExport queues the CSV export of the product report.
+func QueueProductReportExport(db *gorm.DB, productReport *reportModel.ProductsReportPayload) (jobqueue.Job, error) {
+	return jobqueue.Enqueue(db, JobExportProducts, productReport, jobqueue.Options{MaxAttempts: 3})
+}
+
+// ExportProductReportJob handles JobExportProducts: every received product, one CSV row
+// each, stored under exports/reports/.
+func ExportProductReportJob(db *gorm.DB, _ json.RawMessage) (interface{}, error) {
+	log := logger.FromDB(db)
+
+	var rows []reportModel.PurchaseOrderResponse
+	dataQuery := `
+		SELECT *
+		FROM "purchaseOrder"."ProductsDummyAcceptance" pda
+		WHERE pda."acceptanceStatus" = 'Received'
+		ORDER BY pda."dummyProductsId" ASC
+	`
+	if err := db.Raw(dataQuery).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"dummyProductsId", "productName", "refCategoryId", "refSubCategoryId", "HSNCode", "price", "discountPercentage", "discountAmount", "isReceived", "acceptanceStatus", "createdAt", "createdBy", "updatedAt", "updatedBy"})
+	for _, r := range rows {
+		w.Write([]string{
+			strconv.Itoa(r.DummyProductsIdPK), r.ProductName, strconv.Itoa(r.RefCategoryId), strconv.Itoa(r.RefSubCategoryId),
+			r.HSNCode, r.Price, r.DiscountPercentage, r.DiscountAmount, r.IsReceived, r.AcceptanceStatus,
+			r.CreatedAt, r.CreatedBy, r.UpdatedAt, r.UpdatedBy,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+
+	key := "exports/reports/products-" + time.Now().Format("20060102-150405.000") + ".csv"
+	if err := storage.Get().Put(db.Statement.Context, key, bytes.NewReader(buf.Bytes()), int64(buf.Len()), "text/csv"); err != nil {
+		return nil, err
+	}
+	log.Infof("📄 Product report exported: %d rows to %s", len(rows), key)
+
+	return reportModel.ExportResult{Key: key, Rows: len(rows)}, nil
+}
+
+// GetReportExportService tells how an export job is doing, with a download link once
+// it completed.
+func GetReportExportService(db *gorm.DB, jobId int64) (reportModel.ExportStatus, error) {
+	job, err := jobqueue.Get(db, jobId)
+	if err != nil {
+		return reportModel.ExportStatus{}, err
+	}
+	if job.RefJobKind != JobExportProducts {
+		return reportModel.ExportStatus{}, apperror.NotFound("Export not found")
+	}
+
+	status := reportModel.ExportStatus{
+		JobId:     job.RefJobId,
+		Status:    job.RefJobStatus,
+		Attempts:  job.RefJobAttempts,
+		LastError: job.RefJobLastError,
+	}
+	if job.RefJobStatus != jobqueue.StatusCompleted {
+		return status, nil
+	}
+
+	var result reportModel.ExportResult
+	if err := job.DecodeResult(&result); err != nil {
+		return status, err
+	}
+	url, err := storage.Get().PresignGet(db.Statement.Context, result.Key, DownloadExpiry)
+	metrics.ObservePresign("reports", "get", err)
+	if err != nil {
+		return status, apperror.Internal(err, "Unable to create the download link")
+	}
+	status.Rows = result.Rows
+	status.DownloadURL = url
+	status.ExpiresIn = int(DownloadExpiry.Seconds())
+	return status, nil
+}
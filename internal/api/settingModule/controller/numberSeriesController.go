@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/model"
+	settingsService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/service"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetNumberSeriesController lists the numbered document types with their built-in
+// formats, the configured series and the counters of every fiscal year.
+func GetNumberSeriesController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔢 GetNumberSeriesController invoked")
+
+		overview, err := settingsService.GetNumberSeriesService(dbConn)
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch number series"))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   overview,
+		})
+	}
+}
+
+func SaveNumberSeriesController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔢 SaveNumberSeriesController invoked")
+
+		var payload model.NumberSeriesPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			validation.Respond(c, err)
+			return
+		}
+
+		series, err := settingsService.SaveNumberSeriesService(actor.Bind(dbConn, c), &payload)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  true,
+			"message": "Number series saved successfully",
+			"data":    series,
+		})
+	}
+}
+
+func DeleteNumberSeriesController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔢 DeleteNumberSeriesController invoked")
+
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			apperror.Respond(c, apperror.BadRequest("Invalid number series ID"))
+			return
+		}
+
+		if err := settingsService.DeleteNumberSeriesService(actor.Bind(dbConn, c), id); err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  true,
+			"message": "Number series removed, the default format applies again",
+		})
+	}
+}
+
+// GetNumberSeriesGapsController reports skipped and unused numbers of a document type,
+// optionally of one branch (0 for shared series) and fiscal year (its starting year).
+func GetNumberSeriesGapsController(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔢 GetNumberSeriesGapsController invoked")
+
+		var query model.NumberSeriesGapQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			validation.Respond(c, err)
+			return
+		}
+
+		reports, err := settingsService.GetNumberSeriesGapsService(dbConn, query)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   reports,
+		})
+	}
+}
@@ -7,41 +7,39 @@ import (
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/model"
 	settingsService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
-	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type CodeRequest struct {
 	InitialCategoryName string `json:"initialCategoryName"`
 }
 
-func CheckInitialCategoryCodeController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CheckInitialCategoryCodeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("➡ Check Initial Category Code Controller Invoked")
 
 		var req CodeRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid request"})
+			validation.Respond(c, err)
 			return
 		}
 
 		if req.InitialCategoryName == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "InitialCategoryName is required"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "InitialCategoryName is required"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		// Call service to generate code
 		generatedCode, err := settingsService.CheckInitialCategoryCodeService(dbConn, req.InitialCategoryName)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -53,10 +51,9 @@ func CheckInitialCategoryCodeController() gin.HandlerFunc {
 }
 
 // INITIAL CATEGORY CONTROLLER
-func CreateInitialCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateInitialCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nCreate Initial Ctegory Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -67,48 +64,26 @@ func CreateInitialCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var initialCategory model.InitialCategory
 		if err := c.ShouldBindJSON(&initialCategory); err != nil {
 			log.Error("Invalid request body" + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("Request body : %+v", initialCategory)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateInitialCategoryService(dbConnt, &initialCategory, roleName)
+		err := settingsService.CreateInitialCategoryService(actor.Bind(dbConnt, c), &initialCategory)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create category"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create category"))
 			}
 			return
 		}
@@ -119,15 +94,14 @@ func CreateInitialCategoryController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category created successfully",
-			"token":   token,
 		})
 
 	}
 }
 
-func GetAllInitialCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetAllInitialCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\nGetAllInitialCategoriesController Invoked")
 		idValue, idExists := c.Get("id")
 		roleIdValue, roleIdExists := c.Get("roleId")
@@ -137,43 +111,27 @@ func GetAllInitialCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			err := sqlDB.Close()
-			if err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		initialCategories := settingsService.GetAllInitialCategoriesService(dbConnt)
 		log.Infof("Initial Categories fetched: count = %d", len(initialCategories))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Sending response with initial category list\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   initialCategories,
-			"token":  token,
 		})
 
 	}
 }
 
-func UpdateInitialCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func UpdateInitialCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\nUpdate Initial Category Controller Invoked")
 
 		idValue, idExists := c.Get("id")
@@ -184,138 +142,89 @@ func UpdateInitialCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var initialCategory model.InitialCategory
 		if err := c.ShouldBindJSON(&initialCategory); err != nil {
 			log.Error("Invalid request Body " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("Request Body => %+v", initialCategory)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("❌ Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("👤 Role Name: %s", roleName)
-		}
-
 		log.Info("Calling Initial Update Category Service")
-		errH := settingsService.UpdateInitialCategoryService(dbConnt, &initialCategory, roleName)
+		errH := settingsService.UpdateInitialCategoryService(actor.Bind(dbConnt, c), &initialCategory)
 		if errH != nil {
 			log.Error("❌ Service error: " + errH.Error())
 			if errH.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update initial category"})
+				apperror.Respond(c, apperror.Wrap(errH, apperror.CodeInternal, "Failed to update initial category"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("Initial Category updated successfully\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Initial Category updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteInitialCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type DeleteInitialCategoriesRequest struct {
+	IDs []string `json:"ids"`
+}
 
+func DeleteInitialCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🗑️ Bulk Delete Initial Categories Controller Invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var payload struct {
-			IDs []string `json:"ids"`
-		}
+		var payload DeleteInitialCategoriesRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid JSON: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid request body"})
+			validation.Respond(c, err)
 			return
 		}
 
 		if len(payload.IDs) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "No IDs provided"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "No IDs provided"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
+		if err := settingsService.DeleteInitialCategoriesBulkService(actor.Bind(dbConnt, c), payload.IDs); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete initial categories"))
 			return
 		}
 
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		if err := settingsService.DeleteInitialCategoriesBulkService(dbConnt, payload.IDs, roleName); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete initial categories",
-			})
-			return
-		}
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Initial categories deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
 // CATEGORIES CONTROLLER
 
-func CreateCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Create Category Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -326,47 +235,25 @@ func CreateCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var category model.Category
 		if err := c.ShouldBindJSON(&category); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", category)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateCategoryService(dbConnt, &category, roleName)
+		err := settingsService.CreateCategoryService(actor.Bind(dbConnt, c), &category)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create category"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create category"))
 			}
 			return
 		}
@@ -377,15 +264,13 @@ func CreateCategoryController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllCategoriesController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllCategoriesController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetAllCategoriesController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -396,44 +281,27 @@ func GetAllCategoriesController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			err := sqlDB.Close()
-			if err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		log.Info("📦 Fetching all categories from DB")
 		categories := settingsService.GetAllCategoriesService(dbConnt)
 		log.Infof("📊 Categories fetched: count = %d", len(categories))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Sending response with category list\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   categories,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 UpdateCategoryController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -444,73 +312,43 @@ func UpdateCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var category model.Category
 		if err := c.ShouldBindJSON(&category); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", category)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("❌ Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("👤 Role Name: %s", roleName)
-		}
-
 		log.Info("🛠️ Calling UpdateCategoryService")
-		errH := settingsService.UpdateCategoryService(dbConnt, &category, roleName)
+		errH := settingsService.UpdateCategoryService(actor.Bind(dbConnt, c), &category)
 		if errH != nil {
 			log.Error("❌ Service error: " + errH.Error())
 			if errH.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update category"})
+				apperror.Respond(c, apperror.Wrap(errH, apperror.CodeInternal, "Failed to update category"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Category updated successfully\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func DeleteCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 DeleteCategoryController invoked")
 
 		// Get context values
@@ -522,10 +360,7 @@ func DeleteCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user/role/branch information in context")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
@@ -533,67 +368,50 @@ func DeleteCategoryController() gin.HandlerFunc {
 		forceDelete := c.DefaultQuery("forceDelete", "false") == "true"
 		log.Infof("🗑️ Delete Request: categoryId=%s, forceDelete=%t", categoryId, forceDelete)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		// Check subcategories
 		log.Info("🔎 Checking for subcategories before deletion")
 		subcategories, err := settingsService.GetSubcategoriesByCategory(dbConnt, categoryId)
 		if err != nil {
 			log.Error("❌ Error fetching subcategories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Internal server error",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Internal server error"))
 			return
 		}
 
 		if len(subcategories) > 0 && !forceDelete {
 			log.Warn("⚠️ Subcategories found. Confirmation required before force delete.")
-			c.JSON(http.StatusConflict, gin.H{
-				"status":             false,
-				"message":            "This category contains subcategories. Deleting it will make them idle.",
-				"subcategories":      subcategories,
-				"confirmationNeeded": true,
-			})
+			apperror.Respond(c, apperror.Conflict("This category contains subcategories. Deleting it will make them idle.").
+				With("subcategories", subcategories).
+				With("confirmationNeeded", true))
 			return
 		}
 
 		// Perform deletion
 		log.Info("🛠️ Calling DeleteCategoryService")
-		err = settingsService.DeleteCategoryService(dbConnt, categoryId)
+		err = settingsService.DeleteCategoryService(actor.Bind(dbConnt, c), categoryId)
 		if err != nil {
 			log.Error("❌ Service error during category deletion: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete category",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete category"))
 			return
 		}
 
 		log.Info("✅ Category deleted successfully\n\n")
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
-func BulkDeleteCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type BulkDeleteCategoryRequest struct {
+	CategoryIDs []int `json:"categoryIds"`
+	ForceDelete bool  `json:"forceDelete"`
+}
 
+func BulkDeleteCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 BulkDeleteCategoryController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -604,84 +422,43 @@ func BulkDeleteCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var request struct {
-			CategoryIDs []int `json:"categoryIds"`
-			ForceDelete bool  `json:"forceDelete"`
-		}
+		var request BulkDeleteCategoryRequest
 
 		if err := c.ShouldBindJSON(&request); err != nil || len(request.CategoryIDs) == 0 {
 			log.Error("❌ Invalid request body or empty category IDs")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid category IDs",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid category IDs"))
 			return
 		}
 		log.Infof("📦 Bulk delete request: categoryIds=%v, forceDelete=%v", request.CategoryIDs, request.ForceDelete)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		// Step 1: Check for subcategories
 		log.Info("🔎 Checking for subcategories in selected categories")
 		subcategoriesMap, err := settingsService.CheckSubcategoriesExistence(dbConnt, request.CategoryIDs)
 		if err != nil {
 			log.Error("❌ Error checking subcategories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Internal server error",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Internal server error"))
 			return
 		}
 
 		if len(subcategoriesMap) > 0 && !request.ForceDelete {
 			log.Warn("⚠️ Some categories have subcategories. Confirmation needed before force delete.")
-			c.JSON(http.StatusConflict, gin.H{
-				"status":             false,
-				"message":            "Some categories contain subcategories. Deleting them will make subcategories idle.",
-				"subcategoriesMap":   subcategoriesMap,
-				"confirmationNeeded": true,
-			})
+			apperror.Respond(c, apperror.Conflict("Some categories contain subcategories. Deleting them will make subcategories idle.").
+				With("subcategoriesMap", subcategoriesMap).
+				With("confirmationNeeded", true))
 			return
 		}
 
 		// Step 2: Perform soft delete
 		log.Info("🛠️ Proceeding to soft delete categories")
 
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.BulkDeleteCategoriesService(dbConnt, request.CategoryIDs, roleName)
+		err = settingsService.BulkDeleteCategoriesService(actor.Bind(dbConnt, c), request.CategoryIDs)
 		if err != nil {
 			log.Error("❌ Service error during bulk delete: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete categories",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete categories"))
 			return
 		}
 
@@ -689,21 +466,18 @@ func BulkDeleteCategoryController() gin.HandlerFunc {
 		log.Infof("✅ Categories soft deleted successfully: %v\n\n", request.CategoryIDs)
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Categories deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
 // SUB CATEGORIES CONTROLLER
 
-func CreateSubCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func CreateSubCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Create SubCategory Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -714,47 +488,24 @@ func CreateSubCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var subCategory model.SubCategory
 		if err := c.ShouldBindJSON(&subCategory); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", subCategory)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		// ✅ Extract role name
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		if err := settingsService.CreateSubCategoryService(dbConnt, &subCategory, roleName); err != nil {
+		if err := settingsService.CreateSubCategoryService(actor.Bind(dbConnt, c), &subCategory); err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create sub category"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create sub category"))
 			}
 			return
 		}
@@ -765,44 +516,35 @@ func CreateSubCategoryController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Sub category created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllSubCategoriesController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetAllSubCategoriesController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Get All SubCategories Controller invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			// Handle error: ID is missing from context (e.g., middleware didn't set it)
-			c.JSON(http.StatusUnauthorized, gin.H{ // Or StatusInternalServerError depending on why it's missing
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return // Stop processing
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		data := settingsService.GetAllSubCategoriesService(dbConnt)
 		log.Info("Fetched subcategories: ", data)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": data, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": data})
 	}
 }
 
-func UpdateSubCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func UpdateSubCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Update SubCategory Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -813,101 +555,73 @@ func UpdateSubCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var sub model.SubCategory
 		if err := c.ShouldBindJSON(&sub); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📥 Input SubCategory: %+v", sub)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-			roleName = "Unknown"
-		} else {
-			log.Infof("👤 Role Name resolved: %s", roleName)
-		}
-
-		if err := settingsService.UpdateSubCategoryService(dbConnt, &sub, roleName); err != nil {
+		if err := settingsService.UpdateSubCategoryService(actor.Bind(dbConnt, c), &sub); err != nil {
 			log.Error("❌ Service error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update sub category"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to update sub category"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ SubCategory updated successfully")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Sub category updated",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteSubCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func DeleteSubCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Delete SubCategory Controller invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			// Handle error: ID is missing from context (e.g., middleware didn't set it)
-			c.JSON(http.StatusUnauthorized, gin.H{ // Or StatusInternalServerError depending on why it's missing
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return // Stop processing
 		}
 
 		id := c.Param("id")
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
-		if err := settingsService.DeleteSubCategoryService(dbConnt, id); err != nil {
+		if err := settingsService.DeleteSubCategoryService(actor.Bind(dbConnt, c), id); err != nil {
 			log.Error("Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to delete sub category"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete sub category"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("Sub category deleted successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Sub category deleted", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Sub category deleted"})
 	}
 }
 
-func BulkDeleteSubCategoryController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type BulkDeleteSubCategoryRequest struct {
+	SubCategoryIDs []int `json:"subCategoriesId"`
+}
 
+func BulkDeleteSubCategoryController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 BulkDeleteSubCategoryController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -918,79 +632,41 @@ func BulkDeleteSubCategoryController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var request struct {
-			SubCategoryIDs []int `json:"subCategoriesId"`
-		}
+		var request BulkDeleteSubCategoryRequest
 
 		if err := c.ShouldBindJSON(&request); err != nil || len(request.SubCategoryIDs) == 0 {
 			log.Error("❌ Invalid request body or empty subcategory IDs")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid subcategory IDs",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid subcategory IDs"))
 			return
 		}
 
 		log.Infof("📦 Bulk delete request: subCategoryIds=%v", request.SubCategoryIDs)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.BulkDeleteSubCategoriesService(dbConnt, request.SubCategoryIDs, roleName)
+		err := settingsService.BulkDeleteSubCategoriesService(actor.Bind(dbConnt, c), request.SubCategoryIDs)
 		if err != nil {
 			log.Error("❌ Service error during bulk subcategory delete: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete subcategories",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete subcategories"))
 			return
 		}
 
 		log.Infof("✅ SubCategories soft deleted successfully: %v\n\n", request.SubCategoryIDs)
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Subcategories deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
 // BRANCHES CONTROLLER
-func CreateBranchController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateBranchController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Create Branch Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1001,47 +677,25 @@ func CreateBranchController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var branch model.Branch
 		if err := c.ShouldBindJSON(&branch); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", branch)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateBranchService(dbConnt, &branch, roleName)
+		err := settingsService.CreateBranchService(actor.Bind(dbConnt, c), &branch)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create branch"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create branch"))
 			}
 			return
 		}
@@ -1052,15 +706,13 @@ func CreateBranchController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Branch created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllBranchesController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllBranchesController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n📥 Get All Branches Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1071,42 +723,29 @@ func GetAllBranchesController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		branches, err := settingsService.GetAllBranchesService(dbConnt)
 		if err != nil {
 			log.Error("❌ Failed to get branches: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch branches",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch branches"))
 			return
 		}
 
 		log.Infof("✅ %d branches retrieved", len(branches))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   branches,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateBranchController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateBranchController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🔧 Update Branch Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1117,47 +756,27 @@ func UpdateBranchController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var branch model.Branch
 		if err := c.ShouldBindJSON(&branch); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
 		log.Infof("📥 Request Body: %+v", branch)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.UpdateBranchService(dbConnt, &branch, roleName)
+		err := settingsService.UpdateBranchService(actor.Bind(dbConnt, c), &branch)
 		if err != nil {
 			log.Error("❌ Service error: " + err.Error())
 
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update branch"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to update branch"))
 			}
 			return
 		}
@@ -1165,15 +784,13 @@ func UpdateBranchController() gin.HandlerFunc {
 		log.Info("✅ Branch updated successfully")
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch updated successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch updated successfully"})
 	}
 }
 
-func DeleteBranchController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func DeleteBranchController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🗑️ Delete Branch Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1184,82 +801,60 @@ func DeleteBranchController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values")
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User ID, RoleID, Branch ID not found in request context."})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		id := c.Param("id")
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
 
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-			roleName = "Unknown"
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.DeleteBranchService(dbConnt, id, roleName)
+		err := settingsService.DeleteBranchService(actor.Bind(dbConnt, c), id)
 		if err != nil {
 			log.Error("❌ Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to delete branch"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete branch"))
 			return
 		}
 
 		log.Info("✅ Branch soft deleted successfully")
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch deleted successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch deleted successfully"})
+	}
+}
+
+type CreateBranchWithFloorRequest struct {
+	model.BranchWithFloor
+	Floors []struct {
+		FloorName string
+		FloorCode string
+		Sections  []struct {
+			CategoryId       int
+			RefSubCategoryId int
+			SectionName      string
+			SectionCode      string
+		}
 	}
 }
 
 // BRANCH WITH FLOOR CONTROLLER
-func CreateNewBranchWithFloorController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func CreateNewBranchWithFloorController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nCreate Branch Controller invoked")
 
 		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var payload struct {
-			model.BranchWithFloor
-			Floors []struct {
-				FloorName string
-				FloorCode string
-				Sections  []struct {
-					CategoryId       int
-					RefSubCategoryId int
-					SectionName      string
-					SectionCode      string
-				}
-			}
-		}
-
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		var payload CreateBranchWithFloorRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid payload: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid request payload"})
+			validation.Respond(c, err)
 			return
 		}
 
@@ -1271,42 +866,25 @@ func CreateNewBranchWithFloorController() gin.HandlerFunc {
 			userId = v
 		default:
 			// handle unexpected type case
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Invalid user ID type"})
-			return
-		}
-
-		// ✅ Extract role name
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
+			apperror.Respond(c, apperror.New(apperror.CodeInternal, "Invalid user ID type"))
 			return
 		}
 
-		roleName, errRole := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		if errRole != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateNewBranchWithFloor(dbConnt, &payload.BranchWithFloor, payload.Floors, userId)
+		err := settingsService.CreateNewBranchWithFloor(actor.Bind(dbConnt, c), &payload.BranchWithFloor, payload.Floors, userId)
 		if err != nil {
 			log.Error("Failed to create branch with floors: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 		log.Info("Branch with Floors and Sections created successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch created with Floors and Sections", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch created with Floors and Sections"})
 	}
 }
 
-func GetNewBranchWithFloorController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetNewBranchWithFloorController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetNewBranchWithFloorController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1318,10 +896,7 @@ func GetNewBranchWithFloorController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
@@ -1336,42 +911,24 @@ func GetNewBranchWithFloorController() gin.HandlerFunc {
 			branchIdStr = strconv.Itoa(v)
 		default:
 			log.Error("❌ Unsupported type for branchId")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid branchId type",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid branchId type"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		log.Info("📦 Fetching branch with floors from DB")
 		branch, err := settingsService.GetBranchWithFloorsService(dbConnt, branchIdStr)
 		if err != nil {
 			log.Error("❌ Failed to fetch branch: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch branch details",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch branch details"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		if len(branch) == 0 {
 			// No data found but still success
 			c.JSON(http.StatusOK, gin.H{
 				"status":  true,
 				"message": "No data found",
 				"data":    []model.BranchResponse{},
-				"token":   token,
 			})
 			return
 		}
@@ -1380,15 +937,14 @@ func GetNewBranchWithFloorController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   branch,
-			"token":  token,
 		})
 
 	}
 }
 
-func GetNewBranchWithFloorWithIdController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetNewBranchWithFloorWithIdController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetNewBranchWithFloorController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1400,10 +956,7 @@ func GetNewBranchWithFloorWithIdController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
@@ -1418,94 +971,75 @@ func GetNewBranchWithFloorWithIdController() gin.HandlerFunc {
 			branchIdStr = strconv.Itoa(v)
 		default:
 			log.Error("❌ Unsupported type for branchId")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid branchId type",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid branchId type"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		log.Info("📦 Fetching branch with floors from DB")
 		branch, err := settingsService.GetBranchWithFloorsService(dbConnt, branchIdStr)
 		if err != nil {
 			log.Error("❌ Failed to fetch branch: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch branch details",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch branch details"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Sending response with branch floors\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   branch,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateBranchWithFloorController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type UpdateBranchWithFloorRequest struct {
+	model.BranchWithFloor
+	Floors []struct {
+		RefFloorId int
+		FloorName  string
+		FloorCode  string
+		Sections   []struct {
+			RefSectionId     int
+			CategoryId       int
+			RefSubCategoryId int
+			SectionName      string
+			SectionCode      string
+		}
+	}
+}
+
+func UpdateBranchWithFloorController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Update Branch Controller invoked")
 
 		branchIdParam := c.Param("id")
 		branchId, err := strconv.Atoi(branchIdParam)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid branch ID"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid branch ID"))
 			return
 		}
 
 		idValue, idExists := c.Get("id")
 		if !idExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized"})
-			return
-		}
-
-		var payload struct {
-			model.BranchWithFloor
-			Floors []struct {
-				RefFloorId int
-				FloorName  string
-				FloorCode  string
-				Sections   []struct {
-					RefSectionId     int
-					CategoryId       int
-					RefSubCategoryId int
-					SectionName      string
-					SectionCode      string
-				}
-			}
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
+			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		var payload UpdateBranchWithFloorRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid payload"})
+			validation.Respond(c, err)
 			return
 		}
 
 		userId := int(idValue.(float64))
 
-		err = settingsService.UpdateBranchWithFloor(dbConnt, branchId, &payload.BranchWithFloor, payload.Floors, userId)
+		err = settingsService.UpdateBranchWithFloor(actor.Bind(dbConnt, c), branchId, &payload.BranchWithFloor, payload.Floors, userId)
 		if err != nil {
 			log.Error("Failed to update branch: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -1513,29 +1047,23 @@ func UpdateBranchWithFloorController() gin.HandlerFunc {
 	}
 }
 
-func SoftDeleteBranchController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func SoftDeleteBranchController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nSoft Delete Branch Controller invoked")
 
 		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		// Extract branchId from path param
 		paramId := c.Param("id")
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		userId := 0
 		switch v := idValue.(type) {
 		case float64:
@@ -1543,27 +1071,26 @@ func SoftDeleteBranchController() gin.HandlerFunc {
 		case int:
 			userId = v
 		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Invalid user ID type"})
+			apperror.Respond(c, apperror.New(apperror.CodeInternal, "Invalid user ID type"))
 			return
 		}
 
-		err := settingsService.SoftDeleteBranch(dbConnt, paramId, userId)
+		err := settingsService.SoftDeleteBranch(actor.Bind(dbConnt, c), paramId, userId)
 		if err != nil {
 			log.Error("Failed to soft delete branch: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 		log.Info("Branch soft deleted successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch soft deleted successfully", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Branch soft deleted successfully"})
 	}
 }
 
 // ATTRIBUTES
-func GetAttributeDataType() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetAttributeDataType(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetAttributeDataType invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1574,40 +1101,24 @@ func GetAttributeDataType() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		log.Info("📦 Fetching all attributes type from DB")
 		attributes := settingsService.GetAllAttributesService(dbConnt)
 		log.Infof("📊 Attributes fetched: count = %d", len(attributes))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   attributes,
-			"token":  token,
 		})
 	}
 }
 
-func CreateAttributeGroupController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateAttributeGroupController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 Create Attribute Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1618,47 +1129,25 @@ func CreateAttributeGroupController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var attributes model.AttributesTable
 		if err := c.ShouldBindJSON(&attributes); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", attributes)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateAttributesService(dbConnt, &attributes, roleName)
+		err := settingsService.CreateAttributesService(actor.Bind(dbConnt, c), &attributes)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create category"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create category"))
 			}
 			return
 		}
@@ -1669,48 +1158,36 @@ func CreateAttributeGroupController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAttributeGroupController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAttributeGroupController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetAttributeGroupController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		categories := settingsService.GetAttributesService(dbConnt)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   categories,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateAttributeGroupController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateAttributeGroupController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 UpdateCategoryController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1721,73 +1198,48 @@ func UpdateAttributeGroupController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var category model.Category
 		if err := c.ShouldBindJSON(&category); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", category)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("❌ Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("👤 Role Name: %s", roleName)
-		}
-
 		log.Info("🛠️ Calling UpdateCategoryService")
-		errH := settingsService.UpdateCategoryService(dbConnt, &category, roleName)
+		errH := settingsService.UpdateCategoryService(actor.Bind(dbConnt, c), &category)
 		if errH != nil {
 			log.Error("❌ Service error: " + errH.Error())
 			if errH.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update category"})
+				apperror.Respond(c, apperror.Wrap(errH, apperror.CodeInternal, "Failed to update category"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Category updated successfully\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Category updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteAttributeGroupController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type DeleteAttributeGroupRequest struct {
+	CategoryIDs []int `json:"categoryIds"`
+	ForceDelete bool  `json:"forceDelete"`
+}
 
+func DeleteAttributeGroupController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 DeleteAttributeGroupController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1798,84 +1250,43 @@ func DeleteAttributeGroupController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var request struct {
-			CategoryIDs []int `json:"categoryIds"`
-			ForceDelete bool  `json:"forceDelete"`
-		}
+		var request DeleteAttributeGroupRequest
 
 		if err := c.ShouldBindJSON(&request); err != nil || len(request.CategoryIDs) == 0 {
 			log.Error("❌ Invalid request body or empty category IDs")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid category IDs",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid category IDs"))
 			return
 		}
 		log.Infof("📦 Bulk delete request: categoryIds=%v, forceDelete=%v", request.CategoryIDs, request.ForceDelete)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		// Step 1: Check for subcategories
 		log.Info("🔎 Checking for subcategories in selected categories")
 		subcategoriesMap, err := settingsService.CheckSubcategoriesExistence(dbConnt, request.CategoryIDs)
 		if err != nil {
 			log.Error("❌ Error checking subcategories: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Internal server error",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Internal server error"))
 			return
 		}
 
 		if len(subcategoriesMap) > 0 && !request.ForceDelete {
 			log.Warn("⚠️ Some categories have subcategories. Confirmation needed before force delete.")
-			c.JSON(http.StatusConflict, gin.H{
-				"status":             false,
-				"message":            "Some categories contain subcategories. Deleting them will make subcategories idle.",
-				"subcategoriesMap":   subcategoriesMap,
-				"confirmationNeeded": true,
-			})
+			apperror.Respond(c, apperror.Conflict("Some categories contain subcategories. Deleting them will make subcategories idle.").
+				With("subcategoriesMap", subcategoriesMap).
+				With("confirmationNeeded", true))
 			return
 		}
 
 		// Step 2: Perform soft delete
 		log.Info("🛠️ Proceeding to soft delete categories")
 
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.BulkDeleteCategoriesService(dbConnt, request.CategoryIDs, roleName)
+		err = settingsService.BulkDeleteCategoriesService(actor.Bind(dbConnt, c), request.CategoryIDs)
 		if err != nil {
 			log.Error("❌ Service error during bulk delete: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete categories",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete categories"))
 			return
 		}
 
@@ -1883,21 +1294,17 @@ func DeleteAttributeGroupController() gin.HandlerFunc {
 		log.Infof("✅ Categories soft deleted successfully: %v\n\n", request.CategoryIDs)
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Categories deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
 // ATTRIUTES - NEW
-func CreateProductFieldController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateProductFieldController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n🚀 CreateProductFieldController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1908,47 +1315,25 @@ func CreateProductFieldController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var attribute model.ProductFieldDefinition
 		if err := c.ShouldBindJSON(&attribute); err != nil {
 			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", attribute)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = settingsService.CreateProductFieldService(dbConnt, &attribute, roleName)
+		err := settingsService.CreateProductFieldService(actor.Bind(dbConnt, c), &attribute)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create attribute"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create attribute"))
 			}
 			return
 		}
@@ -1959,15 +1344,13 @@ func CreateProductFieldController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Attribute created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllProductFieldsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllProductFieldsController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 GetAllProductFieldsController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -1978,44 +1361,27 @@ func GetAllProductFieldsController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			err := sqlDB.Close()
-			if err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
 		log.Info("📦 Fetching all product fields from DB")
 		attributes := settingsService.GetAllProductFieldsService(dbConnt)
 		log.Infof("📊 Attributes fetched: count = %d", len(attributes))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Sending response with attribute list\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   attributes,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateProductFieldController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateProductFieldController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n📥 UpdateProductFieldController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -2026,236 +1392,184 @@ func UpdateProductFieldController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var attribute model.ProductFieldDefinition
 		if err := c.ShouldBindJSON(&attribute); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 		log.Infof("📦 Request Body: %+v", attribute)
 
-		dbConnt, sqlDB := db.InitDB()
-		defer func() {
-			if err := sqlDB.Close(); err != nil {
-				log.Error("❌ Failed to close DB connection: " + err.Error())
-			} else {
-				log.Info("✅ DB connection closed")
-			}
-		}()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-		if err != nil {
-			log.Error("❌ Failed to get role name: " + err.Error())
-		} else {
-			log.Infof("👤 Role Name: %s", roleName)
-		}
-
 		log.Info("🛠️ Calling UpdateProductFieldService")
-		errH := settingsService.UpdateProductFieldService(dbConnt, &attribute, roleName)
+		errH := settingsService.UpdateProductFieldService(actor.Bind(dbConnt, c), &attribute)
 		if errH != nil {
 			log.Error("❌ Service error: " + errH.Error())
 			if errH.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update attribute"})
+				apperror.Respond(c, apperror.Wrap(errH, apperror.CodeInternal, "Failed to update attribute"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		log.Info("✅ Attribute updated successfully\n\n")
 		log.Info("\n=================================================================\n")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Attribute updated successfully",
-			"token":   token,
 		})
 	}
 }
 
 // ADD NEW EMPLOYEE CONTROLLER
-func GetEmployeeRoleType() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetEmployeeRoleType(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Get Employee Role Type Controller ===> ")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User ID, RoleID, Branch ID not found in request context."})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		roleTypes := settingsService.GetUserRoleTypeService(dbConn)
 
 		if roleTypes == nil || len(roleTypes) == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": "No role types found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "No role types found"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Role types fetched successfully",
 			"roles":   roleTypes,
-			"token":   token,
 		})
 	}
 }
 
-func CreateEmployeeController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func CreateEmployeeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Create Employee Controller")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Missing context info"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing context info"))
 			return
 		}
 
 		var payload model.EmployeePayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid JSON: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		err := settingsService.CreateEmployeeService(dbConn, &payload)
+		err := settingsService.CreateEmployeeService(actor.Bind(dbConn, c), &payload)
 		if err != nil {
 			log.Error("Service error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		log.Info("Employee created successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Employee created", "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Employee created"})
 	}
 }
 
-func GetAllEmployeesController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetAllEmployeesController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Create Employee Controller")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Missing context info"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Missing context info"))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		employees, err := settingsService.GetAllEmployeesService(dbConn)
 		if err != nil {
 			log.Error("Failed to fetch employees: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 		log.Info("Fetched all employees successfully")
-		c.JSON(http.StatusOK, gin.H{"status": true, "data": employees, "token": token})
+		c.JSON(http.StatusOK, gin.H{"status": true, "data": employees})
 	}
 }
 
-func GetEmployeeByIDController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetEmployeeByIDController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		id := c.Param("id")
 		employee, err := settingsService.GetEmployeeByIDService(dbConn, id)
 		if err != nil {
 			log.Error("Failed to fetch employee: " + err.Error())
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, err.Error()))
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"status": true, "data": employee})
 	}
 }
 
-func UpdateEmployeeController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func UpdateEmployeeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		id := c.Param("id")
 		var payload model.EmployeePayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		err := settingsService.UpdateEmployeeService(dbConn, id, &payload)
+		err := settingsService.UpdateEmployeeService(actor.Bind(dbConn, c), id, &payload)
 		if err != nil {
 			log.Error("Failed to update employee: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Employee updated successfully"})
 	}
 }
 
-func DeleteEmployeeController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func DeleteEmployeeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		id := c.Param("id")
-		err := settingsService.SoftDeleteEmployeeService(dbConn, id)
+		err := settingsService.SoftDeleteEmployeeService(actor.Bind(dbConn, c), id)
 		if err != nil {
 			log.Error("Failed to delete employee: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"status": true, "message": "Employee deleted (soft) successfully"})
 	}
 }
 
-func GetEmployeeController() gin.HandlerFunc {
-	log := logger.InitLogger()
+func GetEmployeeController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		idValue, exists := c.Get("id")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized: No ID in token"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized: No ID in token"))
 			return
 		}
 
@@ -2264,7 +1578,7 @@ func GetEmployeeController() gin.HandlerFunc {
 		employee, err := settingsService.GetEmployeeService(dbConn, idStr)
 		if err != nil {
 			log.Error("Failed to fetch employee: " + err.Error())
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, err.Error()))
 			return
 		}
 
@@ -2272,17 +1586,14 @@ func GetEmployeeController() gin.HandlerFunc {
 	}
 }
 
-func UpdateProfileController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateProfileController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		log := logger.FromGin(c)
 
 		// ✅ Get user ID from token (context set by middleware)
 		idValue, exists := c.Get("id")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "Unauthorized"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
@@ -2296,20 +1607,20 @@ func UpdateProfileController() gin.HandlerFunc {
 			id = strconv.Itoa(v)
 		default:
 			log.Error(fmt.Sprintf("Unexpected ID type: %T", v))
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Invalid user ID format"})
+			apperror.Respond(c, apperror.New(apperror.CodeInternal, "Invalid user ID format"))
 			return
 		}
 
 		var payload model.ProfilePayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		err := settingsService.UpdateProfileService(dbConn, id, &payload)
+		err := settingsService.UpdateProfileService(actor.Bind(dbConn, c), id, &payload)
 		if err != nil {
 			log.Error("Failed to update Profile: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -2317,15 +1628,13 @@ func UpdateProfileController() gin.HandlerFunc {
 	}
 }
 
-func GetSettingsOverview() gin.HandlerFunc {
+func GetSettingsOverview(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
 
 		data, err := settingsService.FetchSettingsOverview(dbConn)
 
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": err.Error()})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
@@ -2336,215 +1645,159 @@ func GetSettingsOverview() gin.HandlerFunc {
 	}
 }
 
-func CreateSettingsProductController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateSettingsProductController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nCreate Settings Product Controller Invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var payload model.SettingsProduct
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
-			return
-		}
-
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
+			validation.Respond(c, err)
 			return
 		}
 
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err = settingsService.CreateSettingsProductService(dbConnt, &payload, roleName)
+		err := settingsService.CreateSettingsProductService(actor.Bind(dbConnt, c), &payload)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate product found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate product found"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to create product"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create product"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Product created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllSettingsProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllSettingsProductsController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nGetAllSettingsProductsController Invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID missing",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID missing"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		products := settingsService.GetAllSettingsProductsService(dbConnt)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   products,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateSettingsProductController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateSettingsProductController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\nUpdate Settings Product Controller Invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID missing",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID missing"))
 			return
 		}
 
 		var payload model.SettingsProduct
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.UpdateSettingsProductService(dbConnt, &payload, roleName)
+		err := settingsService.UpdateSettingsProductService(actor.Bind(dbConnt, c), &payload)
 		if err != nil {
 			log.Error("Service Error: " + err.Error())
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate product"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate product"))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Update Failed"})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Update Failed"))
 			}
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Product updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteSettingsProductsController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type DeleteSettingsProductsRequest struct {
+	IDs []int `json:"ids"`
+}
 
+func DeleteSettingsProductsController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("Delete Settings Products Controller Invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User context error",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User context error"))
 			return
 		}
 
 		// FIXED → USE []int
-		var payload struct {
-			IDs []int `json:"ids"`
-		}
+		var payload DeleteSettingsProductsRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil || len(payload.IDs) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid or empty IDs",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid or empty IDs"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.DeleteSettingsProductsService(dbConnt, payload.IDs, roleName)
+		err := settingsService.DeleteSettingsProductsService(actor.Bind(dbConnt, c), payload.IDs)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to delete products"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete products"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Products deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
-func CreateMasterController(table string) gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateMasterController(dbConnt *gorm.DB, table string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
 		log.Infof("\n\n🟢 Create %s Controller Invoked", table)
 
 		// Validate user from JWT middleware
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User context missing"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User context missing"))
 			return
 		}
 
@@ -2552,411 +1805,385 @@ func CreateMasterController(table string) gin.HandlerFunc {
 		var payload model.MasterPayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
 		// DB
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		// Role name
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
 
-		err := settingsService.CreateMasterService(dbConnt, table, payload.Name, roleName)
+		err := settingsService.CreateMasterService(actor.Bind(dbConnt, c), table, payload.Name)
 		if err != nil {
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate value found"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found"))
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Insert failed"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Insert failed"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": fmt.Sprintf("%s created successfully", table),
-			"token":   token,
 		})
 	}
 }
 
 // ---------------------- GET ALL ----------------------
-func GetAllMasterController(table string) gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllMasterController(dbConnt *gorm.DB, table string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
 		log.Infof("\n\n🟣 GetAll %s Controller Invoked", table)
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User context missing"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User context missing"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		data := settingsService.GetAllMasterService(dbConnt, table)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   data,
-			"token":  token,
 		})
 	}
 }
 
 // ---------------------- UPDATE ----------------------
-func UpdateMasterController(table string) gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateMasterController(dbConnt *gorm.DB, table string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
 		log.Infof("\n\n🟡 Update %s Controller Invoked", table)
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User context missing"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User context missing"))
 			return
 		}
 
 		var payload model.MasterUpdatePayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("Invalid body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.UpdateMasterService(dbConnt, table, payload.ID, payload.Name, roleName)
+		err := settingsService.UpdateMasterService(actor.Bind(dbConnt, c), table, payload.ID, payload.Name)
 		if err != nil {
 			if err.Error() == "duplicate value found" {
-				c.JSON(http.StatusConflict, gin.H{"status": false, "message": "Duplicate entry"})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate entry"))
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Update failed"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Update failed"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": fmt.Sprintf("%s updated successfully", table),
-			"token":   token,
 		})
 	}
 }
 
-// ---------------------- DELETE ----------------------
-func DeleteMasterController(table string) gin.HandlerFunc {
-	log := logger.InitLogger()
+type DeleteMasterRequest struct {
+	IDs []int `json:"ids"`
+}
 
+// ---------------------- DELETE ----------------------
+func DeleteMasterController(dbConnt *gorm.DB, table string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 
 		log.Infof("🔴 Delete %s Controller Invoked", table)
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{"status": false, "message": "User context missing"})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User context missing"))
 			return
 		}
 
-		var payload struct {
-			IDs []int `json:"ids"`
-		}
+		var payload DeleteMasterRequest
 
 		if err := c.ShouldBindJSON(&payload); err != nil || len(payload.IDs) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid IDs"})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid IDs"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.DeleteMasterService(dbConnt, table, payload.IDs, roleName)
+		err := settingsService.DeleteMasterService(actor.Bind(dbConnt, c), table, payload.IDs)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Delete failed"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Delete failed"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": fmt.Sprintf("%s deleted successfully", table),
-			"token":   token,
 		})
 	}
 }
 
 // ROUND OFF
-func CreateRoundOffController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateRoundOffController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🚀 CreateRoundOffController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
 		var payload model.RoundOffPayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("❌ Invalid request: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.CreateRoundOffService(dbConnt, payload, roleName)
+		err := settingsService.CreateRoundOffService(actor.Bind(dbConnt, c), payload)
 		if err != nil {
 			log.Error("❌ Service Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		// 🔥 Generate new access token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		log.Info("✅ Round Off Created Successfully")
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Round Off created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllRoundOffController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllRoundOffController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 GetAllRoundOffController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		result := settingsService.GetAllRoundOffService(dbConnt)
 
 		// 🔥 Generate token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		log.Infof("📊 Round Off fetched: %d items", len(result))
 
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   result,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateRoundOffController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateRoundOffController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📥 UpdateRoundOffController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
 		var payload model.RoundOffPayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
 			log.Error("❌ Invalid JSON")
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, _ := roleType.ExtractIntFromInterface(roleIdValue)
-		roleName, _ := roleType.GetRoleTypeNameByID(dbConnt, roleId)
-
-		err := settingsService.UpdateRoundOffService(dbConnt, payload, roleName)
+		err := settingsService.UpdateRoundOffService(actor.Bind(dbConnt, c), payload)
 		if err != nil {
 			log.Error("❌ Update Error: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": err.Error(),
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, err.Error()))
 			return
 		}
 
 		// 🔥 Generate token
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Round Off updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteRoundOffController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func DeleteRoundOffController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🗑 DeleteRoundOffController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
 		roundOffId := c.Param("id")
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := settingsService.DeleteRoundOffService(dbConnt, roundOffId)
 		if err != nil {
 			log.Error("❌ Delete failed: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete"))
 			return
 		}
 
 		// 🔥 Token generation
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Round Off deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
-func BulkDeleteRoundOffController() gin.HandlerFunc {
-	log := logger.InitLogger()
+type BulkDeleteRoundOffRequest struct {
+	Ids []int `json:"ids"`
+}
 
+func BulkDeleteRoundOffController(dbConnt *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🗑 BulkDeleteRoundOffController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "Unauthorized",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Unauthorized"))
 			return
 		}
 
-		var req struct {
-			Ids []int `json:"ids"`
-		}
+		var req BulkDeleteRoundOffRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid request",
-			})
+			validation.Respond(c, err)
 			return
 		}
 
-		dbConnt, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		err := settingsService.BulkDeleteRoundOffService(dbConnt, req.Ids)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Bulk delete failed",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Bulk delete failed"))
 			return
 		}
 
 		// 🔥 Token generation
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
 
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Bulk delete successful",
-			"token":   token,
+		})
+	}
+}
+
+// ROLE PERMISSION CONTROLLERS
+
+func GetPermissionCatalogController() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   permission.Catalog,
+		})
+	}
+}
+
+func GetRolePermissionsController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔐 GetRolePermissionsController invoked")
+
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
+
+		if !idExists || !roleIdExists || !branchIdExists {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
+			return
+		}
+
+		matrix, err := settingsService.GetRolePermissionMatrixService(dbConnt)
+		if err != nil {
+			log.Error("❌ Failed to fetch role permissions: " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch role permissions"))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": true,
+			"data":   matrix,
+		})
+	}
+}
+
+func UpdateRolePermissionsController(dbConnt *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromGin(c)
+		log.Info("🔐 UpdateRolePermissionsController invoked")
+
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
+
+		if !idExists || !roleIdExists || !branchIdExists {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
+			return
+		}
+
+		targetRoleId, err := strconv.Atoi(c.Param("roleId"))
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid role ID"))
+			return
+		}
+
+		var payload model.RolePermissionPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			validation.Respond(c, err)
+			return
+		}
+
+		if err := settingsService.UpdateRolePermissionsService(actor.Bind(dbConnt, c), targetRoleId, &payload); err != nil {
+			log.Error("❌ Failed to update role permissions: " + err.Error())
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status":  true,
+			"message": "Role permissions updated successfully",
 		})
 	}
 }
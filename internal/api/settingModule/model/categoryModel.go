@@ -11,8 +11,8 @@ type TransactionHistory struct {
 
 type InitialCategory struct {
 	InitialCategoryId   int    `json:"initialCategoryId" gorm:"column:initialCategoryId;primaryKey;autoIncrement"`
-	InitialCategoryName string `json:"initialCategoryName" gorm:"column:initialCategoryName"`
-	InitialCategoryCode string `json:"initialCategoryCode" gorm:"column:initialCategoryCode"`
+	InitialCategoryName string `json:"initialCategoryName" gorm:"column:initialCategoryName" binding:"required"`
+	InitialCategoryCode string `json:"initialCategoryCode" gorm:"column:initialCategoryCode" binding:"required"`
 	IsDelete            bool   `json:"isDelete" gorm:"column:isDelete"`
 	CreatedAt           string `json:"createdAt" gorm:"column:createdAt"`
 	CreatedBy           string `json:"createdBy" gorm:"column:createdBy"`
@@ -22,22 +22,22 @@ type InitialCategory struct {
 
 type Category struct {
 	RefCategoryId int    `json:"refCategoryId" gorm:"column:refCategoryid;primaryKey;autoIncrement"`
-	CategoryName  string `json:"categoryName" gorm:"column:categoryName"`
-	CategoryCode  string `json:"categoryCode" gorm:"column:categoryCode"`
+	CategoryName  string `json:"categoryName" gorm:"column:categoryName" binding:"required"`
+	CategoryCode  string `json:"categoryCode" gorm:"column:categoryCode" binding:"required"`
 	IsActive      bool   `json:"isActive" gorm:"column:isActive"`
 	IsDelete      bool   `json:"isDelete" gorm:"column:isDelete"`
 	CreatedAt     string `json:"createdAt" gorm:"column:createdAt"`
 	CreatedBy     string `json:"createdBy" gorm:"column:createdBy"`
 	UpdatedAt     string `json:"updatedAt" gorm:"column:updatedAt"`
 	UpdatedBy     string `json:"updatedBy" gorm:"column:updatedBy"`
-	ProfitMargin  string `json:"profitMargin" gorm:"column:profitMargin"`
+	ProfitMargin  string `json:"profitMargin" gorm:"column:profitMargin" binding:"omitempty,percent"`
 }
 
 type SubCategory struct {
 	RefSubCategoryId int    `json:"refSubCategoryId" gorm:"column:refSubCategoryId;primaryKey;autoIncrement"`
-	SubCategoryName  string `json:"subCategoryName" gorm:"column:subCategoryName"`
-	RefCategoryId    int    `json:"refCategoryId" gorm:"column:refCategoryId"`
-	SubCategoryCode  string `json:"subCategoryCode" gorm:"column:subCategoryCode"`
+	SubCategoryName  string `json:"subCategoryName" gorm:"column:subCategoryName" binding:"required"`
+	RefCategoryId    int    `json:"refCategoryId" gorm:"column:refCategoryId" binding:"required,gt=0"`
+	SubCategoryCode  string `json:"subCategoryCode" gorm:"column:subCategoryCode" binding:"required"`
 	IsActive         bool   `json:"isActive" gorm:"column:isActive"`
 	CreatedAt        string `json:"createdAt" gorm:"column:createdAt"`
 	CreatedBy        string `json:"createdBy" gorm:"column:createdBy"`
@@ -61,11 +61,11 @@ type SubCategoryResponse struct {
 
 type Branch struct {
 	RefBranchId   int    `gorm:"column:refBranchId;primaryKey;autoIncrement" json:"refBranchId"`
-	RefBranchName string `gorm:"column:refBranchName" json:"refBranchName"`
-	RefBranchCode string `gorm:"column:refBranchCode" json:"refBranchCode"`
+	RefBranchName string `gorm:"column:refBranchName" json:"refBranchName" binding:"required"`
+	RefBranchCode string `gorm:"column:refBranchCode" json:"refBranchCode" binding:"required"`
 	RefLocation   string `gorm:"column:refLocation" json:"refLocation"`
-	RefMobile     string `gorm:"column:refMobile" json:"refMobile"`
-	RefEmail      string `gorm:"column:refEmail" json:"refEmail"`
+	RefMobile     string `gorm:"column:refMobile" json:"refMobile" binding:"omitempty,mobile"`
+	RefEmail      string `gorm:"column:refEmail" json:"refEmail" binding:"omitempty,email"`
 	IsMainBranch  bool   `gorm:"column:isMainBranch" json:"isMainBranch"`
 	IsActive      bool   `gorm:"column:isActive" json:"isActive"`
 	RefBTId       int    `gorm:"column:refBTId" json:"refBTId"`
@@ -78,11 +78,11 @@ type Branch struct {
 
 type BranchWithFloor struct {
 	RefBranchId      int    `gorm:"column:refBranchId;primaryKey;autoIncrement"`
-	RefBranchName    string `gorm:"column:refBranchName" json:"refBranchName"`
-	RefBranchCode    string `gorm:"column:refBranchCode" json:"refBranchCode"`
+	RefBranchName    string `gorm:"column:refBranchName" json:"refBranchName" binding:"required"`
+	RefBranchCode    string `gorm:"column:refBranchCode" json:"refBranchCode" binding:"required"`
 	RefLocation      string `gorm:"column:refLocation" json:"refLocation"`
-	RefMobile        string `gorm:"column:refMobile" json:"refMobile"`
-	RefEmail         string `gorm:"column:refEmail" json:"refEmail"`
+	RefMobile        string `gorm:"column:refMobile" json:"refMobile" binding:"omitempty,mobile"`
+	RefEmail         string `gorm:"column:refEmail" json:"refEmail" binding:"omitempty,email"`
 	IsMainBranch     bool   `gorm:"column:isMainBranch" json:"isMainBranch"`
 	IsActive         bool   `gorm:"column:isActive" json:"isActive"`
 	RefBTId          int    `gorm:"column:refBTId" json:"refBTId"`
@@ -97,7 +97,7 @@ type BranchWithFloor struct {
 	RefBranchStreet  string `gorm:"column:refBranchStreet" json:"refBranchStreet"`
 	RefBranchCity    string `gorm:"column:refBranchCity" json:"refBranchCity"`
 	RefBranchState   string `gorm:"column:refBranchState" json:"refBranchState"`
-	RefBranchPincode string `gorm:"column:refBranchPincode" json:"refBranchPincode"`
+	RefBranchPincode string `gorm:"column:refBranchPincode" json:"refBranchPincode" binding:"omitempty,pincode"`
 }
 
 type Floors struct {
@@ -190,7 +190,6 @@ type User struct {
 type UserAuth struct {
 	RefUACId             int    `gorm:"primaryKey;autoIncrement;column:refUACId"`
 	RefUserId            int    `gorm:"column:refUserId"`
-	RefUACPassword       string `gorm:"column:refUACPassword"`
 	RefUACHashedPassword string `gorm:"column:refUACHashedPassword"`
 	RefUACUsername       string `gorm:"column:refUACUsername"`
 	CreatedAt            string `gorm:"column:createdAt"`
@@ -216,15 +215,15 @@ type UserCommunication struct {
 
 type EmployeePayload struct {
 	RefUserId       int    `json:"refUserId"`
-	FirstName       string `json:"firstName"`
+	FirstName       string `json:"firstName" binding:"required"`
 	LastName        string `json:"lastName"`
 	Designation     string `json:"designation"`
-	RoleTypeId      int    `json:"roleTypeId"`
+	RoleTypeId      int    `json:"roleTypeId" binding:"required,gt=0"`
 	RefUserStatus   bool   `json:"refUserStatus"`
-	RefUserBranchId int    `json:"refUserBranchId" gorm:"column:refUserBranchId"`
+	RefUserBranchId int    `json:"refUserBranchId" gorm:"column:refUserBranchId" binding:"gte=0"`
 	Username        string `json:"username"`
-	Mobile          string `json:"mobile"`
-	Email           string `json:"email"`
+	Mobile          string `json:"mobile" binding:"omitempty,mobile"`
+	Email           string `json:"email" binding:"omitempty,email"`
 	DoorNumber      string `json:"doorNumber"`
 	StreetName      string `json:"streetName"`
 	City            string `json:"city"`
@@ -233,14 +232,14 @@ type EmployeePayload struct {
 
 type ProfilePayload struct {
 	RefUserId       int    `json:"refUserId"`
-	FirstName       string `json:"firstName"`
+	FirstName       string `json:"firstName" binding:"required"`
 	LastName        string `json:"lastName"`
 	Designation     string `json:"designation"`
 	RoleTypeId      int    `json:"roleTypeId"`
 	RefUserBranchId int    `gorm:"column:refUserBranchId"`
 	Username        string `json:"username"`
-	Mobile          string `json:"mobile"`
-	Email           string `json:"email"`
+	Mobile          string `json:"mobile" binding:"omitempty,mobile"`
+	Email           string `json:"email" binding:"omitempty,email"`
 	DoorNumber      string `json:"doorNumber"`
 	StreetName      string `json:"streetName"`
 	City            string `json:"city"`
@@ -317,8 +316,8 @@ type SettingsOverview struct {
 type ProductFieldDefinition struct {
 	ID          int     `json:"id" gorm:"primaryKey;autoIncrement"`
 	ColumnName  *string `json:"column_name" gorm:"column:column_name"`
-	ColumnLabel string  `json:"column_label" gorm:"column:column_label"`
-	DataType    string  `json:"data_type" gorm:"column:data_type"`
+	ColumnLabel string  `json:"column_label" gorm:"column:column_label" binding:"required"`
+	DataType    string  `json:"data_type" gorm:"column:data_type" binding:"required"`
 	Type        string  `json:"type" gorm:"column:type"`
 	IsRequired  bool    `json:"is_required" gorm:"column:is_required"`
 	CreatedAt   string  `json:"createdAt" gorm:"column:createdAt"`
@@ -334,11 +333,11 @@ func (ProductFieldDefinition) TableName() string {
 
 type SettingsProduct struct {
 	Id            int    `json:"id" gorm:"column:id;primaryKey"`
-	CategoryId    int    `json:"categoryId" gorm:"column:categoryId"`
-	SubCategoryId int    `json:"subCategoryId" gorm:"column:subCategoryId"`
-	ProductName   string `json:"productName" gorm:"column:productName"`
+	CategoryId    int    `json:"categoryId" gorm:"column:categoryId" binding:"required,gt=0"`
+	SubCategoryId int    `json:"subCategoryId" gorm:"column:subCategoryId" binding:"gte=0"`
+	ProductName   string `json:"productName" gorm:"column:productName" binding:"required"`
 	HsnCode       string `json:"hsn" gorm:"column:hsnCode"`
-	TaxPercentage string `json:"tax" gorm:"column:taxPercentage"`
+	TaxPercentage string `json:"tax" gorm:"column:taxPercentage" binding:"omitempty,percent"`
 	ProductCode   string `json:"productCode" gorm:"column:productCode"`
 	CreatedAt     string `json:"createdAt" gorm:"column:createdAt"`
 	CreatedBy     string `json:"createdBy" gorm:"column:createdBy"`
@@ -404,7 +403,17 @@ type RoundOffPrice struct {
 
 type RoundOffPayload struct {
 	Id        *int   `json:"id"`
-	FromRange string `json:"fromRange"`
-	ToRange   string `json:"toRange"`
-	Prices    []int  `json:"prices"`
+	FromRange string `json:"fromRange" binding:"required,number"`
+	ToRange   string `json:"toRange" binding:"required,number"`
+	Prices    []int  `json:"prices" binding:"dive,gte=0"`
+}
+
+type RolePermissionPayload struct {
+	Permissions []string `json:"permissions" binding:"dive,required"`
+}
+
+type RolePermissionResponse struct {
+	RefRTId     int      `json:"refRTId"`
+	RefRTName   string   `json:"refRTName"`
+	Permissions []string `json:"permissions"`
 }
@@ -0,0 +1,44 @@
+package model
+
+import (
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
+)
+
+// NumberSeriesPayload configures the numbering of a document type. BranchId 0 sets the
+// default of every branch, any other id overrides it for that branch.
+type NumberSeriesPayload struct {
+	DocType   string `json:"docType" binding:"required"`
+	BranchId  int    `json:"branchId" binding:"gte=0"`
+	Format    string `json:"format" binding:"required"`
+	StartAt   int64  `json:"startAt" binding:"omitempty,gte=1"`
+	PerBranch bool   `json:"perBranch"`
+}
+
+type NumberSeriesOverview struct {
+	Types    []numberseries.Type    `json:"types"`
+	Series   []numberseries.Series  `json:"series"`
+	Counters []numberseries.Counter `json:"counters"`
+}
+
+type NumberSeriesGapQuery struct {
+	DocType    string `form:"docType" binding:"required"`
+	BranchId   *int   `form:"branchId"`
+	FiscalYear int    `form:"fiscalYear"`
+}
+
+// NumberSeriesGap is a number the series skipped (notIssued) or handed out without a
+// document keeping it (unused).
+type NumberSeriesGap struct {
+	Seq    int64  `json:"seq" gorm:"column:seq"`
+	Number string `json:"number" gorm:"column:number"`
+	Reason string `json:"reason" gorm:"column:reason"`
+}
+
+type NumberSeriesGapReport struct {
+	DocType    string            `json:"docType"`
+	BranchId   int               `json:"branchId"`
+	FiscalYear int               `json:"fiscalYear"`
+	LastValue  int64             `json:"lastValue"`
+	Issued     int64             `json:"issued"`
+	Gaps       []NumberSeriesGap `json:"gaps"`
+}
@@ -0,0 +1,93 @@
+package settingsRoutes
+
+import (
+	settingsController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/controller"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/model"
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/settings/initialCategoryCode":     {Summary: "Check initial category code", Request: settingsController.CodeRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/initialCategories":       {Summary: "Create initial category", Request: model.InitialCategory{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/initialCategories":        {Summary: "Get all initial category", Response: []model.InitialCategory{}},
+	"PUT /api/v1/admin/settings/initialCategories":        {Summary: "Update initial category", Request: model.InitialCategory{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/initialCategories":     {Summary: "Delete initial category", Request: settingsController.DeleteInitialCategoriesRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/categories":              {Summary: "Create category", Request: model.Category{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/categories":               {Summary: "Get all categories", Response: []model.Category{}},
+	"PUT /api/v1/admin/settings/categories":               {Summary: "Update category", Request: model.Category{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/categories/:id":        {Summary: "Delete category", Query: []string{"forceDelete"}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/categories":            {Summary: "Bulk delete category", Request: settingsController.BulkDeleteCategoryRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/subcategories":           {Summary: "Create sub category", Request: model.SubCategory{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/subcategories":            {Summary: "Get all sub categories", Response: []model.SubCategoryResponse{}},
+	"PUT /api/v1/admin/settings/subcategories":            {Summary: "Update sub category", Request: model.SubCategory{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/subcategories/:id":     {Summary: "Delete sub category", Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/subcategories":         {Summary: "Bulk delete sub category", Request: settingsController.BulkDeleteSubCategoryRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/branches":                {Summary: "Create branch", Request: model.Branch{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/branches":                 {Summary: "Get all branches", Response: []model.Branch{}},
+	"PUT /api/v1/admin/settings/branches":                 {Summary: "Update branch", Request: model.Branch{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/branches/:id":          {Summary: "Delete branch", Permission: permission.SettingsManage},
+	"POST /api/v2/admin/settings/branches":                {Summary: "Create new branch with floor", Request: settingsController.CreateBranchWithFloorRequest{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v2/admin/settings/branches":                 {Summary: "Get new branch with floor"},
+	"GET /api/v2/admin/settings/branches/:id":             {Summary: "Get new branch with floor with id", Response: []model.BranchResponse{}},
+	"PUT /api/v2/admin/settings/branches/:id":             {Summary: "Update branch with floor", Request: settingsController.UpdateBranchWithFloorRequest{}, Permission: permission.SettingsManage},
+	"DELETE /api/v2/admin/settings/branches/:id":          {Summary: "Soft delete branch", Permission: permission.SettingsManage},
+	"GET /api/v1/admin/settings/permissions":              {Summary: "Get permission catalog"},
+	"GET /api/v1/admin/settings/role-permissions":         {Summary: "Get role permissions", Response: []model.RolePermissionResponse{}, Permission: permission.RolesManage},
+	"PUT /api/v1/admin/settings/role-permissions/:roleId": {Summary: "Update role permissions", Request: model.RolePermissionPayload{}, Permission: permission.RolesManage},
+	"GET /api/v1/admin/settings/attributesDataType":       {Summary: "Get attribute data type", Response: []model.AttributeGroupTable{}},
+	"POST /api/v1/admin/settings/attributes":              {Summary: "Create attribute group", Request: model.AttributesTable{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/attributes":               {Summary: "Get attribute group", Response: []model.AttributeWithGroup{}},
+	"PUT /api/v1/admin/settings/attributes":               {Summary: "Update attribute group", Request: model.Category{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/attributesHide":          {Summary: "Delete attribute group", Request: settingsController.DeleteAttributeGroupRequest{}, Permission: permission.SettingsManage, Idempotent: true},
+	"POST /api/v1/admin/settings/product-fields":          {Summary: "Create product field", Request: model.ProductFieldDefinition{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/product-fields":           {Summary: "Get all product fields", Response: []model.ProductFieldDefinition{}},
+	"PUT /api/v1/admin/settings/product-fields":           {Summary: "Update product field", Request: model.ProductFieldDefinition{}, Permission: permission.SettingsManage},
+	"GET /api/v1/admin/settings/employeeRoleType":         {Summary: "Get employee role type"},
+	"POST /api/v1/admin/settings/employees":               {Summary: "Create employee", Request: model.EmployeePayload{}, Permission: permission.EmployeesManage, Idempotent: true},
+	"GET /api/v1/admin/settings/employees":                {Summary: "Get all employees", Response: []model.EmployeeResponse{}, Permission: permission.EmployeesManage},
+	"GET /api/v1/admin/settings/employees/:id":            {Summary: "Get employee by ID", Response: model.EmployeeResponse{}, Permission: permission.EmployeesManage},
+	"PUT /api/v1/admin/settings/employees/:id":            {Summary: "Update employee", Request: model.EmployeePayload{}, Permission: permission.EmployeesManage},
+	"DELETE /api/v1/admin/settings/employees/:id":         {Summary: "Delete employee", Permission: permission.EmployeesManage},
+	"GET /api/v1/admin/settings/getEmployees":             {Summary: "Get employee", Response: model.EmployeeResponse{}},
+	"PUT /api/v1/admin/settings/updateEmployeeProfile":    {Summary: "Update profile", Request: model.ProfilePayload{}},
+	"GET /api/v1/admin/settings/overview":                 {Summary: "Get settings overview", Response: model.SettingsOverview{}},
+	"POST /api/v1/admin/settings/settingsProducts":        {Summary: "Create settings product", Request: model.SettingsProduct{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/settingsProducts":         {Summary: "Get all settings products", Response: []model.SettingsProductResponse{}},
+	"PUT /api/v1/admin/settings/settingsProducts":         {Summary: "Update settings product", Request: model.SettingsProduct{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/settingsProducts":      {Summary: "Delete settings products", Request: settingsController.DeleteSettingsProductsRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/design":                  {Summary: "Create design", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/design":                   {Summary: "Get all designs"},
+	"PUT /api/v1/admin/settings/design":                   {Summary: "Update design", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/design":                {Summary: "Delete design", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/color":                   {Summary: "Create color", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/color":                    {Summary: "Get all colors"},
+	"PUT /api/v1/admin/settings/color":                    {Summary: "Update color", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/color":                 {Summary: "Delete color", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/brand":                   {Summary: "Create brand", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/brand":                    {Summary: "Get all brands"},
+	"PUT /api/v1/admin/settings/brand":                    {Summary: "Update brand", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/brand":                 {Summary: "Delete brand", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/size":                    {Summary: "Create size", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/size":                     {Summary: "Get all sizes"},
+	"PUT /api/v1/admin/settings/size":                     {Summary: "Update size", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/size":                  {Summary: "Delete size", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/varient":                 {Summary: "Create varient", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/varient":                  {Summary: "Get all varients"},
+	"PUT /api/v1/admin/settings/varient":                  {Summary: "Update varient", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/varient":               {Summary: "Delete varient", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/patterns":                {Summary: "Create patterns", Request: model.MasterPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/patterns":                 {Summary: "Get all patterns"},
+	"PUT /api/v1/admin/settings/patterns":                 {Summary: "Update patterns", Request: model.MasterUpdatePayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/patterns":              {Summary: "Delete patterns", Request: settingsController.DeleteMasterRequest{}, Permission: permission.SettingsManage},
+	"POST /api/v1/admin/settings/round-off":               {Summary: "Create round off", Request: model.RoundOffPayload{}, Permission: permission.SettingsManage, Idempotent: true},
+	"GET /api/v1/admin/settings/round-off":                {Summary: "Get all round off"},
+	"PUT /api/v1/admin/settings/round-off":                {Summary: "Update round off", Request: model.RoundOffPayload{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/round-off/:id":         {Summary: "Delete round off", Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/round-off":             {Summary: "Bulk delete round off", Request: settingsController.BulkDeleteRoundOffRequest{}, Permission: permission.SettingsManage},
+	"GET /api/v1/admin/settings/number-series":            {Summary: "Get number series", Description: "Numbered document types with their built-in formats, the configured series and the counter of each fiscal year.", Response: model.NumberSeriesOverview{}, Permission: permission.SettingsManage},
+	"PUT /api/v1/admin/settings/number-series":            {Summary: "Save number series", Description: "Sets the format of a document type for one branch, or for all with branchId 0. Tokens: {FY}, {FYSTART}, {YYYY}, {YY}, {MM}, {BRANCH}, {SEQ} or {SEQ:n}; counters restart each April.", Request: model.NumberSeriesPayload{}, Response: numberseries.Series{}, Permission: permission.SettingsManage},
+	"DELETE /api/v1/admin/settings/number-series/:id":     {Summary: "Delete number series", Permission: permission.SettingsManage},
+	"GET /api/v1/admin/settings/number-series/gaps":       {Summary: "Get number series gaps", Description: "Numbers a series skipped or issued without a document keeping them.", QueryParams: model.NumberSeriesGapQuery{}, Response: []model.NumberSeriesGapReport{}, Permission: permission.AuditView},
+}
@@ -3,125 +3,137 @@ package settingsRoutes
 import (
 	settingsController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func SettingsAdminRoutes(router *gin.Engine) {
+func SettingsAdminRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/settings")
 	routev2 := router.Group("/api/v2/admin/settings")
 
-	route.POST("/initialCategoryCode", accesstoken.JWTMiddleware(), settingsController.CheckInitialCategoryCodeController())
+	route.POST("/initialCategoryCode", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.CheckInitialCategoryCodeController(dbConn))
 
 	// INITIAL ROUTES
-	route.POST("/initialCategories", accesstoken.JWTMiddleware(), settingsController.CreateInitialCategoryController())
-	route.GET("/initialCategories", accesstoken.JWTMiddleware(), settingsController.GetAllInitialCategoryController())
-	route.PUT("/initialCategories", accesstoken.JWTMiddleware(), settingsController.UpdateInitialCategoryController())
-	route.DELETE("/initialCategories", accesstoken.JWTMiddleware(), settingsController.DeleteInitialCategoryController())
+	route.POST("/initialCategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateInitialCategoryController(dbConn))
+	route.GET("/initialCategories", accesstoken.JWTMiddleware(), settingsController.GetAllInitialCategoryController(dbConn))
+	route.PUT("/initialCategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateInitialCategoryController(dbConn))
+	route.DELETE("/initialCategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteInitialCategoryController(dbConn))
 
 	// CATEGORIES ROUTES
-	route.POST("/categories", accesstoken.JWTMiddleware(), settingsController.CreateCategoryController())
-	route.GET("/categories", accesstoken.JWTMiddleware(), settingsController.GetAllCategoriesController())
-	route.PUT("/categories", accesstoken.JWTMiddleware(), settingsController.UpdateCategoryController())
-	route.DELETE("/categories/:id", accesstoken.JWTMiddleware(), settingsController.DeleteCategoryController())
-	route.DELETE("/categories", accesstoken.JWTMiddleware(), settingsController.BulkDeleteCategoryController())
+	route.POST("/categories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateCategoryController(dbConn))
+	route.GET("/categories", accesstoken.JWTMiddleware(), settingsController.GetAllCategoriesController(dbConn))
+	route.PUT("/categories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateCategoryController(dbConn))
+	route.DELETE("/categories/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteCategoryController(dbConn))
+	route.DELETE("/categories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.BulkDeleteCategoryController(dbConn))
 
 	// SUB CATEGORIES ROUTES
-	route.POST("/subcategories", accesstoken.JWTMiddleware(), settingsController.CreateSubCategoryController())
-	route.GET("/subcategories", accesstoken.JWTMiddleware(), settingsController.GetAllSubCategoriesController())
-	route.PUT("/subcategories", accesstoken.JWTMiddleware(), settingsController.UpdateSubCategoryController())
-	route.DELETE("/subcategories/:id", accesstoken.JWTMiddleware(), settingsController.DeleteSubCategoryController())
-	route.DELETE("/subcategories", accesstoken.JWTMiddleware(), settingsController.BulkDeleteSubCategoryController())
+	route.POST("/subcategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateSubCategoryController(dbConn))
+	route.GET("/subcategories", accesstoken.JWTMiddleware(), settingsController.GetAllSubCategoriesController(dbConn))
+	route.PUT("/subcategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateSubCategoryController(dbConn))
+	route.DELETE("/subcategories/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteSubCategoryController(dbConn))
+	route.DELETE("/subcategories", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.BulkDeleteSubCategoryController(dbConn))
 
 	// BRANCHES ROUTES
-	route.POST("/branches", accesstoken.JWTMiddleware(), settingsController.CreateBranchController())
-	route.GET("/branches", accesstoken.JWTMiddleware(), settingsController.GetAllBranchesController())
-	route.PUT("/branches", accesstoken.JWTMiddleware(), settingsController.UpdateBranchController())
-	route.DELETE("/branches/:id", accesstoken.JWTMiddleware(), settingsController.DeleteBranchController())
+	route.POST("/branches", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateBranchController(dbConn))
+	route.GET("/branches", accesstoken.JWTMiddleware(), settingsController.GetAllBranchesController(dbConn))
+	route.PUT("/branches", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateBranchController(dbConn))
+	route.DELETE("/branches/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteBranchController(dbConn))
 
 	// BRANCH WITH FLOOR ROUTES
-	routev2.POST("/branches", accesstoken.JWTMiddleware(), settingsController.CreateNewBranchWithFloorController())
-	routev2.GET("/branches", accesstoken.JWTMiddleware(), settingsController.GetNewBranchWithFloorController())
-	routev2.GET("/branches/:id", accesstoken.JWTMiddleware(), settingsController.GetNewBranchWithFloorWithIdController())
-	routev2.PUT("/branches/:id", accesstoken.JWTMiddleware(), settingsController.UpdateBranchWithFloorController())
-	routev2.DELETE("/branches/:id", accesstoken.JWTMiddleware(), settingsController.SoftDeleteBranchController())
+	routev2.POST("/branches", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateNewBranchWithFloorController(dbConn))
+	routev2.GET("/branches", accesstoken.JWTMiddleware(), settingsController.GetNewBranchWithFloorController(dbConn))
+	routev2.GET("/branches/:id", accesstoken.JWTMiddleware(), settingsController.GetNewBranchWithFloorWithIdController(dbConn))
+	routev2.PUT("/branches/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateBranchWithFloorController(dbConn))
+	routev2.DELETE("/branches/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.SoftDeleteBranchController(dbConn))
 
 	// USER ROLES
+	route.GET("/permissions", accesstoken.JWTMiddleware(), settingsController.GetPermissionCatalogController())
+	route.GET("/role-permissions", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.RolesManage), settingsController.GetRolePermissionsController(dbConn))
+	route.PUT("/role-permissions/:roleId", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.RolesManage), settingsController.UpdateRolePermissionsController(dbConn))
 
 	// ATTRIBUTES
-	// route.POST("/attributes", accesstoken.JWTMiddleware(), settingsController.CreateAttributeGroupController())
-	route.GET("/attributesDataType", accesstoken.JWTMiddleware(), settingsController.GetAttributeDataType())
-	route.POST("/attributes", accesstoken.JWTMiddleware(), settingsController.CreateAttributeGroupController())
-	route.GET("/attributes", accesstoken.JWTMiddleware(), settingsController.GetAttributeGroupController())
-	route.PUT("/attributes", accesstoken.JWTMiddleware(), settingsController.UpdateAttributeGroupController())
-	route.POST("/attributesHide", accesstoken.JWTMiddleware(), settingsController.DeleteAttributeGroupController())
+	// route.POST("/attributes", accesstoken.JWTMiddleware(), settingsController.CreateAttributeGroupController(dbConn))
+	route.GET("/attributesDataType", accesstoken.JWTMiddleware(), settingsController.GetAttributeDataType(dbConn))
+	route.POST("/attributes", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateAttributeGroupController(dbConn))
+	route.GET("/attributes", accesstoken.JWTMiddleware(), settingsController.GetAttributeGroupController(dbConn))
+	route.PUT("/attributes", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateAttributeGroupController(dbConn))
+	route.POST("/attributesHide", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.DeleteAttributeGroupController(dbConn))
 
-	routev2.POST("/attributes")
-
-	route.POST("/product-fields", accesstoken.JWTMiddleware(), settingsController.CreateProductFieldController())
-	route.GET("/product-fields", accesstoken.JWTMiddleware(), settingsController.GetAllProductFieldsController())
-	route.PUT("/product-fields", accesstoken.JWTMiddleware(), settingsController.UpdateProductFieldController())
+	route.POST("/product-fields", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateProductFieldController(dbConn))
+	route.GET("/product-fields", accesstoken.JWTMiddleware(), settingsController.GetAllProductFieldsController(dbConn))
+	route.PUT("/product-fields", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateProductFieldController(dbConn))
 
 	// EMPLOYEES ROUTES
-	route.GET("/employeeRoleType", accesstoken.JWTMiddleware(), settingsController.GetEmployeeRoleType())
-	route.POST("/employees", accesstoken.JWTMiddleware(), settingsController.CreateEmployeeController())
-	route.GET("/employees", accesstoken.JWTMiddleware(), settingsController.GetAllEmployeesController())
-	route.GET("/employees/:id", accesstoken.JWTMiddleware(), settingsController.GetEmployeeByIDController())
-	route.PUT("/employees/:id", accesstoken.JWTMiddleware(), settingsController.UpdateEmployeeController())
-	route.DELETE("/employees/:id", accesstoken.JWTMiddleware(), settingsController.DeleteEmployeeController())
-	route.GET("/getEmployees", accesstoken.JWTMiddleware(), settingsController.GetEmployeeController())
-	route.PUT("/updateEmployeeProfile", accesstoken.JWTMiddleware(), settingsController.UpdateProfileController())
+	route.GET("/employeeRoleType", accesstoken.JWTMiddleware(), settingsController.GetEmployeeRoleType(dbConn))
+	route.POST("/employees", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), idempotency.Middleware(), settingsController.CreateEmployeeController(dbConn))
+	route.GET("/employees", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), settingsController.GetAllEmployeesController(dbConn))
+	route.GET("/employees/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), settingsController.GetEmployeeByIDController(dbConn))
+	route.PUT("/employees/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), settingsController.UpdateEmployeeController(dbConn))
+	route.DELETE("/employees/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.EmployeesManage), settingsController.DeleteEmployeeController(dbConn))
+	route.GET("/getEmployees", accesstoken.JWTMiddleware(), settingsController.GetEmployeeController(dbConn))
+	route.PUT("/updateEmployeeProfile", accesstoken.JWTMiddleware(), settingsController.UpdateProfileController(dbConn))
 
 	// overView
-	route.GET("/overview", accesstoken.JWTMiddleware(), settingsController.GetSettingsOverview())
+	route.GET("/overview", accesstoken.JWTMiddleware(), settingsController.GetSettingsOverview(dbConn))
 
 	// SETTINGS PRODUCTS ROUTES
-	route.POST("/settingsProducts", accesstoken.JWTMiddleware(), settingsController.CreateSettingsProductController())
-	route.GET("/settingsProducts", accesstoken.JWTMiddleware(), settingsController.GetAllSettingsProductsController())
-	route.PUT("/settingsProducts", accesstoken.JWTMiddleware(), settingsController.UpdateSettingsProductController())
-	route.DELETE("/settingsProducts", accesstoken.JWTMiddleware(), settingsController.DeleteSettingsProductsController())
+	route.POST("/settingsProducts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateSettingsProductController(dbConn))
+	route.GET("/settingsProducts", accesstoken.JWTMiddleware(), settingsController.GetAllSettingsProductsController(dbConn))
+	route.PUT("/settingsProducts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateSettingsProductController(dbConn))
+	route.DELETE("/settingsProducts", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteSettingsProductsController(dbConn))
 
 	// DESIGN
-	route.POST("/design", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("design"))
-	route.GET("/design", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("design"))
-	route.PUT("/design", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("design"))
-	route.DELETE("/design", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("design"))
+	route.POST("/design", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "design"))
+	route.GET("/design", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "design"))
+	route.PUT("/design", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "design"))
+	route.DELETE("/design", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "design"))
 
 	// COLOR
-	route.POST("/color", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("color"))
-	route.GET("/color", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("color"))
-	route.PUT("/color", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("color"))
-	route.DELETE("/color", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("color"))
+	route.POST("/color", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "color"))
+	route.GET("/color", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "color"))
+	route.PUT("/color", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "color"))
+	route.DELETE("/color", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "color"))
 
 	// BRAND
-	route.POST("/brand", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("brand"))
-	route.GET("/brand", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("brand"))
-	route.PUT("/brand", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("brand"))
-	route.DELETE("/brand", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("brand"))
+	route.POST("/brand", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "brand"))
+	route.GET("/brand", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "brand"))
+	route.PUT("/brand", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "brand"))
+	route.DELETE("/brand", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "brand"))
 
 	// SIZE
-	route.POST("/size", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("size"))
-	route.GET("/size", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("size"))
-	route.PUT("/size", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("size"))
-	route.DELETE("/size", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("size"))
+	route.POST("/size", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "size"))
+	route.GET("/size", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "size"))
+	route.PUT("/size", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "size"))
+	route.DELETE("/size", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "size"))
 
 	// VARIENT
-	route.POST("/varient", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("Varient"))
-	route.GET("/varient", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("Varient"))
-	route.PUT("/varient", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("Varient"))
-	route.DELETE("/varient", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("Varient"))
+	route.POST("/varient", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "Varient"))
+	route.GET("/varient", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "Varient"))
+	route.PUT("/varient", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "Varient"))
+	route.DELETE("/varient", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "Varient"))
 
 	// PATTERNS
-	route.POST("/patterns", accesstoken.JWTMiddleware(), settingsController.CreateMasterController("Patterns"))
-	route.GET("/patterns", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController("Patterns"))
-	route.PUT("/patterns", accesstoken.JWTMiddleware(), settingsController.UpdateMasterController("Patterns"))
-	route.DELETE("/patterns", accesstoken.JWTMiddleware(), settingsController.DeleteMasterController("Patterns"))
+	route.POST("/patterns", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateMasterController(dbConn, "Patterns"))
+	route.GET("/patterns", accesstoken.JWTMiddleware(), settingsController.GetAllMasterController(dbConn, "Patterns"))
+	route.PUT("/patterns", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateMasterController(dbConn, "Patterns"))
+	route.DELETE("/patterns", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteMasterController(dbConn, "Patterns"))
 
 	// ROUND OFF ROUTES
-	route.POST("/round-off", accesstoken.JWTMiddleware(), settingsController.CreateRoundOffController())
-	route.GET("/round-off", accesstoken.JWTMiddleware(), settingsController.GetAllRoundOffController())
-	route.PUT("/round-off", accesstoken.JWTMiddleware(), settingsController.UpdateRoundOffController())
-	route.DELETE("/round-off/:id", accesstoken.JWTMiddleware(), settingsController.DeleteRoundOffController())
-	route.DELETE("/round-off", accesstoken.JWTMiddleware(), settingsController.BulkDeleteRoundOffController())
-
+	route.POST("/round-off", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), idempotency.Middleware(), settingsController.CreateRoundOffController(dbConn))
+	route.GET("/round-off", accesstoken.JWTMiddleware(), settingsController.GetAllRoundOffController(dbConn))
+	route.PUT("/round-off", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.UpdateRoundOffController(dbConn))
+	route.DELETE("/round-off/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteRoundOffController(dbConn))
+	route.DELETE("/round-off", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.BulkDeleteRoundOffController(dbConn))
+
+	// NUMBER SERIES
+	route.GET("/number-series", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.GetNumberSeriesController(dbConn))
+	route.PUT("/number-series", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.SaveNumberSeriesController(dbConn))
+	route.DELETE("/number-series/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SettingsManage), settingsController.DeleteNumberSeriesController(dbConn))
+	route.GET("/number-series/gaps", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.AuditView), settingsController.GetNumberSeriesGapsController(dbConn))
+
+	openapi.Describe("Settings", docs)
 }
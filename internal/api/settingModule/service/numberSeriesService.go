@@ -0,0 +1,191 @@
+package settingsService
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	numberseries "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/NumberSeries"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxGapsPerCounter keeps the gap report of a badly broken series readable.
+const maxGapsPerCounter = 1000
+
+func GetNumberSeriesService(db *gorm.DB) (model.NumberSeriesOverview, error) {
+	overview := model.NumberSeriesOverview{
+		Types:    numberseries.Types,
+		Series:   []numberseries.Series{},
+		Counters: []numberseries.Counter{},
+	}
+	if err := db.Order(`"refNSDocType", "refNSBranchId"`).Find(&overview.Series).Error; err != nil {
+		return overview, fmt.Errorf("failed to fetch number series: %w", err)
+	}
+	err := db.Order(`"refNSCDocType", "refNSCFiscalYear" DESC, "refNSCBranchId"`).Find(&overview.Counters).Error
+	if err != nil {
+		return overview, fmt.Errorf("failed to fetch number series counters: %w", err)
+	}
+	return overview, nil
+}
+
+// SaveNumberSeriesService creates or replaces the series of a document type and branch.
+// Numbers already issued keep their format; the new one applies from the next number.
+func SaveNumberSeriesService(db *gorm.DB, payload *model.NumberSeriesPayload) (numberseries.Series, error) {
+	log := logger.FromDB(db)
+	author := actor.FromDB(db).By()
+
+	if _, ok := numberseries.TypeOf(payload.DocType); !ok {
+		return numberseries.Series{}, apperror.BadRequest("Unknown document type " + payload.DocType)
+	}
+	if payload.StartAt == 0 {
+		payload.StartAt = 1
+	}
+	series := numberseries.Series{
+		RefNSDocType:   payload.DocType,
+		RefNSBranchId:  payload.BranchId,
+		RefNSFormat:    payload.Format,
+		RefNSStartAt:   payload.StartAt,
+		RefNSPerBranch: payload.PerBranch,
+	}
+	if err := numberseries.Validate(series.RefNSFormat, series.BranchKeyed()); err != nil {
+		return numberseries.Series{}, err
+	}
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var before numberseries.Series
+		err := tx.Where(`"refNSDocType" = ? AND "refNSBranchId" = ?`, series.RefNSDocType, series.RefNSBranchId).
+			First(&before).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			series.CreatedAt, series.CreatedBy = now, author
+		case err != nil:
+			return err
+		default:
+			series.RefNSId = before.RefNSId
+			series.CreatedAt, series.CreatedBy = before.CreatedAt, before.CreatedBy
+			series.UpdatedAt, series.UpdatedBy = now, author
+		}
+
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "refNSDocType"}, {Name: "refNSBranchId"}},
+			DoUpdates: clause.AssignmentColumns([]string{"refNSFormat", "refNSStartAt", "refNSPerBranch", "updatedAt", "updatedBy"}),
+		}).Create(&series).Error
+		if err != nil {
+			return err
+		}
+
+		action, auditBefore := audit.ActionCreate, interface{}(nil)
+		if before.RefNSId != 0 {
+			action, auditBefore = audit.ActionUpdate, before
+		}
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityNumberSeries,
+			EntityId: series.RefNSId,
+			Action:   action,
+			BranchId: series.RefNSBranchId,
+			Before:   auditBefore,
+			After:    series,
+		})
+	})
+	if err != nil {
+		return numberseries.Series{}, err
+	}
+
+	log.Infof("🔢 Number series %s of branch %d set to %s", series.RefNSDocType, series.RefNSBranchId, series.RefNSFormat)
+	return series, nil
+}
+
+// DeleteNumberSeriesService drops a configured series: the branch falls back to the
+// default row, or the default row to the built-in format.
+func DeleteNumberSeriesService(db *gorm.DB, id int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var before numberseries.Series
+		if err := tx.First(&before, `"refNSId" = ?`, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NotFound(fmt.Sprintf("Number series %d not found", id))
+			}
+			return err
+		}
+		if err := tx.Delete(&numberseries.Series{}, `"refNSId" = ?`, id).Error; err != nil {
+			return err
+		}
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityNumberSeries,
+			EntityId: id,
+			Action:   audit.ActionDelete,
+			BranchId: before.RefNSBranchId,
+			Before:   before,
+		})
+	})
+}
+
+// GetNumberSeriesGapsService audits the counters of a document type: sequence numbers
+// no one drew, and numbers drawn that no document holds, e.g. a GRN item whose insert
+// failed after its SKU was issued, or a deleted transfer.
+func GetNumberSeriesGapsService(db *gorm.DB, query model.NumberSeriesGapQuery) ([]model.NumberSeriesGapReport, error) {
+	t, ok := numberseries.TypeOf(query.DocType)
+	if !ok {
+		return nil, apperror.BadRequest("Unknown document type " + query.DocType)
+	}
+
+	counters := db.Where(`"refNSCDocType" = ?`, t.Code)
+	if query.BranchId != nil {
+		counters = counters.Where(`"refNSCBranchId" = ?`, *query.BranchId)
+	}
+	if query.FiscalYear != 0 {
+		counters = counters.Where(`"refNSCFiscalYear" = ?`, query.FiscalYear)
+	}
+	var list []numberseries.Counter
+	if err := counters.Order(`"refNSCFiscalYear" DESC, "refNSCBranchId"`).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch number series counters: %w", err)
+	}
+
+	reports := []model.NumberSeriesGapReport{}
+	for _, counter := range list {
+		report := model.NumberSeriesGapReport{
+			DocType:    counter.RefNSCDocType,
+			BranchId:   counter.RefNSCBranchId,
+			FiscalYear: counter.RefNSCFiscalYear,
+			LastValue:  counter.RefNSCLastValue,
+			Gaps:       []model.NumberSeriesGap{},
+		}
+		key := []interface{}{counter.RefNSCDocType, counter.RefNSCBranchId, counter.RefNSCFiscalYear}
+
+		err := db.Model(&numberseries.Issued{}).
+			Where(`"refNSIDocType" = ? AND "refNSIBranchId" = ? AND "refNSIFiscalYear" = ?`, key...).
+			Count(&report.Issued).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to count issued numbers: %w", err)
+		}
+
+		// t.Table and t.Column come from numberseries.Types, never from the request
+		err = db.Raw(`
+			WITH issued AS (
+				SELECT "refNSISeq" AS seq, "refNSINumber" AS number
+				FROM public."NumberSeriesIssued"
+				WHERE "refNSIDocType" = ? AND "refNSIBranchId" = ? AND "refNSIFiscalYear" = ?
+			)
+			SELECT s.seq, '' AS number, 'notIssued' AS reason
+			FROM generate_series((SELECT MIN(seq) FROM issued), ?::bigint) AS s(seq)
+			WHERE NOT EXISTS (SELECT 1 FROM issued i WHERE i.seq = s.seq)
+			UNION ALL
+			SELECT i.seq, i.number, 'unused' AS reason
+			FROM issued i
+			WHERE NOT EXISTS (SELECT 1 FROM `+t.Table+` d WHERE d.`+t.Column+` = i.number)
+			ORDER BY seq
+			LIMIT `+fmt.Sprint(maxGapsPerCounter), append(key, counter.RefNSCLastValue)...).
+			Scan(&report.Gaps).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to audit %s numbers: %w", t.Code, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -9,15 +9,19 @@ import (
 
 	transactionLogger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/settingModule/model"
+	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
 	becrypt "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Bcrypt"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	mailService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/MailService"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 func CheckInitialCategoryCodeService(db *gorm.DB, categoryName string) (string, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("➡ Check Initial Category Code Service Invoked")
 
 	// Prefix = first letter
@@ -53,11 +57,12 @@ func CheckInitialCategoryCodeService(db *gorm.DB, categoryName string) (string,
 }
 
 // INITIAL CATEGORY SERVICES
-func CreateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCategory, roleName string) error {
-	log := logger.InitLogger()
+func CreateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCategory) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("\n\n\nInitial Category Service Invoked")
-	log.Info("Input Initial Category %+v", initialCategory)
-	log.Info("Created By : %s", roleName)
+	log.Infof("Input Initial Category %+v", initialCategory)
+	log.Infof("Created By : %s", author)
 
 	var existing model.InitialCategory
 	err := db.Table("InitialCategories").
@@ -73,7 +78,7 @@ func CreateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 
 	log.Info("No Duplicate initial categories found, proceed to creation")
 	initialCategory.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	initialCategory.CreatedBy = roleName
+	initialCategory.CreatedBy = author
 
 	err = db.Table("InitialCategories").Create(initialCategory).Error
 	if err != nil {
@@ -82,7 +87,14 @@ func CreateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 	}
 
 	log.Info("Initial Category Inserted Successfully")
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 2, "Initial Category Created : "+initialCategory.InitialCategoryName)
+	audit.RecordOrLog(db, audit.Entry{
+		Entity:   audit.EntityInitialCategory,
+		EntityId: initialCategory.InitialCategoryId,
+		Action:   audit.ActionCreate,
+		After:    initialCategory,
+	})
+
+	transErr := transactionLogger.LogActorTransaction(db, 2, "Initial Category Created : "+initialCategory.InitialCategoryName)
 	if transErr != nil {
 		log.Error("Failed to log transaction : " + transErr.Error())
 
@@ -93,7 +105,7 @@ func CreateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 }
 
 func GetAllInitialCategoriesService(db *gorm.DB) []model.InitialCategory {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetAllInitialCategoriesService invoked")
 
 	var initialCategory []model.InitialCategory
@@ -111,10 +123,11 @@ func GetAllInitialCategoriesService(db *gorm.DB) []model.InitialCategory {
 	return initialCategory
 }
 
-func UpdateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCategory, roleName string) error {
-	log := logger.InitLogger()
+func UpdateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCategory) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("Update Initial Category Service Invoked => ", initialCategory)
-	log.Info("Updated By %s", roleName)
+	log.Infof("Updated By %s", author)
 
 	var existing model.InitialCategory
 	err := db.Table("InitialCategories").
@@ -130,10 +143,10 @@ func UpdateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 
 	log.Info("No Duplicates found, proceeding with update")
 	initialCategory.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-	initialCategory.UpdatedBy = roleName
+	initialCategory.UpdatedBy = author
 
 	log.Info("Logging Transaction for category update")
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 3, "Initial Category Updated : "+initialCategory.InitialCategoryName)
+	transErr := transactionLogger.LogActorTransaction(db, 3, "Initial Category Updated : "+initialCategory.InitialCategoryName)
 	if transErr != nil {
 		log.Error("Failed to log into transactions : " + transErr.Error())
 	} else {
@@ -141,6 +154,7 @@ func UpdateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 	}
 
 	log.Info("Updating initial category in DB")
+	change := audit.Track(db, audit.EntityInitialCategory, audit.ActionUpdate, `"InitialCategories"`, "initialCategoryId", initialCategory.InitialCategoryId)
 	err = db.Table("InitialCategories").
 		Where(`"initialCategoryId" = ?`, initialCategory.InitialCategoryId).
 		Updates(map[string]interface{}{
@@ -152,12 +166,14 @@ func UpdateInitialCategoryService(db *gorm.DB, initialCategory *model.InitialCat
 		log.Error("Initial Category Update Failed = > " + err.Error())
 	} else {
 		log.Info("Initial Category Updated Successfully")
+		change.Done()
 	}
 	return err
 }
 
-func DeleteInitialCategoriesBulkService(db *gorm.DB, ids []string, roleName string) error {
-	log := logger.InitLogger()
+func DeleteInitialCategoriesBulkService(db *gorm.DB, ids []string) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	if len(ids) == 0 {
 		log.Warn("DeleteInitialCategoriesBulkService called with empty ids slice")
 		return fmt.Errorf("no ids provided")
@@ -170,9 +186,11 @@ func DeleteInitialCategoriesBulkService(db *gorm.DB, ids []string, roleName stri
 	updates := map[string]interface{}{
 		"isDelete":  true,
 		"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-		"updatedBy": roleName,
+		"updatedBy": author,
 	}
 
+	change := audit.Track(db, audit.EntityInitialCategory, audit.ActionDelete, `"InitialCategories"`, "initialCategoryId", ids)
+
 	// ✅ Use quoted identifiers for Postgres
 	res := dbg.Table(`"InitialCategories"`).
 		Where(`"initialCategoryId" IN (?)`, ids).
@@ -201,9 +219,9 @@ func DeleteInitialCategoriesBulkService(db *gorm.DB, ids []string, roleName stri
 	}
 
 	log.Infof("Soft deleted %d initial categories (RowsAffected=%d)", res.RowsAffected, res.RowsAffected)
+	change.Done()
 
-	transErr := transactionLogger.LogTransaction(
-		db, 1, "Admin", 4,
+	transErr := transactionLogger.LogActorTransaction(db, 4,
 		fmt.Sprintf("Initial Categories Deleted: %v", ids),
 	)
 	if transErr != nil {
@@ -217,12 +235,13 @@ func DeleteInitialCategoriesBulkService(db *gorm.DB, ids []string, roleName stri
 
 // CATEGORIES SERVICE
 
-func CreateCategoryService(db *gorm.DB, category *model.Category, roleName string) error {
-	log := logger.InitLogger()
+func CreateCategoryService(db *gorm.DB, category *model.Category) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateCategoryService invoked")
 
 	log.Infof("📥 Input Category: %+v", category)
-	log.Infof("👤 Created By (roleName): %s", roleName)
+	log.Infof("👤 Created By : %s", author)
 
 	var existing model.Category
 	err := db.Table("Categories").
@@ -240,7 +259,7 @@ func CreateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 	log.Info("✅ No duplicates found, proceeding to create category")
 
 	category.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	category.CreatedBy = roleName
+	category.CreatedBy = author
 
 	err = db.Table("Categories").Create(category).Error
 	if err != nil {
@@ -249,9 +268,15 @@ func CreateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 	}
 
 	log.Info("✅ Category created in DB, logging transaction...")
+	audit.RecordOrLog(db, audit.Entry{
+		Entity:   audit.EntityCategory,
+		EntityId: category.RefCategoryId,
+		Action:   audit.ActionCreate,
+		After:    category,
+	})
 
 	// Transaction Logging
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 2, "Category Created: "+category.CategoryName)
+	transErr := transactionLogger.LogActorTransaction(db, 2, "Category Created: "+category.CategoryName)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -262,7 +287,7 @@ func CreateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 }
 
 func GetAllCategoriesService(db *gorm.DB) []model.Category {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetAllCategoriesService invoked")
 
 	var categories []model.Category
@@ -281,12 +306,13 @@ func GetAllCategoriesService(db *gorm.DB) []model.Category {
 	return categories
 }
 
-func UpdateCategoryService(db *gorm.DB, category *model.Category, roleName string) error {
-	log := logger.InitLogger()
+func UpdateCategoryService(db *gorm.DB, category *model.Category) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ UpdateCategoryService invoked")
 
 	log.Infof("📥 Category to update: %+v", category)
-	log.Infof("👤 Updated By: %s", roleName)
+	log.Infof("👤 Updated By: %s", author)
 
 	// Check for duplicate category (excluding the current one)
 	var existing model.Category
@@ -307,11 +333,11 @@ func UpdateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 
 	// Update metadata
 	category.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-	category.UpdatedBy = roleName
+	category.UpdatedBy = author
 
 	// Log transaction
 	log.Info("📝 Logging transaction for category update")
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 3, "Category Updated: "+category.CategoryName)
+	transErr := transactionLogger.LogActorTransaction(db, 3, "Category Updated: "+category.CategoryName)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -320,6 +346,7 @@ func UpdateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 
 	// Perform DB update
 	log.Info("🔧 Updating category in DB")
+	change := audit.Track(db, audit.EntityCategory, audit.ActionUpdate, `"Categories"`, "refCategoryid", category.RefCategoryId)
 	err = db.Table("Categories").
 		Where(`"refCategoryid" = ?`, category.RefCategoryId).
 		Updates(map[string]interface{}{
@@ -333,13 +360,14 @@ func UpdateCategoryService(db *gorm.DB, category *model.Category, roleName strin
 		log.Error("❌ Category update failed: " + err.Error())
 	} else {
 		log.Info("✅ Category updated in DB")
+		change.Done()
 	}
 
 	return err
 }
 
 func GetSubcategoriesByCategory(db *gorm.DB, categoryId string) ([]model.SubCategory, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🔎 Fetching subcategories for category ID: %s", categoryId)
 
 	var subcategories []model.SubCategory
@@ -357,15 +385,17 @@ func GetSubcategoriesByCategory(db *gorm.DB, categoryId string) ([]model.SubCate
 }
 
 func DeleteCategoryService(db *gorm.DB, id string) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🗑️ Soft deleting category with ID: %s", id)
 
+	change := audit.Track(db, audit.EntityCategory, audit.ActionDelete, `"Categories"`, "refCategoryid", id)
 	err := db.Table("Categories").
 		Where(`"refCategoryid" = ?`, id).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": "Admin", // Optional: you can pass dynamic roleName if needed
+			"updatedBy": author,
 		}).Error
 
 	if err != nil {
@@ -374,9 +404,10 @@ func DeleteCategoryService(db *gorm.DB, id string) error {
 	}
 
 	log.Info("✅ Category soft deleted successfully")
+	change.Done()
 
 	// Log transaction
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 4, "Category Deleted: "+id)
+	transErr := transactionLogger.LogActorTransaction(db, 4, "Category Deleted: "+id)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -386,16 +417,18 @@ func DeleteCategoryService(db *gorm.DB, id string) error {
 	return nil
 }
 
-func BulkDeleteCategoriesService(db *gorm.DB, ids []int, roleName string) error {
-	log := logger.InitLogger()
+func BulkDeleteCategoriesService(db *gorm.DB, ids []int) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🗑️ Soft deleting categories with IDs: %v", ids)
 
+	change := audit.Track(db, audit.EntityCategory, audit.ActionDelete, `"Categories"`, "refCategoryid", ids)
 	err := db.Table("Categories").
 		Where(`"refCategoryid" IN (?)`, ids).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": roleName,
+			"updatedBy": author,
 		}).Error
 
 	if err != nil {
@@ -404,9 +437,10 @@ func BulkDeleteCategoriesService(db *gorm.DB, ids []int, roleName string) error
 	}
 
 	log.Info("✅ Bulk category soft delete successful")
+	change.Done()
 
 	// Optional: Log one transaction for all deletions
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 5, fmt.Sprintf("Bulk Category Delete: %v", ids))
+	transErr := transactionLogger.LogActorTransaction(db, 5, fmt.Sprintf("Bulk Category Delete: %v", ids))
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -417,7 +451,7 @@ func BulkDeleteCategoriesService(db *gorm.DB, ids []int, roleName string) error
 }
 
 func CheckSubcategoriesExistence(db *gorm.DB, categoryIDs []int) (map[string][]model.SubCategory, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🔎 Checking subcategory existence for category IDs: %v", categoryIDs)
 
 	var subcategories []model.SubCategory
@@ -442,12 +476,13 @@ func CheckSubcategoriesExistence(db *gorm.DB, categoryIDs []int) (map[string][]m
 }
 
 // SUB CATEGORIES SERVICE
-func CreateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName string) error {
-	log := logger.InitLogger()
+func CreateSubCategoryService(db *gorm.DB, sub *model.SubCategory) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateSubCategoryService invoked")
 
 	log.Infof("📥 Input SubCategory: %+v", sub)
-	log.Infof("👤 Created By (roleName): %s", roleName)
+	log.Infof("👤 Created By : %s", author)
 
 	var existing model.SubCategory
 	err := db.Table("SubCategories").
@@ -466,7 +501,7 @@ func CreateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName stri
 	log.Info("✅ No duplicates found, proceeding to create subcategory")
 
 	sub.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	sub.CreatedBy = roleName
+	sub.CreatedBy = author
 
 	err = db.Table("SubCategories").Create(sub).Error
 	if err != nil {
@@ -475,9 +510,15 @@ func CreateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName stri
 	}
 
 	log.Info("✅ SubCategory created in DB, logging transaction...")
+	audit.RecordOrLog(db, audit.Entry{
+		Entity:   audit.EntitySubCategory,
+		EntityId: sub.RefSubCategoryId,
+		Action:   audit.ActionCreate,
+		After:    sub,
+	})
 
 	// ✅ Transaction Logging
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 2, "SubCategory Created: "+sub.SubCategoryName)
+	transErr := transactionLogger.LogActorTransaction(db, 2, "SubCategory Created: "+sub.SubCategoryName)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -488,7 +529,7 @@ func CreateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName stri
 }
 
 func GetAllSubCategoriesService(db *gorm.DB) []model.SubCategoryResponse {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	var subs []model.SubCategoryResponse
 
 	err := db.Table(`"SubCategories" AS sub`).
@@ -507,20 +548,19 @@ func GetAllSubCategoriesService(db *gorm.DB) []model.SubCategoryResponse {
 		Order(`sub."refSubCategoryId" ASC`).
 		Scan(&subs).Error
 
-	fmt.Printf("Result: %+v\n", subs)
-
 	if err != nil {
 		log.Error("Failed to fetch subcategories with category names: " + err.Error())
 	}
 	return subs
 }
 
-func UpdateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName string) error {
-	log := logger.InitLogger()
+func UpdateSubCategoryService(db *gorm.DB, sub *model.SubCategory) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🛠️ UpdateSubCategoryService invoked for ID: %d", sub.RefSubCategoryId)
 
 	log.Infof("📥 Input SubCategory: %+v", sub)
-	log.Infof("👤 Updated By (roleName): %s", roleName)
+	log.Infof("👤 Updated By : %s", author)
 
 	// 1. Check for duplicates
 	var existing model.SubCategory
@@ -544,9 +584,10 @@ func UpdateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName stri
 		"refCategoryId":   sub.RefCategoryId,
 		"isActive":        sub.IsActive,
 		"updatedAt":       time.Now().Format("2006-01-02 15:04:05"),
-		"updatedBY":       roleName,
+		"updatedBY":       author,
 	}
 
+	change := audit.Track(db, audit.EntitySubCategory, audit.ActionUpdate, `"SubCategories"`, "refSubCategoryId", sub.RefSubCategoryId)
 	err = db.Table("SubCategories").
 		Where(`"refSubCategoryId" = ?`, sub.RefSubCategoryId).
 		Updates(updateData).Error
@@ -557,32 +598,43 @@ func UpdateSubCategoryService(db *gorm.DB, sub *model.SubCategory, roleName stri
 	}
 
 	log.Info("✅ SubCategory updated in DB successfully")
+	change.Done()
 	return nil
 }
 
 func DeleteSubCategoryService(db *gorm.DB, id string) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("Soft deleting SubCategory with ID: ", id)
 
-	return db.Table("SubCategories").
+	change := audit.Track(db, audit.EntitySubCategory, audit.ActionDelete, `"SubCategories"`, "refSubCategoryId", id)
+	err := db.Table("SubCategories").
 		Where(`"refSubCategoryId" = ?`, id).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBY": "Admin",
+			"updatedBY": author,
 		}).Error
+	if err != nil {
+		return err
+	}
+
+	change.Done()
+	return nil
 }
 
-func BulkDeleteSubCategoriesService(db *gorm.DB, ids []int, roleName string) error {
-	log := logger.InitLogger()
+func BulkDeleteSubCategoriesService(db *gorm.DB, ids []int) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🗑️ Soft deleting subcategories with IDs: %v", ids)
 
+	change := audit.Track(db, audit.EntitySubCategory, audit.ActionDelete, `"SubCategories"`, "refSubCategoryId", ids)
 	err := db.Table("SubCategories").
 		Where(`"refSubCategoryId" IN (?)`, ids).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBY": roleName,
+			"updatedBY": author,
 		}).Error
 
 	if err != nil {
@@ -591,9 +643,10 @@ func BulkDeleteSubCategoriesService(db *gorm.DB, ids []int, roleName string) err
 	}
 
 	log.Info("✅ Bulk subcategory soft delete successful")
+	change.Done()
 
 	// Log one transaction entry (optional)
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 6, fmt.Sprintf("Bulk SubCategory Delete: %v", ids))
+	transErr := transactionLogger.LogActorTransaction(db, 6, fmt.Sprintf("Bulk SubCategory Delete: %v", ids))
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -604,12 +657,13 @@ func BulkDeleteSubCategoriesService(db *gorm.DB, ids []int, roleName string) err
 }
 
 // BRANCHES SERVICE
-func CreateBranchService(db *gorm.DB, branch *model.Branch, roleName string) error {
-	log := logger.InitLogger()
+func CreateBranchService(db *gorm.DB, branch *model.Branch) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateBranchService invoked")
 
 	log.Infof("📥 Input Branch: %+v", branch)
-	log.Infof("👤 Created By (roleName): %s", roleName)
+	log.Infof("👤 Created By : %s", author)
 
 	var existing model.Branch
 	err := db.Table(`"Branches"`).
@@ -627,7 +681,7 @@ func CreateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 	log.Info("✅ No duplicates found, proceeding to create branch")
 
 	branch.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	branch.CreatedBy = roleName
+	branch.CreatedBy = author
 
 	err = db.Table(`"Branches"`).Create(branch).Error
 	if err != nil {
@@ -636,9 +690,16 @@ func CreateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 	}
 
 	log.Info("✅ Branch created in DB, logging transaction...")
+	audit.RecordOrLog(db, audit.Entry{
+		Entity:   audit.EntityBranch,
+		EntityId: branch.RefBranchId,
+		Action:   audit.ActionCreate,
+		BranchId: branch.RefBranchId,
+		After:    branch,
+	})
 
 	// Transaction Logging (TransTypeID = 4 for branch)
-	transErr := transactionLogger.LogTransaction(db, 1, roleName, 4, "Branch Created: "+branch.RefBranchName)
+	transErr := transactionLogger.LogActorTransaction(db, 4, "Branch Created: "+branch.RefBranchName)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -649,7 +710,7 @@ func CreateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 }
 
 func GetAllBranchesService(db *gorm.DB) ([]model.Branch, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	var branches []model.Branch
 
 	log.Info("🔎 Fetching all active branches sorted by refBranchId ASC")
@@ -668,8 +729,9 @@ func GetAllBranchesService(db *gorm.DB) ([]model.Branch, error) {
 	return branches, nil
 }
 
-func UpdateBranchService(db *gorm.DB, branch *model.Branch, roleName string) error {
-	log := logger.InitLogger()
+func UpdateBranchService(db *gorm.DB, branch *model.Branch) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🔧 UpdateBranchService invoked for Branch ID: %d", branch.RefBranchId)
 
 	var existing model.Branch
@@ -696,9 +758,10 @@ func UpdateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 		"isMainBranch":  branch.IsMainBranch,
 		"isActive":      branch.IsActive,
 		"updatedAt":     time.Now().Format("2006-01-02 15:04:05"),
-		"updatedBy":     roleName,
+		"updatedBy":     author,
 	}
 
+	change := audit.Track(db, audit.EntityBranch, audit.ActionUpdate, `"Branches"`, "refBranchId", branch.RefBranchId)
 	err = db.Table(`"Branches"`).
 		Where(`"refBranchId" = ?`, branch.RefBranchId).
 		Updates(updateData).Error
@@ -709,9 +772,10 @@ func UpdateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 	}
 
 	log.Info("✅ Branch updated successfully in DB")
+	change.Done()
 
 	// Log transaction history
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 3, fmt.Sprintf("Branch Updated: %s", branch.RefBranchName))
+	transErr := transactionLogger.LogActorTransaction(db, 3, fmt.Sprintf("Branch Updated: %s", branch.RefBranchName))
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -721,9 +785,10 @@ func UpdateBranchService(db *gorm.DB, branch *model.Branch, roleName string) err
 	return nil
 }
 
-func DeleteBranchService(db *gorm.DB, id string, roleName string) error {
-	log := logger.InitLogger()
-	log.Infof("🛠️ DeleteBranchService invoked for Branch ID: %s by %s", id, roleName)
+func DeleteBranchService(db *gorm.DB, id string) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
+	log.Infof("🛠️ DeleteBranchService invoked for Branch ID: %s by %s", id, author)
 
 	// Fetch the branch before deleting for logging purpose
 	var branch model.Branch
@@ -736,12 +801,13 @@ func DeleteBranchService(db *gorm.DB, id string, roleName string) error {
 	}
 
 	// Perform soft delete
+	change := audit.Track(db, audit.EntityBranch, audit.ActionDelete, `"Branches"`, "refBranchId", id)
 	err = db.Table(`"Branches"`).
 		Where(`"refBranchId" = ?`, id).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": roleName,
+			"updatedBy": author,
 		}).Error
 
 	if err != nil {
@@ -750,9 +816,10 @@ func DeleteBranchService(db *gorm.DB, id string, roleName string) error {
 	}
 
 	log.Info("✅ Branch soft deleted in DB")
+	change.Done()
 
 	// Transaction logging
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 4, fmt.Sprintf("Branch Deleted: %s", branch.RefBranchName))
+	transErr := transactionLogger.LogActorTransaction(db, 4, fmt.Sprintf("Branch Deleted: %s", branch.RefBranchName))
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -773,7 +840,8 @@ func CreateNewBranchWithFloor(db *gorm.DB, branch *model.BranchWithFloor, floors
 		SectionCode      string
 	}
 }, userId int) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("Creating new branch: " + branch.RefBranchName)
 
 	// Duplicate check
@@ -787,12 +855,12 @@ func CreateNewBranchWithFloor(db *gorm.DB, branch *model.BranchWithFloor, floors
 
 	tx := db.Begin()
 	branch.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	branch.CreatedBy = "Admin"
+	branch.CreatedBy = author
 	if err := tx.Table(`"Branches"`).Create(branch).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
-	log.Info("Inserted Branch ID: %d", branch.RefBranchId)
+	log.Infof("Inserted Branch ID: %d", branch.RefBranchId)
 
 	// Insert floors and sections
 	for _, floor := range floors {
@@ -802,13 +870,13 @@ func CreateNewBranchWithFloor(db *gorm.DB, branch *model.BranchWithFloor, floors
 			RefFloorCode: floor.FloorCode,
 			IsActive:     "true",
 			CreatedAt:    time.Now().Format("2006-01-02 15:04:05"),
-			CreatedBy:    "Admin",
+			CreatedBy:    author,
 		}
 		if err := tx.Table(`"refFloors"`).Create(&floorModel).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
-		log.Info("Inserted Floor ID: %d", floorModel.RefFloorId)
+		log.Infof("Inserted Floor ID: %d", floorModel.RefFloorId)
 
 		for _, section := range floor.Sections {
 			sectionModel := model.Sections{
@@ -819,22 +887,33 @@ func CreateNewBranchWithFloor(db *gorm.DB, branch *model.BranchWithFloor, floors
 				RefSubCategoryId: section.RefSubCategoryId,
 				IsActive:         "true",
 				CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:        "Admin",
+				CreatedBy:        author,
 			}
 			if err := tx.Table(`"refSections"`).Create(&sectionModel).Error; err != nil {
 				tx.Rollback()
 				return err
 			}
-			log.Info("Inserted Section ID: %d", sectionModel.RefSectionId)
+			log.Infof("Inserted Section ID: %d", sectionModel.RefSectionId)
 		}
 	}
 
+	if err := audit.Record(tx, audit.Entry{
+		Entity:   audit.EntityBranch,
+		EntityId: branch.RefBranchId,
+		Action:   audit.ActionCreate,
+		BranchId: branch.RefBranchId,
+		After:    map[string]interface{}{"branch": branch, "floors": floors},
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Insert Transaction History
 	history := model.TransactionHistory{
 		RefTransTypeId:  1,
 		RefTransHisData: fmt.Sprintf("Branch Created: %s with Floors and Sections", branch.RefBranchName),
 		CreatedAt:       time.Now().Format("2006-01-02 15:04:05"),
-		CreatedBy:       "Admin",
+		CreatedBy:       author,
 		RefUserId:       userId,
 	}
 	if err := tx.Table(`"TransactionHistory"`).Create(&history).Error; err != nil {
@@ -842,13 +921,13 @@ func CreateNewBranchWithFloor(db *gorm.DB, branch *model.BranchWithFloor, floors
 		return err
 	}
 
-	log.Info("Inserted transaction history for Branch ID: %d", branch.RefBranchId)
+	log.Infof("Inserted transaction history for Branch ID: %d", branch.RefBranchId)
 	tx.Commit()
 	return nil
 }
 
 func GetBranchWithFloorsService(db *gorm.DB, branchIdStr string) ([]model.BranchResponse, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetBranchWithFloorsService invoked")
 
 	var rows []struct {
@@ -1015,8 +1094,10 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 		SectionCode      string
 	}
 }, userId int) error {
+	author := actor.FromDB(db).By()
 
 	tx := db.Begin()
+	change := audit.Track(tx, audit.EntityBranch, audit.ActionUpdate, `"Branches"`, "refBranchId", branchId)
 
 	// ✅ Update branch info
 	if err := tx.Table(`"Branches"`).
@@ -1028,7 +1109,7 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 			"refMobile":     branch.RefMobile,
 			"refEmail":      branch.RefEmail,
 			"updatedAt":     time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy":     "Admin",
+			"updatedBy":     author,
 		}).Error; err != nil {
 		tx.Rollback()
 		return err
@@ -1056,7 +1137,7 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 					"refFloorName": floor.FloorName,
 					"refFloorCode": floor.FloorCode,
 					"updatedAt":    time.Now().Format("2006-01-02 15:04:05"),
-					"updatedBy":    "Admin",
+					"updatedBy":    author,
 				}).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -1069,7 +1150,7 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 				RefFloorCode: floor.FloorCode,
 				IsActive:     "true",
 				CreatedAt:    time.Now().Format("2006-01-02 15:04:05"),
-				CreatedBy:    "Admin",
+				CreatedBy:    author,
 			}
 			if err := tx.Table(`"refFloors"`).Create(&newFloor).Error; err != nil {
 				tx.Rollback()
@@ -1101,7 +1182,7 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 						"refCategoryId":    section.CategoryId,
 						"refSubCategoryId": section.RefSubCategoryId,
 						"updatedAt":        time.Now().Format("2006-01-02 15:04:05"),
-						"updatedBy":        "Admin",
+						"updatedBy":        author,
 					}).Error; err != nil {
 					tx.Rollback()
 					return err
@@ -1116,7 +1197,7 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 					RefSubCategoryId: section.RefSubCategoryId,
 					IsActive:         "true",
 					CreatedAt:        time.Now().Format("2006-01-02 15:04:05"),
-					CreatedBy:        "Admin",
+					CreatedBy:        author,
 				}
 				if err := tx.Table(`"refSections"`).Create(&newSection).Error; err != nil {
 					tx.Rollback()
@@ -1150,12 +1231,14 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 		}
 	}
 
+	change.Done()
+
 	// ✅ Insert Transaction History
 	history := model.TransactionHistory{
 		RefTransTypeId:  2, // Update
 		RefTransHisData: fmt.Sprintf("Branch Updated: %s with Floors and Sections", branch.RefBranchName),
 		CreatedAt:       time.Now().Format("2006-01-02 15:04:05"),
-		CreatedBy:       "Admin",
+		CreatedBy:       author,
 		RefUserId:       userId,
 	}
 	if err := tx.Table(`"TransactionHistory"`).Create(&history).Error; err != nil {
@@ -1168,7 +1251,8 @@ func UpdateBranchWithFloor(db *gorm.DB, branchId int, branch *model.BranchWithFl
 }
 
 func SoftDeleteBranch(db *gorm.DB, branchId string, userId int) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 
 	tx := db.Begin()
 
@@ -1186,7 +1270,7 @@ func SoftDeleteBranch(db *gorm.DB, branchId string, userId int) error {
 	updateData := map[string]interface{}{
 		"isDelete":  true,
 		"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-		"updatedBy": "Admin",
+		"updatedBy": author,
 	}
 
 	if err := tx.Table(`"Branches"`).Where(`"refBranchId" = ?`, branchId).Updates(updateData).Error; err != nil {
@@ -1194,12 +1278,23 @@ func SoftDeleteBranch(db *gorm.DB, branchId string, userId int) error {
 		return err
 	}
 
+	if err := audit.Record(tx, audit.Entry{
+		Entity:   audit.EntityBranch,
+		EntityId: existing.RefBranchId,
+		Action:   audit.ActionDelete,
+		BranchId: existing.RefBranchId,
+		Before:   existing,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Insert transaction history
 	history := model.TransactionHistory{
 		RefTransTypeId:  3, // 3 = delete
 		RefTransHisData: fmt.Sprintf("Branch Soft Deleted: %s", existing.RefBranchName),
 		CreatedAt:       time.Now().Format("2006-01-02 15:04:05"),
-		CreatedBy:       "Admin",
+		CreatedBy:       author,
 		RefUserId:       userId,
 	}
 	if err := tx.Table(`"TransactionHistory"`).Create(&history).Error; err != nil {
@@ -1208,13 +1303,13 @@ func SoftDeleteBranch(db *gorm.DB, branchId string, userId int) error {
 	}
 
 	tx.Commit()
-	log.Info("Branch soft deleted successfully: %d", existing.RefBranchId)
+	log.Infof("Branch soft deleted successfully: %d", existing.RefBranchId)
 	return nil
 }
 
 // ATTRIBUTES
 func GetAllAttributesService(db *gorm.DB) []model.AttributeGroupTable {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetAllAttributesService invoked")
 
 	var attributes []model.AttributeGroupTable
@@ -1232,12 +1327,13 @@ func GetAllAttributesService(db *gorm.DB) []model.AttributeGroupTable {
 	return attributes
 }
 
-func CreateAttributesService(db *gorm.DB, attribute *model.AttributesTable, roleName string) error {
-	log := logger.InitLogger()
+func CreateAttributesService(db *gorm.DB, attribute *model.AttributesTable) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateAttributesService invoked")
 
 	log.Infof("📥 Input Attribute: %+v", attribute)
-	log.Infof("👤 Created By (roleName): %s", roleName)
+	log.Infof("👤 Created By : %s", author)
 
 	var existing model.AttributesTable
 	err := db.Table("Attributes").
@@ -1255,7 +1351,7 @@ func CreateAttributesService(db *gorm.DB, attribute *model.AttributesTable, role
 	log.Info("✅ No duplicates found, proceeding to create Attribute")
 
 	attribute.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	attribute.CreatedBy = roleName
+	attribute.CreatedBy = author
 
 	err = db.Table("Attributes").Create(attribute).Error
 	if err != nil {
@@ -1266,7 +1362,7 @@ func CreateAttributesService(db *gorm.DB, attribute *model.AttributesTable, role
 	log.Info("✅ Attributes created in DB, logging transaction...")
 
 	// Transaction Logging
-	transErr := transactionLogger.LogTransaction(db, 1, "Admin", 2, "Attribute Created: "+attribute.AttributeValue)
+	transErr := transactionLogger.LogActorTransaction(db, 2, "Attribute Created: "+attribute.AttributeValue)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -1277,7 +1373,7 @@ func CreateAttributesService(db *gorm.DB, attribute *model.AttributesTable, role
 }
 
 func GetAttributesService(db *gorm.DB) []model.AttributeWithGroup {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("🛠️ GetAttributesService invoked")
 
 	query := `
@@ -1312,8 +1408,9 @@ func GetAttributesService(db *gorm.DB) []model.AttributeWithGroup {
 }
 
 // ATTRIBUTES LATEST
-func CreateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition, roleName string) error {
-	log := logger.InitLogger()
+func CreateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateProductFieldService invoked")
 
 	// Duplicate check
@@ -1331,7 +1428,7 @@ func CreateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition,
 	}
 
 	field.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	field.CreatedBy = roleName
+	field.CreatedBy = author
 	field.IsDelete = false
 
 	err = db.Table(`"purchaseOrder".product_field_definitions`).Create(field).Error
@@ -1341,14 +1438,14 @@ func CreateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition,
 	}
 
 	// Log transaction
-	_ = transactionLogger.LogTransaction(db, 1, roleName, 2, "Attribute Created: "+field.ColumnLabel)
+	_ = transactionLogger.LogActorTransaction(db, 2, "Attribute Created: "+field.ColumnLabel)
 
 	log.Info("✅ Attribute created successfully in DB")
 	return nil
 }
 
 func GetAllProductFieldsService(db *gorm.DB) []model.ProductFieldDefinition {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("📦 GetAllProductFieldsService invoked")
 
 	var attributes []model.ProductFieldDefinition
@@ -1364,8 +1461,9 @@ func GetAllProductFieldsService(db *gorm.DB) []model.ProductFieldDefinition {
 	return attributes
 }
 
-func UpdateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition, roleName string) error {
-	log := logger.InitLogger()
+func UpdateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ UpdateProductFieldService invoked")
 
 	var existing model.ProductFieldDefinition
@@ -1390,7 +1488,7 @@ func UpdateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition,
 	}
 
 	field.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-	field.UpdatedBy = roleName
+	field.UpdatedBy = author
 
 	err = db.Table(`"purchaseOrder".product_field_definitions`).Model(&existing).Updates(field).Error
 	if err != nil {
@@ -1398,7 +1496,7 @@ func UpdateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition,
 		return err
 	}
 
-	_ = transactionLogger.LogTransaction(db, 1, roleName, 3, "Attribute Updated: "+field.ColumnLabel)
+	_ = transactionLogger.LogActorTransaction(db, 3, "Attribute Updated: "+field.ColumnLabel)
 
 	log.Info("✅ Attribute updated successfully")
 	return nil
@@ -1407,7 +1505,7 @@ func UpdateProductFieldService(db *gorm.DB, field *model.ProductFieldDefinition,
 // EMPLOYEE - SELECT ROLE TYPE
 
 func GetUserRoleTypeService(db *gorm.DB) []model.RoleType {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	var roleTypes []model.RoleType
 
 	err := db.Find(&roleTypes).Error
@@ -1426,7 +1524,7 @@ func CreateEmployeeService(db *gorm.DB, data *model.EmployeePayload) error {
 	}
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	createdBy := "Admin"
+	createdBy := actor.FromDB(db).By()
 
 	// 🔍 Step 1: Duplicate check on username, email, or mobile
 	var existingCount int64
@@ -1510,8 +1608,7 @@ func CreateEmployeeService(db *gorm.DB, data *model.EmployeePayload) error {
 	auth := model.UserAuth{
 		RefUserId:            user.RefUserId,
 		RefUACUsername:       data.Username,
-		RefUACPassword:       password,       // store raw password if needed (not recommended in prod)
-		RefUACHashedPassword: hashedPassword, // actual used password
+		RefUACHashedPassword: hashedPassword,
 		CreatedAt:            timestamp,
 		CreatedBy:            createdBy,
 	}
@@ -1538,6 +1635,22 @@ func CreateEmployeeService(db *gorm.DB, data *model.EmployeePayload) error {
 		return fmt.Errorf("failed to create communication: %w", err)
 	}
 
+	created, err := GetEmployeeByIDService(txn, strconv.Itoa(user.RefUserId))
+	if err != nil {
+		txn.Rollback()
+		return err
+	}
+	if err := audit.Record(txn, audit.Entry{
+		Entity:   audit.EntityEmployee,
+		EntityId: user.RefUserId,
+		Action:   audit.ActionCreate,
+		BranchId: data.RefUserBranchId,
+		After:    created,
+	}); err != nil {
+		txn.Rollback()
+		return err
+	}
+
 	emailBody := fmt.Sprintf(`
 		<table width="100%%" cellspacing="0" cellpadding="0" style="font-family: Arial, sans-serif; background-color: #f9f9f9; padding: 5px;">
 			<tr>
@@ -1662,9 +1775,13 @@ func UpdateEmployeeService(db *gorm.DB, id string, data *model.EmployeePayload)
 	}
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	updatedBy := "Admin"
+	updatedBy := actor.FromDB(db).By()
 
-	fmt.Println("\n\n\n\nEmployeee data", data)
+	before, err := GetEmployeeByIDService(txn, id)
+	if err != nil {
+		txn.Rollback()
+		return err
+	}
 
 	// Step 1: Update Users
 	userUpdate := map[string]interface{}{
@@ -1708,11 +1825,57 @@ func UpdateEmployeeService(db *gorm.DB, id string, data *model.EmployeePayload)
 		return fmt.Errorf("failed to update communication details: %w", err)
 	}
 
-	return txn.Commit().Error
+	after, err := GetEmployeeByIDService(txn, id)
+	if err != nil {
+		txn.Rollback()
+		return err
+	}
+	if err := audit.Record(txn, audit.Entry{
+		Entity:   audit.EntityEmployee,
+		EntityId: id,
+		Action:   audit.ActionUpdate,
+		BranchId: data.RefUserBranchId,
+		Before:   before,
+		After:    after,
+	}); err != nil {
+		txn.Rollback()
+		return err
+	}
+
+	if err := txn.Commit().Error; err != nil {
+		return err
+	}
+
+	// The employee name is cached for createdBy / updatedBy
+	if userId, err := strconv.Atoi(id); err == nil {
+		actor.Forget(userId)
+	}
+
+	// Disabled employees are logged out everywhere
+	if !data.RefUserStatus {
+		return revokeEmployeeSessions(db, id, "employee disabled")
+	}
+	return nil
 }
 
 func SoftDeleteEmployeeService(db *gorm.DB, id string) error {
-	return db.Table(`"Users"`).Where(`"refUserId" = ?`, id).Update("isDelete", true).Error
+	change := audit.Track(db, audit.EntityEmployee, audit.ActionDelete, `"Users"`, "refUserId", id)
+	if err := db.Table(`"Users"`).Where(`"refUserId" = ?`, id).Update("isDelete", true).Error; err != nil {
+		return err
+	}
+	change.Done()
+	return revokeEmployeeSessions(db, id, "employee deleted")
+}
+
+func revokeEmployeeSessions(db *gorm.DB, id string, reason string) error {
+	userId, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid employee id: %s", id)
+	}
+	if err := accesstoken.RevokeUserSessions(db, userId, reason); err != nil {
+		return fmt.Errorf("failed to revoke employee sessions: %w", err)
+	}
+	return nil
 }
 
 func GetEmployeeService(db *gorm.DB, id string) (*model.EmployeeResponse, error) {
@@ -1753,7 +1916,7 @@ func UpdateProfileService(db *gorm.DB, id string, data *model.ProfilePayload) er
 	}
 
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	updatedBy := "Admin"
+	updatedBy := actor.FromDB(db).By()
 
 	// Step 1: Update Users
 	userUpdate := map[string]interface{}{
@@ -1797,7 +1960,15 @@ func UpdateProfileService(db *gorm.DB, id string, data *model.ProfilePayload) er
 		return fmt.Errorf("failed to update communication details: %w", err)
 	}
 
-	return txn.Commit().Error
+	if err := txn.Commit().Error; err != nil {
+		return err
+	}
+
+	// The employee name is cached for createdBy / updatedBy
+	if userId, err := strconv.Atoi(id); err == nil {
+		actor.Forget(userId)
+	}
+	return nil
 }
 
 func FetchSettingsOverview(db *gorm.DB) (model.SettingsOverview, error) {
@@ -1855,8 +2026,9 @@ func FetchSettingsOverview(db *gorm.DB) (model.SettingsOverview, error) {
 	return overview, nil
 }
 
-func CreateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, roleName string) error {
-	log := logger.InitLogger()
+func CreateSettingsProductService(db *gorm.DB, product *model.SettingsProduct) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("\n\n🟢 Create Settings Product Service Invoked")
 	log.Infof("📥 Input Product: %+v", product)
 
@@ -1899,7 +2071,7 @@ func CreateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, r
 		product.TaxPercentage,
 		product.ProductCode,
 		now,
-		roleName,
+		author,
 	).Error
 
 	if err != nil {
@@ -1910,14 +2082,14 @@ func CreateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, r
 	log.Info("✅ Product inserted successfully")
 
 	// Log Transaction
-	transactionLogger.LogTransaction(db, 1, "Admin", 2, "Product Created : "+product.ProductName)
+	transactionLogger.LogActorTransaction(db, 2, "Product Created : "+product.ProductName)
 
 	log.Info("\n================================================================\n")
 	return nil
 }
 
 func GetAllSettingsProductsService(db *gorm.DB) []model.SettingsProductResponse {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("\n\n🟣 Fetching Settings Products with Category & SubCategory Names...")
 
 	var products []model.SettingsProductResponse
@@ -1961,8 +2133,9 @@ func GetAllSettingsProductsService(db *gorm.DB) []model.SettingsProductResponse
 	return products
 }
 
-func UpdateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, roleName string) error {
-	log := logger.InitLogger()
+func UpdateSettingsProductService(db *gorm.DB, product *model.SettingsProduct) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("\n\n🟡 Update Product Service Invoked")
 
 	// Duplicate check
@@ -2005,7 +2178,7 @@ func UpdateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, r
 		product.TaxPercentage,
 		product.ProductCode,
 		now,
-		roleName,
+		author,
 		product.Id,
 	).Error
 
@@ -2016,14 +2189,15 @@ func UpdateSettingsProductService(db *gorm.DB, product *model.SettingsProduct, r
 
 	log.Info("✅ Product updated successfully")
 
-	transactionLogger.LogTransaction(db, 1, "Admin", 3, "Product Updated : "+product.ProductName)
+	transactionLogger.LogActorTransaction(db, 3, "Product Updated : "+product.ProductName)
 
 	log.Info("\n================================================================\n")
 	return nil
 }
 
-func DeleteSettingsProductsService(db *gorm.DB, ids []int, roleName string) error {
-	log := logger.InitLogger()
+func DeleteSettingsProductsService(db *gorm.DB, ids []int) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("\n\n🔴 Soft Delete Products Invoked")
 
 	query := `
@@ -2034,7 +2208,7 @@ func DeleteSettingsProductsService(db *gorm.DB, ids []int, roleName string) erro
 
 	now := time.Now().Format("2006-01-02 15:04:05")
 
-	err := db.Exec(query, now, roleName, pq.Array(ids)).Error
+	err := db.Exec(query, now, author, pq.Array(ids)).Error
 	if err != nil {
 		log.Error("❌ Delete failed: " + err.Error())
 		return err
@@ -2042,7 +2216,7 @@ func DeleteSettingsProductsService(db *gorm.DB, ids []int, roleName string) erro
 
 	log.Info("✅ Products Soft Deleted Successfully")
 
-	transactionLogger.LogTransaction(db, 1, "Admin", 4, fmt.Sprintf("Products Deleted: %v", ids))
+	transactionLogger.LogActorTransaction(db, 4, fmt.Sprintf("Products Deleted: %v", ids))
 
 	log.Info("\n================================================================\n")
 	return nil
@@ -2059,8 +2233,9 @@ func resolveColumnPrefix(table string) string {
 	}
 }
 
-func CreateMasterService(db *gorm.DB, table string, name string, roleName string) error {
-	log := logger.InitLogger()
+func CreateMasterService(db *gorm.DB, table string, name string) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("\n\n🟢 Create %s Service Invoked", table)
 
 	// Duplicate check
@@ -2089,11 +2264,11 @@ func CreateMasterService(db *gorm.DB, table string, name string, roleName string
 		VALUES ($1, $2, $3, FALSE)
 	`, table, col)
 
-	return db.Exec(insertQuery, name, now, roleName).Error
+	return db.Exec(insertQuery, name, now, author).Error
 }
 
 func GetAllMasterService(db *gorm.DB, table string) []map[string]interface{} {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("\n\n🟣 GetAll %s Service Invoked", table)
 
 	var data []map[string]interface{}
@@ -2105,8 +2280,9 @@ func GetAllMasterService(db *gorm.DB, table string) []map[string]interface{} {
 	return data
 }
 
-func UpdateMasterService(db *gorm.DB, table string, id int, name string, roleName string) error {
-	log := logger.InitLogger()
+func UpdateMasterService(db *gorm.DB, table string, id int, name string) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("\n\n🟡 Update %s Service Invoked", table)
 
 	var exists bool
@@ -2135,11 +2311,12 @@ func UpdateMasterService(db *gorm.DB, table string, id int, name string, roleNam
 		WHERE id=$4
 	`, table, col)
 
-	return db.Exec(updateQuery, name, now, roleName, id).Error
+	return db.Exec(updateQuery, name, now, author, id).Error
 }
 
-func DeleteMasterService(db *gorm.DB, table string, ids []int, roleName string) error {
-	log := logger.InitLogger()
+func DeleteMasterService(db *gorm.DB, table string, ids []int) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("\n\n🔴 Delete %s Service Invoked", table)
 
 	now := time.Now().Format("2006-01-02 15:04:05")
@@ -2150,14 +2327,15 @@ func DeleteMasterService(db *gorm.DB, table string, ids []int, roleName string)
 		WHERE id = ANY($3)
 	`, table)
 
-	return db.Exec(query, now, roleName, pq.Array(ids)).Error
+	return db.Exec(query, now, author, pq.Array(ids)).Error
 }
 
 // ROUND OFF SERVICE WITH DETAILED LOGGING
-func CreateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName string) error {
-	log := logger.InitLogger()
+func CreateRoundOffService(db *gorm.DB, payload model.RoundOffPayload) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠 CreateRoundOffService invoked")
-	log.Infof("📥 Payload received: %+v", payload)
+	log.Debugf("📥 Payload received: %+v", payload)
 
 	now := time.Now().Format("2006-01-02 15:04:05")
 
@@ -2168,8 +2346,8 @@ func CreateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 		ToRange:   payload.ToRange,
 		CreatedAt: now,
 		UpdatedAt: now,
-		CreatedBy: roleName,
-		UpdatedBy: roleName,
+		CreatedBy: author,
+		UpdatedBy: author,
 		IsDelete:  false,
 	}
 
@@ -2191,8 +2369,8 @@ func CreateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 			Price:      fmt.Sprintf("%d", p),
 			CreatedAt:  now,
 			UpdatedAt:  now,
-			CreatedBy:  roleName,
-			UpdatedBy:  roleName,
+			CreatedBy:  author,
+			UpdatedBy:  author,
 			IsDelete:   false,
 		}
 
@@ -2210,7 +2388,7 @@ func CreateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 }
 
 func GetAllRoundOffService(db *gorm.DB) []map[string]interface{} {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Info("📦 GetAllRoundOffService invoked")
 
 	var settings []model.RoundOffSetting
@@ -2262,10 +2440,11 @@ func GetAllRoundOffService(db *gorm.DB) []map[string]interface{} {
 	return result
 }
 
-func UpdateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName string) error {
-	log := logger.InitLogger()
+func UpdateRoundOffService(db *gorm.DB, payload model.RoundOffPayload) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠 UpdateRoundOffService invoked")
-	log.Infof("📥 Update Payload: %+v", payload)
+	log.Debugf("📥 Update Payload: %+v", payload)
 
 	now := time.Now().Format("2006-01-02 15:04:05")
 
@@ -2289,7 +2468,7 @@ func UpdateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 			"from_range": payload.FromRange,
 			"to_range":   payload.ToRange,
 			"updated_at": now,
-			"updated_by": roleName,
+			"updated_by": author,
 		}).Error
 
 	if err != nil {
@@ -2308,8 +2487,8 @@ func UpdateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 			Price:      fmt.Sprintf("%d", p),
 			CreatedAt:  now,
 			UpdatedAt:  now,
-			CreatedBy:  roleName,
-			UpdatedBy:  roleName,
+			CreatedBy:  author,
+			UpdatedBy:  author,
 			IsDelete:   false,
 		}
 
@@ -2326,7 +2505,7 @@ func UpdateRoundOffService(db *gorm.DB, payload model.RoundOffPayload, roleName
 }
 
 func DeleteRoundOffService(db *gorm.DB, id string) error {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🗑 DeleteRoundOffService invoked for ID: %s", id)
 
 	log.Info("🗑 Soft deleting round_off_settings")
@@ -2352,7 +2531,7 @@ func DeleteRoundOffService(db *gorm.DB, id string) error {
 }
 
 func BulkDeleteRoundOffService(db *gorm.DB, ids []int) error {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("🗑 BulkDeleteRoundOffService invoked for IDs: %+v", ids)
 
 	for _, id := range ids {
@@ -2382,3 +2561,49 @@ func BulkDeleteRoundOffService(db *gorm.DB, ids []int) error {
 	log.Info("🎉 BulkDeleteRoundOffService completed")
 	return nil
 }
+
+// ROLE PERMISSION SERVICES
+
+func GetRolePermissionMatrixService(db *gorm.DB) ([]model.RolePermissionResponse, error) {
+	var roleTypes []model.RoleType
+	if err := db.Order(`"refRTId"`).Find(&roleTypes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch role types: %w", err)
+	}
+
+	matrix := make([]model.RolePermissionResponse, 0, len(roleTypes))
+	for _, role := range roleTypes {
+		keys, err := permission.GetRolePermissions(db, role.RefRTId)
+		if err != nil {
+			return nil, err
+		}
+		matrix = append(matrix, model.RolePermissionResponse{
+			RefRTId:     role.RefRTId,
+			RefRTName:   role.RefRTName,
+			Permissions: keys,
+		})
+	}
+
+	return matrix, nil
+}
+
+func UpdateRolePermissionsService(db *gorm.DB, roleId int, payload *model.RolePermissionPayload) error {
+	author := actor.FromDB(db).By()
+	if roleId == permission.SuperAdminRoleID {
+		return fmt.Errorf("super admin permissions cannot be changed")
+	}
+
+	var count int64
+	if err := db.Model(&model.RoleType{}).Where(`"refRTId" = ?`, roleId).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check role type: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("invalid role type ID: %d", roleId)
+	}
+
+	if err := permission.SetRolePermissions(db, roleId, payload.Permissions, author); err != nil {
+		return err
+	}
+
+	_ = transactionLogger.LogActorTransaction(db, 3, fmt.Sprintf("Role Permissions Updated: role %d -> %v", roleId, payload.Permissions))
+	return nil
+}
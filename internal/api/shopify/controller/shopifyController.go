@@ -1,81 +1,84 @@
 package shopifyController
 
 import (
-	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"time"
 
 	shopifyHelper "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/helper"
 	shopifyService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/service"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	goshopify "github.com/bold-commerce/go-shopify/v4"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
 func GetShopifyProducts(ctx *gin.Context) {
 	products, err := shopifyService.GetAllProducts()
 	if err != nil {
-		shopifyHelper.ErrorResponse(ctx, err.Error())
+		shopifyHelper.ErrorResponse(ctx, err)
 		return
 	}
 	shopifyHelper.SuccessResponse(ctx, products)
 }
 
-func CreateShopifyProduct(ctx *gin.Context) {
-	var product goshopify.Product
+// CreateShopifyProduct queues the product for Shopify and answers 202 with the job id;
+// a worker creates it and retries while Shopify fails.
+func CreateShopifyProduct(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var product goshopify.Product
 
-	// Bind JSON payload from request
-	if err := ctx.ShouldBindJSON(&product); err != nil {
-		shopifyHelper.ErrorResponse(ctx, "Invalid payload: "+err.Error())
-		return
-	}
+		// Bind JSON payload from request
+		if err := ctx.ShouldBindJSON(&product); err != nil {
+			apperror.Respond(ctx, apperror.BadRequest("Invalid payload: "+err.Error()))
+			return
+		}
 
-	createdProduct, err := shopifyService.CreateProduct(product)
-	if err != nil {
-		shopifyHelper.ErrorResponse(ctx, err.Error())
-		return
-	}
+		job, err := jobqueue.Enqueue(actor.Bind(dbConn, ctx), shopifyService.JobCreateProduct, product, jobqueue.Options{})
+		if err != nil {
+			apperror.Respond(ctx, apperror.Internal(err, "Unable to queue the Shopify product"))
+			return
+		}
 
-	shopifyHelper.SuccessResponse(ctx, createdProduct)
+		ctx.JSON(http.StatusAccepted, gin.H{
+			"status":  true,
+			"message": "Product queued for Shopify",
+			"data":    gin.H{"jobId": job.RefJobId},
+		})
+	}
 }
 
-func OrderCreationWebhook() gin.HandlerFunc {
-	log := logger.InitLogger()
+// OrderCreationWebhook checks the Shopify signature of the order and publishes a sku.sold
+// event per line item.
+func OrderCreationWebhook(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n\n\nOrder creation web hook called ->>>> \n")
 		body, err := io.ReadAll(c.Request.Body)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot read body"})
+			apperror.Respond(c, apperror.BadRequest("cannot read body"))
 			return
 		}
 
-		log.Info("\n\n\nOrder body", string(body))
-
-		// ✅ Log file path
-		logFile := "shopify_orders.log"
-
-		// ✅ Create or append to the log file
-		file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to open log file"})
+		// ✅ Only Shopify, signing with the app secret, may report orders
+		if !shopifyHelper.VerifyWebhook(body, c.GetHeader(shopifyHelper.WebhookSignatureHeader)) {
+			log.Warn("⚠️ Shopify order webhook with a missing or invalid signature refused")
+			apperror.Respond(c, apperror.Unauthorized("Invalid webhook signature"))
 			return
 		}
-		defer file.Close()
 
-		// ✅ Write timestamp and order data
-		logEntry := fmt.Sprintf("\n\n=== Shopify Order - %s ===\n%s\n", time.Now().Format(time.RFC3339), string(body))
-		if _, err := file.WriteString(logEntry); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to write log"})
+		// ✅ Sales events for stock and reporting
+		sold, err := shopifyService.RecordOrderSales(dbConn.WithContext(c.Request.Context()), body)
+		if err != nil {
+			apperror.Respond(c, apperror.Internal(err, "failed to record the order sales"))
 			return
 		}
-
-		// ✅ Print to console (for debugging)
-		fmt.Println("🛍️ Shopify Order Created:", string(body))
+		log.Infof("🛍️ Published %d sku.sold events", sold)
 
 		// ✅ Respond to Shopify (must be 200 within 5 seconds)
-		c.JSON(http.StatusOK, gin.H{"success": true})
+		c.JSON(http.StatusOK, gin.H{"status": true})
 	}
 }
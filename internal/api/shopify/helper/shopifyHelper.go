@@ -1,22 +1,40 @@
 package shopifyHelper
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"net/http"
 
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	"github.com/gin-gonic/gin"
-
 )
 
 func SuccessResponse(ctx *gin.Context, data interface{}) {
 	ctx.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    data,
+		"status": true,
+		"data":   data,
 	})
 }
 
-func ErrorResponse(ctx *gin.Context, message string) {
-	ctx.JSON(http.StatusBadRequest, gin.H{
-		"success": false,
-		"error":   message,
-	})
+// ErrorResponse answers a failed Shopify call as 502, the store's message kept for the client
+func ErrorResponse(ctx *gin.Context, err error) {
+	apperror.Respond(ctx, apperror.Upstream(err, "Shopify request failed: "+err.Error()))
+}
+
+// WebhookSignatureHeader carries the base64 HMAC-SHA256 Shopify computes over a webhook body.
+const WebhookSignatureHeader = "X-Shopify-Hmac-Sha256"
+
+// VerifyWebhook tells whether signature is the one the app secret gives body. It is false
+// for every body while no secret is configured.
+func VerifyWebhook(body []byte, signature string) bool {
+	secret := config.Get().Shopify.APISecret
+	if !secret.IsSet() || signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret.Reveal()))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
 }
@@ -0,0 +1,43 @@
+package shopifyHelper
+
+import (
+	"testing"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+)
+
+const (
+	testSecret = "shpss_test_secret"
+	testBody   = `{"id":820982911946154508,"line_items":[]}`
+	// base64 HMAC-SHA256 of testBody under testSecret, computed outside Go
+	testSignature = "krxHwz5Ygh9uLZtGESzHnNKnZxGVSwKkwH+855FLHtc="
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	config.Set(&config.Config{Shopify: config.ShopifyConfig{APISecret: testSecret}})
+
+	cases := []struct {
+		name      string
+		body      string
+		signature string
+		want      bool
+	}{
+		{"signed by Shopify", testBody, testSignature, true},
+		{"body changed", `{"id":820982911946154509,"line_items":[]}`, testSignature, false},
+		{"signature changed", testBody, "lrxHwz5Ygh9uLZtGESzHnNKnZxGVSwKkwH+855FLHtc=", false},
+		{"hex instead of base64", testBody, "92bc47c33e58821f6e2d9b46112cc79cd2a7671195", false},
+		{"no signature", testBody, "", false},
+	}
+	for _, c := range cases {
+		if got := VerifyWebhook([]byte(c.body), c.signature); got != c.want {
+			t.Errorf("%s: VerifyWebhook = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVerifyWebhookWithoutSecret(t *testing.T) {
+	config.Set(&config.Config{})
+	if VerifyWebhook([]byte(testBody), testSignature) {
+		t.Error("a webhook was accepted with no app secret configured")
+	}
+}
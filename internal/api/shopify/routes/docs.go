@@ -0,0 +1,12 @@
+package shopfiyRoutes
+
+import (
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+var docs = openapi.Routes{
+	"GET /api/v1/shopify/products":               {Summary: "Get shopify products", Public: true},
+	"POST /api/v1/shopify/products":              {Summary: "Create shopify product", Description: "Answers 202 with the id of the job that creates the product; the job is retried while Shopify fails.", Request: goshopify.Product{}, Public: true},
+	"POST /api/v1/webhook/shopify/ordercreation": {Summary: "Order creation webhook", Description: "Publishes a sku.sold event per line item; a repeated webhook publishes nothing new. Answers 401 unless the X-Shopify-Hmac-Sha256 header is the HMAC of the body under the app secret (SHOPIFY_API_SECRET).", Public: true},
+}
@@ -2,18 +2,26 @@ package shopfiyRoutes
 
 import (
 	shopifyController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/controller"
+	shopifyService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/shopify/service"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func RegisterShopifyRoutes(router *gin.Engine) {
+func RegisterShopifyRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	api := router.Group("/api/v1/shopify")
 	{
 		api.GET("/products", shopifyController.GetShopifyProducts)
-		api.POST("/products", shopifyController.CreateShopifyProduct)
+		api.POST("/products", shopifyController.CreateShopifyProduct(dbConn))
 	}
 
 	api2 := router.Group("/api/v1/webhook/shopify")
 	{
-		api2.POST("/ordercreation", shopifyController.OrderCreationWebhook())
+		api2.POST("/ordercreation", shopifyController.OrderCreationWebhook(dbConn))
 	}
+
+	jobqueue.Handle(shopifyService.JobCreateProduct, shopifyService.CreateProductJob)
+
+	openapi.Describe("Shopify", docs)
 }
@@ -2,13 +2,20 @@ package shopifyService
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	outbox "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Outbox"
 	shopifyConfig "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/shopify"
 	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"gorm.io/gorm"
 )
 
+// JobCreateProduct creates a product in Shopify from a job worker, see CreateProductJob.
+const JobCreateProduct = "shopify.createProduct"
+
 func GetAllProducts() ([]goshopify.Product, error) {
 	ctx := context.Background()
 	client := shopifyConfig.ShopifyClient
@@ -91,7 +98,7 @@ func CreateProduct(product goshopify.Product) (*goshopify.Product, error) {
 			continue
 		}
 
-		log.Printf("\n\n\n\n\n\nInventory Qnty", variant.InventoryQuantity)
+		log.Printf("\n\n\n\n\n\nInventory Qnty %v", variant.InventoryQuantity)
 
 		setReq := goshopify.InventoryLevel{
 			InventoryItemId: variant.InventoryItemId,
@@ -118,3 +125,80 @@ func CreateProduct(product goshopify.Product) (*goshopify.Product, error) {
 	log.Println("🎉 Product created with tracked inventory!")
 	return createdProduct, nil
 }
+
+// CreateProductJob handles JobCreateProduct. Once Shopify has the product the job
+// completes even if setting its inventory failed: another attempt would create the
+// product twice. That failure is kept as the result's warning.
+func CreateProductJob(_ *gorm.DB, payload json.RawMessage) (interface{}, error) {
+	var product goshopify.Product
+	if err := json.Unmarshal(payload, &product); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+
+	createdProduct, err := CreateProduct(product)
+	if createdProduct == nil {
+		return nil, err
+	}
+	result := map[string]interface{}{
+		"productId": createdProduct.Id,
+		"title":     createdProduct.Title,
+	}
+	if err != nil {
+		result["warning"] = err.Error()
+	}
+	return result, nil
+}
+
+// orderWebhook is the part of a Shopify order webhook the sales events need.
+type orderWebhook struct {
+	Id        uint64 `json:"id"`
+	Name      string `json:"name"`
+	LineItems []struct {
+		Id       uint64 `json:"id"`
+		SKU      string `json:"sku"`
+		Quantity int    `json:"quantity"`
+	} `json:"line_items"`
+}
+
+// SKUSoldEvent is the payload of an outbox.SKUSold event.
+type SKUSoldEvent struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+	Channel  string `json:"channel"`
+	OrderId  string `json:"orderId"`
+	Order    string `json:"order"`
+}
+
+// RecordOrderSales publishes one sku.sold event per line item of a Shopify order, all in
+// one transaction. Shopify repeats webhooks, so a line item already published is skipped.
+func RecordOrderSales(db *gorm.DB, body []byte) (int, error) {
+	var order orderWebhook
+	if err := json.Unmarshal(body, &order); err != nil {
+		return 0, fmt.Errorf("cannot read the order: %w", err)
+	}
+
+	published := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range order.LineItems {
+			if item.SKU == "" {
+				continue
+			}
+			key := fmt.Sprintf("shopify:%d:%d", order.Id, item.Id)
+			recorded, err := outbox.PublishOnce(tx, outbox.SKUSold, key, 0, SKUSoldEvent{
+				SKU:      item.SKU,
+				Quantity: item.Quantity,
+				Channel:  "shopify",
+				OrderId:  fmt.Sprint(order.Id),
+				Order:    order.Name,
+			})
+			if err != nil {
+				return err
+			}
+			if recorded {
+				published++
+			}
+		}
+		return nil
+	})
+	return published, err
+}
@@ -6,18 +6,19 @@ import (
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/model"
 	supplierService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/service"
-	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
-	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
-	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	helper "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/RequestHandler"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // SUPPLIER CONTROLLER
-func CreateSupplierController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func CreateSupplierController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n\n🚀 Create Supplier Controller invoked")
 
 		idValue, idExists := c.Get("id")
@@ -28,54 +29,26 @@ func CreateSupplierController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing context values (id/roleId/branchId)")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var supplier model.Supplier
-		if err := c.ShouldBindJSON(&supplier); err != nil {
-			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[model.Supplier](c)
+		if !ok {
 			return
 		}
+		supplier := *req
 
 		log.Infof("📦 Request Body: %+v", supplier)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
-		if err != nil {
-			log.Error("❌ Invalid role ID: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": "Invalid role ID"})
-			return
-		}
-
-		roleName, err := roleType.GetRoleTypeNameByID(dbConn, roleId)
-		if err != nil {
-			log.Error("🔍 Failed to get role name: " + err.Error())
-			roleName = "Unknown"
-		} else {
-			log.Infof("✅ Role Name resolved: %s", roleName)
-		}
-
-		err = supplierService.CreateSupplier(dbConn, &supplier, roleName)
+		err := supplierService.CreateSupplier(actor.Bind(dbConn, c), &supplier)
 		if err != nil {
 			log.Error("❌ Service error: " + err.Error())
 
 			if err.Error() == "duplicate supplier with same name, company, and code already exists" {
-				c.JSON(http.StatusConflict, gin.H{
-					"status":  false,
-					"message": "Duplicate value found. A supplier with the same name, company, and code already exists.",
-				})
+				apperror.Respond(c, apperror.New(apperror.CodeConflict, "Duplicate value found. A supplier with the same name, company, and code already exists."))
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"status":  false,
-					"message": "Failed to create supplier",
-				})
+				apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to create supplier"))
 			}
 			return
 		}
@@ -83,19 +56,16 @@ func CreateSupplierController() gin.HandlerFunc {
 		log.Info("✅ Supplier created successfully")
 		log.Info("\n=================================================================\n")
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Supplier created successfully",
-			"token":   token,
 		})
 	}
 }
 
-func GetAllSuppliersController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetAllSuppliersController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("\n📦 GetAllSuppliersController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -106,42 +76,36 @@ func GetAllSuppliersController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, or Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, or Branch ID not found in request context."))
 			return
 		}
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
+		query, err := listquery.Parse(c, supplierService.SupplierList)
+		if err != nil {
+			apperror.Respond(c, err)
+			return
+		}
 
-		suppliers, err := supplierService.GetAllSuppliers(dbConn)
+		suppliers, page, err := supplierService.GetAllSuppliers(dbConn, query)
 		if err != nil {
 			log.Error("❌ Failed to fetch suppliers: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to fetch suppliers",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to fetch suppliers"))
 			return
 		}
 
 		log.Infof("✅ %d suppliers fetched successfully", len(suppliers))
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
-			"status": true,
-			"data":   suppliers,
-			"token":  token,
+			"status":     true,
+			"data":       suppliers,
+			"pagination": page,
 		})
 	}
 }
 
-func GetSupplierByIdController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func GetSupplierByIdController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("📦 GetSupplierByIdController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -152,42 +116,32 @@ func GetSupplierByIdController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, or Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, or Branch ID not found in request context."))
 			return
 		}
 
 		id := c.Param("id")
 		log.Infof("📌 Request Param: supplierId = %s", id)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
 		supplier, err := supplierService.GetSupplierById(dbConn, id)
 		if err != nil {
 			log.Warnf("❌ Supplier not found with ID: %s | Error: %v", id, err)
-			c.JSON(http.StatusNotFound, gin.H{"status": false, "message": "Supplier not found"})
+			apperror.Respond(c, apperror.New(apperror.CodeNotFound, "Supplier not found"))
 			return
 		}
 
 		log.Infof("✅ Supplier fetched successfully for ID: %s", id)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status": true,
 			"data":   supplier,
-			"token":  token,
 		})
 	}
 }
 
-func UpdateSupplierController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func UpdateSupplierController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🛠️ UpdateSupplierController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -198,46 +152,36 @@ func UpdateSupplierController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
-		var supplier model.Supplier
-		if err := c.ShouldBindJSON(&supplier); err != nil {
-			log.Error("📦 Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{"status": false, "message": err.Error()})
+		req, ok := helper.RequestHandler[model.Supplier](c)
+		if !ok {
 			return
 		}
+		supplier := *req
 		log.Infof("📦 Supplier Update Data: %+v", supplier)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		err := supplierService.UpdateSupplier(dbConn, &supplier)
+		err := supplierService.UpdateSupplier(actor.Bind(dbConn, c), &supplier)
 		if err != nil {
 			log.Error("❌ Failed to update supplier: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"status": false, "message": "Failed to update supplier"})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to update supplier"))
 			return
 		}
 
 		log.Infof("✅ Supplier with ID %v updated successfully", supplier.SupplierID)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-		c.JSON(http.StatusOK, gin.H{
+		helper.Respond(c, http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Supplier updated successfully",
-			"token":   token,
 		})
 	}
 }
 
-func DeleteSupplierController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func DeleteSupplierController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🗑️ DeleteSupplierController invoked")
 
 		idValue, idExists := c.Get("id")
@@ -248,95 +192,65 @@ func DeleteSupplierController() gin.HandlerFunc {
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		id := c.Param("id")
 		log.Infof("🗂️ Supplier ID to delete: %s", id)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		err := supplierService.DeleteSupplier(dbConn, id)
+		err := supplierService.DeleteSupplier(actor.Bind(dbConn, c), id)
 		if err != nil {
 			log.Error("❌ Failed to soft delete supplier: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to delete supplier",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to delete supplier"))
 			return
 		}
 
 		log.Infof("✅ Supplier with ID %s deleted successfully", id)
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": "Supplier deleted successfully",
-			"token":   token,
 		})
 	}
 }
 
-func BulkDeleteSupplierController() gin.HandlerFunc {
-	log := logger.InitLogger()
-
+func BulkDeleteSupplierController(dbConn *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		log := logger.FromGin(c)
 		log.Info("🗑️ BulkDeleteSupplierController invoked")
 
-		idValue, idExists := c.Get("id")
-		roleIdValue, roleIdExists := c.Get("roleId")
-		branchIdValue, branchIdExists := c.Get("branchId")
+		_, idExists := c.Get("id")
+		_, roleIdExists := c.Get("roleId")
+		_, branchIdExists := c.Get("branchId")
 
 		if !idExists || !roleIdExists || !branchIdExists {
 			log.Warn("❌ Missing user context data")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"status":  false,
-				"message": "User ID, RoleID, Branch ID not found in request context.",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 			return
 		}
 
 		var req model.BulkDeleteRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
 			log.Error("❌ Invalid request body: " + err.Error())
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "Invalid request payload",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid request payload"))
 			return
 		}
 
 		if len(req.IDs) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  false,
-				"message": "No supplier IDs provided",
-			})
+			apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "No supplier IDs provided"))
 			return
 		}
 
 		log.Infof("🔍 Bulk action on suppliers: %v, isDelete=%v", req.IDs, req.IsDelete)
 
-		dbConn, sqlDB := db.InitDB()
-		defer sqlDB.Close()
-
-		err := supplierService.BulkDeleteSuppliers(dbConn, req.IDs, req.IsDelete)
+		err := supplierService.BulkDeleteSuppliers(actor.Bind(dbConn, c), req.IDs, req.IsDelete)
 		if err != nil {
 			log.Error("❌ Failed to update suppliers: " + err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  false,
-				"message": "Failed to update suppliers",
-			})
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Failed to update suppliers"))
 			return
 		}
 
-		token := accesstoken.CreateToken(idValue, roleIdValue, branchIdValue)
-
 		action := "deleted"
 		if !req.IsDelete {
 			action = "restored"
@@ -345,7 +259,6 @@ func BulkDeleteSupplierController() gin.HandlerFunc {
 		c.JSON(http.StatusOK, gin.H{
 			"status":  true,
 			"message": fmt.Sprintf("Suppliers %s successfully", action),
-			"token":   token,
 		})
 	}
 }
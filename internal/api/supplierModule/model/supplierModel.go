@@ -5,17 +5,17 @@ type Supplier struct {
 	SupplierName           string `json:"supplierName" gorm:"column:supplierName"`
 	SupplierCompanyName    string `json:"supplierCompanyName" gorm:"column:supplierCompanyName"`
 	SupplierCode           string `json:"supplierCode" gorm:"column:supplierCode"`
-	SupplierEmail          string `json:"supplierEmail" gorm:"column:supplierEmail"`
-	SupplierGSTNumber      string `json:"supplierGSTNumber" gorm:"column:supplierGSTNumber"`
+	SupplierEmail          string `json:"supplierEmail" gorm:"column:supplierEmail" binding:"omitempty,email"`
+	SupplierGSTNumber      string `json:"supplierGSTNumber" gorm:"column:supplierGSTNumber" binding:"omitempty,gstin"`
 	SupplierPaymentTerms   string `json:"supplierPaymentTerms" gorm:"column:supplierPaymentTerms"`
 	SupplierBankACNumber   string `json:"supplierBankACNumber" gorm:"column:supplierBankACNumber"`
 	SupplierIFSC           string `json:"supplierIFSC" gorm:"column:supplierIFSC"`
 	SupplierBankName       string `json:"supplierBankName" gorm:"column:supplierBankName"`
 	SupplierUPI            string `json:"supplierUPI" gorm:"column:supplierUPI"`
 	SupplierIsActive       string `json:"supplierIsActive" gorm:"column:supplierIsActive"`
-	SupplierContactNumber  string `json:"supplierContactNumber" gorm:"column:supplierContactNumber"`
+	SupplierContactNumber  string `json:"supplierContactNumber" gorm:"column:supplierContactNumber" binding:"omitempty,mobile"`
 	EmergencyContactName   string `json:"emergencyContactName" gorm:"column:emergencyContactName"`
-	EmergencyContactNumber string `json:"emergencyContactNumber" gorm:"column:emergencyContactNumber"`
+	EmergencyContactNumber string `json:"emergencyContactNumber" gorm:"column:emergencyContactNumber" binding:"omitempty,mobile"`
 	SupplierDoorNumber     string `json:"supplierDoorNumber" gorm:"column:supplierDoorNumber"`
 	SupplierStreet         string `json:"supplierStreet" gorm:"column:supplierStreet"`
 	SupplierCity           string `json:"supplierCity" gorm:"column:supplierCity"`
@@ -26,8 +26,8 @@ type Supplier struct {
 	UpdatedAt              string `json:"updatedAt" gorm:"column:updatedAt"`
 	UpdatedBy              string `json:"updatedBy" gorm:"column:updatedBy"`
 	IsDelete               bool   `json:"isDelete" gorm:"column:isDelete"`
-	CreditedDays           int    `json:"creditedDays" gorm:"column:creditedDays"`
-	Pincode                string `json:"pincode" gorm:"column:pincode"`
+	CreditedDays           int    `json:"creditedDays" gorm:"column:creditedDays" binding:"gte=0"`
+	Pincode                string `json:"pincode" gorm:"column:pincode" binding:"omitempty,pincode"`
 }
 
 type BulkDeleteRequest struct {
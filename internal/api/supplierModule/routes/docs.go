@@ -0,0 +1,18 @@
+package supplierRoutes
+
+import (
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/model"
+	supplierService "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/service"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+)
+
+var docs = openapi.Routes{
+	"POST /api/v1/admin/suppliers/create":       {Summary: "Create supplier", Request: model.Supplier{}, Permission: permission.SupplierManage, Idempotent: true},
+	"GET /api/v1/admin/suppliers/read":          {Summary: "Get all suppliers", Response: []model.Supplier{}, List: &supplierService.SupplierList},
+	"GET /api/v1/admin/suppliers/read/:id":      {Summary: "Get supplier by id", Response: model.Supplier{}},
+	"PUT /api/v1/admin/suppliers/update":        {Summary: "Update supplier", Request: model.Supplier{}, Permission: permission.SupplierManage},
+	"DELETE /api/v1/admin/suppliers/delete/:id": {Summary: "Delete supplier", Permission: permission.SupplierManage},
+	"DELETE /api/v1/admin/suppliers/delete":     {Summary: "Delete supplier", Permission: permission.SupplierManage},
+	"POST /api/v1/admin/suppliers/delete/bulk":  {Summary: "Bulk delete supplier", Request: model.BulkDeleteRequest{}, Permission: permission.SupplierManage, Idempotent: true},
+}
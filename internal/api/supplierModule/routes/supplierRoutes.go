@@ -3,20 +3,25 @@ package supplierRoutes
 import (
 	supplierController "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/controller"
 	accesstoken "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AccessToken"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
 )
 
-func SupplierRoutes(router *gin.Engine) {
+func SupplierRoutes(router *gin.Engine, dbConn *gorm.DB) {
 	route := router.Group("/api/v1/admin/suppliers")
 
-	route.POST("/create", accesstoken.JWTMiddleware(), supplierController.CreateSupplierController())
-	route.GET("/read", accesstoken.JWTMiddleware(), supplierController.GetAllSuppliersController())
-	route.GET("/read/:id", accesstoken.JWTMiddleware(), supplierController.GetSupplierByIdController())
-	route.PUT("/update", accesstoken.JWTMiddleware(), supplierController.UpdateSupplierController())
-	route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), supplierController.DeleteSupplierController())
-	route.DELETE("/delete", accesstoken.JWTMiddleware(), supplierController.DeleteSupplierController())
+	route.POST("/create", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SupplierManage), idempotency.Middleware(), supplierController.CreateSupplierController(dbConn))
+	route.GET("/read", accesstoken.JWTMiddleware(), supplierController.GetAllSuppliersController(dbConn))
+	route.GET("/read/:id", accesstoken.JWTMiddleware(), supplierController.GetSupplierByIdController(dbConn))
+	route.PUT("/update", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SupplierManage), supplierController.UpdateSupplierController(dbConn))
+	route.DELETE("/delete/:id", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SupplierManage), supplierController.DeleteSupplierController(dbConn))
+	route.DELETE("/delete", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SupplierManage), supplierController.DeleteSupplierController(dbConn))
 
-	route.POST("/delete/bulk", accesstoken.JWTMiddleware(), supplierController.BulkDeleteSupplierController())
+	route.POST("/delete/bulk", accesstoken.JWTMiddleware(), permission.RequirePermission(permission.SupplierManage), idempotency.Middleware(), supplierController.BulkDeleteSupplierController(dbConn))
 
+	openapi.Describe("Suppliers", docs)
 }
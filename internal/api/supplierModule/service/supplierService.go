@@ -6,17 +6,21 @@ import (
 
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/helper/transactions/service"
 	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/supplierModule/model"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"gorm.io/gorm"
 
 )
 
-func CreateSupplier(db *gorm.DB, supplier *model.Supplier, roleName string) error {
-	log := logger.InitLogger()
+func CreateSupplier(db *gorm.DB, supplier *model.Supplier) error {
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Info("🛠️ CreateSupplierService invoked")
 
 	log.Infof("📥 Input Supplier: %+v", supplier)
-	log.Infof("👤 Created By (roleName): %s", roleName)
+	log.Infof("👤 Created By : %s", author)
 
 	// Check for duplicates
 	var existing model.Supplier
@@ -37,7 +41,7 @@ func CreateSupplier(db *gorm.DB, supplier *model.Supplier, roleName string) erro
 
 	// Set metadata
 	supplier.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
-	supplier.CreatedBy = roleName
+	supplier.CreatedBy = author
 	supplier.IsDelete = false
 
 	// Insert supplier
@@ -49,8 +53,15 @@ func CreateSupplier(db *gorm.DB, supplier *model.Supplier, roleName string) erro
 
 	log.Info("✅ Supplier created in DB")
 
+	audit.RecordOrLog(db, audit.Entry{
+		Entity:   audit.EntitySupplier,
+		EntityId: supplier.SupplierID,
+		Action:   audit.ActionCreate,
+		After:    supplier,
+	})
+
 	// Log transaction
-	transErr := service.LogTransaction(db, 1, "Admin", 6, "Supplier Created: "+supplier.SupplierName)
+	transErr := service.LogActorTransaction(db, 6, "Supplier Created: "+supplier.SupplierName)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -60,27 +71,46 @@ func CreateSupplier(db *gorm.DB, supplier *model.Supplier, roleName string) erro
 	return nil
 }
 
-func GetAllSuppliers(db *gorm.DB) ([]model.Supplier, error) {
-	log := logger.InitLogger()
+// SupplierList is what GET /suppliers/read may be filtered, searched and sorted on.
+var SupplierList = listquery.Spec{
+	Filters: map[string]listquery.Field{
+		"supplierIsActive": {Column: "supplierIsActive", Kind: listquery.Text},
+		"supplierCity":     {Column: "supplierCity", Kind: listquery.Text},
+		"supplierState":    {Column: "supplierState", Kind: listquery.Text},
+		"creditedDays":     {Column: "creditedDays", Kind: listquery.Number},
+		"createdAt":        {Column: "createdAt", Kind: listquery.Date},
+	},
+	Sorts: map[string]string{
+		"supplierId":   "supplierId",
+		"supplierName": "supplierName",
+		"supplierCode": "supplierCode",
+		"supplierCity": "supplierCity",
+		"creditedDays": "creditedDays",
+		"createdAt":    "createdAt",
+	},
+	Search:      []string{"supplierName", "supplierCompanyName", "supplierCode", "supplierContactNumber", "supplierGSTNumber"},
+	Key:         "supplierId",
+	DefaultSort: "supplierId",
+}
+
+func GetAllSuppliers(db *gorm.DB, query listquery.Query) ([]model.Supplier, listquery.Page, error) {
+	log := logger.FromDB(db)
 	log.Info("📘 GetAllSuppliers service invoked")
 
-	var suppliers []model.Supplier
-	err := db.Table(`"Supplier"`).
-		Where(`"isDelete" = false`).
-		Order(`"supplierId" ASC`).
-		Find(&suppliers).Error
+	suppliers := []model.Supplier{}
+	page, err := listquery.Find(db, `SELECT * FROM "Supplier" WHERE "isDelete" = false`, nil, query, &suppliers)
 
 	if err != nil {
 		log.Error("❌ DB Error fetching suppliers: " + err.Error())
 	} else {
-		log.Infof("📦 Suppliers fetched: %d", len(suppliers))
+		log.Infof("📦 Suppliers fetched: %d of %d", len(suppliers), page.Total)
 	}
 
-	return suppliers, err
+	return suppliers, page, err
 }
 
 func GetSupplierById(db *gorm.DB, id string) (model.Supplier, error) {
-	log := logger.InitLogger()
+	log := logger.FromDB(db)
 	log.Infof("📘 GetSupplierById service invoked for ID: %s", id)
 
 	var supplier model.Supplier
@@ -98,12 +128,14 @@ func GetSupplierById(db *gorm.DB, id string) (model.Supplier, error) {
 }
 
 func UpdateSupplier(db *gorm.DB, supplier *model.Supplier) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🔧 UpdateSupplier service invoked for ID: %v", supplier.SupplierID)
 
 	supplier.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
-	supplier.UpdatedBy = "Admin"
+	supplier.UpdatedBy = author
 
+	change := audit.Track(db, audit.EntitySupplier, audit.ActionUpdate, `"Supplier"`, "supplierId", supplier.SupplierID)
 	err := db.Table(`"Supplier"`).
 		Where(`"supplierId" = ?`, supplier.SupplierID).
 		Updates(supplier).Error
@@ -114,9 +146,10 @@ func UpdateSupplier(db *gorm.DB, supplier *model.Supplier) error {
 	}
 
 	log.Infof("✅ Supplier updated successfully in DB for ID: %v", supplier.SupplierID)
+	change.Done()
 
 	// Optional: Add transaction log
-	transErr := service.LogTransaction(db, 1, "Admin", 2, fmt.Sprintf("Supplier Updated: %s", supplier.SupplierName))
+	transErr := service.LogActorTransaction(db, 2, fmt.Sprintf("Supplier Updated: %s", supplier.SupplierName))
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	}
@@ -125,15 +158,17 @@ func UpdateSupplier(db *gorm.DB, supplier *model.Supplier) error {
 }
 
 func DeleteSupplier(db *gorm.DB, id string) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	log.Infof("🗑️ Soft deleting supplier with ID: %s", id)
 
+	change := audit.Track(db, audit.EntitySupplier, audit.ActionDelete, `"Supplier"`, "supplierId", id)
 	err := db.Table("Supplier").
 		Where(`"supplierId" = ?`, id).
 		Updates(map[string]interface{}{
 			"isDelete":  true,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": "Admin",
+			"updatedBy": author,
 		}).Error
 
 	if err != nil {
@@ -142,9 +177,10 @@ func DeleteSupplier(db *gorm.DB, id string) error {
 	}
 
 	log.Info("✅ Supplier soft-deleted successfully in DB")
+	change.Done()
 
 	// Optional: Transaction Log
-	transErr := service.LogTransaction(db, 1, "Admin", 2, "Supplier Deleted: "+id)
+	transErr := service.LogActorTransaction(db, 2, "Supplier Deleted: "+id)
 	if transErr != nil {
 		log.Error("⚠️ Failed to log transaction: " + transErr.Error())
 	} else {
@@ -155,19 +191,23 @@ func DeleteSupplier(db *gorm.DB, id string) error {
 }
 
 func BulkDeleteSuppliers(db *gorm.DB, ids []int, isDelete bool) error {
-	log := logger.InitLogger()
+	author := actor.FromDB(db).By()
+	log := logger.FromDB(db)
 	action := "deleting"
+	auditAction := audit.ActionDelete
 	if !isDelete {
 		action = "restoring"
+		auditAction = audit.ActionRestore
 	}
 	log.Infof("🗑️ Bulk %s suppliers: %v", action, ids)
 
+	change := audit.Track(db, audit.EntitySupplier, auditAction, `"Supplier"`, "supplierId", ids)
 	err := db.Table("Supplier").
 		Where(`"supplierId" IN (?)`, ids).
 		Updates(map[string]interface{}{
 			"isDelete":  isDelete,
 			"updatedAt": time.Now().Format("2006-01-02 15:04:05"),
-			"updatedBy": "Admin",
+			"updatedBy": author,
 		}).Error
 
 	if err != nil {
@@ -176,10 +216,11 @@ func BulkDeleteSuppliers(db *gorm.DB, ids []int, isDelete bool) error {
 	}
 
 	log.Infof("✅ Suppliers %s successfully in DB", action)
+	change.Done()
 
 	// Optional: Log transaction for each supplier
 	for _, id := range ids {
-		transErr := service.LogTransaction(db, 1, "Admin", 2,
+		transErr := service.LogActorTransaction(db, 2,
 			fmt.Sprintf("Supplier %s: %d", action, id))
 		if transErr != nil {
 			log.Error("⚠️ Failed to log transaction: " + transErr.Error())
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config is every setting the service reads, loaded once at startup by Load. Subsystems
+// receive their own section from main instead of calling os.Getenv.
+type Config struct {
+	Port    string
+	DB      DBConfig
+	Auth    AuthConfig
+	Storage StorageConfig
+	MinIO   MinIOConfig
+	Shopify ShopifyConfig
+	Mail    MailConfig
+	Jobs    JobsConfig
+	Log     LogConfig
+	Metrics MetricsConfig
+
+	// entries keeps what was read, in order, for Redacted.
+	entries []entry
+}
+
+type DBConfig struct {
+	Host     string
+	Port     string
+	Name     string
+	User     string
+	Password Secret
+
+	// Pool sizing for db.Open:
+	//
+	//	DB_MAX_OPEN_CONNS       upper bound of open connections (default 25)
+	//	DB_MAX_IDLE_CONNS       connections kept idle for reuse (default 10)
+	//	DB_CONN_MAX_LIFETIME    recycle a connection after this long, e.g. 30m (default 30m)
+	//	DB_CONN_MAX_IDLE_TIME   close a connection idle for this long, e.g. 5m (default 5m)
+	//	DB_CONNECT_RETRIES      startup ping attempts before serving without a database (default 10)
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	ConnectRetries  int
+
+	// MigrateOnStart applies pending schema migrations before serving (DB_MIGRATE_ON_START,
+	// default false). Without it a pending migration stops the start, see migrations.Check.
+	MigrateOnStart bool
+}
+
+type AuthConfig struct {
+	// AccessToken signs JWTs until a signing key is rotated in.
+	AccessToken Secret
+	// PasswordReset keys the hashes of password reset OTPs and reset tokens, so a leaked
+	// JWT secret does not also open the stored OTPs (PASSWORD_RESET_SECRET).
+	PasswordReset Secret
+	// EncryptAPI is the base key of the request/response envelopes.
+	EncryptAPI Secret
+	// AllowLegacyCBC keeps accepting unauthenticated CBC envelopes, see hashapi.LegacyAllowed.
+	AllowLegacyCBC bool
+}
+
+type StorageConfig struct {
+	// Driver is minio, or local to keep objects on disk and sign their URLs here, so dev
+	// and test environments run without MinIO (STORAGE_DRIVER, default minio).
+	Driver string
+	// LocalDir holds the objects of the local driver (STORAGE_LOCAL_DIR, default Storage).
+	LocalDir string
+	// LocalURL is the address clients reach this service at; local signed URLs start with
+	// it (STORAGE_LOCAL_URL, default http://localhost:PORT).
+	LocalURL string
+	// LocalSecret signs local URLs (STORAGE_LOCAL_SECRET). Without one a random key is
+	// drawn at start, and URLs signed before a restart stop working.
+	LocalSecret Secret
+}
+
+type MinIOConfig struct {
+	Endpoint  string
+	Port      string
+	UseSSL    bool
+	AccessKey Secret
+	SecretKey Secret
+	Bucket    string
+}
+
+// Address is the host:port handed to minio.New.
+func (m MinIOConfig) Address() string {
+	return net.JoinHostPort(m.Endpoint, m.Port)
+}
+
+type ShopifyConfig struct {
+	ShopName string
+	APIToken Secret
+	APIKey   Secret
+	// APISecret is the app's client secret, which signs the webhooks (SHOPIFY_API_SECRET).
+	// Without it every webhook is refused.
+	APISecret Secret
+}
+
+type MailConfig struct {
+	From     string
+	Password Secret
+	SMTPHost string
+	SMTPPort int
+}
+
+type JobsConfig struct {
+	// Workers is how many jobs this process runs at once (JOBS_WORKERS, default 2); 0 only
+	// enqueues, for an instance that must not run jobs.
+	Workers int
+	// PollInterval is how often an idle worker looks for due jobs (JOBS_POLL_INTERVAL,
+	// default 2s). Jobs enqueued by this process wake a worker at once.
+	PollInterval time.Duration
+}
+
+type LogConfig struct {
+	// Level is the lowest level written: trace, debug, info, warn or error.
+	Level string
+	// Format is json for collectors, text for reading a local console.
+	Format string
+	// Dir receives the daily log files.
+	Dir string
+}
+
+type MetricsConfig struct {
+	// Token, when set, must be sent as a bearer token to read /metrics.
+	Token Secret
+}
+
+var (
+	mu      sync.RWMutex
+	current *Config
+)
+
+// Load reads the environment, after applying the file named by CONFIG_FILE (default
+// .env), validates it and makes the result available through Get. Every problem is
+// reported at once, so a broken deployment is fixed in one pass.
+//
+// A missing .env is fine when the variables come from the environment itself; a
+// CONFIG_FILE that was asked for and cannot be read is an error.
+func Load() (*Config, error) {
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		if err := godotenv.Load(file); err != nil {
+			return nil, fmt.Errorf("config: cannot read CONFIG_FILE %s: %w", file, err)
+		}
+	} else if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("config: cannot read .env: %w", err)
+	}
+
+	r := &reader{}
+	cfg := &Config{
+		Port: r.port("PORT", ""),
+		DB: DBConfig{
+			Host:     r.required("DB_HOST"),
+			Port:     r.port("DB_PORT", "5432"),
+			Name:     r.required("DB_NAME"),
+			User:     r.required("DB_USER"),
+			Password: r.secret("DB_PASSWORD", true),
+
+			MaxOpenConns:    r.integer("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    r.integer("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: r.duration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime: r.duration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			ConnectRetries:  r.integer("DB_CONNECT_RETRIES", 10),
+
+			MigrateOnStart: r.boolean("DB_MIGRATE_ON_START", false),
+		},
+		Auth: AuthConfig{
+			AccessToken:    r.secret("ACCESS_TOKEN", true),
+			PasswordReset:  r.secret("PASSWORD_RESET_SECRET", true),
+			EncryptAPI:     r.secret("ENCRYPT_API", true),
+			AllowLegacyCBC: r.boolean("HASHAPI_ALLOW_CBC", true),
+		},
+		Storage: StorageConfig{
+			Driver:      r.oneOf("STORAGE_DRIVER", "minio", "minio", "local"),
+			LocalDir:    r.optional("STORAGE_LOCAL_DIR", "Storage"),
+			LocalSecret: r.secret("STORAGE_LOCAL_SECRET", false),
+		},
+		Shopify: ShopifyConfig{
+			ShopName:  r.required("SHOPIFY_SHOP_NAME"),
+			APIToken:  r.secret("SHOPIFY_API_TOKEN", true),
+			APIKey:    r.secret("SHOPIFY_API_KEY", false),
+			APISecret: r.secret("SHOPIFY_API_SECRET", false),
+		},
+		Mail: MailConfig{
+			From:     r.required("EMAILID"),
+			Password: r.secret("PASSWORD", true),
+			SMTPHost: r.optional("SMTP_HOST", "smtp.gmail.com"),
+			SMTPPort: r.integer("SMTP_PORT", 465),
+		},
+		Jobs: JobsConfig{
+			Workers:      r.integer("JOBS_WORKERS", 2),
+			PollInterval: r.duration("JOBS_POLL_INTERVAL", 2*time.Second),
+		},
+		Log: LogConfig{
+			Level:  r.oneOf("LOG_LEVEL", "info", "trace", "debug", "info", "warn", "error"),
+			Format: r.oneOf("LOG_FORMAT", "json", "json", "text"),
+			Dir:    r.optional("LOG_DIR", "Logs"),
+		},
+		Metrics: MetricsConfig{
+			Token: r.secret("METRICS_TOKEN", false),
+		},
+	}
+	cfg.Storage.LocalURL = strings.TrimSuffix(r.optional("STORAGE_LOCAL_URL", "http://localhost:"+cfg.Port), "/")
+
+	// MinIO settings matter only to the minio driver
+	if cfg.Storage.Driver == "minio" {
+		cfg.MinIO = MinIOConfig{
+			Endpoint:  r.required("MINIO_ENDPOINT"),
+			Port:      r.port("MINIO_PORT", "9000"),
+			AccessKey: r.secret("MINIO_ACCESS_KEY", true),
+			SecretKey: r.secret("MINIO_SECRET_KEY", true),
+			Bucket:    r.required("MINIO_BUCKET"),
+		}
+		// TLS is the norm for a MinIO served on 443, plain HTTP for the usual 9000.
+		cfg.MinIO.UseSSL = r.boolean("MINIO_USE_SSL", cfg.MinIO.Port == "443")
+	}
+
+	if cfg.DB.MaxIdleConns > cfg.DB.MaxOpenConns && cfg.DB.MaxOpenConns > 0 {
+		r.fail("DB_MAX_IDLE_CONNS (%d) cannot exceed DB_MAX_OPEN_CONNS (%d)", cfg.DB.MaxIdleConns, cfg.DB.MaxOpenConns)
+	}
+	if cfg.Jobs.PollInterval == 0 {
+		r.fail("JOBS_POLL_INTERVAL must be longer than 0")
+	}
+	if r.problems != nil {
+		return nil, &ValidationError{Problems: r.problems}
+	}
+
+	cfg.entries = r.entries
+	mu.Lock()
+	current = cfg
+	mu.Unlock()
+	return cfg, nil
+}
+
+// Get returns the configuration loaded by Load. It panics before Load, which can only be
+// a wiring mistake in main.
+func Get() *Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		panic("config: Get called before Load")
+	}
+	return current
+}
+
+// Set installs cfg as the configuration returned by Get, for tests that do not go through Load.
+func Set(cfg *Config) {
+	mu.Lock()
+	current = cfg
+	mu.Unlock()
+}
+
+// Redacted lists every setting by its variable name, with secrets masked, so the
+// diagnostics view tells whether a secret is set without disclosing it.
+func (c *Config) Redacted() map[string]string {
+	values := make(map[string]string, len(c.entries))
+	for _, e := range c.entries {
+		values[e.key] = e.shown
+	}
+	return values
+}
+
+// ValidationError lists every invalid or missing setting found by Load.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
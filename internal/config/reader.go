@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type entry struct {
+	key   string
+	shown string
+}
+
+// reader parses variables, collecting every problem instead of stopping at the first.
+type reader struct {
+	entries  []entry
+	problems []string
+}
+
+func (r *reader) fail(format string, args ...interface{}) {
+	r.problems = append(r.problems, fmt.Sprintf(format, args...))
+}
+
+func (r *reader) lookup(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+// record keeps the effective value, default included, for Redacted.
+func (r *reader) record(key string, value interface{}) {
+	r.entries = append(r.entries, entry{key: key, shown: fmt.Sprint(value)})
+}
+
+func (r *reader) optional(key, fallback string) string {
+	value := r.lookup(key)
+	if value == "" {
+		value = fallback
+	}
+	r.record(key, value)
+	return value
+}
+
+func (r *reader) oneOf(key, fallback string, allowed ...string) string {
+	value := strings.ToLower(r.lookup(key))
+	if value == "" {
+		value = fallback
+	} else if !contains(allowed, value) {
+		r.fail("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+		value = fallback
+	}
+	r.record(key, value)
+	return value
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reader) required(key string) string {
+	value := r.lookup(key)
+	if value == "" {
+		r.fail("%s is required", key)
+	}
+	r.record(key, value)
+	return value
+}
+
+func (r *reader) secret(key string, required bool) Secret {
+	value := Secret(r.lookup(key))
+	if required && value == "" {
+		r.fail("%s is required", key)
+	}
+	r.record(key, value)
+	return value
+}
+
+// port accepts a TCP port number; an empty fallback makes the variable required.
+func (r *reader) port(key, fallback string) string {
+	value := r.lookup(key)
+	if value == "" {
+		if fallback == "" {
+			r.fail("%s is required", key)
+		}
+		value = fallback
+	} else if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+		r.fail("%s must be a port number between 1 and 65535, got %q", key, value)
+	}
+	r.record(key, value)
+	return value
+}
+
+func (r *reader) integer(key string, fallback int) int {
+	n := fallback
+	if value := r.lookup(key); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			r.fail("%s must be a non-negative whole number, got %q", key, value)
+		} else {
+			n = parsed
+		}
+	}
+	r.record(key, n)
+	return n
+}
+
+func (r *reader) duration(key string, fallback time.Duration) time.Duration {
+	d := fallback
+	if value := r.lookup(key); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			r.fail("%s must be a duration such as 30s, 5m or 1h, got %q", key, value)
+		} else {
+			d = parsed
+		}
+	}
+	r.record(key, d)
+	return d
+}
+
+func (r *reader) boolean(key string, fallback bool) bool {
+	b := fallback
+	if value := r.lookup(key); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			r.fail("%s must be true or false, got %q", key, value)
+		} else {
+			b = parsed
+		}
+	}
+	r.record(key, b)
+	return b
+}
@@ -0,0 +1,32 @@
+package config
+
+const redacted = "[REDACTED]"
+
+// Secret holds a credential. It prints, formats and marshals as [REDACTED], so a
+// config struct that ends up in a log line or a response does not leak it; Reveal
+// is the one way to the value and belongs only where the credential is used.
+type Secret string
+
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) IsSet() bool {
+	return s != ""
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString covers %#v, which would otherwise bypass String.
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
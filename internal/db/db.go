@@ -1,65 +1,81 @@
 package db
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
-	"log"
-	"os"
 	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error in loading .env file")
-	}
-}
-
-func InitDB() (*gorm.DB, *sql.DB) {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("⚠️ Error loading .env file")
-	}
+var ErrUnavailable = errors.New("database unavailable")
 
-	host := os.Getenv("DB_HOST")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	dbname := os.Getenv("DB_NAME")
-	port := os.Getenv("DB_PORT")
-
-	dsn := fmt.Sprintf(
+func dsn(cfg config.DBConfig) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		host, user, password, dbname, port,
+		cfg.Host, cfg.User, cfg.Password.Reveal(), cfg.Name, cfg.Port,
 	)
+}
 
-	var dbConn *gorm.DB
-	var sqlDB *sql.DB
-
-	maxRetries := 10
+// Open creates the process-wide pool. main calls it once and hands the connection to the
+// route registration; handlers never open connections of their own.
+//
+// The pool is returned even when Postgres cannot be reached yet: database/sql dials lazily,
+// so requests succeed again as soon as the server is back, and Guard answers 503 until then.
+// The error reports the failed startup ping.
+func Open(cfg config.DBConfig) (*gorm.DB, error) {
+	dbConn, err := gorm.Open(postgres.Open(dsn(cfg)), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.GormLogger(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure database: %w", err)
+	}
 
-	for i := 1; i <= maxRetries; i++ {
-		fmt.Printf("⏳ Connecting to PostgreSQL... attempt %d/%d\n", i, maxRetries)
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure database: %w", err)
+	}
+	if err := dbConn.Use(metrics.GormPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to configure database: %w", err)
+	}
+	metrics.RegisterPool(sqlDB)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-		dbTemp, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-		if err == nil {
+	attempts := cfg.ConnectRetries
+	if attempts < 1 {
+		attempts = 1
+	}
+	for i := 1; i <= attempts; i++ {
+		fmt.Printf("⏳ Connecting to PostgreSQL... attempt %d/%d\n", i, attempts)
+		if err = sqlDB.Ping(); err == nil {
 			fmt.Println("✅ Successfully connected to PostgreSQL!")
-
-			sqlTemp, err := dbTemp.DB()
-			if err == nil {
-				dbConn = dbTemp
-				sqlDB = sqlTemp
-				return dbConn, sqlDB
-			}
+			markHealth(nil)
+			return dbConn, nil
+		}
+		if i < attempts {
+			fmt.Println("❌ DB connection failed. Retrying in 3 seconds...")
+			time.Sleep(3 * time.Second)
 		}
-
-		fmt.Println("❌ DB connection failed. Retrying in 3 seconds...")
-		time.Sleep(3 * time.Second)
 	}
 
-	fmt.Println("🚨 Could not connect to DB after retries. Server will still start.")
-	return nil, nil
+	markHealth(err)
+	return dbConn, fmt.Errorf("could not connect to database after %d attempts: %w", attempts, err)
+}
+
+// Close releases the pool on shutdown.
+func Close(dbConn *gorm.DB) {
+	if dbConn == nil {
+		return
+	}
+	if sqlDB, err := dbConn.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
 }
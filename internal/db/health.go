@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// A failed ping is retried after unhealthyRecheck, a healthy pool is re-pinged after
+// healthyRecheck, so Guard costs a round trip at most once per interval, not per request.
+const (
+	healthyRecheck   = 10 * time.Second
+	unhealthyRecheck = 2 * time.Second
+	pingTimeout      = 2 * time.Second
+)
+
+var (
+	healthMu  sync.Mutex
+	healthErr error = ErrUnavailable
+	checkedAt time.Time
+
+	// started is set once the startup steps given to WhenReady have run
+	started atomic.Bool
+
+	// unguarded holds the route paths Guard lets through, see Unguard
+	unguarded sync.Map
+)
+
+func markHealth(err error) {
+	healthMu.Lock()
+	healthErr = err
+	checkedAt = time.Now()
+	healthMu.Unlock()
+}
+
+// Ping checks the pool now and records the result for Healthy.
+func Ping(ctx context.Context, dbConn *gorm.DB) error {
+	if dbConn == nil {
+		markHealth(ErrUnavailable)
+		return ErrUnavailable
+	}
+	sqlDB, err := dbConn.DB()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+		defer cancel()
+		err = sqlDB.PingContext(ctx)
+	}
+	markHealth(err)
+	return err
+}
+
+// Healthy returns the last ping result, pinging again once it is stale.
+func Healthy(dbConn *gorm.DB) error {
+	healthMu.Lock()
+	err, age := healthErr, time.Since(checkedAt)
+	healthMu.Unlock()
+
+	recheck := healthyRecheck
+	if err != nil {
+		recheck = unhealthyRecheck
+	}
+	if age < recheck {
+		return err
+	}
+	return Ping(context.Background(), dbConn)
+}
+
+// WhenReady runs start, the startup steps that need Postgres (schema check, seeding,
+// background workers), as soon as the database answers: right away when it does, else in
+// the background, retried every unhealthyRecheck until ctx ends. A start error while the
+// database is reachable will not go away by waiting, so it goes to fatal instead.
+func WhenReady(ctx context.Context, dbConn *gorm.DB, start func() error, fatal func(error)) {
+	run := func() bool {
+		if err := Ping(ctx, dbConn); err != nil {
+			return false
+		}
+		if err := start(); err != nil {
+			if Ping(ctx, dbConn) == nil {
+				fatal(err)
+			}
+			return false
+		}
+		started.Store(true)
+		return true
+	}
+	if run() {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(unhealthyRecheck)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if run() {
+					logger.FromContext(ctx).Info("✅ Database reachable, startup steps completed")
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Unguard lets Guard pass the route registered at path, e.g. the diagnostics, which are
+// most needed while the database is down and handle that themselves.
+func Unguard(path string) {
+	unguarded.Store(path, true)
+}
+
+// Guard answers API requests with 503 while the database is unreachable, or the startup
+// steps of WhenReady have not run yet, instead of letting handlers fail on a dead pool or
+// an unchecked schema. Routes outside /api/ (e.g. /ping) and Unguard ones pass through.
+func Guard(dbConn *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+		if _, ok := unguarded.Load(c.FullPath()); ok {
+			c.Next()
+			return
+		}
+		if !started.Load() {
+			apperror.Respond(c, apperror.New(apperror.CodeUnavailable, "Database unavailable, please retry shortly"))
+			return
+		}
+		if err := Healthy(dbConn); err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeUnavailable, "Database unavailable, please retry shortly"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// Stats is the pool usage, for the diagnostics view.
+func Stats(dbConn *gorm.DB) map[string]interface{} {
+	if dbConn == nil {
+		return nil
+	}
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		return nil
+	}
+	stats := sqlDB.Stats()
+	return map[string]interface{}{
+		"maxOpenConnections": stats.MaxOpenConnections,
+		"openConnections":    stats.OpenConnections,
+		"inUse":              stats.InUse,
+		"idle":               stats.Idle,
+		"waitCount":          stats.WaitCount,
+		"waitDuration":       stats.WaitDuration.String(),
+	}
+}
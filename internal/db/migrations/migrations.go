@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Every table the services touch is created by the scripts under sql/. A migration is a
+// pair of files named NNNN_name.up.sql and NNNN_name.down.sql; versions start at 1 and
+// have no gaps. Never edit a script once it is released, add the next version instead:
+// Check refuses a database whose applied scripts no longer match this build.
+//
+//go:embed sql/*.sql
+var scripts embed.FS
+
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Label is the file stem, e.g. 0003_create_catalog.
+func (m Migration) Label() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+var fileName = regexp.MustCompile(`^(\d{4})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	loadOnce sync.Once
+	loaded   []Migration
+	loadErr  error
+)
+
+// All returns the embedded migrations in version order.
+func All() ([]Migration, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = load(scripts)
+	})
+	return loaded, loadErr
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, path := range names {
+		base := strings.TrimPrefix(path, "sql/")
+		parts := fileName.FindStringSubmatch(base)
+		if parts == nil {
+			return nil, fmt.Errorf("migrations: %s is not named NNNN_name.up.sql or NNNN_name.down.sql", base)
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		version, _ := strconv.Atoi(parts[1])
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		}
+		if m.Name != parts[2] {
+			return nil, fmt.Errorf("migrations: version %04d has two names, %s and %s", version, m.Name, parts[2])
+		}
+		if parts[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrations: %s needs both an up and a down script", m.Label())
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	for i, m := range list {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("migrations: expected version %04d, found %s", i+1, m.Label())
+		}
+	}
+	return list, nil
+}
+
+// Latest is the schema version this build expects.
+func Latest() int {
+	all, err := All()
+	if err != nil || len(all) == 0 {
+		return 0
+	}
+	return all[len(all)-1].Version
+}
@@ -0,0 +1,306 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"gorm.io/gorm"
+)
+
+// lockKey serialises migrations across instances: every change runs in a transaction
+// holding this advisory lock, so two pods starting together apply each version once.
+const lockKey = 0x5a4450524f
+
+// Applied is one row of the schema history.
+type Applied struct {
+	Version   int    `gorm:"column:refSMVersion;primaryKey;autoIncrement:false" json:"version"`
+	Name      string `gorm:"column:refSMName" json:"name"`
+	Checksum  string `gorm:"column:refSMChecksum" json:"checksum"`
+	CreatedAt string `gorm:"column:createdAt" json:"appliedAt"`
+	CreatedBy string `gorm:"column:createdBy" json:"appliedBy"`
+}
+
+func (Applied) TableName() string {
+	return `public."SchemaMigrations"`
+}
+
+const createHistory = `
+CREATE TABLE IF NOT EXISTS public."SchemaMigrations" (
+    "refSMVersion"  INTEGER PRIMARY KEY,
+    "refSMName"     TEXT NOT NULL,
+    "refSMChecksum" TEXT NOT NULL,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT
+)`
+
+func lock(tx *gorm.DB) error {
+	return tx.Exec(`SELECT pg_advisory_xact_lock(?)`, lockKey).Error
+}
+
+func history(dbConn *gorm.DB) ([]Applied, error) {
+	var exists bool
+	if err := dbConn.Raw(`SELECT to_regclass('public."SchemaMigrations"') IS NOT NULL`).Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	var rows []Applied
+	err := dbConn.Order(`"refSMVersion"`).Find(&rows).Error
+	return rows, err
+}
+
+// MismatchError lists why the database schema does not match this build.
+type MismatchError struct {
+	Pending  []string
+	Unknown  []string
+	Modified []string
+}
+
+func (e *MismatchError) Error() string {
+	var problems []string
+	if len(e.Pending) > 0 {
+		problems = append(problems, "pending migrations: "+strings.Join(e.Pending, ", ")+" (run `migrate up`)")
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "applied migrations unknown to this build: "+strings.Join(e.Unknown, ", ")+" (the database is newer than the binary)")
+	}
+	if len(e.Modified) > 0 {
+		problems = append(problems, "applied migrations changed since they ran: "+strings.Join(e.Modified, ", "))
+	}
+	return "schema version mismatch:\n  - " + strings.Join(problems, "\n  - ")
+}
+
+func compare(all []Migration, applied []Applied) *MismatchError {
+	known := map[int]Migration{}
+	for _, m := range all {
+		known[m.Version] = m
+	}
+	done := map[int]bool{}
+	result := &MismatchError{}
+	for _, a := range applied {
+		done[a.Version] = true
+		m, ok := known[a.Version]
+		switch {
+		case !ok:
+			result.Unknown = append(result.Unknown, fmt.Sprintf("%04d_%s", a.Version, a.Name))
+		case m.Checksum != a.Checksum:
+			result.Modified = append(result.Modified, m.Label())
+		}
+	}
+	for _, m := range all {
+		if !done[m.Version] {
+			result.Pending = append(result.Pending, m.Label())
+		}
+	}
+	if result.Pending == nil && result.Unknown == nil && result.Modified == nil {
+		return nil
+	}
+	return result
+}
+
+// Check returns a *MismatchError unless every embedded migration, and nothing else, has
+// been applied unchanged. main refuses to start on it.
+func Check(dbConn *gorm.DB) error {
+	all, err := All()
+	if err != nil {
+		return err
+	}
+	applied, err := history(dbConn)
+	if err != nil {
+		return fmt.Errorf("cannot read the schema history: %w", err)
+	}
+	if mismatch := compare(all, applied); mismatch != nil {
+		return mismatch
+	}
+	return nil
+}
+
+// Up applies every pending migration in order, each in its own transaction, and returns
+// the ones it ran. It refuses to run over a history this build does not recognise.
+func Up(dbConn *gorm.DB, by string) ([]Migration, error) {
+	log := logger.InitLogger()
+
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+		return tx.Exec(createHistory).Error
+	}); err != nil {
+		return nil, fmt.Errorf("cannot create the schema history: %w", err)
+	}
+
+	applied, err := history(dbConn)
+	if err != nil {
+		return nil, err
+	}
+	if mismatch := compare(all, applied); mismatch != nil && (mismatch.Unknown != nil || mismatch.Modified != nil) {
+		mismatch.Pending = nil
+		return nil, mismatch
+	}
+
+	var ran []Migration
+	for _, m := range all {
+		applied, err := apply(dbConn, m, by)
+		if err != nil {
+			return ran, fmt.Errorf("migration %s failed: %w", m.Label(), err)
+		}
+		if applied {
+			log.Infof("✅ Applied migration %s", m.Label())
+			ran = append(ran, m)
+		}
+	}
+	return ran, nil
+}
+
+func apply(dbConn *gorm.DB, m Migration, by string) (bool, error) {
+	applied := false
+	err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+		// Another instance may have applied it while this one waited for the lock.
+		var count int64
+		if err := tx.Model(&Applied{}).Where(`"refSMVersion" = ?`, m.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(m.Up).Error; err != nil {
+			return err
+		}
+		applied = true
+		return tx.Create(&Applied{
+			Version:   m.Version,
+			Name:      m.Name,
+			Checksum:  m.Checksum,
+			CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+			CreatedBy: by,
+		}).Error
+	})
+	return applied && err == nil, err
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns them.
+func Down(dbConn *gorm.DB, steps int) ([]Migration, error) {
+	log := logger.InitLogger()
+
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	known := map[int]Migration{}
+	for _, m := range all {
+		known[m.Version] = m
+	}
+
+	var reverted []Migration
+	for i := 0; i < steps; i++ {
+		var m Migration
+		done := false
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			if err := lock(tx); err != nil {
+				return err
+			}
+			applied, err := history(tx)
+			if err != nil {
+				return err
+			}
+			if len(applied) == 0 {
+				done = true
+				return nil
+			}
+			last := applied[len(applied)-1]
+			var ok bool
+			if m, ok = known[last.Version]; !ok {
+				return fmt.Errorf("version %04d_%s is not part of this build and cannot be reverted by it", last.Version, last.Name)
+			}
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Where(`"refSMVersion" = ?`, m.Version).Delete(&Applied{}).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("reverting migration failed: %w", err)
+		}
+		if done {
+			break
+		}
+		log.Infof("↩️ Reverted migration %s", m.Label())
+		reverted = append(reverted, m)
+	}
+	return reverted, nil
+}
+
+// State is one line of Status.
+type State struct {
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	AppliedAt string `json:"appliedAt,omitempty"`
+	AppliedBy string `json:"appliedBy,omitempty"`
+}
+
+// MIGRATION STATES
+const (
+	StateApplied  = "applied"
+	StatePending  = "pending"
+	StateModified = "modified"
+	StateUnknown  = "unknown"
+)
+
+// Status lists the embedded migrations and whatever else the database recorded.
+func Status(dbConn *gorm.DB) ([]State, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := history(dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]Applied{}
+	for _, a := range applied {
+		byVersion[a.Version] = a
+	}
+	var states []State
+	for _, m := range all {
+		s := State{Version: m.Version, Name: m.Name, State: StatePending}
+		if a, ok := byVersion[m.Version]; ok {
+			s.State = StateApplied
+			if a.Checksum != m.Checksum {
+				s.State = StateModified
+			}
+			s.AppliedAt, s.AppliedBy = a.CreatedAt, a.CreatedBy
+			delete(byVersion, m.Version)
+		}
+		states = append(states, s)
+	}
+	for _, a := range applied {
+		if _, ok := byVersion[a.Version]; ok {
+			states = append(states, State{Version: a.Version, Name: a.Name, State: StateUnknown, AppliedAt: a.CreatedAt, AppliedBy: a.CreatedBy})
+		}
+	}
+	return states, nil
+}
+
+// Current is the highest applied version, 0 on an empty database.
+func Current(dbConn *gorm.DB) (int, error) {
+	applied, err := history(dbConn)
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+	return applied[len(applied)-1].Version, nil
+}
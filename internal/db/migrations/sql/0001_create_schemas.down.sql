@@ -0,0 +1,5 @@
+-- Without CASCADE: a schema still holding tables is kept, never dropped with its data.
+DROP SCHEMA IF EXISTS "BundleInOut";
+DROP SCHEMA IF EXISTS "PurchaseOrderManagement";
+DROP SCHEMA IF EXISTS "purchaseOrderMgmt";
+DROP SCHEMA IF EXISTS "purchaseOrder";
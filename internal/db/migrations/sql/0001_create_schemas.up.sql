@@ -0,0 +1,5 @@
+-- Schemas of the ERP modules; public already exists in every database.
+CREATE SCHEMA IF NOT EXISTS "purchaseOrder";
+CREATE SCHEMA IF NOT EXISTS "purchaseOrderMgmt";
+CREATE SCHEMA IF NOT EXISTS "PurchaseOrderManagement";
+CREATE SCHEMA IF NOT EXISTS "BundleInOut";
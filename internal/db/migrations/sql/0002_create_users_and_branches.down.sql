@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS public.otp_verifications;
+DROP TABLE IF EXISTS public."TransactionHistory";
+DROP TABLE IF EXISTS public."refSections";
+DROP TABLE IF EXISTS public."refFloors";
+DROP TABLE IF EXISTS public."Branches";
+DROP TABLE IF EXISTS public."refUserCommunicationDetails";
+DROP TABLE IF EXISTS public."refUserAuthCred";
+DROP TABLE IF EXISTS public."Users";
+DROP TABLE IF EXISTS public."RoleType";
@@ -0,0 +1,131 @@
+-- Users, their credentials and contact details, roles, branches with their floors and
+-- sections, the transaction history and the password reset OTPs.
+--
+-- Timestamps are stored as text ("2006-01-02 15:04:05"), as every service writes them.
+
+CREATE TABLE IF NOT EXISTS public."RoleType" (
+    "refRTId"   SERIAL PRIMARY KEY,
+    "refRTName" TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public."Users" (
+    "refUserId"          SERIAL PRIMARY KEY,
+    "refUserCustId"      TEXT,
+    "refRTId"            INTEGER,
+    "refUserFName"       TEXT,
+    "refUserLName"       TEXT,
+    "refUserDesignation" TEXT,
+    "refUserStatus"      TEXT,
+    "refUserBranchId"    INTEGER,
+    "createdAt"          TEXT,
+    "createdBy"          TEXT,
+    "updatedAt"          TEXT,
+    "updatedBy"          TEXT,
+    "isDelete"           BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."refUserAuthCred" (
+    "refUACId"             SERIAL PRIMARY KEY,
+    "refUserId"            INTEGER NOT NULL,
+    "refUACPassword"       TEXT,
+    "refUACHashedPassword" TEXT,
+    "refUACUsername"       TEXT,
+    "createdAt"            TEXT,
+    "createdBy"            TEXT,
+    "updatedAt"            TEXT,
+    "updatedBy"            TEXT
+);
+CREATE INDEX IF NOT EXISTS "refUserAuthCred_refUserId_idx" ON public."refUserAuthCred" ("refUserId");
+CREATE INDEX IF NOT EXISTS "refUserAuthCred_refUACUsername_idx" ON public."refUserAuthCred" ("refUACUsername");
+
+CREATE TABLE IF NOT EXISTS public."refUserCommunicationDetails" (
+    "refUserComDetId" SERIAL PRIMARY KEY,
+    "refUserId"       INTEGER NOT NULL,
+    "refUCDMobile"    TEXT,
+    "refUCDEmail"     TEXT,
+    "refUCDDoorNo"    TEXT,
+    "refUCDStreet"    TEXT,
+    "refUCDCity"      TEXT,
+    "refUCDState"     TEXT,
+    "createdAt"       TEXT,
+    "createdBy"       TEXT,
+    "updatedAt"       TEXT,
+    "updatedBy"       TEXT
+);
+CREATE INDEX IF NOT EXISTS "refUserCommunicationDetails_refUserId_idx" ON public."refUserCommunicationDetails" ("refUserId");
+
+CREATE TABLE IF NOT EXISTS public."Branches" (
+    "refBranchId"      SERIAL PRIMARY KEY,
+    "refBranchName"    TEXT NOT NULL,
+    "refBranchCode"    TEXT NOT NULL,
+    "refLocation"      TEXT,
+    "refMobile"        TEXT,
+    "refEmail"         TEXT,
+    "isMainBranch"     BOOLEAN NOT NULL DEFAULT FALSE,
+    "isActive"         BOOLEAN NOT NULL DEFAULT TRUE,
+    "refBTId"          INTEGER,
+    "isOnline"         BOOLEAN NOT NULL DEFAULT FALSE,
+    "isOffline"        BOOLEAN NOT NULL DEFAULT FALSE,
+    "refBranchDoorNo"  TEXT,
+    "refBranchStreet"  TEXT,
+    "refBranchCity"    TEXT,
+    "refBranchState"   TEXT,
+    "refBranchPincode" TEXT,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "updatedBy"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."refFloors" (
+    "refFloorId"   SERIAL PRIMARY KEY,
+    "refBranchId"  INTEGER NOT NULL,
+    "refFloorName" TEXT,
+    "refFloorCode" TEXT,
+    "isActive"     TEXT,
+    "createdAt"    TEXT,
+    "createdBy"    TEXT,
+    "updatedAt"    TEXT,
+    "updatedBy"    TEXT,
+    "isDelete"     BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "refFloors_refBranchId_idx" ON public."refFloors" ("refBranchId");
+
+CREATE TABLE IF NOT EXISTS public."refSections" (
+    "refSectionId"     SERIAL PRIMARY KEY,
+    "refFloorId"       INTEGER NOT NULL,
+    "refSectionName"   TEXT,
+    "refSectionCode"   TEXT,
+    "refCategoryId"    INTEGER,
+    "refSubCategoryId" INTEGER,
+    "isActive"         TEXT,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "updatedBy"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "refSections_refFloorId_idx" ON public."refSections" ("refFloorId");
+
+CREATE TABLE IF NOT EXISTS public."TransactionHistory" (
+    "refTransHisId"   SERIAL PRIMARY KEY,
+    "refTransTypeId"  INTEGER,
+    "refTransHisData" TEXT,
+    "createdAt"       TEXT,
+    "createdBy"       TEXT,
+    "refUserId"       INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS public.otp_verifications (
+    id          SERIAL PRIMARY KEY,
+    email       TEXT NOT NULL,
+    otp         TEXT,
+    expires_at  TEXT,
+    is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt" TEXT,
+    "createdBy" TEXT,
+    "updatedAt" TEXT,
+    "updatedBy" TEXT
+);
+CREATE INDEX IF NOT EXISTS otp_verifications_email_idx ON public.otp_verifications (email);
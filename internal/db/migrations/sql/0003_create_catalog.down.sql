@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS public."POProducts";
+DROP TABLE IF EXISTS public.round_off_prices;
+DROP TABLE IF EXISTS public.round_off_settings;
+DROP TABLE IF EXISTS public.customers;
+DROP TABLE IF EXISTS public."Supplier";
+DROP TABLE IF EXISTS public."Patterns";
+DROP TABLE IF EXISTS public."Varient";
+DROP TABLE IF EXISTS public."size";
+DROP TABLE IF EXISTS public."brand";
+DROP TABLE IF EXISTS public."color";
+DROP TABLE IF EXISTS public."design";
+DROP TABLE IF EXISTS public."SettingsProducts";
+DROP TABLE IF EXISTS public."Attributes";
+DROP TABLE IF EXISTS public."AttributeGroup";
+DROP TABLE IF EXISTS public."SubCategories";
+DROP TABLE IF EXISTS public."Categories";
+DROP TABLE IF EXISTS public."InitialCategories";
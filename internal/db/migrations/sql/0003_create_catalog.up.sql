@@ -0,0 +1,224 @@
+-- Product catalogue settings: categories, attributes, the product masters (design,
+-- colour, brand, size, variant, pattern), suppliers, POS customers, round-off ranges
+-- and the legacy PO product list.
+
+CREATE TABLE IF NOT EXISTS public."InitialCategories" (
+    "initialCategoryId"   SERIAL PRIMARY KEY,
+    "initialCategoryName" TEXT NOT NULL,
+    "initialCategoryCode" TEXT NOT NULL,
+    "createdAt"           TEXT,
+    "createdBy"           TEXT,
+    "updatedAt"           TEXT,
+    "updatedBy"           TEXT,
+    "isDelete"            BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."Categories" (
+    "refCategoryid" SERIAL PRIMARY KEY,
+    "categoryName"  TEXT NOT NULL,
+    "categoryCode"  TEXT NOT NULL,
+    "profitMargin"  TEXT,
+    "isActive"      BOOLEAN NOT NULL DEFAULT TRUE,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT,
+    "updatedAt"     TEXT,
+    "updatedBy"     TEXT,
+    "isDelete"      BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- "updatedBY" is spelled as the settings service reads and writes it.
+CREATE TABLE IF NOT EXISTS public."SubCategories" (
+    "refSubCategoryId" SERIAL PRIMARY KEY,
+    "refCategoryId"    INTEGER NOT NULL,
+    "subCategoryName"  TEXT NOT NULL,
+    "subCategoryCode"  TEXT NOT NULL,
+    "isActive"         BOOLEAN NOT NULL DEFAULT TRUE,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "updatedBY"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "SubCategories_refCategoryId_idx" ON public."SubCategories" ("refCategoryId");
+
+CREATE TABLE IF NOT EXISTS public."AttributeGroup" (
+    "attributeGroupId"   SERIAL PRIMARY KEY,
+    "attributeGroupName" TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public."Attributes" (
+    "attributeId"      SERIAL PRIMARY KEY,
+    "attributeGroupId" INTEGER NOT NULL,
+    "attributeKey"     TEXT,
+    "attributeValue"   TEXT,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "updatedBy"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."SettingsProducts" (
+    id              SERIAL PRIMARY KEY,
+    "categoryId"    INTEGER,
+    "subCategoryId" INTEGER,
+    "productName"   TEXT NOT NULL,
+    "hsnCode"       TEXT,
+    "taxPercentage" TEXT,
+    "productCode"   TEXT,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT,
+    "updatedAt"     TEXT,
+    "updatedBy"     TEXT,
+    "isDelete"      BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."design" (
+    id           SERIAL PRIMARY KEY,
+    "designName" TEXT NOT NULL,
+    "createdAt"  TEXT,
+    "createdBy"  TEXT,
+    "updatedAt"  TEXT,
+    "updatedBy"  TEXT,
+    "isDelete"   BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."color" (
+    id          SERIAL PRIMARY KEY,
+    "colorName" TEXT NOT NULL,
+    "createdAt" TEXT,
+    "createdBy" TEXT,
+    "updatedAt" TEXT,
+    "updatedBy" TEXT,
+    "isDelete"  BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."brand" (
+    id          SERIAL PRIMARY KEY,
+    "brandName" TEXT NOT NULL,
+    "createdAt" TEXT,
+    "createdBy" TEXT,
+    "updatedAt" TEXT,
+    "updatedBy" TEXT,
+    "isDelete"  BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."size" (
+    id          SERIAL PRIMARY KEY,
+    "sizeName"  TEXT NOT NULL,
+    "createdAt" TEXT,
+    "createdBy" TEXT,
+    "updatedAt" TEXT,
+    "updatedBy" TEXT,
+    "isDelete"  BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."Varient" (
+    id            SERIAL PRIMARY KEY,
+    "VarientName" TEXT NOT NULL,
+    "createdAt"   TEXT,
+    "createdBy"   TEXT,
+    "updatedAt"   TEXT,
+    "updatedBy"   TEXT,
+    "isDelete"    BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."Patterns" (
+    id            SERIAL PRIMARY KEY,
+    "PatternName" TEXT NOT NULL,
+    "createdAt"   TEXT,
+    "createdBy"   TEXT,
+    "updatedAt"   TEXT,
+    "updatedBy"   TEXT,
+    "isDelete"    BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public."Supplier" (
+    "supplierId"             SERIAL PRIMARY KEY,
+    "supplierName"           TEXT NOT NULL,
+    "supplierCompanyName"    TEXT,
+    "supplierCode"           TEXT,
+    "supplierEmail"          TEXT,
+    "supplierGSTNumber"      TEXT,
+    "supplierPaymentTerms"   TEXT,
+    "supplierBankACNumber"   TEXT,
+    "supplierIFSC"           TEXT,
+    "supplierBankName"       TEXT,
+    "supplierUPI"            TEXT,
+    "supplierIsActive"       TEXT,
+    "supplierContactNumber"  TEXT,
+    "emergencyContactName"   TEXT,
+    "emergencyContactNumber" TEXT,
+    "supplierDoorNumber"     TEXT,
+    "supplierStreet"         TEXT,
+    "supplierCity"           TEXT,
+    "supplierState"          TEXT,
+    "supplierCountry"        TEXT,
+    pincode                  TEXT,
+    "creditedDays"           INTEGER,
+    "createdAt"              TEXT,
+    "createdBy"              TEXT,
+    "updatedAt"              TEXT,
+    "updatedBy"              TEXT,
+    "isDelete"               BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public.customers (
+    "refCustomerId"       SERIAL PRIMARY KEY,
+    "refCustomerName"     VARCHAR(255) NOT NULL,
+    "refMobileNo"         VARCHAR(20) NOT NULL,
+    "refAddress"          VARCHAR(255) NOT NULL,
+    "refCity"             VARCHAR(100) NOT NULL,
+    "refPincode"          VARCHAR(20),
+    "refState"            VARCHAR(100),
+    "refCountry"          VARCHAR(100) NOT NULL,
+    "refMembershipNumber" VARCHAR(100),
+    "refTaxNumber"        VARCHAR(50),
+    "createdAt"           TIMESTAMP,
+    "createdBy"           VARCHAR(100),
+    "updatedAt"           TIMESTAMP,
+    "updatedBy"           VARCHAR(100),
+    "isDelete"            BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "customers_refMobileNo_key" ON public.customers ("refMobileNo");
+
+CREATE TABLE IF NOT EXISTS public.round_off_settings (
+    id         SERIAL PRIMARY KEY,
+    from_range TEXT NOT NULL,
+    to_range   TEXT NOT NULL,
+    created_at TEXT,
+    created_by TEXT,
+    updated_at TEXT,
+    updated_by TEXT,
+    isdelete   BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS public.round_off_prices (
+    id           SERIAL PRIMARY KEY,
+    round_off_id INTEGER NOT NULL,
+    price        TEXT NOT NULL,
+    created_at   TEXT,
+    created_by   TEXT,
+    updated_at   TEXT,
+    updated_by   TEXT,
+    isdelete     BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS round_off_prices_round_off_id_idx ON public.round_off_prices (round_off_id);
+
+CREATE TABLE IF NOT EXISTS public."POProducts" (
+    "poId"          SERIAL PRIMARY KEY,
+    "poName"        TEXT,
+    "poDescription" TEXT,
+    "poSKU"         TEXT,
+    "poHSN"         TEXT,
+    "poQuantity"    TEXT,
+    "poPrice"       TEXT,
+    "poDiscPercent" TEXT,
+    "poDisc"        TEXT,
+    "poTotalPrice"  TEXT,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT,
+    "updatedAt"     TEXT,
+    "updatedBy"     TEXT,
+    "isDelete"      BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "purchaseOrder".product_field_definitions;
+DROP TABLE IF EXISTS "purchaseOrder".products;
+DROP TABLE IF EXISTS "purchaseOrder"."ProductsDummyAcceptance";
+DROP TABLE IF EXISTS "purchaseOrder"."PurchaseOrderItemsInitial";
+DROP TABLE IF EXISTS "purchaseOrder"."CreatePurchaseOrder";
@@ -0,0 +1,113 @@
+-- The first purchase order flow: orders, their initial items, the dummy SKUs issued on
+-- acceptance, the product catalogue and its custom field definitions.
+--
+-- isDelete and isReceived are text here, as the models of this schema declare them.
+
+CREATE TABLE IF NOT EXISTS "purchaseOrder"."CreatePurchaseOrder" (
+    "purchaseOrderId" SERIAL PRIMARY KEY,
+    "poNumber"        TEXT,
+    "supplierId"      INTEGER,
+    "branchId"        INTEGER,
+    status            INTEGER,
+    "expectedDate"    TEXT,
+    "modeOfTransport" TEXT,
+    "subTotal"        TEXT,
+    "discountOverall" TEXT,
+    "payAmount"       TEXT,
+    "isTaxApplied"    BOOLEAN NOT NULL DEFAULT FALSE,
+    "taxPercentage"   TEXT,
+    "taxedAmount"     TEXT,
+    "totalAmount"     TEXT,
+    "totalPaid"       TEXT,
+    "paymentPending"  TEXT,
+    "isInternalPO"    BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt"       TEXT,
+    "createdBy"       TEXT,
+    "updatedAt"       TEXT,
+    "updatedBy"       TEXT,
+    "isDelete"        TEXT DEFAULT 'false'
+);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrder"."PurchaseOrderItemsInitial" (
+    "itemId"           SERIAL PRIMARY KEY,
+    "purchaseOrderId"  INTEGER NOT NULL,
+    "productName"      TEXT,
+    "refCategoryid"    INTEGER,
+    "refSubCategoryId" INTEGER,
+    "HSNCode"          TEXT,
+    "purchaseQuantity" TEXT,
+    "purchasePrice"    TEXT,
+    "discountPrice"    TEXT,
+    "discountAmount"   TEXT,
+    "totalAmount"      TEXT,
+    "isReceived"       BOOLEAN NOT NULL DEFAULT FALSE,
+    "acceptanceStatus" TEXT,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "updatedBy"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderItemsInitial_purchaseOrderId_idx" ON "purchaseOrder"."PurchaseOrderItemsInitial" ("purchaseOrderId");
+
+CREATE TABLE IF NOT EXISTS "purchaseOrder"."ProductsDummyAcceptance" (
+    "dummyProductsId"    SERIAL PRIMARY KEY,
+    "purchaseOrderId"    INTEGER NOT NULL,
+    "productName"        TEXT,
+    "refCategoryId"      INTEGER,
+    "refSubCategoryId"   INTEGER,
+    "HSNCode"            TEXT,
+    "dummySKU"           TEXT,
+    price                TEXT,
+    "discountPercentage" TEXT,
+    "discountAmount"     TEXT,
+    "isReceived"         TEXT DEFAULT 'false',
+    "acceptanceStatus"   TEXT,
+    "createdAt"          TEXT,
+    "createdBy"          TEXT,
+    "updatedAt"          TEXT,
+    "updatedBy"          TEXT,
+    "isDelete"           TEXT DEFAULT 'false'
+);
+CREATE INDEX IF NOT EXISTS "ProductsDummyAcceptance_purchaseOrderId_idx" ON "purchaseOrder"."ProductsDummyAcceptance" ("purchaseOrderId");
+CREATE INDEX IF NOT EXISTS "ProductsDummyAcceptance_dummySKU_idx" ON "purchaseOrder"."ProductsDummyAcceptance" ("dummySKU");
+
+CREATE TABLE IF NOT EXISTS "purchaseOrder".products (
+    id                   SERIAL PRIMARY KEY,
+    name                 TEXT,
+    sku                  TEXT,
+    gtin                 TEXT,
+    category_id          INTEGER,
+    subcategory_id       INTEGER,
+    description          TEXT,
+    detailed_description TEXT,
+    price                TEXT,
+    mrp                  TEXT,
+    cost                 TEXT,
+    spl_price            TEXT,
+    start_date           TEXT,
+    end_date             TEXT,
+    tax_class            TEXT,
+    product_image        TEXT,
+    featured             BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt"          TEXT,
+    "createdBy"          TEXT,
+    "updatedAt"          TEXT,
+    "updatedBy"          TEXT,
+    "isDelete"           TEXT DEFAULT 'false'
+);
+CREATE INDEX IF NOT EXISTS products_sku_idx ON "purchaseOrder".products (sku);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrder".product_field_definitions (
+    id           SERIAL PRIMARY KEY,
+    column_name  TEXT,
+    column_label TEXT NOT NULL,
+    data_type    TEXT,
+    "type"       TEXT,
+    is_required  BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt"  TEXT,
+    "createdBy"  TEXT,
+    "updatedAt"  TEXT,
+    "updatedBy"  TEXT,
+    "isDelete"   BOOLEAN NOT NULL DEFAULT FALSE
+);
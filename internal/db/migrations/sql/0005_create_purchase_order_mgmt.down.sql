@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."StockTransferItems";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."StockTransferMaster";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."Inventory_StockTransferItems";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."Inventory_StockTransfers";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."ProductImages";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."RejectedProducts";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."PurchaseOrderAcceptedProducts";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."PurchaseOrderProductInstances";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."PurchaseOrderProducts";
+DROP TABLE IF EXISTS "purchaseOrderMgmt"."PurchaseOrders";
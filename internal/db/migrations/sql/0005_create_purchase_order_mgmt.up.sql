@@ -0,0 +1,170 @@
+-- The PO module: orders and their products, accepted and rejected units, product
+-- images, and both generations of stock transfers.
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."PurchaseOrders" (
+    purchase_order_id     SERIAL PRIMARY KEY,
+    "purchaseOrderNumber" TEXT,
+    supplier_id           INTEGER,
+    branch_id             INTEGER,
+    sub_total             TEXT,
+    total_discount        TEXT,
+    tax_enabled           BOOLEAN NOT NULL DEFAULT FALSE,
+    tax_percentage        TEXT,
+    tax_amount            TEXT,
+    total_amount          TEXT,
+    credited_date         TEXT,
+    "invoiceStatus"       BOOLEAN NOT NULL DEFAULT FALSE,
+    "invoiceFinalNumber"  TEXT,
+    "createdAt"           TEXT,
+    "createdBy"           TEXT,
+    "updatedAt"           TEXT,
+    "updatedBy"           TEXT,
+    "isDelete"            BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."PurchaseOrderProducts" (
+    po_product_id     SERIAL PRIMARY KEY,
+    purchase_order_id INTEGER NOT NULL,
+    category_id       INTEGER,
+    description       TEXT,
+    unit_price        TEXT,
+    discount          TEXT,
+    quantity          TEXT,
+    total             TEXT,
+    accepted_quantity TEXT,
+    rejected_quantity TEXT,
+    accepted_total    TEXT,
+    status            TEXT,
+    "createdAt"       TEXT,
+    "createdBy"       TEXT,
+    "updatedAt"       TEXT,
+    "updatedBy"       TEXT
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderProducts_purchase_order_id_idx" ON "purchaseOrderMgmt"."PurchaseOrderProducts" (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."PurchaseOrderProductInstances" (
+    product_instance_id SERIAL PRIMARY KEY,
+    po_product_id       INTEGER NOT NULL,
+    serial_no           TEXT,
+    category_id         INTEGER,
+    product_description TEXT,
+    unit_price          TEXT,
+    status              TEXT,
+    "createdAt"         TEXT,
+    "createdBy"         TEXT
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderProductInstances_po_product_id_idx" ON "purchaseOrderMgmt"."PurchaseOrderProductInstances" (po_product_id);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."PurchaseOrderAcceptedProducts" (
+    product_instance_id SERIAL PRIMARY KEY,
+    po_product_id       INTEGER,
+    "purchaseOrderId"   INTEGER,
+    line_number         TEXT,
+    reference_number    TEXT,
+    product_description TEXT,
+    product_name        TEXT,
+    "SKU"               TEXT,
+    discount            TEXT,
+    unit_price          TEXT,
+    discount_price      TEXT,
+    margin              TEXT,
+    total_amount        TEXT,
+    quantity            TEXT,
+    category_id         INTEGER,
+    sub_category_id     INTEGER,
+    "productBranchId"   INTEGER,
+    status              TEXT,
+    "createdAt"         TEXT,
+    "createdBy"         TEXT,
+    "updatedAt"         TEXT,
+    "updatedBy"         TEXT,
+    "isDelete"          BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderAcceptedProducts_SKU_idx" ON "purchaseOrderMgmt"."PurchaseOrderAcceptedProducts" ("SKU");
+CREATE INDEX IF NOT EXISTS "PurchaseOrderAcceptedProducts_purchaseOrderId_idx" ON "purchaseOrderMgmt"."PurchaseOrderAcceptedProducts" ("purchaseOrderId");
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."RejectedProducts" (
+    rejected_product_id SERIAL PRIMARY KEY,
+    po_product_id       INTEGER NOT NULL,
+    category_id         INTEGER,
+    product_description TEXT,
+    unit_price          TEXT,
+    rejected_qty        TEXT,
+    reason              TEXT,
+    created_at          TEXT,
+    created_by          TEXT
+);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."ProductImages" (
+    image_id            SERIAL PRIMARY KEY,
+    product_instance_id INTEGER,
+    file_name           TEXT,
+    sku_found           TEXT,
+    extracted_sku       TEXT,
+    created_at          TEXT,
+    created_by          TEXT,
+    is_delete           BOOLEAN DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "ProductImages_product_instance_id_idx" ON "purchaseOrderMgmt"."ProductImages" (product_instance_id);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."Inventory_StockTransfers" (
+    stock_transfer_id   SERIAL PRIMARY KEY,
+    from_branch_id      INTEGER NOT NULL,
+    from_branch_name    TEXT,
+    from_branch_email   TEXT,
+    from_branch_address TEXT,
+    to_branch_id        INTEGER NOT NULL,
+    to_branch_name      TEXT,
+    to_branch_email     TEXT,
+    to_branch_address   TEXT,
+    mode_of_transport   TEXT,
+    sub_total           TEXT,
+    discount_overall    TEXT,
+    total_amount        TEXT,
+    payment_pending     TEXT,
+    po_number           TEXT,
+    status              INTEGER,
+    created_at          TEXT,
+    created_by          TEXT,
+    updated_at          TEXT,
+    updated_by          TEXT,
+    is_delete           BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."Inventory_StockTransferItems" (
+    stock_transfer_item_id SERIAL PRIMARY KEY,
+    stock_transfer_id      INTEGER NOT NULL,
+    product_instance_id    INTEGER,
+    product_name           TEXT,
+    sku                    TEXT,
+    is_received            BOOLEAN NOT NULL DEFAULT FALSE,
+    acceptance_status      TEXT
+);
+CREATE INDEX IF NOT EXISTS "Inventory_StockTransferItems_stock_transfer_id_idx" ON "purchaseOrderMgmt"."Inventory_StockTransferItems" (stock_transfer_id);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."StockTransferMaster" (
+    id                    SERIAL PRIMARY KEY,
+    "stockTransferNumber" TEXT,
+    from_branch_id        INTEGER NOT NULL,
+    to_branch_id          INTEGER NOT NULL,
+    created_at            TEXT,
+    created_by            TEXT,
+    updated_at            TEXT,
+    updated_by            TEXT,
+    is_delete             BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS "purchaseOrderMgmt"."StockTransferItems" (
+    id                SERIAL PRIMARY KEY,
+    stock_transfer_id INTEGER NOT NULL,
+    grn_item_id       INTEGER,
+    sku               TEXT,
+    is_received       BOOLEAN NOT NULL DEFAULT FALSE,
+    acceptance_status TEXT,
+    created_at        TEXT,
+    created_by        TEXT,
+    updated_at        TEXT,
+    updated_by        TEXT
+);
+CREATE INDEX IF NOT EXISTS "StockTransferItems_stock_transfer_id_idx" ON "purchaseOrderMgmt"."StockTransferItems" (stock_transfer_id);
+CREATE INDEX IF NOT EXISTS "StockTransferItems_sku_idx" ON "purchaseOrderMgmt"."StockTransferItems" (sku);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."StockTransferAudit";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."DebitNoteItems";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."DebitNote";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."PurchaseOrderGRNItems";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."PurchaseOrderGRN";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."PurchaseOrderItems";
+DROP TABLE IF EXISTS "PurchaseOrderManagement"."PurchaseOrders";
@@ -0,0 +1,132 @@
+-- The current purchase order flow: orders and items, GRNs with their SKU-level items,
+-- debit notes for returns, and the stock transfer audit trail.
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."PurchaseOrders" (
+    id            SERIAL PRIMARY KEY,
+    po_number     TEXT NOT NULL,
+    "supplierId"  INTEGER NOT NULL,
+    branchid      INTEGER NOT NULL,
+    "poYear"      TEXT,
+    "poMonth"     TEXT,
+    status        TEXT,
+    "subTotal"    TEXT,
+    "taxEnabled"  BOOLEAN NOT NULL DEFAULT FALSE,
+    "taxRate"     TEXT,
+    "taxAmount"   TEXT,
+    "shippingFee" TEXT,
+    "paymentFee"  TEXT,
+    "roundOff"    TEXT,
+    total         TEXT,
+    "createdAt"   TEXT,
+    "createdBy"   TEXT,
+    "isDelete"    BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrders_po_number_idx" ON "PurchaseOrderManagement"."PurchaseOrders" (po_number);
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."PurchaseOrderItems" (
+    id                   SERIAL PRIMARY KEY,
+    "purchaseOrderId"    INTEGER NOT NULL,
+    "categoryId"         INTEGER,
+    "subCategoryId"      INTEGER,
+    "productDescription" TEXT,
+    "unitPrice"          TEXT,
+    quantity             TEXT,
+    "discountPercent"    TEXT,
+    "discountAmount"     TEXT,
+    "lineTotal"          TEXT,
+    "receivedQuantity"   TEXT DEFAULT '0',
+    "isClosed"           BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt"          TEXT
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderItems_purchaseOrderId_idx" ON "PurchaseOrderManagement"."PurchaseOrderItems" ("purchaseOrderId");
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."PurchaseOrderGRN" (
+    id                 SERIAL PRIMARY KEY,
+    "purchaseOrderId"  INTEGER NOT NULL,
+    "supplierId"       INTEGER,
+    "supplierName"     TEXT,
+    branchid           INTEGER,
+    "branchCode"       TEXT,
+    "poNumber"         TEXT,
+    "grnDate"          TEXT,
+    "totalReceivedQty" TEXT,
+    "taxRate"          TEXT,
+    "taxAmount"        TEXT,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderGRN_purchaseOrderId_idx" ON "PurchaseOrderManagement"."PurchaseOrderGRN" ("purchaseOrderId");
+
+-- One row per SKU; quantity drops to 0 once the unit is sold or returned.
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."PurchaseOrderGRNItems" (
+    id                 SERIAL PRIMARY KEY,
+    "grnId"            INTEGER NOT NULL,
+    "purchaseOrderId"  INTEGER,
+    "supplierId"       INTEGER,
+    "lineNo"           TEXT,
+    "refNo"            TEXT,
+    "productId"        INTEGER,
+    "productName"      TEXT,
+    "designId"         INTEGER,
+    "designName"       TEXT,
+    "patternId"        INTEGER,
+    "patternName"      TEXT,
+    "varientId"        INTEGER,
+    "varientName"      TEXT,
+    "colorId"          INTEGER,
+    "colorName"        TEXT,
+    "sizeId"           INTEGER,
+    "sizeName"         TEXT,
+    cost               TEXT,
+    "profitPercent"    TEXT,
+    total              TEXT,
+    "roundOff"         TEXT,
+    "meterQty"         TEXT,
+    "clothType"        TEXT,
+    "quantityInMeters" TEXT,
+    "isReadymade"      BOOLEAN NOT NULL DEFAULT FALSE,
+    "isSaree"          BOOLEAN NOT NULL DEFAULT FALSE,
+    quantity           INTEGER NOT NULL DEFAULT 1,
+    sku                TEXT NOT NULL,
+    "productBranchId"  INTEGER,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT,
+    "updatedAt"        TEXT,
+    "isDelete"         BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderGRNItems_grnId_idx" ON "PurchaseOrderManagement"."PurchaseOrderGRNItems" ("grnId");
+CREATE INDEX IF NOT EXISTS "PurchaseOrderGRNItems_sku_idx" ON "PurchaseOrderManagement"."PurchaseOrderGRNItems" (sku);
+CREATE INDEX IF NOT EXISTS "PurchaseOrderGRNItems_productBranchId_idx" ON "PurchaseOrderManagement"."PurchaseOrderGRNItems" ("productBranchId");
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."DebitNote" (
+    id              SERIAL PRIMARY KEY,
+    "poId"          INTEGER,
+    "supplierId"    INTEGER,
+    "totalQuantity" TEXT,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT
+);
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."DebitNoteItems" (
+    id                SERIAL PRIMARY KEY,
+    "debitNoteId"     INTEGER NOT NULL,
+    "poId"            INTEGER,
+    "supplierId"      INTEGER,
+    sku               TEXT,
+    "productId"       INTEGER,
+    "purchaseOrderId" INTEGER,
+    quantity          TEXT,
+    "createdAt"       TEXT,
+    "createdBy"       TEXT
+);
+CREATE INDEX IF NOT EXISTS "DebitNoteItems_debitNoteId_idx" ON "PurchaseOrderManagement"."DebitNoteItems" ("debitNoteId");
+
+CREATE TABLE IF NOT EXISTS "PurchaseOrderManagement"."StockTransferAudit" (
+    id           SERIAL PRIMARY KEY,
+    productid    INTEGER,
+    sku          TEXT,
+    frombranchid INTEGER,
+    tobranchid   INTEGER,
+    createdat    TEXT,
+    createdby    TEXT
+);
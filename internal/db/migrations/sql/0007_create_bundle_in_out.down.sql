@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "BundleInOut".bundle_inward_bills;
+DROP TABLE IF EXISTS "BundleInOut".bundle_inwards;
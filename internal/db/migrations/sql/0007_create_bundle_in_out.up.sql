@@ -0,0 +1,38 @@
+-- Bundle inwards (SSINW-numbered receipts of supplier bundles) and their bills.
+
+CREATE TABLE IF NOT EXISTS "BundleInOut".bundle_inwards (
+    id                   SERIAL PRIMARY KEY,
+    "bundleInwardNumber" TEXT,
+    po_id                INTEGER,
+    po_date              TEXT,
+    supplier_id          INTEGER,
+    location             TEXT,
+    po_value             TEXT,
+    receiving_type       TEXT,
+    remarks              TEXT,
+    po_qty               TEXT,
+    box_count            TEXT,
+    grn_date             TEXT,
+    grn_status           TEXT,
+    grn_value            TEXT,
+    bundle_status        TEXT,
+    transporter_name     TEXT,
+    created_date         TEXT,
+    created_at           TEXT,
+    updated_at           TEXT
+);
+CREATE INDEX IF NOT EXISTS bundle_inwards_po_id_idx ON "BundleInOut".bundle_inwards (po_id);
+
+CREATE TABLE IF NOT EXISTS "BundleInOut".bundle_inward_bills (
+    id            SERIAL PRIMARY KEY,
+    inward_id     INTEGER NOT NULL,
+    bill_date     TEXT,
+    bill_no       TEXT,
+    bill_qty      TEXT,
+    taxable_value TEXT,
+    tax_percent   TEXT,
+    tax_amount    TEXT,
+    invoice_value TEXT,
+    created_at    TEXT
+);
+CREATE INDEX IF NOT EXISTS bundle_inward_bills_inward_id_idx ON "BundleInOut".bundle_inward_bills (inward_id);
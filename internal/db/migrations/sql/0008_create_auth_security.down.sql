@@ -0,0 +1,13 @@
+ALTER TABLE public.otp_verifications DROP COLUMN IF EXISTS reset_token_used_at;
+ALTER TABLE public.otp_verifications DROP COLUMN IF EXISTS reset_token_expires_at;
+ALTER TABLE public.otp_verifications DROP COLUMN IF EXISTS reset_token_hash;
+ALTER TABLE public.otp_verifications DROP COLUMN IF EXISTS attempts;
+ALTER TABLE public.otp_verifications DROP COLUMN IF EXISTS otp_hash;
+
+DROP TABLE IF EXISTS public."RoleTwoFactorPolicy";
+DROP TABLE IF EXISTS public."UserRecoveryCodes";
+DROP TABLE IF EXISTS public."UserTwoFactor";
+DROP TABLE IF EXISTS public."LoginAttempts";
+DROP TABLE IF EXISTS public."RolePermissions";
+DROP TABLE IF EXISTS public."SigningKeys";
+DROP TABLE IF EXISTS public."UserSessions";
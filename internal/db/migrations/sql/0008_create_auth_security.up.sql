@@ -0,0 +1,83 @@
+-- Sessions and refresh tokens, the JWT signing keyset, the role permission matrix,
+-- login lockouts, TOTP two-factor with recovery codes, and hashed reset OTPs.
+
+CREATE TABLE IF NOT EXISTS public."UserSessions" (
+    "refSessionId"            TEXT PRIMARY KEY,
+    "refUserId"               INTEGER NOT NULL,
+    "refRefreshTokenHash"     TEXT NOT NULL,
+    "refPrevRefreshTokenHash" TEXT,
+    "refSessionExpiresAt"     TEXT,
+    "refSessionRevokedAt"     TEXT,
+    "refSessionRevokeReason"  TEXT,
+    "refSessionUserAgent"     TEXT,
+    "refSessionIp"            TEXT,
+    "createdAt"               TEXT,
+    "updatedAt"               TEXT
+);
+CREATE INDEX IF NOT EXISTS "UserSessions_refUserId_idx" ON public."UserSessions" ("refUserId");
+CREATE INDEX IF NOT EXISTS "UserSessions_refRefreshTokenHash_idx" ON public."UserSessions" ("refRefreshTokenHash");
+CREATE INDEX IF NOT EXISTS "UserSessions_refPrevRefreshTokenHash_idx" ON public."UserSessions" ("refPrevRefreshTokenHash");
+
+CREATE TABLE IF NOT EXISTS public."SigningKeys" (
+    "refSKId"        TEXT PRIMARY KEY,
+    "refSKSecret"    TEXT NOT NULL,
+    "refSKStatus"    TEXT NOT NULL,
+    "refSKExpiresAt" TEXT,
+    "createdAt"      TEXT,
+    "createdBy"      TEXT,
+    "updatedAt"      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS public."RolePermissions" (
+    "refRPId"       SERIAL PRIMARY KEY,
+    "refRTId"       INTEGER NOT NULL,
+    "permissionKey" TEXT NOT NULL,
+    "createdAt"     TEXT,
+    "createdBy"     TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "RolePermissions_refRTId_permissionKey_key" ON public."RolePermissions" ("refRTId", "permissionKey");
+
+CREATE TABLE IF NOT EXISTS public."LoginAttempts" (
+    "refLAId"            SERIAL PRIMARY KEY,
+    "refLAScope"         TEXT NOT NULL,
+    "refLAKey"           TEXT NOT NULL,
+    "refLAFailures"      INTEGER NOT NULL DEFAULT 0,
+    "refLALastFailureAt" TEXT,
+    "refLALockedUntil"   TEXT,
+    "createdAt"          TEXT,
+    "updatedAt"          TEXT
+);
+CREATE INDEX IF NOT EXISTS "LoginAttempts_refLAScope_refLAKey_idx" ON public."LoginAttempts" ("refLAScope", "refLAKey");
+
+CREATE TABLE IF NOT EXISTS public."UserTwoFactor" (
+    "refUserId"      INTEGER PRIMARY KEY,
+    "refTFSecret"    TEXT,
+    "refTFEnabled"   BOOLEAN NOT NULL DEFAULT FALSE,
+    "refTFLastStep"  BIGINT,
+    "refTFEnabledAt" TEXT,
+    "createdAt"      TEXT,
+    "updatedAt"      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS public."UserRecoveryCodes" (
+    "refRCId"     SERIAL PRIMARY KEY,
+    "refUserId"   INTEGER NOT NULL,
+    "refRCHash"   TEXT NOT NULL,
+    "refRCUsedAt" TEXT,
+    "createdAt"   TEXT
+);
+CREATE INDEX IF NOT EXISTS "UserRecoveryCodes_refUserId_idx" ON public."UserRecoveryCodes" ("refUserId");
+
+CREATE TABLE IF NOT EXISTS public."RoleTwoFactorPolicy" (
+    "refRTId"     INTEGER PRIMARY KEY,
+    "refTFPolicy" TEXT NOT NULL,
+    "updatedAt"   TEXT,
+    "updatedBy"   TEXT
+);
+
+-- Reset OTPs are stored hashed, with an attempt counter and a single-use reset token.
+ALTER TABLE public.otp_verifications ADD COLUMN IF NOT EXISTS otp_hash TEXT;
+ALTER TABLE public.otp_verifications ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE public.otp_verifications ADD COLUMN IF NOT EXISTS reset_token_hash TEXT;
+ALTER TABLE public.otp_verifications ADD COLUMN IF NOT EXISTS reset_token_expires_at TEXT;
+ALTER TABLE public.otp_verifications ADD COLUMN IF NOT EXISTS reset_token_used_at TEXT;
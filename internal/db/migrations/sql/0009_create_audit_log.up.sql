@@ -0,0 +1,18 @@
+-- Who changed what across the modules, with the before and after state as JSON.
+
+CREATE TABLE IF NOT EXISTS public."AuditLog" (
+    "refALId"          SERIAL PRIMARY KEY,
+    "refALEntity"      TEXT NOT NULL,
+    "refALEntityId"    TEXT,
+    "refALAction"      TEXT NOT NULL,
+    "refALActorId"     INTEGER,
+    "refALActorRoleId" INTEGER,
+    "refALBranchId"    INTEGER,
+    "refALBefore"      JSONB,
+    "refALAfter"       JSONB,
+    "refALChanges"     JSONB,
+    "createdAt"        TEXT
+);
+CREATE INDEX IF NOT EXISTS "AuditLog_refALEntity_refALEntityId_idx" ON public."AuditLog" ("refALEntity", "refALEntityId");
+CREATE INDEX IF NOT EXISTS "AuditLog_refALActorId_idx" ON public."AuditLog" ("refALActorId");
+CREATE INDEX IF NOT EXISTS "AuditLog_createdAt_idx" ON public."AuditLog" ("createdAt");
@@ -0,0 +1,3 @@
+ALTER TABLE "BundleInOut".bundle_inward_bills DROP COLUMN IF EXISTS created_by;
+ALTER TABLE "BundleInOut".bundle_inwards DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE "BundleInOut".bundle_inwards DROP COLUMN IF EXISTS created_by;
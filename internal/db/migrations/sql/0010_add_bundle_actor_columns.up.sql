@@ -0,0 +1,4 @@
+-- Bundle inwards and their bills record the authenticated user who wrote them.
+ALTER TABLE "BundleInOut".bundle_inwards ADD COLUMN IF NOT EXISTS created_by TEXT;
+ALTER TABLE "BundleInOut".bundle_inwards ADD COLUMN IF NOT EXISTS updated_by TEXT;
+ALTER TABLE "BundleInOut".bundle_inward_bills ADD COLUMN IF NOT EXISTS created_by TEXT;
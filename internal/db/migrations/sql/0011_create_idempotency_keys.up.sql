@@ -0,0 +1,20 @@
+-- Idempotency-Key replays: the first answer to a create request is kept for the key's
+-- window and sent again, unchanged, to retries of the same request.
+CREATE TABLE IF NOT EXISTS public."IdempotencyKeys" (
+    "refIKId"             SERIAL PRIMARY KEY,
+    "refIKScope"          TEXT NOT NULL,
+    "refIKKey"            TEXT NOT NULL,
+    "refIKMethod"         TEXT NOT NULL,
+    "refIKPath"           TEXT NOT NULL,
+    "refIKRequestHash"    TEXT NOT NULL,
+    "refIKStatus"         TEXT NOT NULL,
+    "refIKResponseCode"   INTEGER,
+    "refIKResponseType"   TEXT,
+    "refIKResponseBody"   BYTEA,
+    "refIKLockedUntil"    TEXT,
+    "refIKExpiresAt"      TEXT NOT NULL,
+    "createdAt"           TEXT,
+    "updatedAt"           TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "IdempotencyKeys_refIKScope_refIKKey_key" ON public."IdempotencyKeys" ("refIKScope", "refIKKey");
+CREATE INDEX IF NOT EXISTS "IdempotencyKeys_refIKExpiresAt_idx" ON public."IdempotencyKeys" ("refIKExpiresAt");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public."NumberSeriesIssued";
+DROP TABLE IF EXISTS public."NumberSeriesCounters";
+DROP TABLE IF EXISTS public."NumberSeries";
@@ -0,0 +1,40 @@
+-- Document numbering series. NumberSeries holds the configured formats (branch 0 is the
+-- default of every branch), NumberSeriesCounters the last number drawn per document type,
+-- branch and fiscal year, and NumberSeriesIssued every number handed out, for the gap audit.
+
+CREATE TABLE IF NOT EXISTS public."NumberSeries" (
+    "refNSId"        SERIAL PRIMARY KEY,
+    "refNSDocType"   TEXT NOT NULL,
+    "refNSBranchId"  INTEGER NOT NULL DEFAULT 0,
+    "refNSFormat"    TEXT NOT NULL,
+    "refNSStartAt"   BIGINT NOT NULL DEFAULT 1,
+    "refNSPerBranch" BOOLEAN NOT NULL DEFAULT FALSE,
+    "createdAt"      TEXT,
+    "createdBy"      TEXT,
+    "updatedAt"      TEXT,
+    "updatedBy"      TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "NumberSeries_refNSDocType_refNSBranchId_key" ON public."NumberSeries" ("refNSDocType", "refNSBranchId");
+
+CREATE TABLE IF NOT EXISTS public."NumberSeriesCounters" (
+    "refNSCId"         SERIAL PRIMARY KEY,
+    "refNSCDocType"    TEXT NOT NULL,
+    "refNSCBranchId"   INTEGER NOT NULL DEFAULT 0,
+    "refNSCFiscalYear" INTEGER NOT NULL,
+    "refNSCLastValue"  BIGINT NOT NULL,
+    "updatedAt"        TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "NumberSeriesCounters_refNSCDocType_refNSCBranchId_refNSCFiscalYear_key" ON public."NumberSeriesCounters" ("refNSCDocType", "refNSCBranchId", "refNSCFiscalYear");
+
+CREATE TABLE IF NOT EXISTS public."NumberSeriesIssued" (
+    "refNSIId"         SERIAL PRIMARY KEY,
+    "refNSIDocType"    TEXT NOT NULL,
+    "refNSIBranchId"   INTEGER NOT NULL DEFAULT 0,
+    "refNSIFiscalYear" INTEGER NOT NULL,
+    "refNSISeq"        BIGINT NOT NULL,
+    "refNSINumber"     TEXT NOT NULL,
+    "createdAt"        TEXT,
+    "createdBy"        TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "NumberSeriesIssued_refNSIDocType_refNSINumber_key" ON public."NumberSeriesIssued" ("refNSIDocType", "refNSINumber");
+CREATE INDEX IF NOT EXISTS "NumberSeriesIssued_counter_idx" ON public."NumberSeriesIssued" ("refNSIDocType", "refNSIBranchId", "refNSIFiscalYear", "refNSISeq");
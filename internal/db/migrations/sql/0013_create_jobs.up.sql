@@ -0,0 +1,26 @@
+-- Background jobs: slow or failure-prone side effects (Shopify, mail, exports) run here
+-- instead of inside the request. A failed attempt is retried with backoff until
+-- refJobMaxAttempts, then the job is dead and waits for an admin to retry it.
+CREATE TABLE IF NOT EXISTS public."Jobs" (
+    "refJobId"          BIGSERIAL PRIMARY KEY,
+    "refJobKind"        TEXT NOT NULL,
+    "refJobPayload"     TEXT NOT NULL,
+    "refJobStatus"      TEXT NOT NULL,
+    "refJobAttempts"    INTEGER NOT NULL DEFAULT 0,
+    "refJobMaxAttempts" INTEGER NOT NULL,
+    "refJobRunAt"       TEXT NOT NULL,
+    "refJobLockedBy"    TEXT,
+    "refJobLockedUntil" TEXT,
+    "refJobLastError"   TEXT,
+    "refJobResult"      TEXT,
+    "refJobSensitive"   BOOLEAN NOT NULL DEFAULT false,
+    "refJobActor"       TEXT,
+    "refJobRequestId"   TEXT,
+    "refJobCompletedAt" TEXT,
+    "createdAt"         TEXT,
+    "createdBy"         TEXT,
+    "updatedAt"         TEXT,
+    "updatedBy"         TEXT
+);
+CREATE INDEX IF NOT EXISTS "Jobs_due_idx" ON public."Jobs" ("refJobRunAt", "refJobId") WHERE "refJobStatus" IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS "Jobs_refJobKind_refJobStatus_idx" ON public."Jobs" ("refJobKind", "refJobStatus");
@@ -0,0 +1,18 @@
+-- Transactional outbox: domain events are written in the transaction of the business
+-- change, then a dispatcher hands each one to its subscribers as background jobs.
+CREATE TABLE IF NOT EXISTS public."OutboxEvents" (
+    "refOEId"           BIGSERIAL PRIMARY KEY,
+    "refOEType"         TEXT NOT NULL,
+    "refOEKey"          TEXT NOT NULL,
+    "refOEBranchId"     INTEGER NOT NULL DEFAULT 0,
+    "refOEPayload"      TEXT NOT NULL,
+    "refOEStatus"       TEXT NOT NULL,
+    "refOESubscribers"  INTEGER NOT NULL DEFAULT 0,
+    "refOEActor"        TEXT,
+    "refOERequestId"    TEXT,
+    "refOEDispatchedAt" TEXT,
+    "createdAt"         TEXT,
+    "createdBy"         TEXT
+);
+CREATE INDEX IF NOT EXISTS "OutboxEvents_pending_idx" ON public."OutboxEvents" ("refOEId") WHERE "refOEStatus" = 'pending';
+CREATE INDEX IF NOT EXISTS "OutboxEvents_refOEType_refOEKey_idx" ON public."OutboxEvents" ("refOEType", "refOEKey");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public."LoginAttempts_refLAScope_refLAKey_key";
+CREATE INDEX IF NOT EXISTS "LoginAttempts_refLAScope_refLAKey_idx" ON public."LoginAttempts" ("refLAScope", "refLAKey");
@@ -0,0 +1,6 @@
+-- One counter row per scope and key, so concurrent failures add up in a single upsert.
+DELETE FROM public."LoginAttempts" a
+USING public."LoginAttempts" b
+WHERE a."refLAScope" = b."refLAScope" AND a."refLAKey" = b."refLAKey" AND a."refLAId" < b."refLAId";
+DROP INDEX IF EXISTS public."LoginAttempts_refLAScope_refLAKey_idx";
+CREATE UNIQUE INDEX IF NOT EXISTS "LoginAttempts_refLAScope_refLAKey_key" ON public."LoginAttempts" ("refLAScope", "refLAKey");
@@ -0,0 +1,6 @@
+-- Ids of the two-factor challenge tokens already presented, kept until the token expires.
+CREATE TABLE IF NOT EXISTS public."UsedChallenges" (
+    "refUCId"        TEXT PRIMARY KEY,
+    "refUCExpiresAt" TEXT NOT NULL,
+    "createdAt"      TEXT
+);
@@ -0,0 +1,2 @@
+-- The erased payloads cannot be restored.
+SELECT 1;
@@ -0,0 +1,4 @@
+-- Sensitive payloads (e.g. OTP mails) are now erased when a job dies as well as when it
+-- completes; erase the ones left on jobs that died before.
+UPDATE public."Jobs" SET "refJobPayload" = '{}'
+WHERE "refJobSensitive" AND "refJobStatus" = 'dead' AND "refJobPayload" <> '{}';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public."OutboxEvents_refOEType_refOEKey_key";
+CREATE INDEX IF NOT EXISTS "OutboxEvents_refOEType_refOEKey_idx" ON public."OutboxEvents" ("refOEType", "refOEKey");
@@ -0,0 +1,15 @@
+-- One event per type and key, so a repeated publish (e.g. a webhook Shopify delivers twice)
+-- is skipped by ON CONFLICT instead of a racy count first.
+-- A transfer received in parts published stock.received once per part under the transfer
+-- id; those parts keep their events under a key of their own.
+UPDATE public."OutboxEvents" a SET "refOEKey" = a."refOEKey" || ':' || a."refOEId"
+WHERE a."refOEType" = 'stock.received' AND EXISTS (
+    SELECT 1 FROM public."OutboxEvents" b
+    WHERE b."refOEType" = a."refOEType" AND b."refOEKey" = a."refOEKey" AND b."refOEId" < a."refOEId"
+);
+-- any other repeat is a duplicate of the first event
+DELETE FROM public."OutboxEvents" a
+USING public."OutboxEvents" b
+WHERE a."refOEType" = b."refOEType" AND a."refOEKey" = b."refOEKey" AND a."refOEId" > b."refOEId";
+DROP INDEX IF EXISTS public."OutboxEvents_refOEType_refOEKey_idx";
+CREATE UNIQUE INDEX IF NOT EXISTS "OutboxEvents_refOEType_refOEKey_key" ON public."OutboxEvents" ("refOEType", "refOEKey");
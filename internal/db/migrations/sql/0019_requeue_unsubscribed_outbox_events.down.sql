@@ -0,0 +1,2 @@
+-- Pending events are dispatched again as before; nothing to undo.
+SELECT 1;
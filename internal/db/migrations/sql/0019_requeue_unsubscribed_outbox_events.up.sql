@@ -0,0 +1,5 @@
+-- Events of a type without subscribers now stay pending; put back the ones dispatched to
+-- nobody, so the first subscriber of their type receives them.
+UPDATE public."OutboxEvents"
+SET "refOEStatus" = 'pending', "refOEDispatchedAt" = NULL
+WHERE "refOEStatus" = 'dispatched' AND "refOESubscribers" = 0;
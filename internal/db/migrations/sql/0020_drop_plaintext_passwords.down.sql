@@ -0,0 +1 @@
+ALTER TABLE public."refUserAuthCred" ADD COLUMN IF NOT EXISTS "refUACPassword" TEXT;
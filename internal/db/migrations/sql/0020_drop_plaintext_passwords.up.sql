@@ -0,0 +1,3 @@
+-- Only the bcrypt hash is checked at login; the plain text copy written next to it on
+-- employee creation and password reset goes away.
+ALTER TABLE public."refUserAuthCred" DROP COLUMN IF EXISTS "refUACPassword";
@@ -0,0 +1 @@
+ALTER TABLE public."IdempotencyKeys" DROP COLUMN IF EXISTS "refIKResponseEnvelope";
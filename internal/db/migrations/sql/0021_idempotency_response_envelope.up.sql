@@ -0,0 +1,4 @@
+-- Replayed answers are sealed afresh for the retry's token: the body is kept in plain
+-- text and this column names the envelope version it was sealed in, empty when the
+-- first answer was plain JSON. Rows stored before carry the sealed body and no version.
+ALTER TABLE public."IdempotencyKeys" ADD COLUMN IF NOT EXISTS "refIKResponseEnvelope" TEXT;
@@ -0,0 +1,94 @@
+package accesstoken
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// CHALLENGE PURPOSES - a challenge token proves the password step of a login only
+const (
+	PurposeTwoFactor       = "2fa"
+	PurposeTwoFactorEnroll = "2fa-enroll"
+
+	ChallengeTTL = 5 * time.Minute
+)
+
+// ErrChallengeUsed is returned for a challenge token that was already presented once.
+var ErrChallengeUsed = fmt.Errorf("challenge token already used")
+
+// CreateChallengeToken signs a short-lived token for the second login step. It has no
+// session id, so JWTMiddleware never accepts it as an access token.
+func CreateChallengeToken(userId int, purpose string) (string, error) {
+	jti, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"id":      userId,
+		"purpose": purpose,
+		"jti":     jti,
+		"iat":     now.Unix(),
+		"exp":     now.Add(ChallengeTTL).Unix(),
+	}
+	return signToken(claims)
+}
+
+// ParseChallengeToken returns the user id of a valid challenge token issued for purpose.
+func ParseChallengeToken(tokenString string, purpose string) (int, error) {
+	userId, _, err := parseChallenge(tokenString, purpose)
+	return userId, err
+}
+
+// ConsumeChallengeToken is ParseChallengeToken for a token that may be presented only
+// once: every code guess needs a new password step, which the login guard counts.
+func ConsumeChallengeToken(dbConn *gorm.DB, tokenString string, purpose string) (int, error) {
+	userId, jti, err := parseChallenge(tokenString, purpose)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	// used ids are only needed until their token would have expired anyway
+	if err := dbConn.Exec(`DELETE FROM public."UsedChallenges" WHERE "refUCExpiresAt" < ?`, now.Format(timeLayout)).Error; err != nil {
+		return 0, err
+	}
+	result := dbConn.Exec(`
+		INSERT INTO public."UsedChallenges" ("refUCId", "refUCExpiresAt", "createdAt")
+		VALUES (?, ?, ?)
+		ON CONFLICT ("refUCId") DO NOTHING`,
+		jti, now.Add(ChallengeTTL).Format(timeLayout), now.Format(timeLayout))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrChallengeUsed
+	}
+	return userId, nil
+}
+
+func parseChallenge(tokenString string, purpose string) (int, string, error) {
+	token, err := ValidateJWT(tokenString)
+	if err != nil {
+		return 0, "", err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return 0, "", fmt.Errorf("invalid challenge token")
+	}
+	if claimPurpose, _ := claims["purpose"].(string); claimPurpose != purpose {
+		return 0, "", fmt.Errorf("invalid challenge token")
+	}
+	id, ok := claims["id"].(float64)
+	if !ok || id <= 0 {
+		return 0, "", fmt.Errorf("invalid challenge token")
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return 0, "", fmt.Errorf("invalid challenge token")
+	}
+	return int(id), jti, nil
+}
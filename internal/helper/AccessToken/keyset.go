@@ -0,0 +1,280 @@
+package accesstoken
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// SIGNING KEY STATUS
+const (
+	KeyActive  = "active"
+	KeyRetired = "retired"
+
+	// LegacyKid names the ACCESS_TOKEN env secret, which signed every token issued before
+	// the first rotation. Such tokens carry no kid header.
+	LegacyKid = "env"
+
+	// KeyGracePeriod keeps a retired key verifying until every token it signed has expired.
+	KeyGracePeriod = AccessTokenTTL + ChallengeTTL
+
+	keysetTTL = time.Minute
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// SigningKey is one HMAC secret of the keyset. Exactly one key is active and signs new
+// tokens; retired keys only verify until ExpiresAt.
+type SigningKey struct {
+	RefSKId        string `gorm:"column:refSKId;primaryKey" json:"kid"`
+	RefSKSecret    string `gorm:"column:refSKSecret" json:"-"`
+	RefSKStatus    string `gorm:"column:refSKStatus" json:"status"`
+	RefSKExpiresAt string `gorm:"column:refSKExpiresAt" json:"expiresAt"`
+	CreatedAt      string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy      string `gorm:"column:createdBy" json:"createdBy"`
+	UpdatedAt      string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (SigningKey) TableName() string {
+	return `public."SigningKeys"`
+}
+
+type keyset struct {
+	active   *SigningKey
+	byKid    map[string]SigningKey
+	loadedAt time.Time
+}
+
+var (
+	keysetMu    sync.RWMutex
+	cachedKeys  *keyset
+	errNoKeyset = errors.New("keyset unavailable")
+)
+
+// InvalidateKeyset forces the next token operation to reload the keys. The old keyset
+// stays as the fallback should that reload fail.
+func InvalidateKeyset() {
+	keysetMu.Lock()
+	if cachedKeys != nil {
+		stale := *cachedKeys
+		stale.loadedAt = time.Time{}
+		cachedKeys = &stale
+	}
+	keysetMu.Unlock()
+}
+
+// loadKeyset returns the cached keyset, reloading it once keysetTTL passed. While the
+// reload fails, e.g. Postgres is down, the last keyset loaded keeps serving, so valid
+// tokens are not turned away; nothing could rotate or revoke a key meanwhile anyway.
+func loadKeyset() (*keyset, error) {
+	keysetMu.RLock()
+	stale := cachedKeys
+	keysetMu.RUnlock()
+	if stale != nil && time.Since(stale.loadedAt) < keysetTTL {
+		return stale, nil
+	}
+
+	ks, err := fetchKeyset()
+	if err != nil {
+		if stale != nil {
+			logger.InitLogger().Warnf("⚠️ Signing keys not reloaded, serving the cached keyset: %v", err)
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	keysetMu.Lock()
+	cachedKeys = ks
+	keysetMu.Unlock()
+	return ks, nil
+}
+
+func fetchKeyset() (*keyset, error) {
+	dbConn := sessionDB()
+	if dbConn == nil {
+		return nil, errNoKeyset
+	}
+
+	var keys []SigningKey
+	if err := dbConn.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	ks := &keyset{byKid: map[string]SigningKey{}, loadedAt: time.Now()}
+	for i := range keys {
+		ks.byKid[keys[i].RefSKId] = keys[i]
+		if keys[i].RefSKStatus == KeyActive {
+			ks.active = &keys[i]
+		}
+	}
+	return ks, nil
+}
+
+func legacySecret() []byte {
+	return []byte(config.Get().Auth.AccessToken.Reveal())
+}
+
+// signToken signs claims with the active key, or with ACCESS_TOKEN until a key was rotated in.
+func signToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	ks, err := loadKeyset()
+	if err == nil && ks.active != nil {
+		token.Header["kid"] = ks.active.RefSKId
+		return token.SignedString([]byte(ks.active.RefSKSecret))
+	}
+	return token.SignedString(legacySecret())
+}
+
+// verificationKey is the jwt.Keyfunc: it picks the secret named by the kid header.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	ks, err := loadKeyset()
+	if err != nil {
+		// Without the keyset nothing tells whether the env secret was retired
+		return nil, err
+	}
+
+	if kid == "" {
+		// Only before the first rotation is the env secret trusted as is
+		if len(ks.byKid) == 0 {
+			return legacySecret(), nil
+		}
+		kid = LegacyKid
+	}
+
+	key, ok := ks.byKid[kid]
+	if !ok && time.Since(ks.loadedAt) > time.Second {
+		// Another instance may have rotated since the keyset was cached
+		InvalidateKeyset()
+		if ks, err = loadKeyset(); err != nil {
+			return nil, err
+		}
+		key, ok = ks.byKid[kid]
+	}
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if key.RefSKStatus != KeyActive {
+		expiresAt, err := time.ParseInLocation(timeLayout, key.RefSKExpiresAt, time.Local)
+		if err != nil || time.Now().After(expiresAt) {
+			return nil, fmt.Errorf("signing key %s expired", kid)
+		}
+	}
+	if kid == LegacyKid {
+		return legacySecret(), nil
+	}
+	return []byte(key.RefSKSecret), nil
+}
+
+// RotateSigningKey retires the active key, which keeps verifying for KeyGracePeriod,
+// and activates a new random key. The first rotation retires the ACCESS_TOKEN secret.
+func RotateSigningKey(dbConn *gorm.DB, rotatedBy string) (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	kidSuffix, err := randomHex(4)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	now := time.Now()
+	kid := now.Format("20060102") + "-" + kidSuffix
+	retireUntil := now.Add(KeyGracePeriod).Format(timeLayout)
+
+	err = dbConn.Transaction(func(tx *gorm.DB) error {
+		var activeCount int64
+		if err := tx.Model(&SigningKey{}).Where(`"refSKStatus" = ?`, KeyActive).Count(&activeCount).Error; err != nil {
+			return err
+		}
+
+		if activeCount == 0 {
+			legacy := SigningKey{
+				RefSKId:        LegacyKid,
+				RefSKStatus:    KeyRetired,
+				RefSKExpiresAt: retireUntil,
+				CreatedAt:      now.Format(timeLayout),
+				CreatedBy:      rotatedBy,
+				UpdatedAt:      now.Format(timeLayout),
+			}
+			if err := tx.Where(`"refSKId" = ?`, LegacyKid).Delete(&SigningKey{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&legacy).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&SigningKey{}).
+				Where(`"refSKStatus" = ?`, KeyActive).
+				Updates(map[string]interface{}{
+					"refSKStatus":    KeyRetired,
+					"refSKExpiresAt": retireUntil,
+					"updatedAt":      now.Format(timeLayout),
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&SigningKey{
+			RefSKId:     kid,
+			RefSKSecret: secret,
+			RefSKStatus: KeyActive,
+			CreatedAt:   now.Format(timeLayout),
+			CreatedBy:   rotatedBy,
+			UpdatedAt:   now.Format(timeLayout),
+		}).Error
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate signing key: %w", err)
+	}
+
+	InvalidateKeyset()
+	return kid, nil
+}
+
+// RevokeSigningKey stops a retired key from verifying at once, e.g. after a leak.
+// A leaked active key has to be rotated out first.
+func RevokeSigningKey(dbConn *gorm.DB, kid string) error {
+	var key SigningKey
+	err := dbConn.Where(`"refSKId" = ?`, kid).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUnknownKey
+	}
+	if err != nil {
+		return err
+	}
+	if key.RefSKStatus == KeyActive {
+		return fmt.Errorf("rotate the signing key before revoking the active key")
+	}
+
+	now := time.Now().Format(timeLayout)
+	err = dbConn.Model(&SigningKey{}).
+		Where(`"refSKId" = ?`, kid).
+		Updates(map[string]interface{}{"refSKExpiresAt": now, "updatedAt": now}).Error
+	if err != nil {
+		return err
+	}
+
+	InvalidateKeyset()
+	return nil
+}
+
+// ListSigningKeys returns the keyset without secrets, newest first.
+func ListSigningKeys(dbConn *gorm.DB) ([]SigningKey, error) {
+	var keys []SigningKey
+	if err := dbConn.Order(`"createdAt" DESC`).Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,221 @@
+package accesstoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// AccessTokenTTL is the lifetime of a signed access token.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is the absolute lifetime of a login session.
+	RefreshTokenTTL = 7 * 24 * time.Hour
+
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session revoked")
+	ErrSessionExpired  = errors.New("session expired")
+	ErrRefreshReused   = errors.New("refresh token reuse detected")
+)
+
+// UserSession is one login of a user. The refresh token itself is never stored,
+// only its SHA-256 hash; the previous hash is kept to detect replay of a rotated token.
+type UserSession struct {
+	RefSessionId            string `gorm:"column:refSessionId;primaryKey" json:"refSessionId"`
+	RefUserId               int    `gorm:"column:refUserId" json:"refUserId"`
+	RefRefreshTokenHash     string `gorm:"column:refRefreshTokenHash" json:"-"`
+	RefPrevRefreshTokenHash string `gorm:"column:refPrevRefreshTokenHash" json:"-"`
+	RefSessionExpiresAt     string `gorm:"column:refSessionExpiresAt" json:"refSessionExpiresAt"`
+	RefSessionRevokedAt     string `gorm:"column:refSessionRevokedAt" json:"refSessionRevokedAt"`
+	RefSessionRevokeReason  string `gorm:"column:refSessionRevokeReason" json:"refSessionRevokeReason"`
+	RefSessionUserAgent     string `gorm:"column:refSessionUserAgent" json:"refSessionUserAgent"`
+	RefSessionIp            string `gorm:"column:refSessionIp" json:"refSessionIp"`
+	CreatedAt               string `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt               string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (UserSession) TableName() string {
+	return `public."UserSessions"`
+}
+
+var (
+	sessionDBMu   sync.RWMutex
+	sessionDBConn *gorm.DB
+)
+
+// UseDB hands the middleware the shared pool main opened, for session and signing key
+// lookups on every authenticated request.
+func UseDB(dbConn *gorm.DB) {
+	sessionDBMu.Lock()
+	sessionDBConn = dbConn
+	sessionDBMu.Unlock()
+}
+
+func sessionDB() *gorm.DB {
+	sessionDBMu.RLock()
+	defer sessionDBMu.RUnlock()
+	return sessionDBConn
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// SessionID returns the session id the JWT middleware stored for the current request.
+func SessionID(c *gin.Context) string {
+	return c.GetString("sessionId")
+}
+
+// CreateSession starts a new login session and returns its id with the plain refresh token.
+func CreateSession(dbConn *gorm.DB, userId int, userAgent string, ip string) (string, string, error) {
+	sessionId, err := randomHex(16)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	refreshToken, err := randomHex(32)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	session := UserSession{
+		RefSessionId:        sessionId,
+		RefUserId:           userId,
+		RefRefreshTokenHash: hashRefreshToken(refreshToken),
+		RefSessionExpiresAt: now.Add(RefreshTokenTTL).Format(timeLayout),
+		RefSessionUserAgent: userAgent,
+		RefSessionIp:        ip,
+		CreatedAt:           now.Format(timeLayout),
+	}
+	if err := dbConn.Create(&session).Error; err != nil {
+		return "", "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return sessionId, refreshToken, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one on the same session.
+// Presenting an already rotated token revokes the whole session.
+func RotateRefreshToken(dbConn *gorm.DB, refreshToken string) (*UserSession, string, error) {
+	log := logger.InitLogger()
+	tokenHash := hashRefreshToken(refreshToken)
+
+	var session UserSession
+	err := dbConn.Where(`"refRefreshTokenHash" = ?`, tokenHash).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var reused UserSession
+		if dbConn.Where(`"refPrevRefreshTokenHash" = ?`, tokenHash).First(&reused).Error == nil {
+			log.Warnf("⚠️ Refresh token reuse detected for session %s, revoking", reused.RefSessionId)
+			_ = RevokeSession(dbConn, reused.RefSessionId, "refresh token reuse")
+			return nil, "", ErrRefreshReused
+		}
+		return nil, "", ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := checkSession(&session); err != nil {
+		return nil, "", err
+	}
+
+	newRefreshToken, err := randomHex(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	result := dbConn.Model(&UserSession{}).
+		Where(`"refSessionId" = ? AND "refRefreshTokenHash" = ?`, session.RefSessionId, tokenHash).
+		Updates(map[string]interface{}{
+			"refRefreshTokenHash":     hashRefreshToken(newRefreshToken),
+			"refPrevRefreshTokenHash": tokenHash,
+			"updatedAt":               time.Now().Format(timeLayout),
+		})
+	if result.Error != nil {
+		return nil, "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Another request rotated the same token first.
+		return nil, "", ErrRefreshReused
+	}
+
+	return &session, newRefreshToken, nil
+}
+
+// RevokeSession ends a single session, e.g. on logout.
+func RevokeSession(dbConn *gorm.DB, sessionId string, reason string) error {
+	now := time.Now().Format(timeLayout)
+	return dbConn.Model(&UserSession{}).
+		Where(`"refSessionId" = ? AND COALESCE("refSessionRevokedAt", '') = ''`, sessionId).
+		Updates(map[string]interface{}{
+			"refSessionRevokedAt":    now,
+			"refSessionRevokeReason": reason,
+			"updatedAt":              now,
+		}).Error
+}
+
+// RevokeUserSessions ends every open session of a user, e.g. when the employee is
+// disabled or their password changes.
+func RevokeUserSessions(dbConn *gorm.DB, userId int, reason string) error {
+	log := logger.InitLogger()
+	now := time.Now().Format(timeLayout)
+
+	result := dbConn.Model(&UserSession{}).
+		Where(`"refUserId" = ? AND COALESCE("refSessionRevokedAt", '') = ''`, userId).
+		Updates(map[string]interface{}{
+			"refSessionRevokedAt":    now,
+			"refSessionRevokeReason": reason,
+			"updatedAt":              now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	log.Infof("🔒 Revoked %d session(s) for userId=%d (%s)", result.RowsAffected, userId, reason)
+	return nil
+}
+
+// IsSessionActive reports whether the session exists, is not revoked and has not expired.
+func IsSessionActive(dbConn *gorm.DB, sessionId string) error {
+	var session UserSession
+	err := dbConn.Where(`"refSessionId" = ?`, sessionId).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return checkSession(&session)
+}
+
+func checkSession(session *UserSession) error {
+	if session.RefSessionRevokedAt != "" {
+		return ErrSessionRevoked
+	}
+	expiresAt, err := time.ParseInLocation(timeLayout, session.RefSessionExpiresAt, time.Local)
+	if err != nil || time.Now().After(expiresAt) {
+		return ErrSessionExpired
+	}
+	return nil
+}
@@ -1,31 +1,36 @@
 package accesstoken
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
 	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// CreateToken generates a JWT token for a given user ID and expiration duration.
-func CreateToken(id any, roleId any, branchid any) string {
+// CreateToken generates a short-lived JWT token bound to the given login session.
+func CreateToken(id any, roleId any, branchid any, sessionId string) string {
 	log := logger.InitLogger()
-	log.Info("🔑 Creating JWT token...")
+	log.Debug("🔑 Creating JWT token...")
 
-	jwtKey := []byte(os.Getenv("ACCESS_TOKEN"))
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"id":       id,
 		"roleId":   roleId,
 		"branchId": branchid,
-		"exp":      time.Now().Add(24 * time.Hour).Unix(),
+		"sid":      sessionId,
+		"iat":      now.Unix(),
+		"exp":      now.Add(AccessTokenTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtKey)
+	tokenString, err := signToken(claims)
 	if err != nil {
 		log.Error(fmt.Sprintf("❌ Error creating token: %v", err))
 		return "Invalid Token"
@@ -38,15 +43,10 @@ func CreateToken(id any, roleId any, branchid any) string {
 // ValidateJWT validates the JWT token and checks if it is expired.
 func ValidateJWT(tokenString string) (*jwt.Token, error) {
 	log := logger.InitLogger()
-	log.Infof("🔍 Validating JWT token: %s", tokenString)
+	log.Debug("🔍 Validating JWT token")
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Error("❌ Unexpected signing method")
-			return nil, fmt.Errorf("unexpected signing method")
-		}
-		return []byte(os.Getenv("ACCESS_TOKEN")), nil
-	})
+	// The kid header selects the key of the keyset, see verificationKey
+	token, err := jwt.Parse(tokenString, verificationKey)
 
 	if err != nil {
 		log.Error(fmt.Sprintf("❌ JWT parsing failed: %v", err))
@@ -66,7 +66,7 @@ func ValidateJWT(tokenString string) (*jwt.Token, error) {
 			return nil, fmt.Errorf("token expired at %s", expTime.Format(time.RFC3339))
 		}
 
-		log.Infof("✅ Token valid for user ID: %v", claims["id"])
+		log.Debugf("✅ Token valid for user ID: %v", claims["id"])
 	} else {
 		log.Error("❌ Token invalid or missing claims")
 	}
@@ -76,60 +76,94 @@ func ValidateJWT(tokenString string) (*jwt.Token, error) {
 
 // JWTMiddleware protects routes by validating JWT tokens from the Authorization header.
 func JWTMiddleware() gin.HandlerFunc {
+	return jwtMiddleware(false)
+}
+
+// DiagnosticsJWTMiddleware is JWTMiddleware for the diagnostics, which must answer while
+// Postgres is down: when the session store cannot be read, a correctly signed, unexpired
+// token passes without the revocation check instead of getting 503.
+func DiagnosticsJWTMiddleware() gin.HandlerFunc {
+	return jwtMiddleware(true)
+}
+
+func jwtMiddleware(sessionStoreOptional bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		log := logger.InitLogger()
-		log.Info("🔐 JWT Middleware invoked")
+		log := logger.FromGin(c)
+		log.Debug("🔐 JWT Middleware invoked")
 
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
 			log.Error("❌ Missing Token in request header")
-			c.JSON(200, gin.H{"error": "Missing token"})
-			c.Abort()
+			apperror.Respond(c, apperror.Unauthorized("Missing token"))
 			return
 		}
 
 		// Remove "Bearer " prefix if present
-		if strings.HasPrefix(tokenString, "Bearer ") {
-			log.Info("✂️ Stripping Bearer prefix")
-			tokenString = strings.TrimPrefix(tokenString, "Bearer ")
-		}
-		log.Infof("📜 Final Token String: %s", tokenString)
-
-		// 🔎 Parse token without validating expiration first, just to read claims
-		parsedToken, _, _ := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-		if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok {
-			log.Infof("📝 Token Claims Extracted [Pre-Validation] -> id=%v, roleId=%v, branchId=%v, time=%s",
-				claims["id"], claims["roleId"], claims["branchId"], time.Now().Format(time.RFC3339))
-		}
+		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
 		// ✅ Now validate the JWT token (signature + expiration)
 		token, err := ValidateJWT(tokenString)
 		if err != nil {
 			if strings.Contains(err.Error(), "token expired") {
 				log.Warn("⚠️ Token Expired")
-				c.JSON(200, gin.H{"error": "Token expired"})
-				c.Abort()
+				apperror.Respond(c, apperror.New(apperror.CodeTokenExpired, "Token expired"))
 				return
 			}
 			log.Error(fmt.Sprintf("❌ Invalid Token: %v", err))
-			c.JSON(200, gin.H{"error": "Invalid token"})
-			c.Abort()
+			apperror.Respond(c, apperror.New(apperror.CodeTokenInvalid, "Invalid token"))
 			return
 		}
 
 		// Extract the claims (user info) and set it in the context
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			log.Infof("✅ Setting claims in context: id=%v, roleId=%v, branchId=%v",
-				claims["id"], claims["roleId"], claims["branchId"])
-
-			c.Set("id", claims["id"])
-			c.Set("roleId", claims["roleId"])
-			c.Set("branchId", claims["branchId"])
-			c.Set("token", tokenString)
-		} else {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok || !token.Valid {
 			log.Warn("⚠️ Token claims missing or invalid")
+			apperror.Respond(c, apperror.New(apperror.CodeTokenInvalid, "Invalid token"))
+			return
+		}
+
+		// 🔒 Reject tokens whose session was logged out or revoked
+		sessionId, _ := claims["sid"].(string)
+		if sessionId == "" {
+			log.Warn("⚠️ Token has no session id")
+			apperror.Respond(c, apperror.New(apperror.CodeTokenInvalid, "Invalid token"))
+			return
+		}
+
+		sessionConn := sessionDB()
+		err = db.ErrUnavailable
+		if sessionConn != nil {
+			err = IsSessionActive(sessionConn, sessionId)
+		}
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrSessionNotFound), errors.Is(err, ErrSessionRevoked), errors.Is(err, ErrSessionExpired):
+			log.Warnf("⚠️ Session %s rejected: %v", sessionId, err)
+			apperror.Respond(c, apperror.Unauthorized("Session expired or revoked"))
+			return
+		case sessionStoreOptional:
+			log.Warnf("⚠️ Session %s not checked, session store unavailable: %v", sessionId, err)
+		default:
+			log.Error("❌ Session store unavailable: " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeUnavailable, "Unable to verify session"))
+			return
 		}
 
+		log.Infof("✅ Setting claims in context: id=%v, roleId=%v, branchId=%v",
+			claims["id"], claims["roleId"], claims["branchId"])
+
+		c.Set("id", claims["id"])
+		c.Set("roleId", claims["roleId"])
+		c.Set("branchId", claims["branchId"])
+		c.Set("sessionId", sessionId)
+		c.Set("token", tokenString)
+
+		// 👤 The actor stamps createdBy / updatedBy and audit entries downstream
+		userId, _ := roleType.ExtractIntFromInterface(claims["id"])
+		roleId, _ := roleType.ExtractIntFromInterface(claims["roleId"])
+		branchId, _ := roleType.ExtractIntFromInterface(claims["branchId"])
+		actor.Set(c, actor.Resolve(sessionConn, userId, roleId, branchId))
+
 		// Proceed to the next handler if the token is valid
 		c.Next()
 		log.Info("➡️ Passed JWT middleware successfully")
@@ -0,0 +1,41 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	"github.com/gin-gonic/gin"
+)
+
+func TestJWTMiddlewareRejectsMalformedTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/", JWTMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, header := range []string{"Bearer abc", "abc", "Bearer a.b.c", "Bearer "} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%q: status %d, want %d", header, rec.Code, http.StatusUnauthorized)
+			continue
+		}
+		var body struct {
+			Code apperror.Code `json:"code"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%q: decoding body: %v", header, err)
+		}
+		if body.Code != apperror.CodeTokenInvalid {
+			t.Errorf("%q: code %s, want %s", header, body.Code, apperror.CodeTokenInvalid)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package actor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// System is written as the author of changes made outside a request, e.g. by the CLI.
+const System = "System"
+
+// contextKey is also the gin context key JWTMiddleware stores the actor under.
+const contextKey = "actor"
+
+// Actor is the authenticated employee behind a request, built from the JWT claims.
+// Code is the employee id (Users.refUserCustId) and is what createdBy / updatedBy store.
+type Actor struct {
+	UserId   int    `json:"userId"`
+	RoleId   int    `json:"roleId"`
+	BranchId int    `json:"branchId"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+}
+
+// By is the value for createdBy / updatedBy columns.
+func (a Actor) By() string {
+	switch {
+	case a.Code != "":
+		return a.Code
+	case a.Name != "":
+		return a.Name
+	case a.UserId > 0:
+		return "user-" + strconv.Itoa(a.UserId)
+	default:
+		return System
+	}
+}
+
+type ctxKey struct{}
+
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, a)
+}
+
+// From returns the actor carried by ctx, the zero Actor (System) when there is none.
+func From(ctx context.Context) Actor {
+	if ctx == nil {
+		return Actor{}
+	}
+	a, _ := ctx.Value(ctxKey{}).(Actor)
+	return a
+}
+
+// FromDB reads the actor a connection was bound to with Bind.
+func FromDB(dbConn *gorm.DB) Actor {
+	if dbConn == nil || dbConn.Statement == nil {
+		return Actor{}
+	}
+	return From(dbConn.Statement.Context)
+}
+
+// FromGin returns the actor JWTMiddleware resolved, or one built from the bare claims.
+func FromGin(c *gin.Context) Actor {
+	if value, ok := c.Get(contextKey); ok {
+		if a, ok := value.(Actor); ok {
+			return a
+		}
+	}
+	var a Actor
+	if value, ok := c.Get("id"); ok {
+		a.UserId, _ = roleType.ExtractIntFromInterface(value)
+	}
+	if value, ok := c.Get("roleId"); ok {
+		a.RoleId, _ = roleType.ExtractIntFromInterface(value)
+	}
+	if value, ok := c.Get("branchId"); ok {
+		a.BranchId, _ = roleType.ExtractIntFromInterface(value)
+	}
+	return a
+}
+
+// Set stores the actor on the request, for FromGin and for the request context.
+func Set(c *gin.Context, a Actor) {
+	c.Set(contextKey, a)
+	c.Request = c.Request.WithContext(WithActor(c.Request.Context(), a))
+}
+
+// Bind carries the request's actor on the connection, so services can stamp createdBy /
+// updatedBy and audit entries without taking the user as a parameter. Transactions
+// started from the returned connection keep the actor.
+func Bind(dbConn *gorm.DB, c *gin.Context) *gorm.DB {
+	if dbConn == nil {
+		return nil
+	}
+	return dbConn.WithContext(WithActor(c.Request.Context(), FromGin(c)))
+}
+
+// EMPLOYEE NAME CACHE - JWTMiddleware resolves the actor on every request
+
+const nameCacheTTL = 5 * time.Minute
+
+type employee struct {
+	Name     string
+	Code     string
+	loadedAt time.Time
+}
+
+var (
+	nameCacheMu sync.RWMutex
+	nameCache   = map[int]employee{}
+)
+
+// Resolve fills in the employee name and code of the user. A failed lookup still returns
+// the actor with its ids, so a request is never rejected for it.
+func Resolve(dbConn *gorm.DB, userId int, roleId int, branchId int) Actor {
+	a := Actor{UserId: userId, RoleId: roleId, BranchId: branchId}
+	if userId <= 0 || dbConn == nil {
+		return a
+	}
+
+	nameCacheMu.RLock()
+	cached, ok := nameCache[userId]
+	nameCacheMu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < nameCacheTTL {
+		a.Name, a.Code = cached.Name, cached.Code
+		return a
+	}
+
+	var row struct {
+		FirstName string `gorm:"column:refUserFName"`
+		LastName  string `gorm:"column:refUserLName"`
+		Code      string `gorm:"column:refUserCustId"`
+	}
+	err := dbConn.Table(`public."Users"`).
+		Select(`"refUserFName", "refUserLName", "refUserCustId"`).
+		Where(`"refUserId" = ?`, userId).
+		Take(&row).Error
+	if err != nil {
+		return a
+	}
+
+	a.Name = strings.TrimSpace(row.FirstName + " " + row.LastName)
+	a.Code = row.Code
+
+	nameCacheMu.Lock()
+	nameCache[userId] = employee{Name: a.Name, Code: a.Code, loadedAt: time.Now()}
+	nameCacheMu.Unlock()
+	return a
+}
+
+// Forget drops a cached employee, e.g. after the employee was renamed.
+func Forget(userId int) {
+	nameCacheMu.Lock()
+	delete(nameCache, userId)
+	nameCacheMu.Unlock()
+}
@@ -0,0 +1,233 @@
+package apperror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Code tells clients what went wrong without parsing the message. Each code has one
+// HTTP status, so the same failure answers the same way in every module.
+type Code string
+
+// ERROR CODES
+const (
+	CodeBadRequest       Code = "BAD_REQUEST"
+	CodeValidation       Code = "VALIDATION_FAILED"
+	CodeUnauthorized     Code = "UNAUTHORIZED"
+	CodeTokenExpired     Code = "TOKEN_EXPIRED"
+	CodeTokenInvalid     Code = "TOKEN_INVALID"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeMethodNotAllowed Code = "METHOD_NOT_ALLOWED"
+	CodeConflict         Code = "CONFLICT"
+	CodeKeyReused        Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeTooManyRequests  Code = "TOO_MANY_REQUESTS"
+	CodeInternal         Code = "INTERNAL"
+	CodeUpstream         Code = "UPSTREAM_FAILED"
+	CodeUnavailable      Code = "SERVICE_UNAVAILABLE"
+)
+
+var statuses = map[Code]int{
+	CodeBadRequest:       http.StatusBadRequest,
+	CodeValidation:       http.StatusBadRequest,
+	CodeUnauthorized:     http.StatusUnauthorized,
+	CodeTokenExpired:     http.StatusUnauthorized,
+	CodeTokenInvalid:     http.StatusUnauthorized,
+	CodeForbidden:        http.StatusForbidden,
+	CodeNotFound:         http.StatusNotFound,
+	CodeMethodNotAllowed: http.StatusMethodNotAllowed,
+	CodeConflict:         http.StatusConflict,
+	CodeKeyReused:        http.StatusUnprocessableEntity,
+	CodeTooManyRequests:  http.StatusTooManyRequests,
+	CodeInternal:         http.StatusInternalServerError,
+	CodeUpstream:         http.StatusBadGateway,
+	CodeUnavailable:      http.StatusServiceUnavailable,
+}
+
+// Status is the HTTP status answered for the code.
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Codes lists every code ordered by status, for the API docs.
+func Codes() []Code {
+	codes := make([]Code, 0, len(statuses))
+	for code := range statuses {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Status() != codes[j].Status() {
+			return codes[i].Status() < codes[j].Status()
+		}
+		return codes[i] < codes[j]
+	})
+	return codes
+}
+
+// CodeFor is the code of an HTTP error status, for handlers that still pick a status.
+func CodeFor(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusBadGateway:
+		return CodeUpstream
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= 400 && status < 500 {
+		return CodeBadRequest
+	}
+	return CodeInternal
+}
+
+// Error is an application error: what the client is told, plus the cause for the logs.
+type Error struct {
+	Code    Code
+	Message string
+	// Errors lists field-level problems, e.g. validation.FieldError.
+	Errors interface{}
+	// Extra keys written next to message, e.g. retryAfter.
+	Extra map[string]interface{}
+
+	cause error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap keeps err as the logged cause. When err already is an application error, or a
+// database error with a precise meaning (not found, duplicate key ...), that wins over
+// code and message, so a service decides the answer whatever the handler falls back to.
+func Wrap(err error, code Code, message string) *Error {
+	if known := classify(err); known != nil {
+		return known
+	}
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+func BadRequest(message string) *Error   { return New(CodeBadRequest, message) }
+func Unauthorized(message string) *Error { return New(CodeUnauthorized, message) }
+func Forbidden(message string) *Error    { return New(CodeForbidden, message) }
+func NotFound(message string) *Error     { return New(CodeNotFound, message) }
+func Conflict(message string) *Error     { return New(CodeConflict, message) }
+func Internal(err error, message string) *Error {
+	return &Error{Code: CodeInternal, Message: message, cause: err}
+}
+func Upstream(err error, message string) *Error {
+	return &Error{Code: CodeUpstream, Message: message, cause: err}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return string(e.Code) + ": " + e.Message + ": " + e.cause.Error()
+	}
+	return string(e.Code) + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Status() int {
+	return e.Code.Status()
+}
+
+// WithErrors attaches field-level problems.
+func (e *Error) WithErrors(errs interface{}) *Error {
+	e.Errors = errs
+	return e
+}
+
+// With adds a key to the response body next to the message.
+func (e *Error) With(key string, value interface{}) *Error {
+	if e.Extra == nil {
+		e.Extra = map[string]interface{}{}
+	}
+	e.Extra[key] = value
+	return e
+}
+
+// MESSAGES OF THE MAPPED ERRORS
+const (
+	msgInternal    = "Something went wrong, please try again"
+	msgNotFound    = "Record not found"
+	msgDuplicate   = "A record with the same value already exists"
+	msgReferenced  = "The record refers to, or is referred to by, another record"
+	msgInvalid     = "Invalid value for a field"
+	msgRetry       = "The record was changed concurrently, please retry"
+	msgUnavailable = "Database unavailable, please retry shortly"
+	msgTimeout     = "The request took too long, please retry"
+)
+
+// From turns any error into an application error. Errors the database reports precisely
+// get their own code; anything else is an internal error whose detail stays in the logs.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if known := classify(err); known != nil {
+		return known
+	}
+	return Internal(err, msgInternal)
+}
+
+func classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Error{Code: CodeNotFound, Message: msgNotFound, cause: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return &Error{Code: CodeConflict, Message: msgDuplicate, cause: err}
+		case "23503": // foreign_key_violation
+			return &Error{Code: CodeConflict, Message: msgReferenced, cause: err}
+		case "22P02", "22003", "22007", "22008", "23502", "23514": // bad text, range, date, not null, check
+			return &Error{Code: CodeBadRequest, Message: msgInvalid, cause: err}
+		case "40001", "40P01": // serialization_failure, deadlock_detected
+			return &Error{Code: CodeConflict, Message: msgRetry, cause: err}
+		case "57P01", "57P03", "53300": // admin_shutdown, cannot_connect_now, too_many_connections
+			return &Error{Code: CodeUnavailable, Message: msgUnavailable, cause: err}
+		}
+		return nil
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) || errors.Is(err, driver.ErrBadConn) {
+		return &Error{Code: CodeUnavailable, Message: msgUnavailable, cause: err}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Code: CodeUnavailable, Message: msgTimeout, cause: err}
+	}
+	return nil
+}
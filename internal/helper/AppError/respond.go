@@ -0,0 +1,88 @@
+package apperror
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Body is the error envelope every route answers with:
+//
+//	{"status": false, "code": "NOT_FOUND", "message": "...", "errors": [...], "requestId": "..."}
+//
+// errors is only present for field-level problems. Successful responses keep
+// {"status": true, "message": ..., ...}.
+func Body(c *gin.Context, e *Error) gin.H {
+	body := gin.H{
+		"status":  false,
+		"code":    e.Code,
+		"message": e.Message,
+	}
+	if e.Errors != nil {
+		body["errors"] = e.Errors
+	}
+	for key, value := range e.Extra {
+		body[key] = value
+	}
+	if id := logger.RequestID(c.Request.Context()); id != "" {
+		body["requestId"] = id
+	}
+	return body
+}
+
+// Respond answers the request with err in the error envelope and stops the handler chain.
+// Plain errors become internal errors; their text goes to the access log line, through
+// c.Errors, and is never sent.
+func Respond(c *gin.Context, err error) {
+	e := From(err)
+	if e.cause != nil && (len(c.Errors) == 0 || c.Errors.Last().Err != err) {
+		_ = c.Error(e.cause)
+	}
+	c.AbortWithStatusJSON(e.Status(), Body(c, e))
+}
+
+// Middleware recovers panics and answers errors that handlers left in c.Errors (c.Error)
+// without writing a response, both in the error envelope. Register it right after the
+// request logger so the access line carries the final status.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			logger.FromGin(c).WithField("stack", string(debug.Stack())).Error(fmt.Sprintf("❌ Panic: %v", recovered))
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			Respond(c, Internal(fmt.Errorf("panic: %v", recovered), msgInternal))
+		}()
+
+		c.Next()
+
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			Respond(c, c.Errors.Last().Err)
+		}
+	}
+}
+
+// RouteNotFound answers unknown paths, for gin's NoRoute.
+func RouteNotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Respond(c, NotFound("Route not found"))
+	}
+}
+
+// MethodNotAllowed answers a known path called with the wrong method, for gin's NoMethod.
+func MethodNotAllowed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		Respond(c, New(CodeMethodNotAllowed, "Method not allowed"))
+	}
+}
@@ -0,0 +1,261 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"gorm.io/gorm"
+)
+
+// AUDITED ENTITIES
+const (
+	EntitySupplier        = "supplier"
+	EntityBranch          = "branch"
+	EntityEmployee        = "employee"
+	EntityInitialCategory = "initialCategory"
+	EntityCategory        = "category"
+	EntitySubCategory     = "subCategory"
+	EntityPurchaseOrder   = "purchaseOrder"
+	EntityGRN             = "grn"
+	EntityStockTransfer   = "stockTransfer"
+	EntityDebitNote       = "debitNote"
+	EntityNumberSeries    = "numberSeries"
+	EntityJob             = "job"
+)
+
+// AUDIT ACTIONS
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionReceive = "receive"
+	ActionRetry   = "retry"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// Log is one row of the audit trail. Before and After are the full records, Changes holds
+// only the fields that differ as {"field": {"from": .., "to": ..}}.
+type Log struct {
+	RefALId          int             `gorm:"column:refALId;primaryKey;autoIncrement" json:"id"`
+	RefALEntity      string          `gorm:"column:refALEntity" json:"entity"`
+	RefALEntityId    string          `gorm:"column:refALEntityId" json:"entityId"`
+	RefALAction      string          `gorm:"column:refALAction" json:"action"`
+	RefALActorId     int             `gorm:"column:refALActorId" json:"actorId"`
+	RefALActorRoleId int             `gorm:"column:refALActorRoleId" json:"actorRoleId"`
+	RefALBranchId    int             `gorm:"column:refALBranchId" json:"branchId"`
+	RefALBefore      json.RawMessage `gorm:"column:refALBefore;type:jsonb" json:"before"`
+	RefALAfter       json.RawMessage `gorm:"column:refALAfter;type:jsonb" json:"after"`
+	RefALChanges     json.RawMessage `gorm:"column:refALChanges;type:jsonb" json:"changes"`
+	CreatedAt        string          `gorm:"column:createdAt" json:"createdAt"`
+}
+
+func (Log) TableName() string {
+	return `public."AuditLog"`
+}
+
+// Change is one field of an entry that differs between before and after.
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Entry describes one audited change. Before and After take any value that marshals to a
+// JSON object: a model struct, a payload or a Snapshot. BranchId defaults to the actor's.
+type Entry struct {
+	Entity   string
+	EntityId interface{}
+	Action   string
+	BranchId int
+	Before   interface{}
+	After    interface{}
+}
+
+// sensitive columns never reach the audit trail in clear
+var sensitiveMarkers = []string{"password", "secret", "token", "otp", "hash"}
+
+const redacted = "[REDACTED]"
+
+func isSensitive(field string) bool {
+	lower := strings.ToLower(field)
+	for _, marker := range sensitiveMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// toObject normalises a value to its JSON object form with sensitive fields masked.
+func toObject(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(value); (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && rv.IsNil() {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		// Not an object, e.g. a list of ids
+		var plain interface{}
+		if err := json.Unmarshal(raw, &plain); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"value": plain}, nil
+	}
+
+	for field := range object {
+		if isSensitive(field) {
+			object[field] = redacted
+		}
+	}
+	return object, nil
+}
+
+// Diff lists the fields whose values differ between two objects. A create or a hard
+// delete has only one side and no diff.
+func Diff(before, after map[string]interface{}) map[string]Change {
+	if before == nil || after == nil {
+		return nil
+	}
+	changes := map[string]Change{}
+	for field, from := range before {
+		if to := after[field]; !reflect.DeepEqual(from, to) {
+			changes[field] = Change{From: from, To: to}
+		}
+	}
+	for field, to := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = Change{From: nil, To: to}
+		}
+	}
+	return changes
+}
+
+func marshalObject(object map[string]interface{}) json.RawMessage {
+	if object == nil {
+		return nil
+	}
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+// Record writes an entry to the audit trail in the connection's transaction, if any.
+// The actor comes from actor.Bind; without one the change is attributed to the system (0).
+func Record(db *gorm.DB, entry Entry) error {
+	before, err := toObject(entry.Before)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit before state: %w", err)
+	}
+	after, err := toObject(entry.After)
+	if err != nil {
+		return fmt.Errorf("failed to serialize audit after state: %w", err)
+	}
+
+	by := actor.FromDB(db)
+	branchId := entry.BranchId
+	if branchId == 0 {
+		branchId = by.BranchId
+	}
+
+	row := Log{
+		RefALEntity:      entry.Entity,
+		RefALEntityId:    fmt.Sprint(entry.EntityId),
+		RefALAction:      entry.Action,
+		RefALActorId:     by.UserId,
+		RefALActorRoleId: by.RoleId,
+		RefALBranchId:    branchId,
+		RefALBefore:      marshalObject(before),
+		RefALAfter:       marshalObject(after),
+		CreatedAt:        time.Now().Format(timeLayout),
+	}
+	if changes := Diff(before, after); len(changes) > 0 {
+		row.RefALChanges, _ = json.Marshal(changes)
+	}
+
+	if err := db.Create(&row).Error; err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Snapshot reads rows of table keyed on keyColumn as column maps, for the before and
+// after state of a change. ids may be a single id or a slice.
+func Snapshot(db *gorm.DB, table string, keyColumn string, ids interface{}) map[string]map[string]interface{} {
+	var rows []map[string]interface{}
+	err := db.Table(table).
+		Where(fmt.Sprintf(`%q IN (?)`, keyColumn), ids).
+		Find(&rows).Error
+	if err != nil {
+		logger.InitLogger().Warn("⚠️ Audit snapshot of " + table + " failed: " + err.Error())
+		return nil
+	}
+
+	byId := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		byId[fmt.Sprint(row[keyColumn])] = row
+	}
+	return byId
+}
+
+// Tracker records an update of existing rows: Track snapshots them before the change,
+// Done snapshots them again and writes one entry per row.
+type Tracker struct {
+	db        *gorm.DB
+	entity    string
+	action    string
+	table     string
+	keyColumn string
+	ids       interface{}
+	before    map[string]map[string]interface{}
+}
+
+func Track(db *gorm.DB, entity string, action string, table string, keyColumn string, ids interface{}) *Tracker {
+	return &Tracker{
+		db:        db,
+		entity:    entity,
+		action:    action,
+		table:     table,
+		keyColumn: keyColumn,
+		ids:       ids,
+		before:    Snapshot(db, table, keyColumn, ids),
+	}
+}
+
+// Done writes the entries. Failures are logged, the audited change itself already happened.
+func (t *Tracker) Done() {
+	after := Snapshot(t.db, t.table, t.keyColumn, t.ids)
+	for id, before := range t.before {
+		if err := Record(t.db, Entry{
+			Entity:   t.entity,
+			EntityId: id,
+			Action:   t.action,
+			Before:   before,
+			After:    after[id],
+		}); err != nil {
+			logger.InitLogger().Error("⚠️ " + err.Error())
+		}
+	}
+}
+
+// RecordOrLog is Record for callers that must not fail on a missing audit row.
+func RecordOrLog(db *gorm.DB, entry Entry) {
+	if err := Record(db, entry); err != nil {
+		logger.InitLogger().Error("⚠️ " + err.Error())
+	}
+}
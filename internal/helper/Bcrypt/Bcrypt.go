@@ -1,7 +1,6 @@
 package becrypt
 
 import (
-	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,10 +25,7 @@ func HashPassword(password string) (string, error) {
 
 // ComparePasswords compares a plaintext password with a hashed password
 func ComparePasswords(hashedPassword, password string) bool {
-	log := logger.InitLogger()
-
 	// Compare the hashed password with the provided password
-	log.Info("Hashed Password", hashedPassword)
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
 }
@@ -0,0 +1,95 @@
+package branchscope
+
+import (
+	"fmt"
+	"strconv"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	permission "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Permission"
+	"github.com/gin-gonic/gin"
+)
+
+// Scope limits what branch data a request may read or change. Store staff are
+// pinned to the branchId of their token; roles holding permission.BranchCrossView
+// may ask for every branch with ?allBranches=true or for another one with ?branchId=.
+type Scope struct {
+	BranchId    int
+	AllBranches bool
+}
+
+var ErrOutOfScope = fmt.Errorf("branch is outside your access scope")
+
+// FromContext builds the scope of the current request; it must run after accesstoken.JWTMiddleware.
+func FromContext(c *gin.Context) (Scope, error) {
+	branchIdValue, branchExists := c.Get("branchId")
+	roleIdValue, roleExists := c.Get("roleId")
+	if !branchExists || !roleExists {
+		return Scope{}, fmt.Errorf("branch or role not found in request context")
+	}
+
+	branchId, err := roleType.ExtractIntFromInterface(branchIdValue)
+	if err != nil {
+		return Scope{}, fmt.Errorf("invalid branch id in token")
+	}
+	scope := Scope{BranchId: branchId}
+
+	wantsAll := c.Query("allBranches") == "true"
+	requested := c.Query("branchId")
+	if !wantsAll && requested == "" {
+		return scope, nil
+	}
+
+	roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
+	if err != nil {
+		return Scope{}, fmt.Errorf("invalid role id in token")
+	}
+	allowed, err := permission.HasPermission(roleId, permission.BranchCrossView)
+	if err != nil {
+		return Scope{}, err
+	}
+
+	if requested != "" {
+		requestedId, err := strconv.Atoi(requested)
+		if err != nil {
+			return Scope{}, fmt.Errorf("invalid branchId")
+		}
+		if requestedId != branchId && !allowed {
+			return Scope{}, ErrOutOfScope
+		}
+		return Scope{BranchId: requestedId}, nil
+	}
+
+	if !allowed {
+		return Scope{}, ErrOutOfScope
+	}
+	scope.AllBranches = true
+	return scope, nil
+}
+
+// Resolve is FromContext for controllers: it writes the error response itself.
+func Resolve(c *gin.Context) (Scope, bool) {
+	scope, err := FromContext(c)
+	if err == ErrOutOfScope {
+		apperror.Respond(c, apperror.New(apperror.CodeForbidden, err.Error()))
+		return Scope{}, false
+	}
+	if err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, err.Error()))
+		return Scope{}, false
+	}
+	return scope, true
+}
+
+// Allows reports whether a record owned by branchId is visible in this scope.
+func (s Scope) Allows(branchId int) bool {
+	return s.AllBranches || s.BranchId == branchId
+}
+
+// Condition returns a SQL condition restricting column to the scope, "TRUE" when unrestricted.
+func (s Scope) Condition(column string) (string, []interface{}) {
+	if s.AllBranches {
+		return "TRUE", nil
+	}
+	return column + " = ?", []interface{}{s.BranchId}
+}
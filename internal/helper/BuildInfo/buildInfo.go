@@ -0,0 +1,53 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Set at build time, e.g.
+//
+//	go build -ldflags "-X github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BuildInfo.Version=1.4.0 \
+//	  -X github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BuildInfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/BuildInfo.BuiltAt=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd
+var (
+	Version = "dev"
+	Commit  = ""
+	BuiltAt = ""
+)
+
+var startedAt = time.Now()
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuiltAt   string `json:"builtAt"`
+	GoVersion string `json:"goVersion"`
+	StartedAt string `json:"startedAt"`
+	Uptime    string `json:"uptime"`
+}
+
+// Get falls back to the VCS stamp the go tool embeds when Commit was not set.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuiltAt:   BuiltAt,
+		GoVersion: runtime.Version(),
+		StartedAt: startedAt.Format("2006-01-02 15:04:05"),
+		Uptime:    time.Since(startedAt).Round(time.Second).String(),
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuiltAt == "":
+				info.BuiltAt = setting.Value
+			}
+		}
+	}
+	return info
+}
@@ -1,9 +1,9 @@
 package contextutil
 
 import (
-	"net/http"
 
 	reportModel "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/api/reportModule/model"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	"github.com/gin-gonic/gin"
 
 )
@@ -14,10 +14,7 @@ func ExtractUserContext(c *gin.Context) (*reportModel.ContextUser, bool) {
 	branchIdValue, branchIdExists := c.Get("branchId")
 
 	if !idExists || !roleIdExists || !branchIdExists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  false,
-			"message": "User ID, RoleID, Branch ID not found in request context.",
-		})
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "User ID, RoleID, Branch ID not found in request context."))
 		return nil, false
 	}
 
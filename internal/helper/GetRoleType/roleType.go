@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
 	"gorm.io/gorm"
 )
 
@@ -32,8 +33,9 @@ func GetRoleTypeNameByID(db *gorm.DB, refRTId int) (string, error) {
 		Name string `gorm:"column:refRTName"`
 	}
 
-	fmt.Println("refRTId", refRTId)
-	err := db.Debug().
+	log := logger.FromDB(db)
+	log.Debugf("Fetching role name for refRTId=%d", refRTId)
+	err := db.
 		Table(`"RoleType"`).
 		Where(`"refRTId" = ?`, refRTId).
 		Order(`"RoleType"."refRTName"`).
@@ -42,6 +44,6 @@ func GetRoleTypeNameByID(db *gorm.DB, refRTId int) (string, error) {
 		return "", fmt.Errorf("error fetching role name: %v", err)
 	}
 
-	fmt.Println("Role Name:", role.Name)
+	log.Debugf("Role name: %s", role.Name)
 	return role.Name, nil
 }
@@ -0,0 +1,161 @@
+package hashapi
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+)
+
+// ENVELOPE VERSIONS
+//
+//	legacy  [ivHex, cipherHex]               AES-256-CBC, PKCS7, no MAC
+//	"v1"    ["v1", ivHex, cipherHex]         same as legacy, with an explicit marker
+//	"v2"    ["v2", nonceHex, cipherHex]      AES-256-GCM, the marker is authenticated too
+const (
+	VersionCBC = "v1"
+	VersionGCM = "v2"
+)
+
+var ErrLegacyDisabled = errors.New("legacy CBC envelopes are disabled")
+
+// LegacyAllowed reports whether CBC envelopes are still accepted. Set
+// HASHAPI_ALLOW_CBC=false once every client sends v2.
+func LegacyAllowed() bool {
+	return config.Get().Auth.AllowLegacyCBC
+}
+
+// gcmKey derives a key separate from the CBC one, so the two modes never share a key.
+func gcmKey(token string) []byte {
+	mac := hmac.New(sha256.New, []byte(config.Get().Auth.EncryptAPI.Reveal()))
+	mac.Write([]byte("hashapi-gcm:" + token))
+	return mac.Sum(nil)
+}
+
+func marshalPlainText(data interface{}) ([]byte, error) {
+	if v, ok := data.(string); ok {
+		return []byte(v), nil
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize object: %v", err)
+	}
+	return bytes, nil
+}
+
+func newGCM(token string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(gcmKey(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptGCM seals data into a v2 envelope.
+func EncryptGCM(data interface{}, token string) ([]string, error) {
+	plainText, err := marshalPlainText(data)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(token)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	cipherText := aead.Seal(nil, nonce, plainText, []byte(VersionGCM))
+	return []string{VersionGCM, hex.EncodeToString(nonce), hex.EncodeToString(cipherText)}, nil
+}
+
+// decryptGCM opens a v2 envelope; any change to nonce, cipher text or marker fails.
+func decryptGCM(encryptedData []string, token string) (interface{}, error) {
+	if len(encryptedData) != 3 || encryptedData[0] != VersionGCM {
+		return nil, errors.New("invalid encrypted data format")
+	}
+
+	nonce, err := hex.DecodeString(encryptedData[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nonce: %v", err)
+	}
+	cipherText, err := hex.DecodeString(encryptedData[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cipherText: %v", err)
+	}
+
+	aead, err := newGCM(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+
+	plainText, err := aead.Open(nil, nonce, cipherText, []byte(VersionGCM))
+	if err != nil {
+		return nil, errors.New("message authentication failed")
+	}
+	return decodePlainText(plainText), nil
+}
+
+// EnvelopeVersion reads the version marker; an unmarked two element envelope is legacy CBC.
+func EnvelopeVersion(encryptedData []string) (string, error) {
+	switch {
+	case len(encryptedData) == 2:
+		return VersionCBC, nil
+	case len(encryptedData) == 3 && (encryptedData[0] == VersionCBC || encryptedData[0] == VersionGCM):
+		return encryptedData[0], nil
+	default:
+		return "", errors.New("invalid encrypted data format")
+	}
+}
+
+// Open decrypts an envelope in the mode named by its version marker and returns that version.
+func Open(encryptedData []string, token string) (interface{}, string, error) {
+	version, err := EnvelopeVersion(encryptedData)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if version == VersionGCM {
+		result, err := decryptGCM(encryptedData, token)
+		return result, version, err
+	}
+
+	if !LegacyAllowed() {
+		return nil, version, ErrLegacyDisabled
+	}
+	if len(encryptedData) == 3 {
+		encryptedData = encryptedData[1:]
+	}
+	result, err := decryptCBC(encryptedData, token)
+	return result, version, err
+}
+
+// Seal encrypts data in the given version, so a response can mirror its request.
+func Seal(data interface{}, version string, token string) ([]string, error) {
+	switch version {
+	case VersionGCM:
+		return EncryptGCM(data, token)
+	case VersionCBC:
+		sealed := Encrypt(data, true, token)
+		if err, ok := sealed.(error); ok {
+			return nil, err
+		}
+		return sealed.([]string), nil
+	default:
+		return nil, fmt.Errorf("unknown envelope version: %s", version)
+	}
+}
@@ -0,0 +1,84 @@
+package hashapi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+)
+
+const testToken = "header.payload.signature"
+
+func init() {
+	config.Set(&config.Config{Auth: config.AuthConfig{EncryptAPI: "test-encrypt-key", AllowLegacyCBC: true}})
+}
+
+func TestSealOpenGCM(t *testing.T) {
+	payload := map[string]interface{}{"supplierId": float64(7), "name": "Snehalaya"}
+
+	sealed, err := Seal(payload, VersionGCM, testToken)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if len(sealed) != 3 || sealed[0] != VersionGCM {
+		t.Fatalf("Seal returned %v, want a v2 envelope", sealed)
+	}
+
+	opened, version, err := Open(sealed, testToken)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if version != VersionGCM {
+		t.Errorf("version = %q, want %q", version, VersionGCM)
+	}
+	got, ok := opened.(map[string]interface{})
+	if !ok || got["supplierId"] != payload["supplierId"] || got["name"] != payload["name"] {
+		t.Errorf("Open = %v, want %v", opened, payload)
+	}
+}
+
+func TestOpenGCMRejectsTampering(t *testing.T) {
+	sealed, err := Seal(map[string]interface{}{"amount": float64(100)}, VersionGCM, testToken)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	flip := func(s string) string {
+		b, _ := hex.DecodeString(s)
+		b[0] ^= 0x01
+		return hex.EncodeToString(b)
+	}
+
+	tests := []struct {
+		name  string
+		data  []string
+		token string
+	}{
+		{"cipher text", []string{sealed[0], sealed[1], flip(sealed[2])}, testToken},
+		{"nonce", []string{sealed[0], flip(sealed[1]), sealed[2]}, testToken},
+		{"marker downgraded", []string{VersionCBC, sealed[1], sealed[2]}, testToken},
+		{"other token", sealed, "another.token.value"},
+		{"truncated", []string{sealed[0], sealed[1], sealed[2][:len(sealed[2])-2]}, testToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if opened, _, err := Open(tt.data, tt.token); err == nil {
+				t.Errorf("Open accepted a tampered envelope: %v", opened)
+			}
+		})
+	}
+}
+
+func TestOpenLegacyDisabled(t *testing.T) {
+	sealed, err := Seal("plain", VersionCBC, testToken)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	config.Set(&config.Config{Auth: config.AuthConfig{EncryptAPI: "test-encrypt-key", AllowLegacyCBC: false}})
+	defer config.Set(&config.Config{Auth: config.AuthConfig{EncryptAPI: "test-encrypt-key", AllowLegacyCBC: true}})
+
+	if _, _, err := Open(sealed, testToken); err != ErrLegacyDisabled {
+		t.Errorf("Open = %v, want ErrLegacyDisabled", err)
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
 )
 
-// Encrypt encrypts the given text using AES-256-CBC and PKCS7 padding.
+// Encrypt encrypts the given text using AES-256-CBC and PKCS7 padding (legacy envelope,
+// new code should use Seal or EncryptGCM).
 // If encryptStatus is false, it returns the plain data wrapped in a map.
 // The key is derived from ENCRYPT_API + token using SHA256.
 func Encrypt(text interface{}, encryptStatus bool, token string) interface{} {
@@ -22,20 +24,13 @@ func Encrypt(text interface{}, encryptStatus bool, token string) interface{} {
 	}
 
 	// Derive 32-byte key from secret + token
-	keyData := os.Getenv("ENCRYPT_API") + token
+	keyData := config.Get().Auth.EncryptAPI.Reveal() + token
 	key := sha256.Sum256([]byte(keyData)) // Always 32 bytes
 
 	// Convert object to JSON string
-	var plainText string
-	switch v := text.(type) {
-	case string:
-		plainText = v
-	default:
-		bytes, err := json.Marshal(v)
-		if err != nil {
-			return fmt.Errorf("failed to serialize object: %v", err)
-		}
-		plainText = string(bytes)
+	plainText, err := marshalPlainText(text)
+	if err != nil {
+		return err
 	}
 
 	// Generate random IV
@@ -51,7 +46,7 @@ func Encrypt(text interface{}, encryptStatus bool, token string) interface{} {
 	}
 
 	mode := cipher.NewCBCEncrypter(block, iv)
-	padded := PKCS7Pad([]byte(plainText), aes.BlockSize)
+	padded := PKCS7Pad(plainText, aes.BlockSize)
 
 	cipherText := make([]byte, len(padded))
 	mode.CryptBlocks(cipherText, padded)
@@ -62,13 +57,21 @@ func Encrypt(text interface{}, encryptStatus bool, token string) interface{} {
 		hex.EncodeToString(cipherText),
 	}
 }
+
+// Decrypt opens an envelope of any supported version, see Open.
 func Decrypt(encryptedData []string, token string) (interface{}, error) {
+	result, _, err := Open(encryptedData, token)
+	return result, err
+}
+
+// decryptCBC opens the legacy [iv, cipherText] envelope.
+func decryptCBC(encryptedData []string, token string) (interface{}, error) {
 	if len(encryptedData) != 2 {
 		return nil, errors.New("invalid encrypted data format")
 	}
 
 	// Derive the same key using ENCRYPT_API + token
-	keyData := os.Getenv("ENCRYPT_API") + token
+	keyData := config.Get().Auth.EncryptAPI.Reveal() + token
 	key := sha256.Sum256([]byte(keyData)) // 32 bytes
 
 	// Decode IV and cipherText from hex
@@ -103,12 +106,16 @@ func Decrypt(encryptedData []string, token string) (interface{}, error) {
 		return nil, fmt.Errorf("unpadding error: %v", err)
 	}
 
-	// Try to decode JSON, otherwise return as string
+	return decodePlainText(plainText), nil
+}
+
+// decodePlainText decodes JSON, otherwise returns the text as a string
+func decodePlainText(plainText []byte) interface{} {
 	var result interface{}
 	if err := json.Unmarshal(plainText, &result); err != nil {
-		return string(plainText), nil
+		return string(plainText)
 	}
-	return result, nil
+	return result
 }
 
 // PKCS7Unpad removes padding from the decrypted data
@@ -0,0 +1,374 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	hashapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/HashAPI"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	model "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/model"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HEADERS - the client picks the key, replayed answers are marked
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	// Window is how long an answer is kept for replay.
+	Window = 24 * time.Hour
+	// LockFor bounds how long a request holds its key; a key still locked after a crash
+	// is taken over once it passes.
+	LockFor = 5 * time.Minute
+
+	maxKeyLength = 255
+	timeLayout   = "2006-01-02 15:04:05"
+)
+
+// KEY STATUSES
+const (
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+)
+
+type Key struct {
+	RefIKId           int    `gorm:"column:refIKId;primaryKey;autoIncrement" json:"refIKId"`
+	RefIKScope        string `gorm:"column:refIKScope" json:"refIKScope"`
+	RefIKKey          string `gorm:"column:refIKKey" json:"refIKKey"`
+	RefIKMethod       string `gorm:"column:refIKMethod" json:"refIKMethod"`
+	RefIKPath         string `gorm:"column:refIKPath" json:"refIKPath"`
+	RefIKRequestHash  string `gorm:"column:refIKRequestHash" json:"refIKRequestHash"`
+	RefIKStatus       string `gorm:"column:refIKStatus" json:"refIKStatus"`
+	RefIKResponseCode int    `gorm:"column:refIKResponseCode" json:"refIKResponseCode"`
+	RefIKResponseType string `gorm:"column:refIKResponseType" json:"refIKResponseType"`
+	RefIKResponseBody []byte `gorm:"column:refIKResponseBody" json:"-"`
+	// RefIKResponseEnvelope is the envelope version the answer was sealed in, empty for
+	// plain JSON; the body itself is kept unsealed, see unseal.
+	RefIKResponseEnvelope string `gorm:"column:refIKResponseEnvelope" json:"refIKResponseEnvelope"`
+	RefIKLockedUntil      string `gorm:"column:refIKLockedUntil" json:"refIKLockedUntil"`
+	RefIKExpiresAt        string `gorm:"column:refIKExpiresAt" json:"refIKExpiresAt"`
+	CreatedAt             string `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt             string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (Key) TableName() string {
+	return `public."IdempotencyKeys"`
+}
+
+var (
+	dbMu     sync.RWMutex
+	keysDB   *gorm.DB
+	purgedAt time.Time
+)
+
+// UseDB hands Middleware the shared pool main opened. Without one, keys are ignored.
+func UseDB(dbConn *gorm.DB) {
+	dbMu.Lock()
+	keysDB = dbConn
+	dbMu.Unlock()
+}
+
+func pool() *gorm.DB {
+	dbMu.RLock()
+	defer dbMu.RUnlock()
+	return keysDB
+}
+
+// Middleware makes a create route safe to retry. A request carrying an Idempotency-Key
+// runs once per user and key: retries inside Window get the first answer again, a retry
+// while the first is still running gets 409, and reusing the key for a different request
+// gets 422. 5xx answers are not kept, so a failed request can be retried with its key.
+// Encrypted bodies are compared by their plain text and a sealed answer is sealed again
+// for the retry's token: clients seal every retry afresh, and tokens expire long before
+// the key does.
+// Put it after JWTMiddleware: keys are scoped to the authenticated user.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		dbConn := pool()
+		if key == "" || dbConn == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			apperror.Respond(c, apperror.BadRequest(Header+" must be at most "+strconv.Itoa(maxKeyLength)+" characters"))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			apperror.Respond(c, apperror.BadRequest("Unable to read the request body"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		token := c.GetString("token")
+		dbConn = dbConn.WithContext(c.Request.Context())
+		claim := Key{
+			RefIKScope:       scope(c),
+			RefIKKey:         key,
+			RefIKMethod:      c.Request.Method,
+			RefIKPath:        c.Request.URL.Path,
+			RefIKRequestHash: requestHash(c.Request.Method, c.Request.URL.Path, payload(body, token)),
+		}
+
+		stored, err := acquire(dbConn, &claim)
+		if err != nil {
+			var appErr *apperror.Error
+			if errors.As(err, &appErr) && appErr.Code == apperror.CodeConflict {
+				c.Header("Retry-After", "1")
+			}
+			apperror.Respond(c, err)
+			c.Abort()
+			return
+		}
+		if stored != nil {
+			replay(c, stored, token)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		defer func() {
+			// a panic answers 500 further up: free the key so the request can be retried
+			if recovered := recover(); recovered != nil {
+				release(dbConn, claim)
+				panic(recovered)
+			}
+		}()
+		c.Next()
+
+		if err := finish(dbConn, claim, recorder, token); err != nil {
+			logger.FromGin(c).WithError(err).Warn("idempotency: could not store the answer of key " + key)
+		}
+	}
+}
+
+func scope(c *gin.Context) string {
+	a := actor.FromGin(c)
+	if a.UserId > 0 {
+		return "user-" + strconv.Itoa(a.UserId)
+	}
+	return actor.System
+}
+
+// payload is the body as the handler reads it: an encrypted envelope is opened with the
+// caller's token, and JSON is compacted with sorted keys, so a retry sealed afresh reads
+// the same as the first attempt. A body that does not open is compared as sent.
+func payload(body []byte, token string) []byte {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return body
+	}
+
+	var envelope model.ReqVal
+	if err := json.Unmarshal(body, &envelope); err == nil && len(envelope.EncryptedData) > 0 {
+		opened, _, err := hashapi.Open(envelope.EncryptedData, token)
+		if err != nil {
+			return body
+		}
+		data = opened
+	}
+
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return plain
+}
+
+// requestHash tells a retry from a different request sent with the same key.
+func requestHash(method string, path string, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(method + " " + path + "\n"))
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// acquire claims the key for this request, or returns the completed key to replay.
+func acquire(dbConn *gorm.DB, claim *Key) (*Key, error) {
+	now := time.Now()
+	claim.RefIKStatus = StatusProcessing
+	claim.RefIKLockedUntil = now.Add(LockFor).Format(timeLayout)
+	claim.RefIKExpiresAt = now.Add(Window).Format(timeLayout)
+	claim.CreatedAt = now.Format(timeLayout)
+	claim.UpdatedAt = claim.CreatedAt
+
+	purgeExpired(dbConn, now)
+
+	inserted := dbConn.Clauses(clause.OnConflict{DoNothing: true}).Create(claim)
+	if inserted.Error != nil {
+		return nil, apperror.Wrap(inserted.Error, apperror.CodeInternal, "Unable to check the "+Header)
+	}
+	if inserted.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing Key
+	err := dbConn.Where(`"refIKScope" = ? AND "refIKKey" = ?`, claim.RefIKScope, claim.RefIKKey).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// purged between the insert and the read: claim it again
+		return acquire(dbConn, claim)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.CodeInternal, "Unable to check the "+Header)
+	}
+
+	nowText := now.Format(timeLayout)
+	expired := existing.RefIKExpiresAt < nowText
+	abandoned := existing.RefIKStatus == StatusProcessing && existing.RefIKLockedUntil < nowText
+	if expired || abandoned {
+		// take the key over, unless another retry just did
+		taken := dbConn.Model(&Key{}).
+			Where(`"refIKId" = ? AND "updatedAt" = ?`, existing.RefIKId, existing.UpdatedAt).
+			Updates(map[string]interface{}{
+				"refIKMethod":           claim.RefIKMethod,
+				"refIKPath":             claim.RefIKPath,
+				"refIKRequestHash":      claim.RefIKRequestHash,
+				"refIKStatus":           StatusProcessing,
+				"refIKResponseCode":     nil,
+				"refIKResponseType":     nil,
+				"refIKResponseBody":     nil,
+				"refIKResponseEnvelope": nil,
+				"refIKLockedUntil":      claim.RefIKLockedUntil,
+				"refIKExpiresAt":        claim.RefIKExpiresAt,
+				"updatedAt":             claim.UpdatedAt,
+			})
+		if taken.Error != nil {
+			return nil, apperror.Wrap(taken.Error, apperror.CodeInternal, "Unable to check the "+Header)
+		}
+		if taken.RowsAffected == 1 {
+			claim.RefIKId = existing.RefIKId
+			return nil, nil
+		}
+		return nil, inProgress()
+	}
+
+	if existing.RefIKRequestHash != claim.RefIKRequestHash {
+		return nil, apperror.New(apperror.CodeKeyReused, "This "+Header+" was already used for a different request").
+			With("path", existing.RefIKPath)
+	}
+	if existing.RefIKStatus != StatusCompleted {
+		return nil, inProgress()
+	}
+	return &existing, nil
+}
+
+func inProgress() *apperror.Error {
+	return apperror.Conflict("A request with this "+Header+" is still being processed, retry shortly").
+		With("retryAfter", 1)
+}
+
+// finish keeps the answer for replay, or frees the key when the request failed on our side.
+func finish(dbConn *gorm.DB, claim Key, recorder *responseRecorder, token string) error {
+	status := recorder.Status()
+	if status >= http.StatusInternalServerError {
+		return release(dbConn, claim)
+	}
+	body, envelope := unseal(recorder.body.Bytes(), token)
+	return processing(dbConn, claim).Updates(map[string]interface{}{
+		"refIKStatus":           StatusCompleted,
+		"refIKResponseCode":     status,
+		"refIKResponseType":     recorder.Header().Get("Content-Type"),
+		"refIKResponseBody":     body,
+		"refIKResponseEnvelope": envelope,
+		"updatedAt":             time.Now().Format(timeLayout),
+	}).Error
+}
+
+// unseal opens an answer sealed for the caller's token and returns its plain text and
+// envelope version; the token expires long before the key, so replay seals it again.
+// Plain answers come back as they are, with no version.
+func unseal(body []byte, token string) ([]byte, string) {
+	var envelope model.ReqVal
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.EncryptedData) == 0 {
+		return body, ""
+	}
+	opened, version, err := hashapi.Open(envelope.EncryptedData, token)
+	if err != nil {
+		return body, ""
+	}
+	plain, err := json.Marshal(opened)
+	if err != nil {
+		return body, ""
+	}
+	return plain, version
+}
+
+func release(dbConn *gorm.DB, claim Key) error {
+	return processing(dbConn, claim).Delete(&Key{}).Error
+}
+
+func processing(dbConn *gorm.DB, claim Key) *gorm.DB {
+	return dbConn.Model(&Key{}).Where(`"refIKScope" = ? AND "refIKKey" = ? AND "refIKStatus" = ?`,
+		claim.RefIKScope, claim.RefIKKey, StatusProcessing)
+}
+
+func replay(c *gin.Context, stored *Key, token string) {
+	contentType := stored.RefIKResponseType
+	if contentType == "" {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	body := stored.RefIKResponseBody
+	if stored.RefIKResponseEnvelope != "" {
+		sealed, err := hashapi.Seal(json.RawMessage(body), stored.RefIKResponseEnvelope, token)
+		if err == nil {
+			body, err = json.Marshal(model.ReqVal{EncryptedData: sealed})
+		}
+		if err != nil {
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Encryption failed: "+err.Error()))
+			c.Abort()
+			return
+		}
+	}
+
+	c.Header(ReplayedHeader, "true")
+	c.Data(stored.RefIKResponseCode, contentType, body)
+	c.Abort()
+}
+
+// purgeExpired drops keys past their window, at most once a minute.
+func purgeExpired(dbConn *gorm.DB, now time.Time) {
+	dbMu.Lock()
+	due := now.Sub(purgedAt) >= time.Minute
+	if due {
+		purgedAt = now
+	}
+	dbMu.Unlock()
+	if due {
+		dbConn.Where(`"refIKExpiresAt" < ?`, now.Format(timeLayout)).Delete(&Key{})
+	}
+}
+
+// responseRecorder copies the answer while it is written to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
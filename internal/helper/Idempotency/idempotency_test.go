@@ -0,0 +1,232 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	hashapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/HashAPI"
+	helper "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/RequestHandler"
+	model "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/model"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// keyStore is an in-memory "IdempotencyKeys" table, answering the statements the
+// middleware sends through gorm.
+type keyStore struct {
+	mu     sync.Mutex
+	nextId int64
+	rows   []map[string]driver.Value
+}
+
+var (
+	insertColumns = regexp.MustCompile(`^INSERT INTO \S+ \(([^)]*)\)`)
+	setColumns    = regexp.MustCompile(`"(\w+)"=\$(\d+)`)
+	keyColumns    = []string{"refIKId", "refIKScope", "refIKKey", "refIKMethod", "refIKPath", "refIKRequestHash",
+		"refIKStatus", "refIKResponseCode", "refIKResponseType", "refIKResponseBody", "refIKResponseEnvelope",
+		"refIKLockedUntil", "refIKExpiresAt", "createdAt", "updatedAt"}
+)
+
+func (s *keyStore) Open(string) (driver.Conn, error) { return &storeConn{s}, nil }
+
+type storeConn struct{ s *keyStore }
+
+func (c *storeConn) Prepare(string) (driver.Stmt, error) { return nil, fmt.Errorf("not supported") }
+func (c *storeConn) Close() error                        { return nil }
+func (c *storeConn) Begin() (driver.Tx, error)           { return storeTx{}, nil }
+
+type storeTx struct{}
+
+func (storeTx) Commit() error   { return nil }
+func (storeTx) Rollback() error { return nil }
+
+func (s *keyStore) find(scope, key driver.Value) map[string]driver.Value {
+	for _, row := range s.rows {
+		if row["refIKScope"] == scope && row["refIKKey"] == key {
+			return row
+		}
+	}
+	return nil
+}
+
+func (c *storeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT"):
+		row := map[string]driver.Value{}
+		for i, column := range strings.Split(insertColumns.FindStringSubmatch(query)[1], ",") {
+			row[strings.Trim(strings.TrimSpace(column), `"`)] = args[i].Value
+		}
+		if s.find(row["refIKScope"], row["refIKKey"]) != nil {
+			return &storeRows{columns: []string{"refIKId"}}, nil
+		}
+		s.nextId++
+		row["refIKId"] = s.nextId
+		s.rows = append(s.rows, row)
+		return &storeRows{columns: []string{"refIKId"}, rows: [][]driver.Value{{s.nextId}}}, nil
+
+	case strings.HasPrefix(query, "SELECT"):
+		rows := &storeRows{columns: keyColumns}
+		if row := s.find(args[0].Value, args[1].Value); row != nil {
+			values := make([]driver.Value, len(keyColumns))
+			for i, column := range keyColumns {
+				values[i] = row[column]
+			}
+			rows.rows = append(rows.rows, values)
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func (c *storeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "DELETE"):
+		return driver.RowsAffected(0), nil
+
+	case strings.HasPrefix(query, "UPDATE"):
+		set, where, _ := strings.Cut(query, " WHERE ")
+		assignments := setColumns.FindAllStringSubmatch(set, -1)
+		rest := args[len(assignments):]
+		row := s.find(rest[0].Value, rest[1].Value)
+		if row == nil {
+			return driver.RowsAffected(0), nil
+		}
+		if strings.Contains(where, `"refIKStatus" = $`) && row["refIKStatus"] != rest[2].Value {
+			return driver.RowsAffected(0), nil
+		}
+		for _, assignment := range assignments {
+			n, _ := strconv.Atoi(assignment[2])
+			row[assignment[1]] = args[n-1].Value
+		}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+type storeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *storeRows) Columns() []string { return r.columns }
+func (r *storeRows) Close() error      { return nil }
+
+func (r *storeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type createRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func TestRetrySealedAfreshIsReplayedForTheRetryToken(t *testing.T) {
+	config.Set(&config.Config{Auth: config.AuthConfig{EncryptAPI: "test-encrypt-key", AllowLegacyCBC: true}})
+	gin.SetMode(gin.TestMode)
+
+	sql.Register("idempotency-keystore", &keyStore{})
+	sqlDB, err := sql.Open("idempotency-keystore", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	dbConn, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseDB(dbConn)
+	defer UseDB(nil)
+
+	calls := 0
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.POST("/items", func(c *gin.Context) {
+		// stands in for JWTMiddleware: the token is the retry's, the user stays the same
+		c.Set("token", c.GetHeader("Authorization"))
+		actor.Set(c, actor.Actor{UserId: 7})
+	}, Middleware(), func(c *gin.Context) {
+		data, ok := helper.RequestHandler[createRequest](c)
+		if !ok {
+			return
+		}
+		calls++
+		helper.Respond(c, http.StatusCreated, gin.H{"status": true, "id": calls, "name": data.Name})
+	})
+
+	send := func(token string, payload interface{}) *httptest.ResponseRecorder {
+		t.Helper()
+		sealed, err := hashapi.EncryptGCM(payload, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := json.Marshal(model.ReqVal{EncryptedData: sealed})
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(string(body)))
+		req.Header.Set("Authorization", token)
+		req.Header.Set(Header, "create-item-1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	open := func(rec *httptest.ResponseRecorder, token string) map[string]interface{} {
+		t.Helper()
+		var envelope model.ReqVal
+		if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil || len(envelope.EncryptedData) == 0 {
+			t.Fatalf("answer is not sealed: %s", rec.Body.String())
+		}
+		opened, _, err := hashapi.Open(envelope.EncryptedData, token)
+		if err != nil {
+			t.Fatalf("answer does not open with %s: %v", token, err)
+		}
+		return opened.(map[string]interface{})
+	}
+
+	first := send("token-1", map[string]interface{}{"name": "Saree"})
+	if first.Code != http.StatusCreated || first.Header().Get(ReplayedHeader) != "" {
+		t.Fatalf("first request: status %d, replayed %q: %s", first.Code, first.Header().Get(ReplayedHeader), first.Body.String())
+	}
+	want := open(first, "token-1")
+
+	// The retry seals the same payload again, with a nonce of its own and a refreshed token
+	retry := send("token-2", map[string]interface{}{"name": "Saree"})
+	if retry.Code != http.StatusCreated || retry.Header().Get(ReplayedHeader) != "true" {
+		t.Fatalf("retry: status %d, replayed %q: %s", retry.Code, retry.Header().Get(ReplayedHeader), retry.Body.String())
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+	if got := open(retry, "token-2"); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("replayed %v, want %v", got, want)
+	}
+
+	other := send("token-2", map[string]interface{}{"name": "Kurta"})
+	if other.Code != http.StatusUnprocessableEntity {
+		t.Errorf("different payload with the same key: status %d, want %d", other.Code, http.StatusUnprocessableEntity)
+	}
+}
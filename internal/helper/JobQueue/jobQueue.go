@@ -0,0 +1,272 @@
+package jobqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	audit "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Audit"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JOB STATUSES - a failed attempt goes back to pending until MaxAttempts, then the job is
+// dead (the dead letter) until an admin retries it
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusDead      = "dead"
+)
+
+const (
+	DefaultMaxAttempts = 8
+	// LockFor bounds one attempt. A job still running after it, because its worker
+	// stopped, is claimed again.
+	LockFor = 5 * time.Minute
+
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+	maxErrorLen = 2000
+	timeLayout  = "2006-01-02 15:04:05"
+)
+
+type Job struct {
+	RefJobId          int64  `gorm:"column:refJobId;primaryKey;autoIncrement" json:"refJobId"`
+	RefJobKind        string `gorm:"column:refJobKind" json:"refJobKind"`
+	RefJobPayload     string `gorm:"column:refJobPayload" json:"-"`
+	RefJobStatus      string `gorm:"column:refJobStatus" json:"refJobStatus"`
+	RefJobAttempts    int    `gorm:"column:refJobAttempts" json:"refJobAttempts"`
+	RefJobMaxAttempts int    `gorm:"column:refJobMaxAttempts" json:"refJobMaxAttempts"`
+	RefJobRunAt       string `gorm:"column:refJobRunAt" json:"refJobRunAt"`
+	RefJobLockedBy    string `gorm:"column:refJobLockedBy" json:"refJobLockedBy"`
+	RefJobLockedUntil string `gorm:"column:refJobLockedUntil" json:"refJobLockedUntil"`
+	RefJobLastError   string `gorm:"column:refJobLastError" json:"refJobLastError"`
+	RefJobResult      string `gorm:"column:refJobResult" json:"-"`
+	RefJobSensitive   bool   `gorm:"column:refJobSensitive" json:"refJobSensitive"`
+	RefJobActor       string `gorm:"column:refJobActor" json:"-"`
+	RefJobRequestId   string `gorm:"column:refJobRequestId" json:"refJobRequestId"`
+	RefJobCompletedAt string `gorm:"column:refJobCompletedAt" json:"refJobCompletedAt"`
+	CreatedAt         string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy         string `gorm:"column:createdBy" json:"createdBy"`
+	UpdatedAt         string `gorm:"column:updatedAt" json:"updatedAt"`
+	UpdatedBy         string `gorm:"column:updatedBy" json:"updatedBy"`
+}
+
+func (Job) TableName() string {
+	return `public."Jobs"`
+}
+
+// View is a job as the admin API shows it: payload and result as JSON, the payload of a
+// sensitive job left out.
+type View struct {
+	Job
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+func (j Job) View() View {
+	v := View{Job: j}
+	if !j.RefJobSensitive && json.Valid([]byte(j.RefJobPayload)) {
+		v.Payload = json.RawMessage(j.RefJobPayload)
+	}
+	if j.RefJobResult != "" && json.Valid([]byte(j.RefJobResult)) {
+		v.Result = json.RawMessage(j.RefJobResult)
+	}
+	return v
+}
+
+// DecodeResult reads the result a completed job stored into v.
+func (j Job) DecodeResult(v interface{}) error {
+	if j.RefJobResult == "" {
+		return errors.New("jobqueue: job has no result")
+	}
+	return json.Unmarshal([]byte(j.RefJobResult), v)
+}
+
+// Handler runs one attempt of a job. db carries the actor who enqueued the job and a
+// context that ends with the attempt's lock. What it returns is kept as the job's result;
+// an error schedules a retry unless it is Permanent.
+type Handler func(db *gorm.DB, payload json.RawMessage) (interface{}, error)
+
+var (
+	handlersMu sync.RWMutex
+	handlers   = map[string]Handler{}
+)
+
+// Handle registers the handler of a kind; modules call it next to their routes.
+func Handle(kind string, h Handler) {
+	handlersMu.Lock()
+	handlers[kind] = h
+	handlersMu.Unlock()
+}
+
+func handlerFor(kind string) (Handler, bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	h, ok := handlers[kind]
+	return h, ok
+}
+
+// Kinds lists the registered kinds, in order.
+func Kinds() []string {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	kinds := make([]string, 0, len(handlers))
+	for kind := range handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error no retry can fix, e.g. a payload the handler cannot read: the
+// job goes straight to dead.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+type Options struct {
+	// MaxAttempts before the job is dead, DefaultMaxAttempts when 0.
+	MaxAttempts int
+	// Delay postpones the first attempt.
+	Delay time.Duration
+	// Sensitive keeps the payload out of the admin API and erases it once the job
+	// completes or dies, e.g. for a mail carrying an OTP. A dead sensitive job cannot
+	// be retried.
+	Sensitive bool
+}
+
+// Enqueue stores a job for the workers. Pass the transaction of the business change so
+// the job exists exactly when the change committed.
+func Enqueue(db *gorm.DB, kind string, payload interface{}, opts Options) (Job, error) {
+	if _, ok := handlerFor(kind); !ok {
+		return Job{}, fmt.Errorf("jobqueue: no handler registered for %q", kind)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("jobqueue: cannot encode the %s payload: %w", kind, err)
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+
+	who := actor.FromDB(db)
+	actorJSON, _ := json.Marshal(who)
+	requestId := ""
+	if db.Statement != nil && db.Statement.Context != nil {
+		requestId = logger.RequestID(db.Statement.Context)
+	}
+
+	now := time.Now()
+	job := Job{
+		RefJobKind:        kind,
+		RefJobPayload:     string(body),
+		RefJobStatus:      StatusPending,
+		RefJobMaxAttempts: opts.MaxAttempts,
+		RefJobRunAt:       now.Add(opts.Delay).Format(timeLayout),
+		RefJobSensitive:   opts.Sensitive,
+		RefJobActor:       string(actorJSON),
+		RefJobRequestId:   requestId,
+		CreatedAt:         now.Format(timeLayout),
+		CreatedBy:         who.By(),
+		UpdatedAt:         now.Format(timeLayout),
+		UpdatedBy:         who.By(),
+	}
+	if err := db.Create(&job).Error; err != nil {
+		return Job{}, fmt.Errorf("jobqueue: cannot enqueue %s: %w", kind, err)
+	}
+	logger.FromDB(db).Infof("📬 Enqueued %s job %d", kind, job.RefJobId)
+	if opts.Delay == 0 {
+		wake()
+	}
+	return job, nil
+}
+
+// Get reads one job.
+func Get(db *gorm.DB, id int64) (Job, error) {
+	var job Job
+	err := db.Where(`"refJobId" = ?`, id).Take(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Job{}, apperror.NotFound("Job not found")
+	}
+	return job, err
+}
+
+// Retry puts a dead job back in the queue with a fresh set of attempts.
+func Retry(db *gorm.DB, id int64) (Job, error) {
+	var job Job
+	err := db.Transaction(func(tx *gorm.DB) error {
+		before, err := Get(tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+		if err != nil {
+			return err
+		}
+		if before.RefJobStatus != StatusDead {
+			return apperror.Conflict("Only a dead job can be retried, this one is " + before.RefJobStatus)
+		}
+		if before.RefJobSensitive {
+			return apperror.Conflict("A sensitive job cannot be retried, its payload was erased when it died")
+		}
+
+		now := time.Now().Format(timeLayout)
+		by := actor.FromDB(tx).By()
+		err = tx.Model(&Job{}).Where(`"refJobId" = ?`, id).Updates(map[string]interface{}{
+			"refJobStatus":      StatusPending,
+			"refJobAttempts":    0,
+			"refJobRunAt":       now,
+			"refJobLockedBy":    nil,
+			"refJobLockedUntil": nil,
+			"updatedAt":         now,
+			"updatedBy":         by,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if job, err = Get(tx, id); err != nil {
+			return err
+		}
+		return audit.Record(tx, audit.Entry{
+			Entity:   audit.EntityJob,
+			EntityId: id,
+			Action:   audit.ActionRetry,
+			Before:   before,
+			After:    job,
+		})
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	wake()
+	return job, nil
+}
+
+// backoff is the wait after a failed attempt: 30s doubling per attempt, at most an hour.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
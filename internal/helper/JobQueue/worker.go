@@ -0,0 +1,182 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	"gorm.io/gorm"
+)
+
+// wakeup lets Enqueue start an idle worker of this process without waiting for its poll.
+var wakeup = make(chan struct{}, 1)
+
+func wake() {
+	select {
+	case wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs cfg.Workers workers until ctx ends. Each claims one due job at a time with
+// FOR UPDATE SKIP LOCKED, so any number of processes can share the queue.
+func Start(ctx context.Context, db *gorm.DB, cfg config.JobsConfig) {
+	host, _ := os.Hostname()
+	for i := 1; i <= cfg.Workers; i++ {
+		name := fmt.Sprintf("%s-%d-%d", host, os.Getpid(), i)
+		go work(ctx, db, name, cfg.PollInterval)
+	}
+	if cfg.Workers > 0 {
+		logger.FromContext(ctx).Infof("⚙️ Started %d job workers", cfg.Workers)
+	}
+}
+
+func work(ctx context.Context, db *gorm.DB, name string, poll time.Duration) {
+	log := logger.FromContext(ctx).WithField("worker", name)
+	for {
+		ran, err := runNext(ctx, db, name)
+		if err != nil {
+			log.Errorf("❌ Job worker: %v", err)
+		}
+		if ran && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wakeup:
+		case <-time.After(poll):
+		}
+	}
+}
+
+// claim takes the oldest due job: a pending one whose time came, or a running one whose
+// worker let its lock pass.
+func claim(db *gorm.DB, worker string) (Job, error) {
+	now := time.Now()
+	var job Job
+	err := db.Raw(`
+		UPDATE public."Jobs"
+		SET "refJobStatus" = ?, "refJobAttempts" = "refJobAttempts" + 1,
+			"refJobLockedBy" = ?, "refJobLockedUntil" = ?, "updatedAt" = ?
+		WHERE "refJobId" = (
+			SELECT "refJobId" FROM public."Jobs"
+			WHERE ("refJobStatus" = ? AND "refJobRunAt" <= ?)
+				OR ("refJobStatus" = ? AND "refJobLockedUntil" < ?)
+			ORDER BY "refJobRunAt", "refJobId"
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		StatusRunning, worker, now.Add(LockFor).Format(timeLayout), now.Format(timeLayout),
+		StatusPending, now.Format(timeLayout),
+		StatusRunning, now.Format(timeLayout),
+	).Scan(&job).Error
+	return job, err
+}
+
+// runNext runs one attempt of the next due job; false when none was due.
+func runNext(ctx context.Context, db *gorm.DB, worker string) (bool, error) {
+	job, err := claim(db.WithContext(ctx), worker)
+	if err != nil {
+		return false, fmt.Errorf("cannot claim a job: %w", err)
+	}
+	if job.RefJobId == 0 {
+		return false, nil
+	}
+
+	var who actor.Actor
+	_ = json.Unmarshal([]byte(job.RefJobActor), &who)
+	attemptCtx, cancel := context.WithTimeout(actor.WithActor(logger.WithRequestID(ctx, job.RefJobRequestId), who), LockFor)
+	defer cancel()
+	log := logger.FromContext(attemptCtx).WithField("jobId", job.RefJobId).WithField("kind", job.RefJobKind)
+
+	started := time.Now()
+	var result interface{}
+	if job.RefJobAttempts > job.RefJobMaxAttempts {
+		err = Permanent(fmt.Errorf("the worker stopped during the last attempt"))
+	} else if handler, ok := handlerFor(job.RefJobKind); !ok {
+		err = fmt.Errorf("no handler registered for %q in this process", job.RefJobKind)
+	} else {
+		result, err = run(handler, db.WithContext(attemptCtx), job)
+	}
+
+	outcome := finish(db.WithContext(context.Background()), job, result, err)
+	metrics.ObserveJob(job.RefJobKind, outcome, time.Since(started).Seconds())
+	switch outcome {
+	case StatusCompleted:
+		log.Infof("✅ Job completed on attempt %d", job.RefJobAttempts)
+	case StatusDead:
+		log.Errorf("💀 Job dead after attempt %d: %v", job.RefJobAttempts, err)
+	default:
+		log.Warnf("🔁 Job attempt %d failed, retrying: %v", job.RefJobAttempts, err)
+	}
+	return true, nil
+}
+
+// run calls the handler, turning a panic into a failed attempt.
+func run(handler Handler, db *gorm.DB, job Job) (result interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+	return handler(db, json.RawMessage(job.RefJobPayload))
+}
+
+// finish stores the outcome of an attempt, unless the job was claimed again meanwhile,
+// and answers completed, retry or dead.
+func finish(db *gorm.DB, job Job, result interface{}, runErr error) string {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"refJobLockedBy":    nil,
+		"refJobLockedUntil": nil,
+		"updatedAt":         now.Format(timeLayout),
+	}
+
+	outcome := StatusCompleted
+	switch {
+	case runErr == nil:
+		body, err := json.Marshal(result)
+		if err != nil {
+			body = []byte("null")
+		}
+		updates["refJobStatus"] = StatusCompleted
+		updates["refJobResult"] = string(body)
+		updates["refJobCompletedAt"] = now.Format(timeLayout)
+	case isPermanent(runErr) || job.RefJobAttempts >= job.RefJobMaxAttempts:
+		outcome = StatusDead
+		updates["refJobStatus"] = StatusDead
+		updates["refJobLastError"] = truncate(runErr.Error())
+	default:
+		outcome = "retry"
+		updates["refJobStatus"] = StatusPending
+		updates["refJobRunAt"] = now.Add(backoff(job.RefJobAttempts)).Format(timeLayout)
+		updates["refJobLastError"] = truncate(runErr.Error())
+	}
+	// a sensitive payload only lives while the job can still run
+	if job.RefJobSensitive && outcome != "retry" {
+		updates["refJobPayload"] = "{}"
+	}
+
+	err := db.Model(&Job{}).
+		Where(`"refJobId" = ? AND "refJobLockedBy" = ? AND "refJobAttempts" = ?`, job.RefJobId, job.RefJobLockedBy, job.RefJobAttempts).
+		Updates(updates).Error
+	if err != nil {
+		logger.FromDB(db).Errorf("❌ Cannot store the outcome of job %d: %v", job.RefJobId, err)
+	}
+	return outcome
+}
+
+func truncate(text string) string {
+	if len(text) > maxErrorLen {
+		return text[:maxErrorLen]
+	}
+	return text
+}
@@ -0,0 +1,459 @@
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PAGE SIZES - a list never answers more than MaxLimit rows
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Kind decides how a filter value is read and which operators it takes.
+type Kind int
+
+const (
+	// Text matches one value or any of a comma separated list.
+	Text Kind = iota
+	// ID is Text for integer ids, e.g. supplierId=3,7.
+	ID
+	// Number is Text plus <name>From / <name>To bounds.
+	Number
+	// Date takes YYYY-MM-DD (or YYYY-MM-DD HH:MM:SS): <name> is that day, <name>From and
+	// <name>To bound a range, both days included. Columns hold "2006-01-02 15:04:05" text.
+	Date
+	// Bool takes true or false.
+	Bool
+)
+
+// Field is a filterable column of the list's base query.
+type Field struct {
+	Column string
+	Kind   Kind
+}
+
+// Spec whitelists what a list endpoint may be filtered, searched and sorted on. Names are
+// the keys of the rows the endpoint answers; columns are output columns of its base query.
+type Spec struct {
+	Filters map[string]Field
+	// Sorts maps a sort name to its column.
+	Sorts map[string]string
+	// Search lists the columns ?q= matches, case-insensitively and anywhere in the value.
+	Search []string
+	// Key is a unique column: the last sort key, so pages never overlap.
+	Key string
+	// DefaultSort is used without ?sort=, e.g. "-id".
+	DefaultSort string
+}
+
+// Order is one sort key.
+type Order struct {
+	Name   string
+	Column string
+	Desc   bool
+}
+
+// Query is a parsed list request: filters, sort and the page to read.
+type Query struct {
+	Limit  int
+	Page   int
+	Sort   []Order
+	cursor []*string
+
+	conditions []string
+	args       []interface{}
+	spec       Spec
+}
+
+// Page describes the slice of the list an answer holds; it is sent as "pagination".
+type Page struct {
+	Limit      int    `json:"limit"`
+	Page       int    `json:"page,omitempty"`
+	Total      int64  `json:"total"`
+	HasMore    bool   `json:"hasMore"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	Sort       string `json:"sort"`
+}
+
+// Parse reads ?limit, ?page or ?cursor, ?sort (e.g. -createdAt,supplierName), ?q and the
+// spec's filters. Unknown sort names and malformed values are a 400; parameters the spec
+// does not name are left alone, e.g. branchId and allBranches for branchscope.
+func Parse(c *gin.Context, spec Spec) (Query, error) {
+	q := Query{Limit: DefaultLimit, Page: 1, spec: spec}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return Query{}, apperror.BadRequest("limit must be a positive number")
+		}
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+		q.Limit = limit
+	}
+	if value := c.Query("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return Query{}, apperror.BadRequest("page must be a positive number")
+		}
+		q.Page = page
+	}
+
+	sortValue := c.DefaultQuery("sort", spec.DefaultSort)
+	if err := q.parseSort(sortValue); err != nil {
+		return Query{}, err
+	}
+	if value := c.Query("cursor"); value != "" {
+		if err := q.parseCursor(value); err != nil {
+			return Query{}, err
+		}
+	}
+
+	if value := strings.TrimSpace(c.Query("q")); value != "" && len(spec.Search) > 0 {
+		var matches []string
+		for _, column := range spec.Search {
+			matches = append(matches, quote(column)+"::text ILIKE ?")
+			q.args = append(q.args, "%"+escapeLike(value)+"%")
+		}
+		q.conditions = append(q.conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+
+	for _, name := range sortedNames(spec.Filters) {
+		if err := q.parseFilter(c, name, spec.Filters[name]); err != nil {
+			return Query{}, err
+		}
+	}
+	return q, nil
+}
+
+func (q *Query) parseSort(value string) error {
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		order := Order{Name: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		column, ok := q.spec.Sorts[order.Name]
+		if !ok {
+			return apperror.BadRequest("Cannot sort on "+order.Name).With("sortable", sortedNames(q.spec.Sorts))
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		order.Column = column
+		q.Sort = append(q.Sort, order)
+	}
+	if !seen[q.spec.Key] {
+		q.Sort = append(q.Sort, Order{Name: q.spec.Key, Column: q.spec.Key})
+	}
+	return nil
+}
+
+func (q *Query) sortString() string {
+	var parts []string
+	for _, order := range q.Sort {
+		if order.Desc {
+			parts = append(parts, "-"+order.Name)
+		} else {
+			parts = append(parts, order.Name)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// A cursor is the sort values of the last row of the previous page, bound to its sort.
+type cursorBody struct {
+	Sort   string    `json:"s"`
+	Values []*string `json:"v"`
+}
+
+func (q *Query) parseCursor(value string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	var body cursorBody
+	if err == nil {
+		err = json.Unmarshal(raw, &body)
+	}
+	if err != nil || body.Sort != q.sortString() || len(body.Values) != len(q.Sort) {
+		return apperror.BadRequest("Invalid cursor, or one made for another sort")
+	}
+	q.cursor = body.Values
+	return nil
+}
+
+func (q *Query) parseFilter(c *gin.Context, name string, field Field) error {
+	column := quote(field.Column)
+
+	if value := c.Query(name); value != "" {
+		switch field.Kind {
+		case Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return apperror.BadRequest(name + " must be true or false")
+			}
+			q.where(column+" = ?", b)
+		case Date:
+			day, err := parseDay(value)
+			if err != nil {
+				return apperror.BadRequest(name + " must be a date, YYYY-MM-DD")
+			}
+			q.where(column+" >= ? AND "+column+" < ?", day.Format(dayLayout), day.AddDate(0, 0, 1).Format(dayLayout))
+		default:
+			values := splitList(value)
+			for _, v := range values {
+				if field.Kind == ID {
+					if _, err := strconv.Atoi(v); err != nil {
+						return apperror.BadRequest(name + " must be an id or a comma separated list of ids")
+					}
+				}
+				if field.Kind == Number {
+					if _, err := strconv.ParseFloat(v, 64); err != nil {
+						return apperror.BadRequest(name + " must be a number or a comma separated list of numbers")
+					}
+				}
+			}
+			if len(values) == 1 {
+				q.where(column+" = ?", values[0])
+			} else {
+				q.where(column+" IN ?", values)
+			}
+		}
+	}
+
+	if field.Kind != Number && field.Kind != Date {
+		return nil
+	}
+	if value := c.Query(name + "From"); value != "" {
+		bound, err := q.bound(name+"From", field.Kind, value, false)
+		if err != nil {
+			return err
+		}
+		q.where(column+" >= ?", bound)
+	}
+	if value := c.Query(name + "To"); value != "" {
+		bound, err := q.bound(name+"To", field.Kind, value, true)
+		if err != nil {
+			return err
+		}
+		if field.Kind == Date && len(value) == len(dayLayout) {
+			// the whole "to" day is included
+			q.where(column+" < ?", bound)
+		} else {
+			q.where(column+" <= ?", bound)
+		}
+	}
+	return nil
+}
+
+const (
+	dayLayout  = "2006-01-02"
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+func (q *Query) bound(name string, kind Kind, value string, upper bool) (interface{}, error) {
+	if kind == Number {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, apperror.BadRequest(name + " must be a number")
+		}
+		return value, nil
+	}
+	if t, err := time.Parse(timeLayout, value); err == nil {
+		return t.Format(timeLayout), nil
+	}
+	day, err := parseDay(value)
+	if err != nil {
+		return nil, apperror.BadRequest(name + " must be a date, YYYY-MM-DD")
+	}
+	if upper {
+		return day.AddDate(0, 0, 1).Format(dayLayout), nil
+	}
+	return day.Format(dayLayout), nil
+}
+
+func parseDay(value string) (time.Time, error) {
+	return time.Parse(dayLayout, value)
+}
+
+func (q *Query) where(condition string, args ...interface{}) {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+}
+
+// Find reads one page of base, a SELECT whose output columns the spec names, into dest, a
+// pointer to a slice of maps or of structs with gorm column tags. It also counts the rows
+// matching the filters.
+func Find(db *gorm.DB, base string, baseArgs []interface{}, q Query, dest interface{}) (Page, error) {
+	where := "TRUE"
+	if len(q.conditions) > 0 {
+		where = strings.Join(q.conditions, " AND ")
+	}
+	args := append(append([]interface{}{}, baseArgs...), q.args...)
+
+	page := Page{Limit: q.Limit, Sort: q.sortString()}
+	err := db.Raw(`SELECT COUNT(*) FROM (`+base+`) AS list WHERE `+where, args...).Scan(&page.Total).Error
+	if err != nil {
+		return page, err
+	}
+
+	offset := 0
+	if q.cursor != nil {
+		after, afterArgs := q.after()
+		where += " AND " + after
+		args = append(args, afterArgs...)
+	} else {
+		page.Page = q.Page
+		offset = (q.Page - 1) * q.Limit
+	}
+
+	var orders []string
+	for _, order := range q.Sort {
+		direction := "ASC"
+		if order.Desc {
+			direction = "DESC"
+		}
+		orders = append(orders, quote(order.Column)+" "+direction+" NULLS LAST")
+	}
+
+	sql := `SELECT * FROM (` + base + `) AS list WHERE ` + where +
+		` ORDER BY ` + strings.Join(orders, ", ") +
+		fmt.Sprintf(` LIMIT %d OFFSET %d`, q.Limit+1, offset)
+	if err := db.Raw(sql, args...).Scan(dest).Error; err != nil {
+		return page, err
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	if rows.Len() > q.Limit {
+		page.HasMore = true
+		rows.Set(rows.Slice(0, q.Limit))
+		page.NextCursor = q.cursorAfter(rows.Index(q.Limit - 1))
+	}
+	return page, nil
+}
+
+// after is the keyset condition "comes after the cursor row" for the query's sort, nulls
+// sorting last in both directions.
+func (q *Query) after() (string, []interface{}) {
+	var alternatives []string
+	var args []interface{}
+	for i, order := range q.Sort {
+		if q.cursor[i] == nil {
+			// nothing sorts after a null but other nulls, ordered by the next key
+			continue
+		}
+		var parts []string
+		for j := 0; j < i; j++ {
+			column := quote(q.Sort[j].Column)
+			if q.cursor[j] == nil {
+				parts = append(parts, column+" IS NULL")
+			} else {
+				parts = append(parts, column+" = ?")
+				args = append(args, *q.cursor[j])
+			}
+		}
+		column := quote(order.Column)
+		operator := ">"
+		if order.Desc {
+			operator = "<"
+		}
+		parts = append(parts, "("+column+" "+operator+" ? OR "+column+" IS NULL)")
+		args = append(args, *q.cursor[i])
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+	if len(alternatives) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+func (q *Query) cursorAfter(row reflect.Value) string {
+	body := cursorBody{Sort: q.sortString()}
+	for _, order := range q.Sort {
+		body.Values = append(body.Values, columnValue(row, order.Column))
+	}
+	raw, _ := json.Marshal(body)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// columnValue reads a column of a scanned row as text, nil for NULL.
+func columnValue(row reflect.Value, column string) *string {
+	for row.Kind() == reflect.Ptr || row.Kind() == reflect.Interface {
+		if row.IsNil() {
+			return nil
+		}
+		row = row.Elem()
+	}
+
+	var value reflect.Value
+	switch row.Kind() {
+	case reflect.Map:
+		value = row.MapIndex(reflect.ValueOf(column))
+	case reflect.Struct:
+		for i := 0; i < row.NumField(); i++ {
+			for _, setting := range strings.Split(row.Type().Field(i).Tag.Get("gorm"), ";") {
+				if setting == "column:"+column {
+					value = row.Field(i)
+				}
+			}
+		}
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	var text string
+	switch v := value.Interface().(type) {
+	case time.Time:
+		text = v.Format(time.RFC3339Nano)
+	case []byte:
+		text = string(v)
+	default:
+		text = fmt.Sprint(v)
+	}
+	return &text
+}
+
+func quote(column string) string {
+	return `"` + strings.ReplaceAll(column, `"`, `""`) + `"`
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,112 @@
+package listquery
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func text(value string) *string { return &value }
+
+var createdThenId = []Order{
+	{Name: "createdAt", Column: "createdAt", Desc: true},
+	{Name: "id", Column: "id"},
+}
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     []Order
+		cursor   []*string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "no nulls",
+			sort:     createdThenId,
+			cursor:   []*string{text("2026-04-01 10:00:00"), text("7")},
+			wantSQL:  `((("createdAt" < ? OR "createdAt" IS NULL)) OR ("createdAt" = ? AND ("id" > ? OR "id" IS NULL)))`,
+			wantArgs: []interface{}{"2026-04-01 10:00:00", "2026-04-01 10:00:00", "7"},
+		},
+		{
+			// a null sorts last, so only rows sharing the null and a later id follow
+			name:     "null leading key",
+			sort:     createdThenId,
+			cursor:   []*string{nil, text("7")},
+			wantSQL:  `(("createdAt" IS NULL AND ("id" > ? OR "id" IS NULL)))`,
+			wantArgs: []interface{}{"7"},
+		},
+		{
+			name:     "null trailing key",
+			sort:     createdThenId,
+			cursor:   []*string{text("2026-04-01 10:00:00"), nil},
+			wantSQL:  `((("createdAt" < ? OR "createdAt" IS NULL)))`,
+			wantArgs: []interface{}{"2026-04-01 10:00:00"},
+		},
+		{
+			name:    "all keys null",
+			sort:    createdThenId,
+			cursor:  []*string{nil, nil},
+			wantSQL: "FALSE",
+		},
+		{
+			name:     "ascending with a null middle key",
+			sort:     []Order{{Name: "status", Column: "status"}, {Name: "supplierName", Column: "supplierName"}, {Name: "id", Column: "id"}},
+			cursor:   []*string{text("OPEN"), nil, text("12")},
+			wantSQL:  `((("status" > ? OR "status" IS NULL)) OR ("status" = ? AND "supplierName" IS NULL AND ("id" > ? OR "id" IS NULL)))`,
+			wantArgs: []interface{}{"OPEN", "OPEN", "12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Query{Sort: tt.sort, cursor: tt.cursor}
+			sql, args := q.after()
+			if sql != tt.wantSQL {
+				t.Errorf("after() sql =\n%s\nwant\n%s", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("after() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCursorKeepsNulls(t *testing.T) {
+	spec := Spec{Sorts: map[string]string{"createdAt": "createdAt", "id": "id"}, Key: "id", DefaultSort: "-createdAt"}
+	q := Query{Sort: createdThenId, spec: spec}
+
+	row := map[string]interface{}{"createdAt": nil, "id": 7}
+	cursor := q.cursorAfter(reflect.ValueOf(row))
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?cursor="+cursor, nil)
+
+	parsed, err := Parse(c, spec)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(parsed.cursor) != 2 || parsed.cursor[0] != nil || parsed.cursor[1] == nil || *parsed.cursor[1] != "7" {
+		t.Fatalf("cursor = %v, want [nil 7]", parsed.cursor)
+	}
+	if sql, _ := parsed.after(); sql != `(("createdAt" IS NULL AND ("id" > ? OR "id" IS NULL)))` {
+		t.Errorf("after() = %s", sql)
+	}
+}
+
+func TestCursorBoundToSort(t *testing.T) {
+	spec := Spec{Sorts: map[string]string{"createdAt": "createdAt", "id": "id"}, Key: "id"}
+	q := Query{Sort: createdThenId, spec: spec}
+	cursor := q.cursorAfter(reflect.ValueOf(map[string]interface{}{"createdAt": "2026-04-01 10:00:00", "id": 7}))
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?sort=createdAt&cursor="+cursor, nil)
+
+	if _, err := Parse(c, spec); err == nil {
+		t.Error("Parse accepted a cursor made for another sort")
+	}
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const requestIDField = "requestId"
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID is the id RequestLogger assigned to the request ctx belongs to, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext is the process logger tagged with the request id carried by ctx.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(InitLogger())
+	if id := RequestID(ctx); id != "" {
+		entry = entry.WithField(requestIDField, id)
+	}
+	return entry
+}
+
+func FromGin(c *gin.Context) *logrus.Entry {
+	return FromContext(c.Request.Context())
+}
+
+// FromDB reads the request id from a connection bound to the request (actor.Bind), so
+// services log with the id of the request that called them.
+func FromDB(dbConn *gorm.DB) *logrus.Entry {
+	if dbConn == nil || dbConn.Statement == nil {
+		return FromContext(context.Background())
+	}
+	return FromContext(dbConn.Statement.Context)
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const slowQuery = 500 * time.Millisecond
+
+// gormLogger routes GORM's output through the process logger, tagged with the request
+// id of the statement's context. Failed and slow statements are logged at their level;
+// every statement is logged at trace, since the SQL carries the bound values.
+type gormLogger struct{}
+
+// GormLogger is set as gorm.Config.Logger by db.Open.
+func GormLogger() gormlogger.Interface {
+	return gormLogger{}
+}
+
+func (l gormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).Infof(msg, args...)
+}
+
+func (gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).Warnf(msg, args...)
+}
+
+func (gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	FromContext(ctx).Errorf(msg, args...)
+}
+
+func (gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := elapsed > slowQuery
+	if !failed && !slow && !InitLogger().IsLevelEnabled(logrus.TraceLevel) {
+		return
+	}
+
+	sql, rows := fc()
+	entry := FromContext(ctx).WithFields(logrus.Fields{
+		"sql":       sql,
+		"rows":      rows,
+		"latencyMs": elapsed.Milliseconds(),
+	})
+	switch {
+	case failed:
+		entry.WithError(err).Error("sql failed")
+	case slow:
+		entry.Warn(fmt.Sprintf("slow sql, over %s", slowQuery))
+	default:
+		entry.Trace("sql")
+	}
+}
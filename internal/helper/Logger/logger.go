@@ -4,52 +4,143 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"syscall"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// LOG FORMATTER
+const timeLayout = "2006-01-02 15:04:05"
+
+// LOG FORMATTER - the human readable line, for LOG_FORMAT=text
 type CustomFormatter struct{}
 
 func (f *CustomFormatter) Format(entry *logrus.Entry) ([]byte, error) {
-	return []byte(fmt.Sprintf("%s [%s]: %s\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		entry.Level.String(),
-		entry.Message)), nil
+	line := fmt.Sprintf("%s [%s]: %s", entry.Time.Format(timeLayout), entry.Level.String(), entry.Message)
+	if id, ok := entry.Data[requestIDField]; ok {
+		line += fmt.Sprintf(" (%s=%v)", requestIDField, id)
+	}
+	return []byte(line + "\n"), nil
 }
 
-// INIT LOGGER
+var (
+	once sync.Once
+	base *logrus.Logger
+	// file stays closed until Configure names a directory, so tests and the openapi
+	// command log to stdout only and leave no Logs/ behind.
+	file = &dailyFile{}
+)
 
+// INIT LOGGER
+//
+// InitLogger returns the process-wide logger. Every call hands out the same instance, so
+// packages may keep calling it where they need a logger. Use FromContext, FromGin or
+// FromDB inside a request, so the line carries the request id.
 func InitLogger() *logrus.Logger {
-	log := logrus.New()
-	log.SetFormatter(new(CustomFormatter))
+	once.Do(func() {
+		base = logrus.New()
+		base.SetFormatter(&redactingFormatter{inner: jsonFormatter()})
+		base.SetOutput(io.MultiWriter(os.Stdout, file))
+		base.SetLevel(logrus.InfoLevel)
+	})
+	return base
+}
 
-	now := time.Now()
-	filename := fmt.Sprintf("Logs/Log_%02d_%02d_%d.log", now.Day(), now.Month(), now.Year())
-	os.MkdirAll("Logs", 0755)
+// Configure applies the log settings; main calls it right after loading the config.
+func Configure(cfg config.LogConfig) {
+	log := InitLogger()
+
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	log.SetLevel(level)
+
+	var inner logrus.Formatter = jsonFormatter()
+	if cfg.Format == "text" {
+		inner = new(CustomFormatter)
+	}
+	log.SetFormatter(&redactingFormatter{inner: inner})
+
+	file.setDir(cfg.Dir)
+}
+
+func jsonFormatter() *logrus.JSONFormatter {
+	return &logrus.JSONFormatter{
+		TimestampFormat: timeLayout,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime: "time",
+			logrus.FieldKeyMsg:  "message",
+		},
+	}
+}
+
+// dailyFile writes to Log_DD_MM_YYYY.log under dir, switching to a new file at midnight;
+// lumberjack still caps the size of each day's file. An empty dir drops the writes.
+type dailyFile struct {
+	mu      sync.Mutex
+	dir     string
+	day     string
+	current *lumberjack.Logger
+}
+
+func (d *dailyFile) setDir(dir string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dir == d.dir {
+		return
+	}
+	d.dir = dir
+	d.closeCurrent()
+}
 
-	// create file once with required permissions
-	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err == nil {
-		file.Close()
-		// explicitly set permissions
-		syscall.Chmod(filename, 0644)
+func (d *dailyFile) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dir == "" {
+		return len(p), nil
 	}
 
-	// lumberjack rotation
-	logFile := &lumberjack.Logger{
-		Filename:   filename,
-		MaxSize:    10, // MB
-		MaxBackups: 0,
-		MaxAge:     7, // days
-		Compress:   true,
+	now := time.Now()
+	day := now.Format("2006-01-02")
+	if d.current == nil || day != d.day {
+		d.closeCurrent()
+		os.MkdirAll(d.dir, 0755)
+		d.day = day
+		d.current = &lumberjack.Logger{
+			Filename:   filepath.Join(d.dir, fmt.Sprintf("Log_%02d_%02d_%d.log", now.Day(), now.Month(), now.Year())),
+			MaxSize:    10, // MB
+			MaxBackups: 0,
+			MaxAge:     7, // days
+			Compress:   true,
+		}
 	}
+	return d.current.Write(p)
+}
 
-	log.SetOutput(io.MultiWriter(os.Stdout, logFile))
-	log.SetLevel(logrus.InfoLevel)
+func (d *dailyFile) closeCurrent() {
+	if d.current != nil {
+		d.current.Close()
+		d.current = nil
+	}
+}
 
-	return log
+// redactingFormatter masks secrets and personal data in the message and the fields
+// before the inner formatter renders the entry, whatever the call site logged.
+type redactingFormatter struct {
+	inner logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clean := entry.Dup()
+	clean.Level = entry.Level
+	clean.Message = Redact(strings.TrimSpace(entry.Message))
+	for key, value := range clean.Data {
+		clean.Data[key] = redactField(key, value)
+	}
+	return f.inner.Format(clean)
 }
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// An id sent by a proxy or the client is kept when it looks sane, so one request can be
+// followed across services; anything else is replaced.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// quietPaths are polled by orchestrators every few seconds; successful hits log at debug.
+var quietPaths = map[string]bool{"/healthz": true, "/readyz": true, "/ping": true}
+
+func newRequestID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// RequestLogger tags every request with an id, echoed in the X-Request-ID response
+// header and carried on the request context for FromGin and FromDB, and writes one
+// access line per request. The query string is left out, it may carry tokens.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDField, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+
+		started := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := logrus.Fields{
+			requestIDField: id,
+			"method":       c.Request.Method,
+			"path":         c.Request.URL.Path,
+			"status":       status,
+			"latencyMs":    time.Since(started).Milliseconds(),
+			"clientIp":     c.ClientIP(),
+		}
+		if userId, ok := c.Get("id"); ok {
+			fields["userId"] = userId
+		}
+		if len(c.Errors) > 0 {
+			fields["errors"] = c.Errors.String()
+		}
+
+		entry := InitLogger().WithFields(fields)
+		switch {
+		case status >= 500:
+			entry.Error("request completed")
+		case status >= 400:
+			entry.Warn("request completed")
+		case quietPaths[c.Request.URL.Path]:
+			entry.Debug("request completed")
+		default:
+			entry.Info("request completed")
+		}
+	}
+}
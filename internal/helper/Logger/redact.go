@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKey matches field names whose value must never reach a log: credentials,
+// tokens, OTPs, bank account numbers and mobile numbers. It also catches the repo's
+// prefixed names such as refUACPassword, supplierBankACNumber or refMobileNo.
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|otp|recoverycode|bankac|accountno|accountnumber|accno|supplierupi|mobile|phone)`)
+
+var (
+	// jwtPattern is any compact JWS, whatever label it is logged with.
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`)
+
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9._~+/=-]+`)
+
+	// keyValuePattern is `name: value`, `name=value` or `"name":"value"`, as printed by
+	// %+v on structs, by query strings and by JSON.
+	keyValuePattern = regexp.MustCompile(`(?i)("?[A-Za-z_]*(?:password|passwd|secret|token|otp|recoverycode|bankac|accountno|accountnumber|accno|supplierupi|mobile|phone)[A-Za-z_]*"?\s*[:=]\s*"?)([^",\s}\]&]+)`)
+
+	// mobilePattern is an Indian mobile number, with or without the country code.
+	mobilePattern = regexp.MustCompile(`(?:\+?91[\s-]?)?\b[6-9]\d{9}\b`)
+)
+
+// Redact masks tokens, credentials, OTPs, bank account and mobile numbers in free text.
+func Redact(text string) string {
+	if text == "" {
+		return text
+	}
+	text = jwtPattern.ReplaceAllString(text, redacted)
+	text = bearerPattern.ReplaceAllString(text, "$1 "+redacted)
+	text = keyValuePattern.ReplaceAllString(text, "${1}"+redacted)
+	text = mobilePattern.ReplaceAllStringFunc(text, maskMobile)
+	return text
+}
+
+// maskMobile keeps the last two digits, enough to tell numbers apart in support cases.
+func maskMobile(number string) string {
+	digits := strings.TrimLeft(number, "+ -")
+	if len(digits) < 2 {
+		return redacted
+	}
+	return "********" + digits[len(digits)-2:]
+}
+
+func redactField(key string, value interface{}) interface{} {
+	if sensitiveKey.MatchString(key) {
+		return redacted
+	}
+	switch v := value.(type) {
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	case fmt.Stringer:
+		return Redact(v.String())
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr:
+		// Structs, maps and slices would be marshalled field by field, render them first.
+		return Redact(fmt.Sprintf("%+v", value))
+	}
+	return value
+}
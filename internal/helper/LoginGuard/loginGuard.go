@@ -0,0 +1,222 @@
+package loginguard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ATTEMPT SCOPES - a failure is counted once per scope it belongs to
+const (
+	ScopeUsername = "username"
+	ScopeIP       = "ip"
+	ScopeOTP      = "otp"
+	// ScopeReset counts every forgot-password request, as each one sends an email
+	ScopeReset = "reset"
+)
+
+// Policy is how many failures a key may have inside Window before it is locked for LockFor.
+// After FreeAttempts failures every further attempt must wait an exponentially growing delay.
+type Policy struct {
+	MaxFailures  int
+	FreeAttempts int
+	Window       time.Duration
+	LockFor      time.Duration
+}
+
+var Policies = map[string]Policy{
+	ScopeUsername: {MaxFailures: 5, FreeAttempts: 2, Window: 15 * time.Minute, LockFor: 15 * time.Minute},
+	ScopeIP:       {MaxFailures: 20, FreeAttempts: 5, Window: 15 * time.Minute, LockFor: 15 * time.Minute},
+	ScopeOTP:      {MaxFailures: 5, FreeAttempts: 2, Window: 15 * time.Minute, LockFor: 30 * time.Minute},
+	ScopeReset:    {MaxFailures: 5, FreeAttempts: 1, Window: 15 * time.Minute, LockFor: 30 * time.Minute},
+}
+
+const (
+	maxDelay   = time.Minute
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+// LockedError is returned while a key is locked or still inside its progressive delay.
+type LockedError struct {
+	Scope      string
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LockedError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed attempts, try again in %d minute(s)", int(e.RetryAfter.Minutes())+1)
+	}
+	return fmt.Sprintf("too many failed attempts, try again in %d second(s)", int(e.RetryAfter.Seconds())+1)
+}
+
+// RetryAfterSeconds is meant for the Retry-After header.
+func (e *LockedError) RetryAfterSeconds() int {
+	return int(e.RetryAfter.Seconds()) + 1
+}
+
+type LoginAttempt struct {
+	RefLAId            int    `gorm:"column:refLAId;primaryKey;autoIncrement" json:"refLAId"`
+	RefLAScope         string `gorm:"column:refLAScope" json:"refLAScope"`
+	RefLAKey           string `gorm:"column:refLAKey" json:"refLAKey"`
+	RefLAFailures      int    `gorm:"column:refLAFailures" json:"refLAFailures"`
+	RefLALastFailureAt string `gorm:"column:refLALastFailureAt" json:"refLALastFailureAt"`
+	RefLALockedUntil   string `gorm:"column:refLALockedUntil" json:"refLALockedUntil"`
+	CreatedAt          string `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt          string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (LoginAttempt) TableName() string {
+	return `public."LoginAttempts"`
+}
+
+func parseTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(timeLayout, value, time.Local)
+	return t, err == nil
+}
+
+func find(dbConn *gorm.DB, scope string, key string) (*LoginAttempt, error) {
+	var attempt LoginAttempt
+	err := dbConn.Where(`"refLAScope" = ? AND "refLAKey" = ?`, scope, key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// delayFor is the wait imposed after the given number of failures: 1s, 2s, 4s ... capped at maxDelay.
+func delayFor(policy Policy, failures int) time.Duration {
+	extra := failures - policy.FreeAttempts
+	if extra <= 0 {
+		return 0
+	}
+	if extra > 6 {
+		return maxDelay
+	}
+	delay := time.Duration(1<<(extra-1)) * time.Second
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// Check returns a *LockedError when the key may not attempt a login right now.
+func Check(dbConn *gorm.DB, scope string, key string) error {
+	if key == "" {
+		return nil
+	}
+	attempt, err := find(dbConn, scope, key)
+	if err != nil || attempt == nil {
+		return err
+	}
+
+	now := time.Now()
+	if lockedUntil, ok := parseTime(attempt.RefLALockedUntil); ok && now.Before(lockedUntil) {
+		return &LockedError{Scope: scope, RetryAfter: lockedUntil.Sub(now), Locked: true}
+	}
+
+	policy := Policies[scope]
+	lastFailure, ok := parseTime(attempt.RefLALastFailureAt)
+	if !ok || now.Sub(lastFailure) > policy.Window {
+		return nil
+	}
+	if next := lastFailure.Add(delayFor(policy, attempt.RefLAFailures)); now.Before(next) {
+		return &LockedError{Scope: scope, RetryAfter: next.Sub(now)}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt and reports whether it just locked the key. The
+// count is one upsert on the unique (scope, key) row, so concurrent failures all add up.
+func RecordFailure(dbConn *gorm.DB, scope string, key string) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+	policy := Policies[scope]
+	now := time.Now()
+	lockAt := now.Add(policy.LockFor).Format(timeLayout)
+
+	// Failures older than the window, or from before an expired lock, start a new count
+	var attempt LoginAttempt
+	err := dbConn.Raw(`
+		INSERT INTO public."LoginAttempts" AS la
+			("refLAScope", "refLAKey", "refLAFailures", "refLALastFailureAt", "refLALockedUntil", "createdAt", "updatedAt")
+		VALUES (@scope, @key, 1, @now, CASE WHEN 1 >= @max THEN @lockAt ELSE '' END, @now, @now)
+		ON CONFLICT ("refLAScope", "refLAKey") DO UPDATE SET
+			"refLAFailures" = CASE
+				WHEN COALESCE(la."refLALastFailureAt", '') < @windowStart
+					OR (COALESCE(la."refLALockedUntil", '') <> '' AND la."refLALockedUntil" <= @now) THEN 1
+				ELSE la."refLAFailures" + 1
+			END,
+			"refLALockedUntil" = CASE
+				WHEN COALESCE(la."refLALastFailureAt", '') < @windowStart
+					OR (COALESCE(la."refLALockedUntil", '') <> '' AND la."refLALockedUntil" <= @now)
+					THEN CASE WHEN 1 >= @max THEN @lockAt ELSE '' END
+				WHEN COALESCE(la."refLALockedUntil", '') <> '' THEN la."refLALockedUntil"
+				WHEN la."refLAFailures" + 1 >= @max THEN @lockAt
+				ELSE ''
+			END,
+			"refLALastFailureAt" = @now,
+			"updatedAt" = @now
+		RETURNING *`,
+		map[string]interface{}{
+			"scope":       scope,
+			"key":         key,
+			"now":         now.Format(timeLayout),
+			"windowStart": now.Add(-policy.Window).Format(timeLayout),
+			"max":         policy.MaxFailures,
+			"lockAt":      lockAt,
+		}).Scan(&attempt).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to record login attempt: %w", err)
+	}
+	return attempt.RefLALockedUntil == lockAt, nil
+}
+
+// Reset clears the counter of a key after a successful attempt.
+func Reset(dbConn *gorm.DB, scope string, key string) error {
+	if key == "" {
+		return nil
+	}
+	return dbConn.Where(`"refLAScope" = ? AND "refLAKey" = ?`, scope, key).Delete(&LoginAttempt{}).Error
+}
+
+// Unlock is Reset for administrators; it reports whether anything was locked or counted.
+func Unlock(dbConn *gorm.DB, scope string, key string) (bool, error) {
+	if _, ok := Policies[scope]; !ok {
+		return false, fmt.Errorf("unknown scope: %s", scope)
+	}
+	result := dbConn.Where(`"refLAScope" = ? AND "refLAKey" = ?`, scope, key).Delete(&LoginAttempt{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unlock: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListLocked returns every key that is locked right now.
+func ListLocked(dbConn *gorm.DB) ([]LoginAttempt, error) {
+	var attempts []LoginAttempt
+	err := dbConn.Where(`COALESCE("refLALockedUntil", '') <> ''`).
+		Order(`"refLALockedUntil" DESC`).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch locked accounts: %w", err)
+	}
+
+	now := time.Now()
+	locked := []LoginAttempt{}
+	for _, a := range attempts {
+		if until, ok := parseTime(a.RefLALockedUntil); ok && now.Before(until) {
+			locked = append(locked, a)
+		}
+	}
+	return locked, nil
+}
@@ -1,25 +1,53 @@
 package mailService
 
 import (
-	"fmt"
+	"encoding/json"
 	"log"
-	"os"
 
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
 	"gopkg.in/gomail.v2"
+	"gorm.io/gorm"
 )
 
-func MailService(toMailer string, htmlContent string, subject string) bool {
-	m := gomail.NewMessage()
-	m.SetHeader("From", os.Getenv("EMAILID"))
-	m.SetHeader("To", toMailer)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", htmlContent)
+// JobSend is the kind of the jobs Queue enqueues.
+const JobSend = "mail.send"
+
+var settings config.MailConfig
 
-	fmt.Println(os.Getenv("PASSWORD"))
+// Configure sets the sender account and registers the mail job; main calls it once at
+// startup.
+func Configure(cfg config.MailConfig) {
+	settings = cfg
+	jobqueue.Handle(JobSend, sendJob)
+}
+
+// Message is the payload of a JobSend job.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+}
+
+// Queue sends the mail from a job worker, retried while the SMTP server fails, so the
+// request does not wait on it. Mark a mail carrying an OTP or a password Sensitive.
+func Queue(db *gorm.DB, toMailer string, htmlContent string, subject string, opts jobqueue.Options) (jobqueue.Job, error) {
+	return jobqueue.Enqueue(db, JobSend, Message{To: toMailer, Subject: subject, HTML: htmlContent}, opts)
+}
 
-	d := gomail.NewDialer("smtp.gmail.com", 465, os.Getenv("EMAILID"), os.Getenv("PASSWORD"))
+func sendJob(_ *gorm.DB, payload json.RawMessage) (interface{}, error) {
+	var msg Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+	if err := send(msg.To, msg.HTML, msg.Subject); err != nil {
+		return nil, err
+	}
+	return map[string]string{"to": msg.To}, nil
+}
 
-	if err := d.DialAndSend(m); err != nil {
+func MailService(toMailer string, htmlContent string, subject string) bool {
+	if err := send(toMailer, htmlContent, subject); err != nil {
 		log.Printf("Could not send email: %v", err)
 		return false
 	}
@@ -27,3 +55,14 @@ func MailService(toMailer string, htmlContent string, subject string) bool {
 	log.Println("Email sent successfully!")
 	return true
 }
+
+func send(toMailer string, htmlContent string, subject string) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", settings.From)
+	m.SetHeader("To", toMailer)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", htmlContent)
+
+	d := gomail.NewDialer(settings.SMTPHost, settings.SMTPPort, settings.From, settings.Password.Reveal())
+	return d.DialAndSend(m)
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Middleware records every request under its route template (/api/v1/x/:id, not the
+// raw path), so the series stay bounded. Unmatched paths share one label.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+		started := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(started).Seconds())
+	}
+}
+
+// Handler serves the Prometheus exposition. When METRICS_TOKEN is set the scraper must
+// send it as a bearer token; otherwise keep /metrics off the public ingress.
+func Handler(cfg config.MetricsConfig) gin.HandlerFunc {
+	promHandler := promhttp.Handler()
+	return func(c *gin.Context) {
+		if cfg.Token.IsSet() {
+			sent := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(sent), []byte(cfg.Token.Reveal())) != 1 {
+				apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Invalid metrics token"))
+				return
+			}
+		}
+		promHandler.ServeHTTP(c.Writer, c.Request)
+	}
+}
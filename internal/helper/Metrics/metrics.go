@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "zadpro"
+
+// HTTP
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template and method.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route"})
+
+	httpInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+)
+
+// DATABASE
+var (
+	dbQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_queries_total",
+		Help:      "GORM statements by operation and outcome.",
+	}, []string{"operation", "outcome"})
+
+	dbDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "GORM statement latency by operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+)
+
+// INTEGRATIONS
+var (
+	minioPresigns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "minio_presign_total",
+		Help:      "Presigned storage URLs by client, method (put, get) and outcome.",
+	}, []string{"client", "method", "outcome"})
+
+	shopifyCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shopify_calls_total",
+		Help:      "Shopify Admin API calls by method, resource and outcome.",
+	}, []string{"method", "resource", "outcome"})
+
+	shopifyDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "shopify_call_duration_seconds",
+		Help:      "Shopify Admin API latency by method and resource.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "resource"})
+)
+
+// JOBS
+var (
+	jobAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_attempts_total",
+		Help:      "Background job attempts by kind and outcome (completed, retry, dead).",
+	}, []string{"kind", "outcome"})
+
+	outboxEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_dispatched_total",
+		Help:      "Domain events handed to their subscribers, by type.",
+	}, []string{"type"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_attempt_duration_seconds",
+		Help:      "Background job attempt latency by kind.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"kind"})
+)
+
+// BUSINESS
+var (
+	GRNsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grns_created_total",
+		Help:      "Goods received notes created.",
+	})
+
+	SKUsGenerated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "skus_generated_total",
+		Help:      "SKUs generated for received GRN items.",
+	})
+
+	TransfersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_transfers_created_total",
+		Help:      "Stock transfers sent to another branch.",
+	})
+
+	TransfersReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_transfers_received_total",
+		Help:      "Stock transfers accepted by the receiving branch.",
+	})
+)
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// ObservePresign counts one presigned URL; client names the module that asked for it.
+func ObservePresign(client string, method string, err error) {
+	minioPresigns.WithLabelValues(client, method, outcome(err)).Inc()
+}
+
+// ObserveJob counts one attempt of a background job and how long it ran.
+func ObserveJob(kind string, outcome string, seconds float64) {
+	jobAttempts.WithLabelValues(kind, outcome).Inc()
+	jobDuration.WithLabelValues(kind).Observe(seconds)
+}
+
+// ObserveEvent counts one domain event the outbox dispatched.
+func ObserveEvent(eventType string) {
+	outboxEvents.WithLabelValues(eventType).Inc()
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterPool exports the database/sql pool stats (open, in use, idle, wait count and
+// duration) as go_sql_* series labelled db_name="postgres".
+func RegisterPool(sqlDB *sql.DB) {
+	err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, "postgres"))
+	var already prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &already) {
+		panic(err)
+	}
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	shopifyAPIPrefix = regexp.MustCompile(`^/admin/(api/[^/]+/)?`)
+	numericSegment   = regexp.MustCompile(`^\d+$`)
+)
+
+// shopifyResource turns /admin/api/2024-04/products/123/images.json into
+// products/:id/images, so ids do not become label values.
+func shopifyResource(path string) string {
+	path = shopifyAPIPrefix.ReplaceAllString(path, "")
+	path = strings.TrimSuffix(path, ".json")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if numericSegment.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+type shopifyTransport struct {
+	next http.RoundTripper
+}
+
+// ShopifyHTTPClient counts and times every call the Shopify client makes; pass it with
+// goshopify.WithHTTPClient.
+func ShopifyHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   10 * time.Second, // go-shopify's own default
+		Transport: shopifyTransport{next: http.DefaultTransport},
+	}
+}
+
+func (t shopifyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := shopifyResource(req.URL.Path)
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	shopifyDuration.WithLabelValues(req.Method, resource).Observe(time.Since(started).Seconds())
+
+	result := "ok"
+	switch {
+	case err != nil:
+		result = "error"
+	case resp.StatusCode == http.StatusTooManyRequests:
+		result = "throttled"
+	case resp.StatusCode >= 400:
+		result = "error"
+	}
+	shopifyCalls.WithLabelValues(req.Method, resource, result).Inc()
+	return resp, err
+}
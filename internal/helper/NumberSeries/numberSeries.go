@@ -0,0 +1,309 @@
+package numberseries
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// NUMBERED DOCUMENT TYPES
+const (
+	PurchaseOrder = "purchaseOrder"
+	SKU           = "sku"
+	StockTransfer = "stockTransfer"
+	BundleInward  = "bundleInward"
+
+	// documents of the older screens, still in use alongside the ones above
+	LegacyPurchaseOrder    = "legacyPurchaseOrder"
+	MgmtPurchaseOrder      = "mgmtPurchaseOrder"
+	POProductSKU           = "poProductSku"
+	InventoryStockTransfer = "inventoryStockTransfer"
+	DummyProductSKU        = "dummyProductSku"
+	POAcceptedSKU          = "poAcceptedSku"
+	POInvoice              = "poInvoice"
+)
+
+const (
+	timeLayout     = "2006-01-02 15:04:05"
+	maxFormatLen   = 40
+	defaultSeqSize = 4
+	maxSeqSize     = 12
+)
+
+// Type is a numbered document: the format it uses until a series is configured, and the
+// table and column its numbers end up in, which the gap audit checks.
+type Type struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	DefaultFormat string `json:"defaultFormat"`
+	PerBranch     bool   `json:"perBranch"`
+	Table         string `json:"-"`
+	Column        string `json:"-"`
+}
+
+// Types lists every document numbered through a series.
+var Types = []Type{
+	{PurchaseOrder, "Purchase order", "PO{FY}{SEQ:5}", false, `"PurchaseOrderManagement"."PurchaseOrders"`, `po_number`},
+	{SKU, "GRN item SKU", "SS{FY}{SEQ:6}", false, `"PurchaseOrderManagement"."PurchaseOrderGRNItems"`, `sku`},
+	{StockTransfer, "Stock transfer", "ST{BRANCH}{FY}{SEQ:5}", true, `"purchaseOrderMgmt"."StockTransferMaster"`, `"stockTransferNumber"`},
+	{BundleInward, "Bundle inward", "SSINW{FY}{SEQ:4}", false, `"BundleInOut".bundle_inwards`, `"bundleInwardNumber"`},
+	{LegacyPurchaseOrder, "Purchase order (legacy)", "PO-{FY}-{SEQ:4}", false, `"purchaseOrder"."CreatePurchaseOrder"`, `"poNumber"`},
+	{MgmtPurchaseOrder, "Purchase order (PO management)", "PO-{FY}-{SEQ:5}", false, `"purchaseOrderMgmt"."PurchaseOrders"`, `"purchaseOrderNumber"`},
+	{POProductSKU, "PO product SKU", "SKU-{FY}-{SEQ:5}", false, `public."POProducts"`, `"poSKU"`},
+	{InventoryStockTransfer, "Stock transfer (inventory)", "ST-{BRANCH}-{FY}{SEQ:5}", true, `"purchaseOrderMgmt"."Inventory_StockTransfers"`, `po_number`},
+	{DummyProductSKU, "Dummy product SKU (legacy PO)", "SS{FY}{SEQ:5}", false, `"purchaseOrder"."ProductsDummyAcceptance"`, `"dummySKU"`},
+	{POAcceptedSKU, "Accepted product SKU (PO management)", "SS-{FY}-{SEQ:5}", false, `"purchaseOrderMgmt"."PurchaseOrderAcceptedProducts"`, `"SKU"`},
+	{POInvoice, "Purchase order invoice (PO management)", "PO-INV-{FY}-{SEQ:5}", false, `"purchaseOrderMgmt"."PurchaseOrders"`, `"invoiceFinalNumber"`},
+}
+
+// TypeOf finds a document type by its code.
+func TypeOf(code string) (Type, bool) {
+	for _, t := range Types {
+		if t.Code == code {
+			return t, true
+		}
+	}
+	return Type{}, false
+}
+
+// Series is the configured format of a document type. BranchId 0 is the default of every
+// branch; a row for one branch overrides it there.
+type Series struct {
+	RefNSId        int    `gorm:"column:refNSId;primaryKey;autoIncrement" json:"refNSId"`
+	RefNSDocType   string `gorm:"column:refNSDocType" json:"refNSDocType"`
+	RefNSBranchId  int    `gorm:"column:refNSBranchId" json:"refNSBranchId"`
+	RefNSFormat    string `gorm:"column:refNSFormat" json:"refNSFormat"`
+	RefNSStartAt   int64  `gorm:"column:refNSStartAt" json:"refNSStartAt"`
+	RefNSPerBranch bool   `gorm:"column:refNSPerBranch" json:"refNSPerBranch"`
+	CreatedAt      string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy      string `gorm:"column:createdBy" json:"createdBy"`
+	UpdatedAt      string `gorm:"column:updatedAt" json:"updatedAt"`
+	UpdatedBy      string `gorm:"column:updatedBy" json:"updatedBy"`
+}
+
+func (Series) TableName() string {
+	return `public."NumberSeries"`
+}
+
+// BranchKeyed tells whether the series counts each branch on its own.
+func (s Series) BranchKeyed() bool {
+	return s.RefNSPerBranch || s.RefNSBranchId != 0
+}
+
+// Counter is the last number drawn of a series in one branch and fiscal year. BranchId is
+// 0 for series shared by all branches.
+type Counter struct {
+	RefNSCId         int    `gorm:"column:refNSCId;primaryKey;autoIncrement" json:"refNSCId"`
+	RefNSCDocType    string `gorm:"column:refNSCDocType" json:"refNSCDocType"`
+	RefNSCBranchId   int    `gorm:"column:refNSCBranchId" json:"refNSCBranchId"`
+	RefNSCFiscalYear int    `gorm:"column:refNSCFiscalYear" json:"refNSCFiscalYear"`
+	RefNSCLastValue  int64  `gorm:"column:refNSCLastValue" json:"refNSCLastValue"`
+	UpdatedAt        string `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+func (Counter) TableName() string {
+	return `public."NumberSeriesCounters"`
+}
+
+// Issued is one number handed out.
+type Issued struct {
+	RefNSIId         int    `gorm:"column:refNSIId;primaryKey;autoIncrement" json:"refNSIId"`
+	RefNSIDocType    string `gorm:"column:refNSIDocType" json:"refNSIDocType"`
+	RefNSIBranchId   int    `gorm:"column:refNSIBranchId" json:"refNSIBranchId"`
+	RefNSIFiscalYear int    `gorm:"column:refNSIFiscalYear" json:"refNSIFiscalYear"`
+	RefNSISeq        int64  `gorm:"column:refNSISeq" json:"refNSISeq"`
+	RefNSINumber     string `gorm:"column:refNSINumber" json:"refNSINumber"`
+	CreatedAt        string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy        string `gorm:"column:createdBy" json:"createdBy"`
+}
+
+func (Issued) TableName() string {
+	return `public."NumberSeriesIssued"`
+}
+
+// FiscalYear is the year the April to March fiscal year of at starts in.
+func FiscalYear(at time.Time) int {
+	if at.Month() >= time.April {
+		return at.Year()
+	}
+	return at.Year() - 1
+}
+
+// tokens of a format, e.g. {FY} or {SEQ:5}
+var token = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+// Validate checks a format before it is saved. Formats take the tokens {FY} (2526 for
+// April 2025 to March 2026), {FYSTART} (2025), {YYYY}, {YY}, {MM}, {BRANCH} (the branch
+// code) and exactly one {SEQ} or {SEQ:n}, the number zero padded to n digits. Counters
+// restart every fiscal year, so a format needs {FY} or {FYSTART}, and a series counted
+// per branch needs {BRANCH}, or two documents could get the same number.
+func Validate(format string, branchKeyed bool) error {
+	if strings.TrimSpace(format) == "" || len(format) > maxFormatLen {
+		return apperror.BadRequest(fmt.Sprintf("Format must have 1 to %d characters", maxFormatLen))
+	}
+	seen := map[string]int{}
+	for _, match := range token.FindAllStringSubmatch(format, -1) {
+		switch match[1] {
+		case "FY", "FYSTART", "YYYY", "YY", "MM", "BRANCH":
+			if match[2] != "" {
+				return apperror.BadRequest("Only {SEQ} takes a width, found " + match[0])
+			}
+		case "SEQ":
+			if match[2] != "" {
+				if width, _ := strconv.Atoi(match[2]); width < 1 || width > maxSeqSize {
+					return apperror.BadRequest(fmt.Sprintf("{SEQ} width must be 1 to %d", maxSeqSize))
+				}
+			}
+		default:
+			return apperror.BadRequest("Unknown token " + match[0])
+		}
+		seen[match[1]]++
+	}
+	if seen["SEQ"] != 1 {
+		return apperror.BadRequest("Format must contain {SEQ} exactly once")
+	}
+	if seen["FY"] == 0 && seen["FYSTART"] == 0 {
+		return apperror.BadRequest("Format must contain {FY} or {FYSTART}: numbers restart every fiscal year")
+	}
+	if branchKeyed && seen["BRANCH"] == 0 {
+		return apperror.BadRequest("A series counted per branch must contain {BRANCH}")
+	}
+	return nil
+}
+
+// Format renders the number seq of a series.
+func Format(format string, fiscalYear int, at time.Time, branchCode string, seq int64) string {
+	return token.ReplaceAllStringFunc(format, func(tok string) string {
+		match := token.FindStringSubmatch(tok)
+		switch match[1] {
+		case "FY":
+			return fmt.Sprintf("%02d%02d", fiscalYear%100, (fiscalYear+1)%100)
+		case "FYSTART":
+			return strconv.Itoa(fiscalYear)
+		case "YYYY":
+			return at.Format("2006")
+		case "YY":
+			return at.Format("06")
+		case "MM":
+			return at.Format("01")
+		case "BRANCH":
+			return branchCode
+		case "SEQ":
+			width := defaultSeqSize
+			if match[2] != "" {
+				width, _ = strconv.Atoi(match[2])
+			}
+			return fmt.Sprintf("%0*d", width, seq)
+		}
+		return tok
+	})
+}
+
+// Resolve finds the series a branch uses for docType: its own, else the default row,
+// else the built-in format of the type.
+func Resolve(db *gorm.DB, docType string, branchId int) (Series, error) {
+	t, ok := TypeOf(docType)
+	if !ok {
+		return Series{}, apperror.BadRequest("Unknown document type " + docType)
+	}
+
+	var rows []Series
+	err := db.Where(`"refNSDocType" = ? AND "refNSBranchId" IN (?, 0)`, docType, branchId).
+		Order(`"refNSBranchId" DESC`).Limit(1).Find(&rows).Error
+	if err != nil {
+		return Series{}, err
+	}
+	if len(rows) > 0 {
+		return rows[0], nil
+	}
+	return Series{RefNSDocType: docType, RefNSFormat: t.DefaultFormat, RefNSStartAt: 1, RefNSPerBranch: t.PerBranch}, nil
+}
+
+// Issue draws the next number of docType for a branch, in the fiscal year of at.
+//
+// Call it inside the transaction that stores the document: the counter row stays locked
+// until that transaction ends, so concurrent requests wait for each other instead of
+// drawing the same number, and a rollback hands the number back. Called outside one it
+// runs in its own, and a document that then fails to save leaves a gap for the audit.
+func Issue(db *gorm.DB, docType string, branchId int, at time.Time) (string, error) {
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); !inTx {
+		var number string
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			number, err = Issue(tx, docType, branchId, at)
+			return err
+		})
+		return number, err
+	}
+
+	series, err := Resolve(db, docType, branchId)
+	if err != nil {
+		return "", err
+	}
+	counterBranch := 0
+	if series.BranchKeyed() {
+		counterBranch = branchId
+	}
+	fiscalYear := FiscalYear(at)
+	now := time.Now().Format(timeLayout)
+
+	// first draw of the year starts the counter, later ones bump it under the row lock;
+	// raising the start of a series moves a running counter forward
+	var seq int64
+	err = db.Raw(`
+		INSERT INTO public."NumberSeriesCounters"
+			("refNSCDocType", "refNSCBranchId", "refNSCFiscalYear", "refNSCLastValue", "updatedAt")
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT ("refNSCDocType", "refNSCBranchId", "refNSCFiscalYear")
+		DO UPDATE SET
+			"refNSCLastValue" = GREATEST("NumberSeriesCounters"."refNSCLastValue" + 1, EXCLUDED."refNSCLastValue"),
+			"updatedAt" = EXCLUDED."updatedAt"
+		RETURNING "refNSCLastValue"
+	`, docType, counterBranch, fiscalYear, series.RefNSStartAt, now).Scan(&seq).Error
+	if err != nil {
+		return "", err
+	}
+
+	branchCode := ""
+	if strings.Contains(series.RefNSFormat, "{BRANCH}") {
+		err := db.Raw(`SELECT "refBranchCode" FROM public."Branches" WHERE "refBranchId" = ?`, branchId).
+			Scan(&branchCode).Error
+		if err != nil {
+			return "", err
+		}
+		if branchCode == "" {
+			return "", apperror.BadRequest(fmt.Sprintf("Branch %d has no branch code to number its %s", branchId, docType))
+		}
+	}
+
+	number := Format(series.RefNSFormat, fiscalYear, at, branchCode, seq)
+	issued := Issued{
+		RefNSIDocType:    docType,
+		RefNSIBranchId:   counterBranch,
+		RefNSIFiscalYear: fiscalYear,
+		RefNSISeq:        seq,
+		RefNSINumber:     number,
+		CreatedAt:        now,
+		CreatedBy:        actor.FromDB(db).By(),
+	}
+	if err := db.Create(&issued).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return "", apperror.Conflict("Number "+number+" was already issued, check the "+docType+" series format").
+				With("number", number)
+		}
+		return "", err
+	}
+
+	logger.FromDB(db).Infof("🔢 Issued %s number %s", docType, number)
+	return number, nil
+}
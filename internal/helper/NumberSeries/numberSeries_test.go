@@ -0,0 +1,95 @@
+package numberseries
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFiscalYear(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	cases := []struct {
+		at   time.Time
+		want int
+	}{
+		{time.Date(2026, time.January, 1, 0, 0, 0, 0, ist), 2025},
+		{time.Date(2026, time.March, 31, 23, 59, 59, 0, ist), 2025},
+		{time.Date(2026, time.April, 1, 0, 0, 0, 0, ist), 2026},
+		{time.Date(2026, time.December, 31, 23, 59, 59, 0, ist), 2026},
+	}
+	for _, c := range cases {
+		if got := FiscalYear(c.at); got != c.want {
+			t.Errorf("FiscalYear(%s) = %d, want %d", c.at.Format(timeLayout), got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		format      string
+		branchKeyed bool
+		wantErr     string
+	}{
+		{"PO{FY}{SEQ:5}", false, ""},
+		{"PO{FYSTART}-{SEQ}", false, ""},
+		{"ST{BRANCH}{FY}{SEQ:5}", true, ""},
+		{"{YYYY}{MM}{YY}{FY}{SEQ:12}", false, ""},
+		{"", false, "1 to 40 characters"},
+		{"   ", false, "1 to 40 characters"},
+		{strings.Repeat("X", 37) + "{FY}{SEQ}", false, "1 to 40 characters"},
+		{"PO{FY}", false, "{SEQ} exactly once"},
+		{"PO{FY}{SEQ}{SEQ}", false, "{SEQ} exactly once"},
+		{"PO{SEQ:5}", false, "{FY} or {FYSTART}"},
+		{"PO{FY}{SEQ:0}", false, "width must be 1 to 12"},
+		{"PO{FY}{SEQ:13}", false, "width must be 1 to 12"},
+		{"PO{FY:2}{SEQ}", false, "Only {SEQ} takes a width"},
+		{"PO{DD}{FY}{SEQ}", false, "Unknown token {DD}"},
+		{"ST{FY}{SEQ:5}", true, "must contain {BRANCH}"},
+	}
+	for _, c := range cases {
+		err := Validate(c.format, c.branchKeyed)
+		switch {
+		case c.wantErr == "" && err != nil:
+			t.Errorf("Validate(%q) = %v, want nil", c.format, err)
+		case c.wantErr != "" && err == nil:
+			t.Errorf("Validate(%q) = nil, want %q", c.format, c.wantErr)
+		case c.wantErr != "" && !strings.Contains(err.Error(), c.wantErr):
+			t.Errorf("Validate(%q) = %q, want %q", c.format, err.Error(), c.wantErr)
+		}
+	}
+}
+
+func TestDefaultFormatsValidate(t *testing.T) {
+	for _, typ := range Types {
+		if err := Validate(typ.DefaultFormat, typ.PerBranch); err != nil {
+			t.Errorf("default format of %s: %v", typ.Code, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	march := time.Date(2026, time.March, 31, 18, 0, 0, 0, time.UTC)
+	april := time.Date(2026, time.April, 1, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		format string
+		at     time.Time
+		branch string
+		seq    int64
+		want   string
+	}{
+		{"PO{FY}{SEQ:5}", march, "", 42, "PO252600042"},
+		{"PO{FY}{SEQ:5}", april, "", 1, "PO262700001"},
+		{"PO-{FYSTART}-{SEQ}", march, "", 7, "PO-2025-0007"},
+		{"PO-{FYSTART}-{SEQ}", april, "", 7, "PO-2026-0007"},
+		{"ST{BRANCH}{FY}{SEQ:3}", april, "CBE", 12, "STCBE2627012"},
+		{"{YYYY}/{MM}/{YY}-{SEQ:2}", march, "", 5, "2026/03/26-05"},
+		{"INV{FY}{SEQ:2}", april, "", 123, "INV2627123"},
+		{"X{FY}{SEQ:4}", time.Date(2099, time.June, 1, 0, 0, 0, 0, time.UTC), "", 1, "X99000001"},
+	}
+	for _, c := range cases {
+		got := Format(c.format, FiscalYear(c.at), c.at, c.branch, c.seq)
+		if got != c.want {
+			t.Errorf("Format(%q, %s, %d) = %q, want %q", c.format, c.at.Format(timeLayout), c.seq, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const specPath = "/docs/openapi.json"
+
+// Swagger UI from its CDN, pointed at the generated document.
+const page = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Snehalayaa Backend API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + specPath + `", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>`
+
+// Register serves the interactive docs at /docs and the document at /docs/openapi.json.
+// Call it after every other route: the document is built once, from the routes gin knows.
+func Register(r *gin.Engine) {
+	Describe("Docs", Routes{
+		"GET /docs":       {Summary: "Interactive API docs (Swagger UI)", Public: true},
+		"GET " + specPath: {Summary: "This OpenAPI 3 document", Public: true},
+	})
+
+	var (
+		once sync.Once
+		spec map[string]interface{}
+	)
+	r.GET(specPath, func(c *gin.Context) {
+		once.Do(func() { spec = Build(r.Routes()) })
+		c.JSON(http.StatusOK, spec)
+	})
+	r.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	})
+}
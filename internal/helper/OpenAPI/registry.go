@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
+)
+
+// Operation documents one route. Request and Response are zero values of the structs the
+// handler binds and answers with, e.g. supplierModel.Supplier{} or []model.Branch{}; their
+// schemas are generated from the json and binding tags.
+type Operation struct {
+	Summary     string
+	Description string
+	// Request is the JSON body, nil when the route reads none.
+	Request interface{}
+	// Response is the "data" of a successful answer, nil when it has none or it is untyped.
+	Response interface{}
+	// Query lists the query string parameters the handler reads with c.Query.
+	Query []string
+	// QueryParams is a struct bound with ShouldBindQuery; its form tags are the parameters.
+	QueryParams interface{}
+	// Public routes take no bearer token.
+	Public bool
+	// Permission is the key RequirePermission checks, see permission.Catalog.
+	Permission string
+	// Encrypted bodies may also be sent as an encrypted envelope, see RequestHandler.
+	Encrypted bool
+	// Idempotent routes run idempotency.Middleware and take an Idempotency-Key header.
+	Idempotent bool
+	// List is the spec of a paginated list: its query parameters and "pagination" answer.
+	List *listquery.Spec
+}
+
+// Routes maps "METHOD /full/path", as registered with gin, to its documentation.
+type Routes map[string]Operation
+
+type entry struct {
+	Tag string
+	Operation
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]entry{}
+	tags       []string
+)
+
+// Describe records the documentation of a module's routes under tag. Route packages call
+// it next to their registrations; `go run ./cmd openapi check` fails on routes without one.
+func Describe(tag string, routes Routes) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	known := false
+	for _, t := range tags {
+		known = known || t == tag
+	}
+	if !known {
+		tags = append(tags, tag)
+	}
+	for key, op := range routes {
+		registry[normalize(key)] = entry{Tag: tag, Operation: op}
+	}
+}
+
+func normalize(key string) string {
+	method, path, _ := strings.Cut(strings.TrimSpace(key), " ")
+	return strings.ToUpper(method) + " " + strings.TrimSpace(path)
+}
+
+func lookup(method, path string) (entry, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	e, ok := registry[method+" "+path]
+	return e, ok
+}
+
+func describedKeys() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	keys := make([]string, 0, len(registry))
+	for key := range registry {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
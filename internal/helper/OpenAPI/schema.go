@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI schema object.
+type Schema map[string]interface{}
+
+// schemas turns Go types into schemas. Named structs become components referenced by
+// $ref, so a struct used by many routes is described once.
+type schemas struct {
+	components map[string]Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]Schema{}, names: map[reflect.Type]string{}}
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+	unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	versionElem = regexp.MustCompile(`^v\d+$`)
+)
+
+// componentName is "<module>.<Type>", e.g. settingModule.Branch: package names such as
+// model repeat across modules, the directory above them does not.
+func componentName(t reflect.Type) string {
+	elems := strings.Split(t.PkgPath(), "/")
+	if len(elems) > 1 && versionElem.MatchString(elems[len(elems)-1]) {
+		elems = elems[:len(elems)-1]
+	}
+	pkg := elems[len(elems)-1]
+	switch pkg {
+	case "model", "service", "controller", "helper", "config":
+		if len(elems) > 1 {
+			pkg = elems[len(elems)-2]
+		}
+	}
+	return unsafeChars.ReplaceAllString(pkg+"."+t.Name(), "_")
+}
+
+func (s *schemas) of(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case rawJSONType:
+		return Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Schema{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return Schema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "format": "byte"}
+		}
+		return Schema{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name, ok := s.names[t]
+		if !ok {
+			name = componentName(t)
+			s.names[t] = name
+			s.components[name] = Schema{}
+			s.components[name] = s.object(t)
+		}
+		return Schema{"$ref": "#/components/schemas/" + name}
+	}
+	// interface{} and anything else: any JSON value
+	return Schema{}
+}
+
+func (s *schemas) object(t reflect.Type) Schema {
+	properties := Schema{}
+	var required []string
+	s.fields(t, properties, &required)
+
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fields follows encoding/json: the json tag names the field, "-" hides it and embedded
+// structs without a tag are flattened. binding tags give required, email and oneof.
+func (s *schemas) fields(t reflect.Type, properties Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.fields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := s.of(field.Type)
+		if strings.Contains(options, "string") {
+			schema = Schema{"type": "string"}
+		}
+		binding := field.Tag.Get("binding")
+		for _, rule := range strings.Split(binding, ",") {
+			switch {
+			case rule == "required":
+				*required = append(*required, name)
+			case rule == "email" && schema["type"] == "string":
+				schema["format"] = "email"
+			case strings.HasPrefix(rule, "oneof="):
+				schema["enum"] = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+// queryParams lists the query parameters of a struct bound with ShouldBindQuery.
+func (s *schemas) queryParams(t reflect.Type) []map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var parameters []map[string]interface{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			parameters = append(parameters, s.queryParams(field.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		schema := s.of(field.Type)
+		required := false
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			switch {
+			case rule == "required":
+				required = true
+			case strings.HasPrefix(rule, "oneof="):
+				schema["enum"] = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "query", "required": required, "schema": schema,
+		})
+	}
+	return parameters
+}
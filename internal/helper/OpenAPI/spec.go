@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	idempotency "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Idempotency"
+	listquery "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/ListQuery"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+)
+
+const conventions = `Every answer is JSON.
+
+**Success** - ` + "`{\"status\": true, \"message\": ..., \"data\": ...}`" + `. Most admin routes also return a
+refreshed ` + "`token`" + `; send it as the bearer token of the next call.
+
+**Errors** - ` + "`{\"status\": false, \"code\": \"NOT_FOUND\", \"message\": ..., \"errors\": [...], \"requestId\": ...}`" + `
+with the HTTP status of the code (see the Error schema). ` + "`errors`" + ` lists field problems of
+VALIDATION_FAILED answers. Quote ` + "`requestId`" + `, also sent as the ` + logger.RequestIDHeader + ` header, when
+reporting a problem.
+
+**Authentication** - ` + "`Authorization: Bearer <token>`" + ` with the token from /api/v1/admin/login.
+Routes that check a permission list it under x-permission; a missing one answers 403 FORBIDDEN.
+
+**Encrypted envelope** - bodies marked x-encrypted may be sent as ` + "`{\"encryptedData\": [...]}`" + `
+instead of plain JSON: the plain body serialised and sealed with the bearer token, versions
+legacy ` + "`[ivHex, cipherHex]`" + ` (AES-256-CBC), ` + "`[\"v1\", ivHex, cipherHex]`" + ` (same, marked) and
+` + "`[\"v2\", nonceHex, cipherHex]`" + ` (AES-256-GCM). Encrypted answers use the version of the request.
+
+**Idempotency** - create routes marked x-idempotent take an optional ` + "`" + idempotency.Header + "`" + ` header
+(any unique string, at most 255 characters). A retry with the same key and body inside
+24 hours gets the first answer again, marked ` + "`" + idempotency.ReplayedHeader + ": true`" + `; a retry while the
+first is running answers 409 CONFLICT and the same key with a different body 422
+IDEMPOTENCY_KEY_REUSED. Keys belong to the signed-in user; 5xx answers are not kept.`
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Build generates the OpenAPI 3 document of the routes gin has registered, with the
+// operations their modules described. Undocumented routes are listed without details.
+func Build(routes gin.RoutesInfo) map[string]interface{} {
+	s := newSchemas()
+	s.components["Error"] = errorSchema()
+	s.components["EncryptedEnvelope"] = Schema{
+		"type":        "object",
+		"description": "A JSON body sealed with the bearer token, see the encrypted envelope convention.",
+		"required":    []string{"encryptedData"},
+		"properties": Schema{
+			"encryptedData": Schema{"type": "array", "items": Schema{"type": "string"}, "minItems": 2, "maxItems": 3},
+		},
+	}
+
+	paths := map[string]map[string]interface{}{}
+	operationIds := map[string]int{}
+	for _, route := range sorted(routes) {
+		path := ginParam.ReplaceAllString(route.Path, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		paths[path][strings.ToLower(route.Method)] = operation(s, route, operationIds)
+	}
+
+	var tagList []map[string]string
+	registryMu.RLock()
+	for _, tag := range tags {
+		tagList = append(tagList, map[string]string{"name": tag})
+	}
+	registryMu.RUnlock()
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Snehalayaa Backend API",
+			"version":     "1.0.0",
+			"description": conventions,
+		},
+		"servers": []map[string]string{{"url": "/"}},
+		"tags":    tagList,
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": s.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]string{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+			"responses": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"description": "Error envelope",
+					"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": Schema{"$ref": "#/components/schemas/Error"}}},
+				},
+			},
+		},
+	}
+}
+
+func sorted(routes gin.RoutesInfo) gin.RoutesInfo {
+	list := append(gin.RoutesInfo{}, routes...)
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Path != list[j].Path {
+			return list[i].Path < list[j].Path
+		}
+		return list[i].Method < list[j].Method
+	})
+	return list
+}
+
+func errorSchema() Schema {
+	var codes []string
+	for _, code := range apperror.Codes() {
+		codes = append(codes, string(code))
+	}
+	return Schema{
+		"type":     "object",
+		"required": []string{"status", "code", "message"},
+		"properties": Schema{
+			"status":    Schema{"type": "boolean", "enum": []bool{false}},
+			"code":      Schema{"type": "string", "enum": codes},
+			"message":   Schema{"type": "string"},
+			"errors":    Schema{"type": "array", "items": Schema{}, "description": "Field problems of VALIDATION_FAILED"},
+			"requestId": Schema{"type": "string"},
+		},
+		"additionalProperties": true,
+	}
+}
+
+var errorResponse = map[string]string{"$ref": "#/components/responses/Error"}
+
+func operation(s *schemas, route gin.RouteInfo, operationIds map[string]int) map[string]interface{} {
+	e, documented := lookup(route.Method, route.Path)
+
+	op := map[string]interface{}{"operationId": operationId(route, operationIds)}
+	if !documented {
+		op["summary"] = "Undocumented"
+		op["tags"] = []string{"Undocumented"}
+		op["responses"] = map[string]interface{}{"default": errorResponse}
+		return op
+	}
+
+	op["tags"] = []string{e.Tag}
+	op["summary"] = e.Summary
+	if e.Description != "" {
+		op["description"] = e.Description
+	}
+
+	var parameters []map[string]interface{}
+	for _, match := range ginParam.FindAllStringSubmatch(route.Path, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name": match[1], "in": "path", "required": true, "schema": Schema{"type": "string"},
+		})
+	}
+	if e.Idempotent {
+		parameters = append(parameters, map[string]interface{}{
+			"name": idempotency.Header, "in": "header", "schema": Schema{"type": "string", "maxLength": 255},
+			"description": "Makes retries of this request safe, see the idempotency convention.",
+		})
+	}
+	for _, name := range e.Query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "query", "schema": Schema{"type": "string"},
+		})
+	}
+	if e.List != nil {
+		parameters = append(parameters, listParameters(*e.List)...)
+	}
+	if e.QueryParams != nil {
+		parameters = append(parameters, s.queryParams(reflect.TypeOf(e.QueryParams))...)
+	}
+	if parameters != nil {
+		op["parameters"] = parameters
+	}
+
+	if e.Request != nil {
+		body := s.of(reflect.TypeOf(e.Request))
+		if e.Encrypted {
+			body = Schema{"oneOf": []Schema{body, {"$ref": "#/components/schemas/EncryptedEnvelope"}}}
+			op["x-encrypted"] = true
+		}
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": body}},
+		}
+	}
+
+	success := Schema{
+		"type": "object",
+		"properties": Schema{
+			"status":  Schema{"type": "boolean", "enum": []bool{true}},
+			"message": Schema{"type": "string"},
+			"token":   Schema{"type": "string", "description": "Refreshed bearer token"},
+		},
+		"additionalProperties": true,
+	}
+	if e.Response != nil {
+		success["properties"].(Schema)["data"] = s.of(reflect.TypeOf(e.Response))
+	}
+	if e.List != nil {
+		success["properties"].(Schema)["pagination"] = s.of(reflect.TypeOf(listquery.Page{}))
+	}
+	responses := map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "Success",
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": success}},
+		},
+		"default": errorResponse,
+	}
+
+	if e.Public {
+		op["security"] = []interface{}{}
+	} else {
+		op["security"] = []map[string][]string{{"bearerAuth": {}}}
+		responses["401"] = errorResponse
+	}
+	if e.Idempotent {
+		op["x-idempotent"] = true
+		responses["409"] = errorResponse
+		responses["422"] = errorResponse
+	}
+	if e.Permission != "" {
+		op["x-permission"] = e.Permission
+		responses["403"] = errorResponse
+	}
+	op["responses"] = responses
+	return op
+}
+
+// listParameters documents the query parameters listquery.Parse reads for spec.
+func listParameters(spec listquery.Spec) []map[string]interface{} {
+	param := func(name string, schema Schema, description string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "in": "query", "schema": schema, "description": description}
+	}
+
+	var sorts []string
+	for name := range spec.Sorts {
+		sorts = append(sorts, name)
+	}
+	sort.Strings(sorts)
+
+	parameters := []map[string]interface{}{
+		param("limit", Schema{"type": "integer", "minimum": 1, "maximum": listquery.MaxLimit, "default": listquery.DefaultLimit}, "Rows per page"),
+		param("page", Schema{"type": "integer", "minimum": 1, "default": 1}, "Page number, ignored with cursor"),
+		param("cursor", Schema{"type": "string"}, "pagination.nextCursor of the previous page, same sort"),
+		param("sort", Schema{"type": "string", "default": spec.DefaultSort},
+			"Comma separated keys, - for descending, of: "+strings.Join(sorts, ", ")),
+	}
+	if len(spec.Search) > 0 {
+		parameters = append(parameters, param("q", Schema{"type": "string"}, "Matches "+strings.Join(spec.Search, ", ")))
+	}
+
+	var filters []string
+	for name := range spec.Filters {
+		filters = append(filters, name)
+	}
+	sort.Strings(filters)
+	for _, name := range filters {
+		switch spec.Filters[name].Kind {
+		case listquery.Bool:
+			parameters = append(parameters, param(name, Schema{"type": "boolean"}, ""))
+		case listquery.Date:
+			parameters = append(parameters,
+				param(name, Schema{"type": "string", "format": "date"}, "That day"),
+				param(name+"From", Schema{"type": "string", "format": "date"}, "From this day"),
+				param(name+"To", Schema{"type": "string", "format": "date"}, "To this day, included"))
+		case listquery.ID:
+			parameters = append(parameters, param(name, Schema{"type": "string"}, "One id or a comma separated list"))
+		case listquery.Number:
+			parameters = append(parameters,
+				param(name, Schema{"type": "string"}, "One value or a comma separated list"),
+				param(name+"From", Schema{"type": "number"}, "At least"),
+				param(name+"To", Schema{"type": "number"}, "At most"))
+		default:
+			parameters = append(parameters, param(name, Schema{"type": "string"}, "One value or a comma separated list"))
+		}
+	}
+	return parameters
+}
+
+// operationId is the handler name, e.g. CreateSupplierController, numbered when a handler
+// serves several routes.
+func operationId(route gin.RouteInfo, seen map[string]int) string {
+	name := route.Handler
+	name = strings.TrimSuffix(name, "-fm")
+	for strings.Contains(name, ".func") {
+		name = name[:strings.LastIndex(name, ".func")]
+	}
+	name = name[strings.LastIndex(name, ".")+1:]
+	if name == "" {
+		name = strings.ToLower(route.Method) + ginParam.ReplaceAllString(route.Path, "$1")
+	}
+	seen[name]++
+	if seen[name] > 1 {
+		return fmt.Sprintf("%s%d", name, seen[name])
+	}
+	return name
+}
+
+// Check lists registered routes nobody described and descriptions of routes that are not
+// registered, both as "METHOD /path".
+func Check(routes gin.RoutesInfo) (undocumented []string, stale []string) {
+	registered := map[string]bool{}
+	for _, route := range sorted(routes) {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if _, ok := lookup(route.Method, route.Path); !ok {
+			undocumented = append(undocumented, key)
+		}
+	}
+	for _, key := range describedKeys() {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+	return undocumented, stale
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	jobqueue "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/JobQueue"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	metrics "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Metrics"
+	"gorm.io/gorm"
+)
+
+// JobDeliver hands one event to one subscriber.
+const JobDeliver = "outbox.deliver"
+
+// batchSize bounds the events one dispatch transaction takes.
+const batchSize = 100
+
+// delivery is the payload of a JobDeliver job.
+type delivery struct {
+	EventId    int64  `json:"eventId"`
+	Type       string `json:"type"`
+	Subscriber string `json:"subscriber"`
+}
+
+// Start registers the delivery job and dispatches pending events every poll until ctx
+// ends. Call it before jobqueue.Start, so no worker claims a delivery it cannot run.
+func Start(ctx context.Context, db *gorm.DB, poll time.Duration) {
+	jobqueue.Handle(JobDeliver, deliver)
+	go func() {
+		log := logger.FromContext(ctx)
+		for {
+			n, err := dispatch(db.WithContext(ctx))
+			if err != nil {
+				log.Errorf("❌ Outbox dispatch: %v", err)
+			}
+			if n == batchSize && err == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(poll):
+			}
+		}
+	}()
+}
+
+// dispatch turns a batch of pending events into one delivery job per subscriber. The jobs
+// and the dispatched mark commit together, so every subscriber gets each event from its
+// own job, retried until it succeeds or is dead. Events of a type nobody subscribes to
+// yet stay pending, for the first subscriber a later release adds.
+func dispatch(db *gorm.DB) (int, error) {
+	types := subscribedTypes()
+	if len(types) == 0 {
+		return 0, nil
+	}
+
+	var events []Event
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw(`
+			SELECT * FROM public."OutboxEvents"
+			WHERE "refOEStatus" = ? AND "refOEType" IN ?
+			ORDER BY "refOEId"
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`, StatusPending, types, batchSize).Scan(&events).Error
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			// the delivery jobs run as the author of the business change
+			var who actor.Actor
+			_ = json.Unmarshal([]byte(event.RefOEActor), &who)
+			eventTx := tx.WithContext(actor.WithActor(logger.WithRequestID(tx.Statement.Context, event.RefOERequestId), who))
+
+			names := subscriberNames(event.RefOEType)
+			for _, name := range names {
+				job := delivery{EventId: event.RefOEId, Type: event.RefOEType, Subscriber: name}
+				if _, err := jobqueue.Enqueue(eventTx, JobDeliver, job, jobqueue.Options{}); err != nil {
+					return err
+				}
+			}
+			err := tx.Model(&Event{}).Where(`"refOEId" = ?`, event.RefOEId).Updates(map[string]interface{}{
+				"refOEStatus":       StatusDispatched,
+				"refOESubscribers":  len(names),
+				"refOEDispatchedAt": time.Now().Format(timeLayout),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		metrics.ObserveEvent(event.RefOEType)
+	}
+	return len(events), nil
+}
+
+func deliver(db *gorm.DB, payload json.RawMessage) (interface{}, error) {
+	var job delivery
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+	fn, ok := subscriber(job.Type, job.Subscriber)
+	if !ok {
+		return nil, fmt.Errorf("no subscriber %q for %s in this process", job.Subscriber, job.Type)
+	}
+
+	var event Event
+	err := db.Where(`"refOEId" = ?`, job.EventId).Take(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, jobqueue.Permanent(fmt.Errorf("event %d no longer exists", job.EventId))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(db, event.message()); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"eventId": event.RefOEId, "subscriber": job.Subscriber}, nil
+}
@@ -0,0 +1,188 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	actor "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Actor"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EVENT TYPES
+const (
+	GRNCreated       = "grn.created"
+	StockTransferred = "stock.transferred"
+	StockReceived    = "stock.received"
+	DebitNoteCreated = "debitNote.created"
+	SKUSold          = "sku.sold"
+)
+
+// Types lists every event type, for the admin API.
+var Types = []string{GRNCreated, StockTransferred, StockReceived, DebitNoteCreated, SKUSold}
+
+// EVENT STATUSES - dispatched once a delivery job exists for every subscriber
+const (
+	StatusPending    = "pending"
+	StatusDispatched = "dispatched"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+type Event struct {
+	RefOEId           int64  `gorm:"column:refOEId;primaryKey;autoIncrement" json:"refOEId"`
+	RefOEType         string `gorm:"column:refOEType" json:"refOEType"`
+	RefOEKey          string `gorm:"column:refOEKey" json:"refOEKey"`
+	RefOEBranchId     int    `gorm:"column:refOEBranchId" json:"refOEBranchId"`
+	RefOEPayload      string `gorm:"column:refOEPayload" json:"-"`
+	RefOEStatus       string `gorm:"column:refOEStatus" json:"refOEStatus"`
+	RefOESubscribers  int    `gorm:"column:refOESubscribers" json:"refOESubscribers"`
+	RefOEActor        string `gorm:"column:refOEActor" json:"-"`
+	RefOERequestId    string `gorm:"column:refOERequestId" json:"refOERequestId"`
+	RefOEDispatchedAt string `gorm:"column:refOEDispatchedAt" json:"refOEDispatchedAt"`
+	CreatedAt         string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy         string `gorm:"column:createdBy" json:"createdBy"`
+}
+
+func (Event) TableName() string {
+	return `public."OutboxEvents"`
+}
+
+// View is an event as the admin API shows it, with its payload as JSON.
+type View struct {
+	Event
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+func (e Event) View() View {
+	v := View{Event: e}
+	if json.Valid([]byte(e.RefOEPayload)) {
+		v.Payload = json.RawMessage(e.RefOEPayload)
+	}
+	return v
+}
+
+// Message is what a subscriber receives. Delivery is at least once: a subscriber may see
+// the same Id again and must tolerate it, e.g. by checking what it already did.
+type Message struct {
+	Id         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Key        string          `json:"key"`
+	BranchId   int             `json:"branchId"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt string          `json:"occurredAt"`
+}
+
+// Decode reads the payload into v.
+func (m Message) Decode(v interface{}) error {
+	return json.Unmarshal(m.Payload, v)
+}
+
+func (e Event) message() Message {
+	return Message{
+		Id:         e.RefOEId,
+		Type:       e.RefOEType,
+		Key:        e.RefOEKey,
+		BranchId:   e.RefOEBranchId,
+		Payload:    json.RawMessage(e.RefOEPayload),
+		OccurredAt: e.CreatedAt,
+	}
+}
+
+// Subscriber handles one event. db carries the actor of the business change; an error
+// has the delivery retried with the job queue's backoff.
+type Subscriber func(db *gorm.DB, msg Message) error
+
+var (
+	subscribersMu sync.RWMutex
+	subscribers   = map[string]map[string]Subscriber{}
+)
+
+// Subscribe registers fn under a name unique for the event type; modules call it next to
+// their routes. The name keys the delivery jobs, so keep it stable across releases.
+func Subscribe(eventType string, name string, fn Subscriber) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	if subscribers[eventType] == nil {
+		subscribers[eventType] = map[string]Subscriber{}
+	}
+	subscribers[eventType][name] = fn
+}
+
+func subscriberNames(eventType string) []string {
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+	names := make([]string, 0, len(subscribers[eventType]))
+	for name := range subscribers[eventType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subscribedTypes lists the event types with at least one subscriber.
+func subscribedTypes() []string {
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+	types := make([]string, 0, len(subscribers))
+	for eventType, names := range subscribers {
+		if len(names) > 0 {
+			types = append(types, eventType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+func subscriber(eventType string, name string) (Subscriber, bool) {
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+	fn, ok := subscribers[eventType][name]
+	return fn, ok
+}
+
+// Publish records an event. Call it with the transaction of the business change: the
+// event exists exactly when the change committed, and is delivered after the commit.
+// The key identifies the event within its type; an event whose type and key were already
+// recorded is skipped.
+func Publish(tx *gorm.DB, eventType string, key interface{}, branchId int, payload interface{}) error {
+	_, err := PublishOnce(tx, eventType, key, branchId, payload)
+	return err
+}
+
+// PublishOnce is Publish telling whether the event was new, e.g. to count the sales of a
+// webhook Shopify may deliver more than once.
+func PublishOnce(tx *gorm.DB, eventType string, key interface{}, branchId int, payload interface{}) (bool, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("outbox: cannot encode the %s payload: %w", eventType, err)
+	}
+
+	who := actor.FromDB(tx)
+	actorJSON, _ := json.Marshal(who)
+	requestId := ""
+	if tx.Statement != nil && tx.Statement.Context != nil {
+		requestId = logger.RequestID(tx.Statement.Context)
+	}
+
+	event := Event{
+		RefOEType:      eventType,
+		RefOEKey:       fmt.Sprint(key),
+		RefOEBranchId:  branchId,
+		RefOEPayload:   string(body),
+		RefOEStatus:    StatusPending,
+		RefOEActor:     string(actorJSON),
+		RefOERequestId: requestId,
+		CreatedAt:      time.Now().Format(timeLayout),
+		CreatedBy:      who.By(),
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+	if result.Error != nil {
+		return false, fmt.Errorf("outbox: cannot record %s: %w", eventType, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,307 @@
+package permission
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/db"
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	roleType "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/GetRoleType"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PERMISSION KEYS
+const (
+	SettingsManage      = "settings.manage"
+	EmployeesManage     = "employees.manage"
+	RolesManage         = "roles.manage"
+	SupplierManage      = "supplier.manage"
+	PurchaseOrderManage = "purchaseOrder.manage"
+	PurchaseOrderView   = "purchaseOrder.view"
+	GRNManage           = "grn.manage"
+	InventoryView       = "inventory.view"
+	InventoryTransfer   = "inventory.transfer"
+	ProductsManage      = "products.manage"
+	DebitNoteManage     = "debitNote.manage"
+	ReportsView         = "reports.view"
+	POSSales            = "pos.sales"
+	BranchCrossView     = "branch.crossView"
+	SecurityManage      = "security.manage"
+	AuditView           = "audit.view"
+	JobsManage          = "jobs.manage"
+)
+
+// SuperAdminRoleID always passes permission checks so the matrix can never lock everyone out.
+const SuperAdminRoleID = 1
+
+type Definition struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// Catalog lists every permission a route can require.
+var Catalog = []Definition{
+	{SettingsManage, "Create, update and delete master data (categories, branches, attributes, round-off)"},
+	{EmployeesManage, "Create, update and disable employees"},
+	{RolesManage, "Edit the role permission matrix"},
+	{SupplierManage, "Create, update and delete suppliers"},
+	{PurchaseOrderManage, "Create and update purchase orders"},
+	{PurchaseOrderView, "View purchase orders, GRNs and their items"},
+	{GRNManage, "Create GRNs, bundle inwards and accept stock intake"},
+	{InventoryView, "View inventory and reports of stock"},
+	{InventoryTransfer, "Create and receive stock transfers"},
+	{ProductsManage, "Create, update and delete products and product images"},
+	{DebitNoteManage, "Create debit notes"},
+	{ReportsView, "View purchase, ageing and product reports"},
+	{POSSales, "Use the point of sale"},
+	{BranchCrossView, "See and act on stock, transfers and purchase orders of every branch"},
+	{SecurityManage, "Rotate and revoke access token signing keys"},
+	{AuditView, "See who changed master data and stock documents, and when"},
+	{JobsManage, "Inspect background jobs and retry the dead ones"},
+}
+
+// DefaultRolePermissions is what SeedDefaults grants, keyed on RoleType.refRTId.
+var DefaultRolePermissions = map[int][]string{
+	// Admin
+	2: {SettingsManage, EmployeesManage, SupplierManage, PurchaseOrderManage, PurchaseOrderView, GRNManage, InventoryView, InventoryTransfer, ProductsManage, DebitNoteManage, ReportsView, POSSales, BranchCrossView, AuditView, JobsManage},
+	// Accounts Manager
+	3: {SupplierManage, PurchaseOrderView, DebitNoteManage, InventoryView, ReportsView, BranchCrossView, AuditView},
+	// Store Manager
+	4: {PurchaseOrderView, GRNManage, InventoryView, InventoryTransfer, ProductsManage, ReportsView, POSSales},
+	// Purchase Manager
+	5: {SupplierManage, PurchaseOrderManage, PurchaseOrderView, GRNManage, InventoryView, ProductsManage, DebitNoteManage, ReportsView, BranchCrossView},
+	// Billing Executive
+	6: {InventoryView, POSSales},
+	// Sales Executive
+	7: {InventoryView, POSSales},
+	// SEO
+	8: {ProductsManage},
+	// Customer Support
+	9: {InventoryView},
+}
+
+type RolePermission struct {
+	RefRPId       int    `gorm:"column:refRPId;primaryKey;autoIncrement" json:"refRPId"`
+	RefRTId       int    `gorm:"column:refRTId" json:"refRTId"`
+	PermissionKey string `gorm:"column:permissionKey" json:"permissionKey"`
+	CreatedAt     string `gorm:"column:createdAt" json:"createdAt"`
+	CreatedBy     string `gorm:"column:createdBy" json:"createdBy"`
+}
+
+func (RolePermission) TableName() string {
+	return `public."RolePermissions"`
+}
+
+func IsKnown(key string) bool {
+	for _, d := range Catalog {
+		if d.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRolePermissions reads the permission keys granted to a role.
+func GetRolePermissions(dbConn *gorm.DB, roleId int) ([]string, error) {
+	keys := []string{}
+	err := dbConn.Model(&RolePermission{}).
+		Where(`"refRTId" = ?`, roleId).
+		Order(`"permissionKey"`).
+		Pluck(`"permissionKey"`, &keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role permissions: %w", err)
+	}
+	return keys, nil
+}
+
+// SetRolePermissions replaces the full permission set of a role.
+func SetRolePermissions(dbConn *gorm.DB, roleId int, keys []string, createdBy string) error {
+	for _, key := range keys {
+		if !IsKnown(key) {
+			return fmt.Errorf("unknown permission: %s", key)
+		}
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(`"refRTId" = ?`, roleId).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		for _, key := range keys {
+			row := RolePermission{RefRTId: roleId, PermissionKey: key, CreatedAt: timestamp, CreatedBy: createdBy}
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save role permissions: %w", err)
+	}
+
+	InvalidateCache()
+	return nil
+}
+
+// SeedDefaults grants DefaultRolePermissions for every key no role holds yet. An empty
+// matrix gets the full defaults; a live one picks up keys added since it was seeded,
+// while grants an admin removed from some roles stay removed.
+func SeedDefaults(dbConn *gorm.DB) error {
+	held := []string{}
+	if err := dbConn.Model(&RolePermission{}).Distinct(`"permissionKey"`).Pluck(`"permissionKey"`, &held).Error; err != nil {
+		return err
+	}
+	seeded := make(map[string]bool, len(held))
+	for _, key := range held {
+		seeded[key] = true
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	added := 0
+	err := dbConn.Transaction(func(tx *gorm.DB) error {
+		for roleId, keys := range DefaultRolePermissions {
+			for _, key := range keys {
+				if seeded[key] {
+					continue
+				}
+				result := tx.Exec(`
+					INSERT INTO public."RolePermissions" ("refRTId", "permissionKey", "createdAt", "createdBy")
+					VALUES (?, ?, ?, 'System')
+					ON CONFLICT ("refRTId", "permissionKey") DO NOTHING
+				`, roleId, key, timestamp)
+				if result.Error != nil {
+					return result.Error
+				}
+				added += int(result.RowsAffected)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed role permissions: %w", err)
+	}
+
+	if added > 0 {
+		InvalidateCache()
+	}
+	return nil
+}
+
+// ROLE PERMISSION CACHE - the middleware runs on every request, the matrix rarely changes
+
+const cacheTTL = time.Minute
+
+type cacheEntry struct {
+	keys     map[string]bool
+	loadedAt time.Time
+}
+
+var (
+	cacheMu sync.RWMutex
+	cache   = map[int]cacheEntry{}
+
+	permissionDB *gorm.DB
+)
+
+// UseDB hands RequirePermission the shared pool main opened. Call it before serving.
+func UseDB(dbConn *gorm.DB) {
+	cacheMu.Lock()
+	permissionDB = dbConn
+	cacheMu.Unlock()
+}
+
+// InvalidateCache makes the next check reload the role's permissions; the old sets stay
+// as the fallback should that reload fail.
+func InvalidateCache() {
+	cacheMu.Lock()
+	for roleId, entry := range cache {
+		entry.loadedAt = time.Time{}
+		cache[roleId] = entry
+	}
+	cacheMu.Unlock()
+}
+
+// cachedPermissions reloads a role's set once cacheTTL passed. While the reload fails,
+// e.g. Postgres is down, the last set loaded keeps serving, so the diagnostics stay
+// reachable; nobody can change the matrix meanwhile anyway.
+func cachedPermissions(roleId int) (map[string]bool, error) {
+	cacheMu.RLock()
+	entry, ok := cache[roleId]
+	dbConn := permissionDB
+	cacheMu.RUnlock()
+	if ok && time.Since(entry.loadedAt) < cacheTTL {
+		return entry.keys, nil
+	}
+
+	var keys []string
+	err := db.ErrUnavailable
+	if dbConn != nil {
+		keys, err = GetRolePermissions(dbConn, roleId)
+	}
+	if err != nil {
+		if ok {
+			logger.InitLogger().Warnf("⚠️ Permissions of role %d not reloaded, serving the cached set: %v", roleId, err)
+			return entry.keys, nil
+		}
+		return nil, err
+	}
+
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+
+	cacheMu.Lock()
+	cache[roleId] = cacheEntry{keys: set, loadedAt: time.Now()}
+	cacheMu.Unlock()
+	return set, nil
+}
+
+// HasPermission reports whether the role holds the permission.
+func HasPermission(roleId int, key string) (bool, error) {
+	if roleId == SuperAdminRoleID {
+		return true, nil
+	}
+	keys, err := cachedPermissions(roleId)
+	if err != nil {
+		return false, err
+	}
+	return keys[key], nil
+}
+
+// RequirePermission must run after accesstoken.JWTMiddleware, which puts roleId in the context.
+func RequirePermission(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.InitLogger()
+
+		roleIdValue, exists := c.Get("roleId")
+		if !exists {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "RoleID not found in request context."))
+			return
+		}
+
+		roleId, err := roleType.ExtractIntFromInterface(roleIdValue)
+		if err != nil {
+			apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Invalid role ID"))
+			return
+		}
+
+		allowed, err := HasPermission(roleId, key)
+		if err != nil {
+			log.Error("❌ Permission lookup failed: " + err.Error())
+			apperror.Respond(c, apperror.Wrap(err, apperror.CodeUnavailable, "Unable to verify permissions"))
+			return
+		}
+
+		if !allowed {
+			log.Warnf("⛔ roleId=%d denied permission %s on %s %s", roleId, key, c.Request.Method, c.FullPath())
+			apperror.Respond(c, apperror.New(apperror.CodeForbidden, "You do not have permission to perform this action"))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,75 +1,132 @@
 package helper
 
 import (
-	"fmt"
-	"net/http"
+	"encoding/json"
 
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
 	hashapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/HashAPI"
+	validation "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Validation"
 	model "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/model"
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 )
 
+// EnvelopeVersionKey holds the envelope version of the request, RespondEncrypted answers in the same one.
+const EnvelopeVersionKey = "envelopeVersion"
+
+// RequestHandler reads the body into T and validates it against the `binding` tags of T.
+// The body is either an encrypted envelope {"encryptedData": [...]} or plain JSON; both
+// give the same field-level errors, see validation.Respond.
 func RequestHandler[T any](c *gin.Context) (*T, bool) {
 	// EXTRACK TOKEN FROM CONTEXT
 	tokenVal, exists := c.Get("token")
 
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"status":  false,
-			"message": "Token not found in context.",
-		})
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Token not found in context."))
 		return nil, false
 
 	}
-	// BIND ENCRYPTED BODY
-	var encryptedData model.ReqVal
-	if err := c.BindJSON(&encryptedData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  false,
-			"message": "Invalid request body : " + err.Error(),
-		})
+
+	rawBody, err := c.GetRawData()
+	if err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid request body : "+err.Error()))
 		return nil, false
 	}
-	if len(encryptedData.EncryptedData) < 2 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  false,
-			"message": "Invalid encrypted data format",
-		})
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(rawBody, &probe); err != nil {
+		validation.Respond(c, err)
 		return nil, false
 	}
 
-	// DECRYPTED DATA
-	decryptedInterface, err := hashapi.Decrypt(encryptedData.EncryptedData, tokenVal.(string))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  false,
-			"message": "Decryption failed: " + err.Error(),
-		})
+	var data T
+	if _, encrypted := probe["encryptedData"]; encrypted {
+		if !decodeEncrypted(c, rawBody, tokenVal.(string), &data) {
+			return nil, false
+		}
+	} else if err := json.Unmarshal(rawBody, &data); err != nil {
+		validation.Respond(c, err)
+		return nil, false
+	}
+
+	// VALIDATE DECODED STRUCTURE
+	if err := validation.Struct(&data); err != nil {
+		validation.Respond(c, err)
 		return nil, false
 	}
 
+	return &data, true
+}
+
+func decodeEncrypted[T any](c *gin.Context, rawBody []byte, token string, data *T) bool {
+	// BIND ENCRYPTED BODY
+	var encryptedData model.ReqVal
+	if err := json.Unmarshal(rawBody, &encryptedData); err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid request body : "+err.Error()))
+		return false
+	}
+	if _, err := hashapi.EnvelopeVersion(encryptedData.EncryptedData); err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid encrypted data format"))
+		return false
+	}
+
+	// DECRYPTED DATA - MODE PICKED FROM THE ENVELOPE VERSION
+	decryptedInterface, version, err := hashapi.Open(encryptedData.EncryptedData, token)
+	if err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Decryption failed: "+err.Error()))
+		return false
+	}
+	c.Set(EnvelopeVersionKey, version)
+
 	// VALIDATE DECRYPTED SSTRUCURE
 	mapData, ok := decryptedInterface.(map[string]interface{})
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  false,
-			"message": "Invalid decrypt format",
-		})
-		return nil, false
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Invalid decrypt format"))
+		return false
 	}
 
-	// PRINT DATA
-	fmt.Println("\n\nDecoded Struct Data : ", mapData)
+	// Field names follow the json tags, as for a plain body
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: data})
+	if err == nil {
+		err = decoder.Decode(mapData)
+	}
+	if err != nil {
+		apperror.Respond(c, apperror.New(apperror.CodeBadRequest, "Failed to decode decrypted data : "+err.Error()))
+		return false
+	}
+	return true
+}
 
-	var data T
-	if err := mapstructure.Decode(mapData, &data); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  false,
-			"message": "Failed to decode decrypted data : " + err.Error(),
-		})
-		return nil, false
+// RespondEncrypted writes payload as {"encryptedData": [...]} sealed with the request token,
+// in the envelope version the request used (v2 when the request was not encrypted).
+func RespondEncrypted(c *gin.Context, status int, payload interface{}) {
+	tokenVal, exists := c.Get("token")
+	token, _ := tokenVal.(string)
+	if !exists || token == "" {
+		apperror.Respond(c, apperror.New(apperror.CodeUnauthorized, "Token not found in context."))
+		return
 	}
 
-	return &data, true
+	version := c.GetString(EnvelopeVersionKey)
+	if version == "" {
+		version = hashapi.VersionGCM
+	}
+
+	sealed, err := hashapi.Seal(payload, version, token)
+	if err != nil {
+		apperror.Respond(c, apperror.Wrap(err, apperror.CodeInternal, "Encryption failed: "+err.Error()))
+		return
+	}
+
+	c.JSON(status, model.ReqVal{EncryptedData: sealed})
+}
+
+// Respond answers in kind: sealed with RespondEncrypted when the request came encrypted,
+// plain JSON otherwise.
+func Respond(c *gin.Context, status int, payload interface{}) {
+	if c.GetString(EnvelopeVersionKey) != "" {
+		RespondEncrypted(c, status, payload)
+		return
+	}
+	c.JSON(status, payload)
 }
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	logger "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/Logger"
+	openapi "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/OpenAPI"
+	"github.com/gin-gonic/gin"
+)
+
+// maxUpload bounds one upload to the local driver.
+const maxUpload = 100 << 20
+
+// Register serves the signed URLs of the local driver: GET downloads an object, PUT
+// uploads one. With any other driver both answer 404.
+func Register(r *gin.Engine) {
+	r.GET(RoutePrefix+"/*key", serve)
+	r.PUT(RoutePrefix+"/*key", serve)
+
+	openapi.Describe("Storage", openapi.Routes{
+		"GET " + RoutePrefix + "/*key": {Summary: "Download an object of the local storage driver", Query: []string{"expires", "signature"}, Public: true},
+		"PUT " + RoutePrefix + "/*key": {Summary: "Upload an object to the local storage driver", Query: []string{"expires", "signature"}, Public: true},
+	})
+}
+
+func serve(c *gin.Context) {
+	local, ok := Get().(*Local)
+	if !ok {
+		apperror.Respond(c, apperror.NotFound("Resource not found"))
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	method := c.Request.Method
+	if !local.verify(method, key, c.Query("expires"), c.Query("signature")) {
+		apperror.Respond(c, apperror.New(apperror.CodeForbidden, "The link is invalid or has expired"))
+		return
+	}
+	name, err := local.file(key)
+	if err != nil {
+		apperror.Respond(c, apperror.BadRequest(err.Error()))
+		return
+	}
+
+	if method == http.MethodGet {
+		if _, err := local.Stat(c.Request.Context(), key); errors.Is(err, ErrNotFound) {
+			apperror.Respond(c, apperror.NotFound("Object not found"))
+			return
+		}
+		c.Header("Content-Type", contentType(key))
+		c.File(name)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxUpload)
+	if err := local.write(key, body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apperror.Respond(c, apperror.BadRequest("Upload exceeds 100 MB"))
+			return
+		}
+		apperror.Respond(c, apperror.Internal(err, "Unable to store the upload"))
+		return
+	}
+	logger.FromGin(c).Infof("📦 Stored %s in local storage", key)
+	c.Status(http.StatusOK)
+}
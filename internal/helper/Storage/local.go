@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+)
+
+// RoutePrefix is where Register serves the objects of the local driver.
+const RoutePrefix = "/storage"
+
+// Local keeps objects as files under a directory and signs URLs to this service, which
+// serves them through Register. Meant for development and tests.
+type Local struct {
+	dir     string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocal creates the directory if needed. Without STORAGE_LOCAL_SECRET the URLs are
+// signed with a key drawn now.
+func NewLocal(cfg config.StorageConfig) (*Local, error) {
+	dir, err := filepath.Abs(cfg.LocalDir)
+	if err != nil {
+		return nil, fmt.Errorf("storage: bad STORAGE_LOCAL_DIR: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: cannot create %s: %w", dir, err)
+	}
+
+	secret := []byte(cfg.LocalSecret.Reveal())
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("storage: cannot draw a signing key: %w", err)
+		}
+	}
+	return &Local{dir: dir, baseURL: cfg.LocalURL, secret: secret}, nil
+}
+
+// file maps a key to its path, refusing keys that would leave the directory.
+func (l *Local) file(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
+
+func (l *Local) signature(method string, key string, expires int64) string {
+	mac := hmac.New(sha256.New, l.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) presign(method string, key string, expiry time.Duration) (string, error) {
+	if _, err := l.file(key); err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiry).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", l.signature(method, key, expires))
+	return l.baseURL + RoutePrefix + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// verify checks a signed URL for method on key.
+func (l *Local) verify(method string, key string, expiresText string, signature string) bool {
+	expires, err := strconv.ParseInt(expiresText, 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(l.signature(method, key, expires)))
+}
+
+func (l *Local) PresignPut(_ context.Context, key string, expiry time.Duration) (string, error) {
+	return l.presign("PUT", key, expiry)
+}
+
+func (l *Local) PresignGet(_ context.Context, key string, expiry time.Duration) (string, error) {
+	return l.presign("GET", key, expiry)
+}
+
+// Put ignores contentType: the local driver serves a type from the key's extension.
+func (l *Local) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	return l.write(key, body)
+}
+
+func (l *Local) Stat(_ context.Context, key string) (Object, error) {
+	name, err := l.file(key)
+	if err != nil {
+		return Object{}, err
+	}
+	info, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return Object{}, ErrNotFound
+	}
+	if err != nil {
+		return Object{}, err
+	}
+	return l.object(key, info), nil
+}
+
+func (l *Local) object(key string, info fs.FileInfo) Object {
+	return Object{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  contentType(key),
+		LastModified: info.ModTime(),
+	}
+}
+
+func contentType(key string) string {
+	if t := mime.TypeByExtension(path.Ext(key)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	name, err := l.file(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) List(_ context.Context, prefix string, limit int) ([]Object, error) {
+	objects := []Object{}
+	err := filepath.WalkDir(l.dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.dir, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, l.object(key, info))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	if limit > 0 && len(objects) > limit {
+		objects = objects[:limit]
+	}
+	return objects, nil
+}
+
+func (l *Local) Check(_ context.Context) (string, error) {
+	probe, err := os.CreateTemp(l.dir, ".check-*")
+	if err != nil {
+		return "local " + l.dir, err
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return "local " + l.dir, nil
+}
+
+// write stores body under key, replacing any object there only once it is complete.
+func (l *Local) write(key string, body io.Reader) error {
+	name, err := l.file(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinIO keeps objects in one bucket of a MinIO (or any S3 compatible) server.
+type MinIO struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewMinIO builds the client from the MinIO settings. It does not connect: an unreachable
+// server shows in Check and in the calls that need it.
+func NewMinIO(cfg config.MinIOConfig) (*MinIO, error) {
+	client, err := minio.New(cfg.Address(), &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey.Reveal(), cfg.SecretKey.Reveal(), ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: cannot create the MinIO client: %w", err)
+	}
+	return &MinIO{client: client, bucket: cfg.Bucket}, nil
+}
+
+func (m *MinIO) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := m.client.PresignedPutObject(ctx, m.bucket, key, expiry)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (m *MinIO) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := m.client.PresignedGetObject(ctx, m.bucket, key, expiry, url.Values{})
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (m *MinIO) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := m.client.PutObject(ctx, m.bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (m *MinIO) Stat(ctx context.Context, key string) (Object, error) {
+	info, err := m.client.StatObject(ctx, m.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return Object{}, ErrNotFound
+		}
+		return Object{}, err
+	}
+	return Object{Key: info.Key, Size: info.Size, ContentType: info.ContentType, LastModified: info.LastModified}, nil
+}
+
+func (m *MinIO) Delete(ctx context.Context, key string) error {
+	return m.client.RemoveObject(ctx, m.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (m *MinIO) List(ctx context.Context, prefix string, limit int) ([]Object, error) {
+	// stop the listing goroutine once enough objects came back
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := []Object{}
+	for info := range m.client.ListObjects(ctx, m.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		objects = append(objects, Object{Key: info.Key, Size: info.Size, ContentType: info.ContentType, LastModified: info.LastModified})
+		if limit > 0 && len(objects) == limit {
+			break
+		}
+	}
+	return objects, nil
+}
+
+func (m *MinIO) Check(ctx context.Context) (string, error) {
+	detail := m.client.EndpointURL().Host + "/" + m.bucket
+	exists, err := m.client.BucketExists(ctx, m.bucket)
+	if err != nil {
+		return detail, err
+	}
+	if !exists {
+		return detail, fmt.Errorf("bucket %q does not exist", m.bucket)
+	}
+	return detail, nil
+}
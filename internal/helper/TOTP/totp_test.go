@@ -0,0 +1,144 @@
+package totp
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// base32 of the RFC 6238 SHA1 seed "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// RFC 6238 appendix B SHA1 vectors, cut to the last six of their eight digits
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeAtRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("CodeAt at %d: %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("CodeAt at %d = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestCodeAtNormalisesSecret(t *testing.T) {
+	got, err := CodeAt("  gezdgnbvgy3tqojqgezdgnbvgy3tqojq ", Step(time.Unix(59, 0)))
+	if err != nil || got != "287082" {
+		t.Errorf("CodeAt with a lower case secret = %q, %v, want 287082", got, err)
+	}
+	if _, err := CodeAt("not base32!", 1); err == nil {
+		t.Error("CodeAt accepted a secret that is not base32")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	step := Step(at)
+	code := "050471"
+
+	cases := []struct {
+		name     string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", code, at, step, true},
+		{"spaces typed by the user", " 050 471 ", at, step, true},
+		{"one period late", code, at.Add(Period), step, true},
+		{"one period early", code, at.Add(-Period), step, true},
+		{"two periods late", code, at.Add(2 * Period), 0, false},
+		{"two periods early", code, at.Add(-2 * Period), 0, false},
+		{"wrong code", "050472", at, 0, false},
+		{"too short", "05047", at, 0, false},
+		{"too long", "0504710", at, 0, false},
+		{"empty", "", at, 0, false},
+	}
+	for _, c := range cases {
+		gotStep, gotOK := Validate(rfcSecret, c.code, c.at)
+		if gotOK != c.wantOK || gotStep != c.wantStep {
+			t.Errorf("%s: Validate = %d, %v, want %d, %v", c.name, gotStep, gotOK, c.wantStep, c.wantOK)
+		}
+	}
+
+	if _, ok := Validate("not base32!", code, at); ok {
+		t.Error("Validate accepted a code for a broken secret")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := GenerateSecret()
+	if a == b {
+		t.Error("two secrets are equal")
+	}
+	code, err := CodeAt(a, Step(time.Now()))
+	if err != nil || len(code) != Digits {
+		t.Errorf("a generated secret gives code %q, %v", code, err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("Snehalaya", "user@example.com", rfcSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Snehalaya:user@example.com" {
+		t.Errorf("unexpected URI %s", uri)
+	}
+	q := uri.Query()
+	want := map[string]string{"secret": rfcSecret, "issuer": "Snehalaya", "algorithm": "SHA1", "digits": "6", "period": "30"}
+	for key, value := range want {
+		if q.Get(key) != value {
+			t.Errorf("%s = %q, want %q", key, q.Get(key), value)
+		}
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("got %d codes, want 10", len(codes))
+	}
+	shape := regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`)
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if !shape.MatchString(code) {
+			t.Errorf("code %q is not xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q issued twice", code)
+		}
+		seen[code] = true
+	}
+
+	hash := HashRecoveryCode("abcde-12345")
+	for _, typed := range []string{"ABCDE-12345", " abcde12345 ", "AbCdE12345"} {
+		if HashRecoveryCode(typed) != hash {
+			t.Errorf("HashRecoveryCode(%q) differs from the stored form", typed)
+		}
+	}
+	if HashRecoveryCode("abcde-12346") == hash {
+		t.Error("different codes hash the same")
+	}
+}
@@ -0,0 +1,244 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	apperror "github.com/ZADPRO/Snehalaya-Backend-GoLang/internal/helper/AppError"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Rules are written in the `binding` tag, the same tag gin checks in ShouldBindJSON, so a
+// struct validates identically whether it arrives encrypted through RequestHandler or as
+// plain JSON. Besides the validator built-ins (required, gt, lte, oneof, email, dive ...)
+// these are available:
+//
+//	mobile   10 digit Indian mobile number
+//	gstin    15 character GST identification number
+//	pincode  6 digit Indian postal code
+//	number   a JSON number or a numeric string, for fields typed any
+//	percent  a number between 0 and 100, for fields typed any
+var (
+	mobileRegex  = regexp.MustCompile(`^[6-9][0-9]{9}$`)
+	gstinRegex   = regexp.MustCompile(`^[0-9]{2}[A-Z]{5}[0-9]{4}[A-Z][1-9A-Z]Z[0-9A-Z]$`)
+	pincodeRegex = regexp.MustCompile(`^[1-9][0-9]{5}$`)
+
+	registerOnce sync.Once
+)
+
+// FieldError is one failed rule, Field is the JSON path of the value, e.g. items[2].cost.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+var messages = map[string]string{
+	"required": "is required",
+	"email":    "must be a valid email address",
+	"mobile":   "must be a valid 10 digit mobile number",
+	"gstin":    "must be a valid GSTIN",
+	"pincode":  "must be a valid 6 digit pincode",
+	"number":   "must be a number",
+	"percent":  "must be a number between 0 and 100",
+	"oneof":    "must be one of: %s",
+	"gt":       "must be greater than %s",
+	"gte":      "must be at least %s",
+	"lt":       "must be less than %s",
+	"lte":      "must be at most %s",
+	"min":      "must have at least %s",
+	"max":      "must have at most %s",
+	"len":      "must have length %s",
+	"datetime": "must match the format %s",
+	"nefield":  "must differ from %s",
+}
+
+// numberValue reads ints, floats and numeric strings, including through interface fields.
+func numberValue(fl validator.FieldLevel) (float64, bool) {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(field.String()), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func regexRule(re *regexp.Regexp) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return re.MatchString(strings.TrimSpace(fl.Field().String()))
+	}
+}
+
+// itemError is the failure of one element of a JSON array body.
+type itemError struct {
+	index int
+	err   error
+}
+
+// itemErrors keeps the position of each failed element, which gin's SliceValidationError drops.
+type itemErrors []itemError
+
+func (e itemErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, item := range e {
+		parts[i] = fmt.Sprintf("[%d]: %v", item.index, item.err)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// indexedValidator validates array bodies element by element and reports the index of
+// each failure, so items[2] of a bare array reads [2] in the field errors.
+type indexedValidator struct {
+	binding.StructValidator
+}
+
+func (v indexedValidator) ValidateStruct(obj any) error {
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return v.StructValidator.ValidateStruct(obj)
+	}
+
+	var failed itemErrors
+	for i := 0; i < value.Len(); i++ {
+		if err := v.StructValidator.ValidateStruct(value.Index(i).Interface()); err != nil {
+			failed = append(failed, itemError{index: i, err: err})
+		}
+	}
+	if failed == nil {
+		return nil
+	}
+	return failed
+}
+
+// Register adds the custom rules and JSON field names to gin's validator. It is safe to
+// call more than once; main calls it at startup.
+func Register() {
+	registerOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		binding.Validator = indexedValidator{binding.Validator}
+
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+
+		_ = v.RegisterValidation("mobile", regexRule(mobileRegex))
+		_ = v.RegisterValidation("gstin", func(fl validator.FieldLevel) bool {
+			return gstinRegex.MatchString(strings.ToUpper(strings.TrimSpace(fl.Field().String())))
+		})
+		_ = v.RegisterValidation("pincode", regexRule(pincodeRegex))
+		_ = v.RegisterValidation("number", func(fl validator.FieldLevel) bool {
+			_, ok := numberValue(fl)
+			return ok
+		})
+		_ = v.RegisterValidation("percent", func(fl validator.FieldLevel) bool {
+			f, ok := numberValue(fl)
+			return ok && f >= 0 && f <= 100
+		})
+	})
+}
+
+// Struct runs the binding rules of obj.
+func Struct(obj interface{}) error {
+	Register()
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// fieldPath drops the struct name from the namespace: GRNPayload.items[0].cost -> items[0].cost
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// Errors turns a validation or JSON binding error into field errors.
+// It returns nil when err is of another kind.
+func Errors(err error) []FieldError {
+	var items itemErrors
+	if errors.As(err, &items) {
+		fields := make([]FieldError, 0, len(items))
+		for _, item := range items {
+			for _, field := range Errors(item.err) {
+				field.Field = fmt.Sprintf("[%d].%s", item.index, field.Field)
+				fields = append(fields, field)
+			}
+		}
+		return fields
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			message, ok := messages[fe.Tag()]
+			if !ok {
+				message = "is invalid"
+			}
+			param := fe.Param()
+			if strings.HasSuffix(fe.Tag(), "field") && param != "" {
+				// field rules name the Go field, the client knows the JSON one
+				param = strings.ToLower(param[:1]) + param[1:]
+			}
+			if strings.Contains(message, "%s") {
+				message = fmt.Sprintf(message, param)
+			}
+			fields = append(fields, FieldError{
+				Field:   fieldPath(fe),
+				Rule:    fe.Tag(),
+				Param:   param,
+				Message: message,
+			})
+		}
+		return fields
+	}
+	return nil
+}
+
+// Respond writes a binding or validation error. Field errors come back as
+//
+//	{"status": false, "code": "VALIDATION_FAILED", "message": "Validation failed", "errors": [{field, rule, param, message}]}
+//
+// anything else, e.g. malformed JSON, as a plain BAD_REQUEST message.
+func Respond(c *gin.Context, err error) {
+	if fields := Errors(err); fields != nil {
+		RespondFields(c, fields...)
+		return
+	}
+	apperror.Respond(c, apperror.BadRequest("Invalid request body : "+err.Error()))
+}
+
+// RespondFields writes field errors found by a check the tags cannot express, e.g. two
+// fields of sibling structs that must differ, in the same shape as Respond.
+func RespondFields(c *gin.Context, fields ...FieldError) {
+	apperror.Respond(c, apperror.New(apperror.CodeValidation, "Validation failed").WithErrors(fields))
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type ruleCase struct {
+	value interface{}
+	valid bool
+}
+
+type mobileBody struct {
+	Mobile string `json:"mobile" binding:"mobile"`
+}
+
+type gstinBody struct {
+	GSTIN string `json:"gstin" binding:"gstin"`
+}
+
+type pincodeBody struct {
+	Pincode string `json:"pincode" binding:"pincode"`
+}
+
+type numberBody struct {
+	Amount any `json:"amount" binding:"number"`
+}
+
+type percentBody struct {
+	Rate any `json:"rate" binding:"percent"`
+}
+
+func TestRules(t *testing.T) {
+	cases := map[string]struct {
+		build func(value interface{}) interface{}
+		cases []ruleCase
+	}{
+		"mobile": {
+			build: func(v interface{}) interface{} { return &mobileBody{Mobile: v.(string)} },
+			cases: []ruleCase{
+				{"9876543210", true},
+				{" 6000000000 ", true},
+				{"5876543210", false},
+				{"987654321", false},
+				{"98765432101", false},
+				{"+919876543210", false},
+				{"98765abcde", false},
+			},
+		},
+		"gstin": {
+			build: func(v interface{}) interface{} { return &gstinBody{GSTIN: v.(string)} },
+			cases: []ruleCase{
+				{"33AABCU9603R1ZM", true},
+				{"33aabcu9603r1zm", true},
+				{"33AABCU9603R0ZM", false},
+				{"33AABCU9603R1XM", false},
+				{"3AABCU9603R1ZM", false},
+				{"", false},
+			},
+		},
+		"pincode": {
+			build: func(v interface{}) interface{} { return &pincodeBody{Pincode: v.(string)} },
+			cases: []ruleCase{
+				{"600001", true},
+				{"060001", false},
+				{"60001", false},
+				{"6000011", false},
+				{"60OO01", false},
+			},
+		},
+		"number": {
+			build: func(v interface{}) interface{} { return &numberBody{Amount: v} },
+			cases: []ruleCase{
+				{float64(12.5), true},
+				{7, true},
+				{"-3.25", true},
+				{" 42 ", true},
+				{"12a", false},
+				{"", false},
+				{true, false},
+			},
+		},
+		"percent": {
+			build: func(v interface{}) interface{} { return &percentBody{Rate: v} },
+			cases: []ruleCase{
+				{float64(0), true},
+				{float64(100), true},
+				{"18", true},
+				{float64(100.01), false},
+				{-1, false},
+				{"eighteen", false},
+			},
+		},
+	}
+
+	for rule, c := range cases {
+		for _, rc := range c.cases {
+			err := Struct(c.build(rc.value))
+			if rc.valid && err != nil {
+				t.Errorf("%s: %#v rejected: %v", rule, rc.value, err)
+			}
+			if !rc.valid {
+				fields := Errors(err)
+				if len(fields) != 1 || fields[0].Rule != rule {
+					t.Errorf("%s: %#v gave %+v, want one %s error", rule, rc.value, fields, rule)
+				}
+			}
+		}
+	}
+}
+
+type lineItem struct {
+	Cost float64 `json:"cost" binding:"gte=0"`
+	SKU  string  `json:"sku" binding:"required"`
+}
+
+type grnBody struct {
+	BranchId int        `json:"branchId" binding:"required,gt=0"`
+	Items    []lineItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type passwordBody struct {
+	OldPassword string `json:"oldPassword" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,nefield=OldPassword"`
+}
+
+func TestErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		body interface{}
+		want []FieldError
+	}{
+		{
+			name: "valid body",
+			body: &grnBody{BranchId: 1, Items: []lineItem{{Cost: 10, SKU: "SS1"}}},
+			want: nil,
+		},
+		{
+			name: "nested item paths",
+			body: &grnBody{Items: []lineItem{{Cost: 1, SKU: "SS1"}, {Cost: -1}}},
+			want: []FieldError{
+				{Field: "branchId", Rule: "required", Message: "is required"},
+				{Field: "items[1].cost", Rule: "gte", Param: "0", Message: "must be at least 0"},
+				{Field: "items[1].sku", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "array body keeps the index",
+			body: &[]lineItem{{Cost: 1, SKU: "SS1"}, {Cost: 2}, {Cost: -5, SKU: "SS3"}},
+			want: []FieldError{
+				{Field: "[1].sku", Rule: "required", Message: "is required"},
+				{Field: "[2].cost", Rule: "gte", Param: "0", Message: "must be at least 0"},
+			},
+		},
+		{
+			name: "field rule names the JSON field",
+			body: &passwordBody{OldPassword: "secret", NewPassword: "secret"},
+			want: []FieldError{
+				{Field: "newPassword", Rule: "nefield", Param: "oldPassword", Message: "must differ from oldPassword"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		err := Struct(c.body)
+		if c.want == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", c.name, err)
+			}
+			continue
+		}
+		if got := Errors(err); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s:\n got  %+v\n want %+v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestErrorsIgnoresOtherErrors(t *testing.T) {
+	if fields := Errors(errors.New("unexpected EOF")); fields != nil {
+		t.Errorf("Errors(malformed JSON) = %+v, want nil", fields)
+	}
+}